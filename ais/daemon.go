@@ -24,6 +24,7 @@ import (
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/reb"
 	"github.com/NVIDIA/aistore/space"
 	"github.com/NVIDIA/aistore/sys"
 	"github.com/NVIDIA/aistore/tracing"
@@ -253,6 +254,7 @@ func initDaemon(version, buildTime string) cos.Runner {
 	// reg xaction factories
 	xs.Xreg(false /* x-ele only */)
 	space.Xreg()
+	reb.Xreg()
 
 	t := newTarget(co)
 	t.init(config)
@@ -38,6 +38,7 @@ type dpq struct {
 	fltPresence string // QparamFltPresence
 	etlName     string // QparamETLName
 	binfo       string // bucket info, with or without requirement to summarize remote obj-s
+	user        string // QparamUserID
 
 	skipVC        bool // QparamSkipVC (skip loading existing object's metadata)
 	isGFN         bool // QparamIsGFNRequest
@@ -137,6 +138,10 @@ func (dpq *dpq) parse(rawQuery string) (err error) {
 			}
 		case apc.QparamOWT:
 			dpq.owt = value
+		case apc.QparamUserID: // (never client-specified - see stripUserID)
+			if dpq.user, err = url.QueryUnescape(value); err != nil {
+				return
+			}
 
 		case apc.QparamFltPresence:
 			dpq.fltPresence = value
@@ -62,6 +62,7 @@ type (
 		rproxy     reverseProxy
 		notifs     notifs
 		lstca      lstca
+		ratelims   ratelims
 		reg        struct {
 			pool nodeRegPool
 			mu   sync.RWMutex
@@ -202,6 +203,7 @@ func (p *proxy) Run() error {
 	p.notifs.init(p)
 	p.ic.init(p)
 	p.qm.init()
+	p.ratelims.init()
 
 	//
 	// REST API: register proxy handlers and start listening
@@ -743,6 +745,10 @@ func (p *proxy) httpobjget(w http.ResponseWriter, r *http.Request, origURLBck ..
 		return
 	}
 
+	if p.ratelim(w, r, bck, false /*s3*/) {
+		return
+	}
+
 	started := time.Now()
 
 	// 3. redirect
@@ -823,6 +829,9 @@ func (p *proxy) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiRe
 		p.writeErr(w, r, err)
 		return
 	}
+	if p.ratelim(w, r, bck, false /*s3*/) {
+		return
+	}
 	if nodeID == "" {
 		tsi, netPub, err = smap.HrwMultiHome(bck.MakeUname(objName))
 		if err != nil {
@@ -874,6 +883,9 @@ func (p *proxy) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		p.writeErr(w, r, err)
 		return
 	}
+	if p.ratelim(w, r, bck, false /*s3*/) {
+		return
+	}
 	smap := p.owner.smap.get()
 	tsi, err := smap.HrwName2T(bck.MakeUname(objName))
 	if err != nil {
@@ -1659,6 +1671,13 @@ func (p *proxy) listObjects(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 	if bck.IsHT() || lsmsg.IsFlagSet(apc.LsArchDir) {
 		lsmsg.SetFlag(apc.LsObjCached)
 	}
+	if lsmsg.Where != "" {
+		if err := _lsWhere(lsmsg); err != nil {
+			p.statsT.IncErr(stats.ErrListCount)
+			p.writeErr(w, r, err)
+			return
+		}
+	}
 
 	// do page
 	beg := mono.NanoTime()
@@ -1704,6 +1723,27 @@ func _checkVerChanged(bck *meta.Bck, lsmsg *apc.LsoMsg) error {
 	return nil
 }
 
+// filtering predicate (evaluated by targets):
+// - make sure to list the properties it needs;
+// - filtered pages are not cached
+func _lsWhere(lsmsg *apc.LsoMsg) error {
+	where, err := cmn.ParseLsoWhere(lsmsg.Where)
+	if err != nil {
+		return err
+	}
+	lsmsg.ClearFlag(apc.UseListObjsCache)
+	props := where.Props()
+	if len(props) == 0 {
+		return nil
+	}
+	lsmsg.AddProps(props...)
+	lsmsg.ClearFlag(apc.LsNameOnly)
+	if len(props) > 1 || props[0] != apc.GetPropsSize {
+		lsmsg.ClearFlag(apc.LsNameSize)
+	}
+	return nil
+}
+
 // one page; common code (native, s3 api)
 func (p *proxy) lsPage(bck *meta.Bck, amsg *apc.ActMsg, lsmsg *apc.LsoMsg, hdr http.Header, smap *smapX) (*cmn.LsoRes, error) {
 	var (
@@ -1755,6 +1795,12 @@ func (p *proxy) lsPage(bck *meta.Bck, amsg *apc.ActMsg, lsmsg *apc.LsoMsg, hdr h
 
 		config := cmn.GCO.Get()
 		lst, err = p.lsObjsR(bck, lsmsg, hdr, smap, tsi, config, wantOnlyRemote)
+		if err == nil && lsmsg.Where != "" {
+			next := func(msg *apc.LsoMsg) (*cmn.LsoRes, error) {
+				return p.lsObjsR(bck, msg, hdr, smap, tsi, config, wantOnlyRemote)
+			}
+			err = lsRefill(lst, lsmsg, bck.MaxPageSize(), config.Client.ListObjTimeout.D(), next)
+		}
 
 		// TODO: `status == http.StatusGone`: at this point we know that this
 		// remote bucket exists and is offline. We should somehow try to list
@@ -1767,6 +1813,33 @@ func (p *proxy) lsPage(bck *meta.Bck, amsg *apc.ActMsg, lsmsg *apc.LsoMsg, hdr h
 	return lst, err
 }
 
+// filtered (lsmsg.Where) remote page comes back short (or empty) when the predicate rejects
+// some (or all) of the listed objects - keep listing the remaining count until the page is full,
+// the listing is exhausted, or the time runs out (in which case the page remains short)
+func lsRefill(lst *cmn.LsoRes, lsmsg *apc.LsoMsg, maxPageSize int64, timeout time.Duration,
+	next func(*apc.LsoMsg) (*cmn.LsoRes, error)) error {
+	var (
+		pageSize      = lsmsg.PageSize
+		msize, mtoken = lsmsg.PageSize, lsmsg.ContinuationToken // (restore upon return)
+		started       = mono.NanoTime()
+	)
+	defer func() { lsmsg.PageSize, lsmsg.ContinuationToken = msize, mtoken }()
+	if pageSize == 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	for int64(len(lst.Entries)) < pageSize && lst.ContinuationToken != "" && mono.Since(started) < timeout {
+		lsmsg.PageSize = pageSize - int64(len(lst.Entries))
+		lsmsg.ContinuationToken = lst.ContinuationToken
+		nlst, err := next(lsmsg)
+		if err != nil {
+			return err
+		}
+		lst.Entries = append(lst.Entries, nlst.Entries...)
+		lst.ContinuationToken = nlst.ContinuationToken
+	}
+	return nil
+}
+
 // list-objects flow control helper
 func (p *proxy) _lsofc(bck *meta.Bck, lsmsg *apc.LsoMsg, smap *smapX) (tsi *meta.Snode, listRemote, wantOnlyRemote bool, err error) {
 	listRemote = bck.IsRemote() && !lsmsg.IsFlagSet(apc.LsObjCached)
@@ -1821,7 +1894,7 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 	if err != nil {
 		return
 	}
-	if msg.Action == apc.ActRenameObject {
+	if msg.Action == apc.ActRenameObject || msg.Action == apc.ActUndeleteObject {
 		apireq.after = 2
 	}
 	if err := p.parseReq(w, r, apireq); err != nil {
@@ -1848,6 +1921,15 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 		}
 		p.redirectAction(w, r, bck, apireq.items[1], msg)
 		p.statsT.Inc(stats.RenameCount)
+	case apc.ActUndeleteObject:
+		if err := p.checkAccess(w, r, bck, apc.AcePUT); err != nil {
+			return
+		}
+		if !bck.IsAIS() {
+			p.writeErr(w, r, cmn.NewErrUnsupp("undelete object in", bck.Cname("")))
+			return
+		}
+		p.redirectAction(w, r, bck, apireq.items[1], msg)
 	case apc.ActPromote:
 		if err := p.checkAccess(w, r, bck, apc.AcePromote); err != nil {
 			p.statsT.IncErr(stats.ErrRenameCount)
@@ -2136,6 +2218,9 @@ func (p *proxy) httpobjhead(w http.ResponseWriter, r *http.Request, origURLBck .
 	if err != nil {
 		return
 	}
+	if p.ratelim(w, r, bck, false /*s3*/) {
+		return
+	}
 	smap := p.owner.smap.get()
 	si, err := smap.HrwName2T(bck.MakeUname(objName))
 	if err != nil {
@@ -3216,6 +3301,7 @@ func (p *proxy) receiveConfig(newConfig *globalConfig, msg *aisMsg, payload msPa
 	if err != nil {
 		return
 	}
+	p.authn.reconfig(oldConfig, cmn.GCO.Get())
 
 	if !p.NodeStarted() {
 		if msg.Action == apc.ActAttachRemAis || msg.Action == apc.ActDetachRemAis {
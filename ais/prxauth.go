@@ -17,7 +17,6 @@ import (
 	"github.com/NVIDIA/aistore/cmd/authn/tok"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
-	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/memsys"
@@ -34,8 +33,10 @@ type (
 		// Authn sends these tokens to primary for broadcasting
 		revokedTokens map[string]bool
 		version       int64
-		// signing key secret
+		// HMAC signing key secret
 		secret string
+		// validates tokens signed with the secret or (RSA | ECDSA) private key
+		verifier *tok.Verifier
 	}
 )
 
@@ -44,12 +45,57 @@ type (
 /////////////////
 
 func newAuthManager(config *cmn.Config) *authManager {
-	return &authManager{
+	a := &authManager{
 		tkList:        make(tkList),
 		revokedTokens: make(map[string]bool), // TODO: preallocate
 		version:       1,
-		secret:        cos.Right(config.Auth.Secret, os.Getenv(env.AuthN.SecretKey)), // environment override
 	}
+	a.secret, a.verifier = newVerifier(config)
+	return a
+}
+
+func newVerifier(config *cmn.Config) (string, *tok.Verifier) {
+	secret := cos.Right(config.Auth.Secret, os.Getenv(env.AuthN.SecretKey)) // environment override
+	verifier := tok.NewVerifier(secret)
+	if config.Auth.PubKey != "" {
+		if err := verifier.AddKey("", []byte(config.Auth.PubKey)); err != nil {
+			nlog.Errorln("invalid auth.public_key:", err)
+		}
+	}
+	if url := config.Auth.JWKSURL; url != "" {
+		var (
+			cargs  = cmn.TransportArgs{Timeout: config.Client.Timeout.D()}
+			client *http.Client
+		)
+		if cos.IsHTTPS(url) {
+			client = cmn.NewClientTLS(cargs, cmn.TLSArgs{}, false /*intra-cluster*/)
+		} else {
+			client = cmn.NewClient(cargs)
+		}
+		verifier.SetJWKS(url, client)
+	}
+	if config.Auth.PubKey != "" || config.Auth.JWKSURL != "" {
+		if config.Auth.Issuer == "" && config.Auth.Audience == "" {
+			nlog.Warningln("auth: neither issuer nor audience configured - accepting all tokens signed with the configured keys")
+		}
+		verifier.RequireClaims(config.Auth.Issuer, config.Auth.Audience)
+	}
+	return secret, verifier
+}
+
+// runtime config change (see proxy.receiveConfig): replace the verifier
+// and drop decrypted tokens validated with the previous one
+func (a *authManager) reconfig(oldConf, newConf *cmn.Config) {
+	o, n := &oldConf.Auth, &newConf.Auth
+	if o.Secret == n.Secret && o.PubKey == n.PubKey && o.JWKSURL == n.JWKSURL && o.Issuer == n.Issuer && o.Audience == n.Audience {
+		return
+	}
+	secret, verifier := newVerifier(newConf)
+	a.Lock()
+	a.secret, a.verifier = secret, verifier
+	clear(a.tkList)
+	a.Unlock()
+	nlog.Infoln("auth: token validation reconfigured")
 }
 
 // Add tokens to the list of invalid ones and clean up the list from expired tokens.
@@ -79,12 +125,12 @@ func (a *authManager) updateRevokedList(newRevoked *tokenList) (allRevoked *toke
 	}
 
 	// Clean up expired tokens from the revoked list.
+	// NOTE: only those that are confirmed to be expired - not verifying signatures
+	// (that'd be network I/O under lock, and an unknown or rotated key is not a reason to un-revoke)
 	now := time.Now()
 
 	for token := range a.revokedTokens {
-		tk, err := tok.DecryptToken(token, a.secret)
-		debug.AssertNoErr(err)
-		if tk.Expires.Before(now) {
+		if expires, err := tok.Expires(token); err == nil && expires.Before(now) {
 			delete(a.revokedTokens, token)
 		} else {
 			allRevoked.Tokens = append(allRevoked.Tokens, token)
@@ -117,31 +163,37 @@ func (a *authManager) revokedTokenList() (allRevoked *tokenList) {
 //   - must have all mandatory fields: userID, creds, issued, expires
 //
 // Returns decrypted token information if it is valid
-func (a *authManager) validateToken(token string) (tk *tok.Token, err error) {
+// NOTE: decrypting outside the lock - may entail fetching JWKS (see tok.Verifier)
+func (a *authManager) validateToken(token string) (*tok.Token, error) {
 	a.Lock()
 	if _, ok := a.revokedTokens[token]; ok {
-		tk, err = nil, fmt.Errorf("%v: %s", tok.ErrTokenRevoked, tk)
-	} else {
-		tk, err = a.validateAddRm(token, time.Now())
+		a.Unlock()
+		return nil, tok.ErrTokenRevoked
 	}
+	tk, ok := a.tkList[token]
+	verifier := a.verifier
 	a.Unlock()
-	return
-}
 
-// Decrypts and validates token. Adds it to authManager.token if not found. Removes if expired.
-// Must be called under lock.
-func (a *authManager) validateAddRm(token string, now time.Time) (*tok.Token, error) {
-	tk, ok := a.tkList[token]
 	if !ok || tk == nil {
 		var err error
-		if tk, err = tok.DecryptToken(token, a.secret); err != nil {
+		if tk, err = verifier.Decrypt(token); err != nil {
 			nlog.Errorln(err)
 			return nil, tok.ErrInvalidToken
 		}
-		a.tkList[token] = tk
+		a.Lock()
+		if _, ok := a.revokedTokens[token]; ok { // revoked in the meantime
+			a.Unlock()
+			return nil, tok.ErrTokenRevoked
+		}
+		if verifier == a.verifier { // (not reconfigured in the meantime)
+			a.tkList[token] = tk
+		}
+		a.Unlock()
 	}
-	if tk.Expires.Before(now) {
+	if tk.Expires.Before(time.Now()) {
+		a.Lock()
 		delete(a.tkList, token)
+		a.Unlock()
 		return nil, fmt.Errorf("%v: %s", tok.ErrTokenExpired, tk)
 	}
 	return tk, nil
@@ -182,8 +234,11 @@ func (p *proxy) validateSecret(w http.ResponseWriter, r *http.Request) {
 	if _, err := p.parseURL(w, r, apc.URLPathTokens.L, 0, false); err != nil {
 		return
 	}
+	p.authn.Lock()
+	secret := p.authn.secret
+	p.authn.Unlock()
 	cksum := cos.NewCksumHash(cos.ChecksumSHA256)
-	cksum.H.Write([]byte(p.authn.secret))
+	cksum.H.Write([]byte(secret))
 	cksum.Finalize()
 
 	cluConf := &authn.ServerConf{}
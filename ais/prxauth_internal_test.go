@@ -0,0 +1,66 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// revoked token must remain revoked when its signing key cannot be fetched
+func TestRevokedUnknownKey(t *testing.T) {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tassert.CheckFatal(t, err)
+	b, err := x509.MarshalECPrivateKey(k)
+	tassert.CheckFatal(t, err)
+	signer, err := tok.NewSigner(tok.AlgES256, "k1", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b}))
+	tassert.CheckFatal(t, err)
+
+	config := &cmn.Config{}
+	a := newAuthManager(config)
+	a.verifier.SetJWKS("http://127.0.0.1:1/jwks", &http.Client{Timeout: time.Second}) // unreachable
+
+	var (
+		live, _    = tok.AdminJWT(time.Now().Add(time.Hour), "admin", signer)
+		expired, _ = tok.AdminJWT(time.Now().Add(-time.Hour), "admin", signer)
+	)
+	all := a.updateRevokedList(&tokenList{Tokens: []string{live, expired}})
+	tassert.Fatalf(t, all != nil && len(all.Tokens) == 1 && all.Tokens[0] == live, "expecting (only) the live token to remain revoked, got %v", all)
+
+	_, err = a.validateToken(live)
+	tassert.Errorf(t, errors.Is(err, tok.ErrTokenRevoked), "expecting %v, got %v", tok.ErrTokenRevoked, err)
+}
+
+// runtime config change replaces the verifier
+func TestAuthReconfig(t *testing.T) {
+	var (
+		oldConf = &cmn.Config{}
+		newConf = &cmn.Config{}
+	)
+	oldConf.Auth.Secret = "old-secret"
+	newConf.Auth.Secret = "new-secret"
+	a := newAuthManager(oldConf)
+
+	token, err := tok.AdminJWT(time.Now().Add(time.Hour), "admin", tok.NewHMACSigner("new-secret"))
+	tassert.CheckFatal(t, err)
+	_, err = a.validateToken(token)
+	tassert.Errorf(t, err != nil, "expecting token signed with the new secret to fail")
+
+	a.reconfig(oldConf, newConf)
+	tk, err := a.validateToken(token)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.UserID == "admin", "expected user %q, got %q", "admin", tk.UserID)
+}
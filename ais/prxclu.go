@@ -81,6 +81,8 @@ func (p *proxy) httpcluget(w http.ResponseWriter, r *http.Request) {
 		p.qcluSysinfo(w, r, what, query)
 	case apc.WhatMountpaths:
 		p.qcluMountpaths(w, r, what, query)
+	case apc.WhatRebEstimate:
+		p.qcluRebEstimate(w, r, what)
 	case apc.WhatBackends:
 		config := cmn.GCO.Get()
 		out := make([]string, 0, len(config.Backend.Providers))
@@ -307,6 +309,58 @@ func (p *proxy) qcluMountpaths(w http.ResponseWriter, r *http.Request, what stri
 	p.writeJSON(w, r, out, what)
 }
 
+// rebalance dry-run (compare with bsummact):
+// - start new job on all targets and return its ID (when msg.UUID is empty);
+// - otherwise, query the job: StatusAccepted while any target is still traversing
+func (p *proxy) qcluRebEstimate(w http.ResponseWriter, r *http.Request, what string) {
+	msg := &apc.RebEstimateMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	news := msg.UUID == ""
+	q := url.Values{apc.QparamWhat: []string{what}}
+	if news {
+		msg.UUID = cos.GenUUID()
+		q.Set(apc.QparamPrepare, "true")
+	}
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodGet, Path: apc.URLPathDae.S, Query: q, Body: cos.MustMarshal(msg)}
+	args.timeout = cmn.Rom.MaxKeepalive()
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+
+	var (
+		tres        = make(cos.JSONRawMsgs, len(results))
+		numAccepted int
+	)
+	for _, res := range results {
+		if res.err != nil {
+			if res.details == "" || res.details == dfltDetail {
+				res.details = xact.Cname(apc.ActRebEstimate, msg.UUID)
+			}
+			p.writeErr(w, r, res.toErr())
+			freeBcastRes(results)
+			return
+		}
+		if res.status == http.StatusAccepted {
+			numAccepted++
+			continue
+		}
+		tres[res.si.ID()] = res.bytes
+	}
+	freeBcastRes(results)
+
+	switch {
+	case news:
+		w.WriteHeader(http.StatusAccepted)
+		writeXid(w, msg.UUID)
+	case numAccepted > 0:
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		p.writeJSON(w, r, tres, what)
+	}
+}
+
 // helper methods for querying targets
 
 func (p *proxy) _queryTs(w http.ResponseWriter, r *http.Request, query url.Values) (cos.JSONRawMsgs, bool) {
@@ -1144,6 +1198,10 @@ func (p *proxy) setCluCfgPersistent(w http.ResponseWriter, r *http.Request, toUp
 	// do
 	if _, err := p.owner.config.modify(ctx); err != nil {
 		p.writeErr(w, r, err)
+		return
+	}
+	if toUpdate.Auth != nil {
+		p.authn.reconfig(config, cmn.GCO.Get()) // (other proxies: via receiveConfig)
 	}
 }
 
@@ -5,6 +5,11 @@
 package ais
 
 import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -452,4 +457,78 @@ var _ = Describe("ListObjectsCache+ListObjectsBuffer", func() {
 			Expect(hasEnough).To(BeFalse())
 		})
 	})
+
+	Describe("lsRefill", func() {
+		const numObjs = 40
+		// remote bucket (numObjs objects) listed page by page; the predicate keeps `keep(i)`
+		remote := func(keep func(int) bool, calls *int) func(*apc.LsoMsg) (*cmn.LsoRes, error) {
+			return func(msg *apc.LsoMsg) (*cmn.LsoRes, error) {
+				*calls++
+				var (
+					lst   = &cmn.LsoRes{}
+					start int
+				)
+				if msg.ContinuationToken != "" {
+					start, _ = strconv.Atoi(msg.ContinuationToken)
+				}
+				end := min(start+int(msg.PageSize), numObjs)
+				for i := start; i < end; i++ {
+					if keep(i) {
+						lst.Entries = append(lst.Entries, &cmn.LsoEnt{Name: fmt.Sprintf("o%02d", i)})
+					}
+				}
+				if end < numObjs {
+					lst.ContinuationToken = strconv.Itoa(end)
+				}
+				return lst, nil
+			}
+		}
+
+		It("should refill short pages", func() {
+			var (
+				calls int
+				next  = remote(func(i int) bool { return i%4 == 0 }, &calls)
+				msg   = &apc.LsoMsg{PageSize: 4}
+			)
+			lst, err := next(msg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lst.Entries).To(HaveLen(1))
+
+			err = lsRefill(lst, msg, 1000, time.Minute, next)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(extractNames(lst.Entries)).To(Equal([]string{"o00", "o04", "o08", "o12"}))
+			Expect(lst.ContinuationToken).NotTo(BeEmpty())
+			Expect(msg.PageSize).To(BeEquivalentTo(4))
+			Expect(msg.ContinuationToken).To(BeEmpty())
+		})
+
+		It("should stop when the listing is exhausted", func() {
+			var (
+				calls int
+				next  = remote(func(int) bool { return false }, &calls)
+				msg   = &apc.LsoMsg{PageSize: 4}
+			)
+			lst, err := next(msg)
+			Expect(err).NotTo(HaveOccurred())
+			err = lsRefill(lst, msg, 1000, time.Minute, next)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lst.Entries).To(BeEmpty())
+			Expect(lst.ContinuationToken).To(BeEmpty())
+			Expect(calls).To(Equal(numObjs / 4))
+		})
+
+		It("should return short page when out of time", func() {
+			var (
+				calls int
+				next  = remote(func(i int) bool { return i%4 == 0 }, &calls)
+				msg   = &apc.LsoMsg{PageSize: 4}
+			)
+			lst, err := next(msg)
+			Expect(err).NotTo(HaveOccurred())
+			err = lsRefill(lst, msg, 1000, 0 /*timeout*/, next)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lst.Entries).To(HaveLen(1))
+			Expect(lst.ContinuationToken).NotTo(BeEmpty())
+		})
+	})
 })
@@ -8,6 +8,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -26,6 +27,8 @@ import (
 	jsoniter "github.com/json-iterator/go"
 )
 
+const maxBckConfS3 = 256 * cos.KiB // lifecycle, CORS, policy, ACL
+
 var (
 	errS3Req    = errors.New("invalid s3 request")
 	errS3Obj    = errors.New("missing or empty object name")
@@ -44,6 +47,9 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if len(apiItems) > 0 && r.Method != http.MethodOptions {
+		s3.CORS(w, r, apiItems[0], p.owner.bmd)
+	}
 
 	switch r.Method {
 	case http.MethodHead:
@@ -71,15 +77,10 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		var (
-			q            = r.URL.Query()
-			_, lifecycle = q[s3.QparamLifecycle]
-			_, policy    = q[s3.QparamPolicy]
-			_, cors      = q[s3.QparamCORS]
-			_, acl       = q[s3.QparamACL]
-		)
-		if lifecycle || policy || cors || acl {
-			p.unsupported(w, r, apiItems[0])
+		q := r.URL.Query()
+		if what := s3.BckConfParam(q); what != "" {
+			// perms: apc.AceBckHEAD
+			p.getBckConfS3(w, r, apiItems[0], what)
 			return
 		}
 		listMultipart := q.Has(s3.QparamMptUploads)
@@ -108,6 +109,11 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.putBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if what := s3.BckConfParam(q); what != "" {
+				// perms: apc.AcePATCH
+				p.putBckConfS3(w, r, apiItems[0], what)
+				return
+			}
 			// perms: apc.AceCreateBucket
 			p.putBckS3(w, r, apiItems[0])
 			return
@@ -142,15 +148,29 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.delMultipleObjs(w, r, apiItems[0])
 				return
 			}
+			if what := s3.BckConfParam(q); what != "" {
+				// perms: apc.AcePATCH
+				p.delBckConfS3(w, r, apiItems[0], what)
+				return
+			}
 			// perms: apc.AceDestroyBucket
 			p.delBckS3(w, r, apiItems[0])
 			return
 		}
 		// perms: apc.AceObjDELETE
 		p.delObjS3(w, r, apiItems)
+	case http.MethodOptions:
+		// CORS preflight
+		if len(apiItems) == 0 {
+			s3.WriteErr(w, r, errS3Req, 0)
+			return
+		}
+		if bck := p.initByNameOnly(w, r, apiItems[0]); bck != nil {
+			s3.Preflight(w, r, bck.Bucket(), bck.Props)
+		}
 	default:
 		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodHead,
-			http.MethodPost, http.MethodPut)
+			http.MethodPost, http.MethodPut, http.MethodOptions)
 	}
 }
 
@@ -187,8 +207,34 @@ func (p *proxy) putBckS3(w http.ResponseWriter, r *http.Request, bucket string)
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	// optional canned ACL (that is, other than the default "private")
+	var acp *s3.AccessControlPolicy
+	if canned := r.Header.Get(s3.HdrCannedACL); canned != "" {
+		var err error
+		if acp, err = s3.NewCannedACL(canned); err != nil {
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+	}
+	objLock := cos.IsParseBool(r.Header.Get(s3.HdrBckObjLockEnabled))
 	if err := p.createBucket(&msg, bck, nil); err != nil {
 		s3.WriteErr(w, r, err, crerrStatus(err))
+		return
+	}
+	if acp == nil && !objLock {
+		return
+	}
+	if err := bck.Init(p.owner.bmd); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	nprops := bck.Props.Clone()
+	if acp != nil {
+		nprops.S3.ACL = acp.String()
+	}
+	nprops.ObjLock.Enabled = nprops.ObjLock.Enabled || objLock
+	if _, err := p.setBprops(&apc.ActMsg{Action: apc.ActSetBprops}, bck, nprops); err != nil {
+		s3.WriteErr(w, r, err, 0)
 	}
 }
 
@@ -441,9 +487,48 @@ func (p *proxy) putObjS3(w http.ResponseWriter, r *http.Request, items []string)
 		p.directPutObjS3(w, r, items)
 		return
 	}
+	q := r.URL.Query()
+	if q.Has(s3.QparamMptPartNo) && q.Has(s3.QparamMptUploadID) {
+		// UploadPartCopy: redirect to the target that handles the upload (i.e., the destination)
+		// and let the latter read the source
+		if p.accessCopySrc(w, r) {
+			p.directPutObjS3(w, r, items)
+		}
+		return
+	}
 	p.copyObjS3(w, r, items)
 }
 
+// bypassing governance retention requires permission to update bucket props
+func (p *proxy) accessBypassGov(w http.ResponseWriter, r *http.Request, bck *meta.Bck) bool {
+	if !s3.BypassGovernance(r.Header) {
+		return true
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func (p *proxy) accessCopySrc(w http.ResponseWriter, r *http.Request) bool {
+	src := strings.Trim(r.Header.Get(cos.S3HdrObjSrc), "/")
+	parts := strings.SplitN(src, "/", 2)
+	if len(parts) < 2 {
+		s3.WriteErr(w, r, errS3Obj, 0)
+		return false
+	}
+	bckSrc := p.initByNameOnly(w, r, parts[0])
+	if bckSrc == nil {
+		return false
+	}
+	if err := p.access(r.Header, bckSrc, apc.AceGET); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
 // PUT /s3/<bucket-name>/<object-name> - with HeaderObjSrc in the request header
 // (compare with p.directPutObjS3)
 func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string) {
@@ -499,6 +584,9 @@ func (p *proxy) directPutObjS3(w http.ResponseWriter, r *http.Request, items []s
 		s3.WriteErr(w, r, err, http.StatusForbidden)
 		return
 	}
+	if !p.accessBypassGov(w, r, bck) {
+		return
+	}
 	if len(items) < 2 {
 		s3.WriteErr(w, r, errS3Obj, 0)
 		return
@@ -508,6 +596,9 @@ func (p *proxy) directPutObjS3(w http.ResponseWriter, r *http.Request, items []s
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if p.ratelim(w, r, bck, true /*s3*/) {
+		return
+	}
 
 	smap := p.owner.smap.get()
 	si, netPub, err := smap.HrwMultiHome(bck.MakeUname(objName))
@@ -547,6 +638,9 @@ func (p *proxy) getObjS3(w http.ResponseWriter, r *http.Request, items []string,
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if p.ratelim(w, r, bck, true /*s3*/) {
+		return
+	}
 
 	smap := p.owner.smap.get()
 	si, netPub, err := smap.HrwMultiHome(bck.MakeUname(objName))
@@ -629,6 +723,9 @@ func (p *proxy) headObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if p.ratelim(w, r, bck, true /*s3*/) {
+		return
+	}
 	smap := p.owner.smap.get()
 	si, err := smap.HrwName2T(bck.MakeUname(objName))
 	if err != nil {
@@ -656,11 +753,17 @@ func (p *proxy) delObjS3(w http.ResponseWriter, r *http.Request, items []string)
 		s3.WriteErr(w, r, err, http.StatusForbidden)
 		return
 	}
+	if !p.accessBypassGov(w, r, bck) {
+		return
+	}
 	objName := s3.ObjName(items)
 	if err := cmn.ValidOname(objName); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if p.ratelim(w, r, bck, true /*s3*/) {
+		return
+	}
 
 	smap := p.owner.smap.get()
 	si, err := smap.HrwName2T(bck.MakeUname(objName))
@@ -690,13 +793,161 @@ func (p *proxy) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 	sgl.Free()
 }
 
-// GET /s3/<bucket-name>?lifecycle|cors|policy|acl
-func (p *proxy) unsupported(w http.ResponseWriter, r *http.Request, bucket string) {
-	if _, err, ecode := meta.InitByNameOnly(bucket, p.owner.bmd); err != nil {
-		s3.WriteErr(w, r, err, ecode)
+// GET /s3/<bucket-name>?lifecycle|cors|policy|acl|object-lock
+func (p *proxy) getBckConfS3(w http.ResponseWriter, r *http.Request, bucket, what string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
 		return
 	}
-	w.WriteHeader(http.StatusNotImplemented)
+	if err := p.access(r.Header, bck, apc.AceBckHEAD); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	var (
+		doc   string
+		props = &bck.Props.S3
+	)
+	switch what {
+	case s3.QparamLifecycle:
+		if doc = props.Lifecycle; doc == "" {
+			s3.WriteErr(w, r, s3.NewErrNoSuchConfig(s3.ErrNoSuchLifecycle, bucket), http.StatusNotFound)
+			return
+		}
+	case s3.QparamCORS:
+		if doc = props.CORS; doc == "" {
+			s3.WriteErr(w, r, s3.NewErrNoSuchConfig(s3.ErrNoSuchCORS, bucket), http.StatusNotFound)
+			return
+		}
+	case s3.QparamPolicy:
+		if doc = props.Policy; doc == "" {
+			s3.WriteErr(w, r, s3.NewErrNoSuchConfig(s3.ErrNoSuchPolicy, bucket), http.StatusNotFound)
+			return
+		}
+		w.Header().Set(cos.HdrContentType, cos.ContentJSON)
+		w.Write(cos.UnsafeB(doc))
+		return
+	case s3.QparamObjectLock:
+		if doc = s3.ObjLockConfDoc(&bck.Props.ObjLock); doc == "" {
+			s3.WriteErr(w, r, s3.NewErrNoSuchConfig(s3.ErrNoSuchObjLockConfig, bucket), http.StatusNotFound)
+			return
+		}
+	case s3.QparamACL:
+		if doc = props.ACL; doc == "" {
+			acp, err := s3.NewCannedACL("")
+			debug.AssertNoErr(err)
+			doc = acp.String()
+		}
+	}
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	w.Write(cos.UnsafeB(doc))
+}
+
+// PUT /s3/<bucket-name>?lifecycle|cors|policy|acl|object-lock
+func (p *proxy) putBckConfS3(w http.ResponseWriter, r *http.Request, bucket, what string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	doc, err := cos.ReadAll(io.LimitReader(r.Body, maxBckConfS3+1))
+	if err == nil && len(doc) > maxBckConfS3 {
+		err = fmt.Errorf("%s configuration exceeds maximum allowed size %s", what, cos.ToSizeIEC(maxBckConfS3, 0))
+	}
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	nprops := bck.Props.Clone()
+	switch what {
+	case s3.QparamLifecycle:
+		err = _lifecycleDoc(bck, doc)
+		nprops.S3.Lifecycle = string(doc)
+	case s3.QparamCORS:
+		_, err = s3.ParseCORS(doc)
+		nprops.S3.CORS = string(doc)
+	case s3.QparamPolicy:
+		err = s3.ValidatePolicy(doc)
+		nprops.S3.Policy = string(doc)
+	case s3.QparamACL:
+		nprops.S3.ACL, err = _aclDoc(r, doc)
+	case s3.QparamObjectLock:
+		var conf *cmn.ObjLockConf
+		if conf, err = s3.ParseObjLockConf(doc); err == nil {
+			nprops.ObjLock = *conf
+		}
+	}
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if _, err := p.setBprops(msg, bck, nprops); err != nil {
+		s3.WriteErr(w, r, err, 0)
+	}
+}
+
+// expiration is based on the time the object was last written _locally_ (and
+// expired objects get deleted from the remote backend as well) - hence, ais:// only
+func _lifecycleDoc(bck *meta.Bck, doc []byte) error {
+	if bck.IsRemote() {
+		return cmn.NewErrUnsupp("set lifecycle configuration on remote (or remote-backed) bucket", bck.Cname(""))
+	}
+	_, err := s3.ParseLifecycle(doc)
+	return err
+}
+
+// ACL can be specified either in the request body or as a canned ACL (header)
+func _aclDoc(r *http.Request, doc []byte) (string, error) {
+	if len(doc) > 0 {
+		_, err := s3.ParseACL(doc)
+		return string(doc), err
+	}
+	acp, err := s3.NewCannedACL(r.Header.Get(s3.HdrCannedACL))
+	if err != nil {
+		return "", err
+	}
+	return acp.String(), nil
+}
+
+// DELETE /s3/<bucket-name>?lifecycle|cors|policy
+func (p *proxy) delBckConfS3(w http.ResponseWriter, r *http.Request, bucket, what string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	nprops := bck.Props.Clone()
+	switch what {
+	case s3.QparamLifecycle:
+		nprops.S3.Lifecycle = ""
+	case s3.QparamCORS:
+		nprops.S3.CORS = ""
+	case s3.QparamPolicy:
+		nprops.S3.Policy = ""
+	default:
+		cmn.WriteErr405(w, r, http.MethodGet, http.MethodPut)
+		return
+	}
+	if nprops.S3 != bck.Props.S3 {
+		if _, err := p.setBprops(msg, bck, nprops); err != nil {
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
 }
 
 // PUT /s3/<bucket-name>?versioning
@@ -0,0 +1,38 @@
+// Package ais provides AIStore's proxy and target nodes.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestLifecycleRemote(t *testing.T) {
+	const doc = `<LifecycleConfiguration><Rule><Status>Enabled</Status>` +
+		`<Expiration><Days>7</Days></Expiration></Rule></LifecycleConfiguration>`
+	var (
+		props = &cmn.Bprops{BackendBck: cmn.Bck{Name: "remote", Provider: apc.AWS}}
+		tests = []struct {
+			bck *meta.Bck
+			ok  bool
+		}{
+			{meta.NewBck("lc", apc.AIS, cmn.NsGlobal, &cmn.Bprops{}), true},
+			{meta.NewBck("lc", apc.AWS, cmn.NsGlobal, &cmn.Bprops{}), false},
+			{meta.NewBck("lc", apc.AIS, cmn.NsGlobal, props), false}, // remote-backed
+		}
+	)
+	for _, test := range tests {
+		err := _lifecycleDoc(test.bck, []byte(doc))
+		if test.ok {
+			tassert.CheckError(t, err)
+		} else {
+			tassert.Errorf(t, err != nil, "%s: expecting lifecycle rejected, got %v", test.bck, err)
+		}
+	}
+}
@@ -402,6 +402,8 @@ func (p *proxy) setBprops(msg *apc.ActMsg, bck *meta.Bck, nprops *cmn.Bprops) (s
 			bargs.hdr = remoteBckProps
 		}
 		nprops = defaultBckProps(bargs)
+		nprops.ObjLock.Enabled = bprops.ObjLock.Enabled // (cannot be disabled - see makeNewBckProps)
+		nprops.SSE.KeySource = bprops.SSE.KeySource     // (to keep decrypting - ditto)
 	default:
 		return "", fmt.Errorf(fmtErrInvaldAction, msg.Action, []string{apc.ActSetBprops, apc.ActResetBprops})
 	}
@@ -1104,6 +1106,16 @@ func (p *proxy) makeNewBckProps(bck *meta.Bck, propsToUpdate *cmn.BpropsToSet, c
 			return
 		}
 	}
+	if bprops.ObjLock.Enabled && !nprops.ObjLock.Enabled {
+		err = fmt.Errorf("%s: once enabled, object lock cannot be disabled (bucket %s)", p.si, bck)
+		return
+	}
+	// (the bucket may already hold objects encrypted with the keys from the current source)
+	if src := bprops.SSE.KeySource; src != "" && src != nprops.SSE.KeySource && !propsToUpdate.Force {
+		err = fmt.Errorf("%s: once set, sse.key_source cannot change (bucket %s, %q) - existing encrypted objects would become undecryptable",
+			p.si, bck, src)
+		return
+	}
 	if bprops.EC.Enabled && nprops.EC.Enabled {
 		sameSlices := bprops.EC.DataSlices == nprops.EC.DataSlices && bprops.EC.ParitySlices == nprops.EC.ParitySlices
 		sameLimit := bprops.EC.ObjSizeLimit == nprops.EC.ObjSizeLimit
@@ -0,0 +1,208 @@
+// Package ais provides AIStore's proxy and target nodes.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Per-bucket and per-user rate limiting (see cmn.RateLimitConf):
+// - gateways limit object requests per second - before redirecting;
+// - targets limit data bytes per second (GET and PUT).
+//
+// Each node enforces its share of the configured limit, i.e., the limit divided
+// by the number of active gateways or targets, respectively. Per-user limits
+// require AuthN: having validated the token, gateway passes user ID to the
+// designated target (via apc.QparamUserID) - after having removed the one that
+// may have been specified by the client.
+//
+// Throttled requests fail with http.StatusTooManyRequests (S3: "SlowDown")
+// and "Retry-After" response header.
+
+const (
+	ratelimName = "ratelim"
+	ratelimIval = 10 * time.Minute // remove idle limiters
+)
+
+const (
+	rlRequests = "requests"
+	rlBytes    = "bytes"
+)
+
+type (
+	ratelims struct {
+		m  map[string]*ratelim // by (bucket ID, user, requests|bytes)
+		mu sync.Mutex
+	}
+	ratelim struct {
+		*cos.RateLim
+		used int64 // mono time
+	}
+)
+
+func (rls *ratelims) init() {
+	rls.m = make(map[string]*ratelim, 4)
+	hk.Reg(ratelimName+hk.NameSuffix, rls.housekeep, ratelimIval)
+}
+
+func (rls *ratelims) get(bck *meta.Bck, user, what string, rate int64) *ratelim {
+	key := what + "|" + user + "|" + strconv.FormatUint(bck.Props.BID, 36)
+	rls.mu.Lock()
+	rl, ok := rls.m[key]
+	switch {
+	case !ok:
+		rl = &ratelim{RateLim: cos.NewRateLim(rate, rate)}
+		rls.m[key] = rl
+	case rl.Rate() != rate:
+		rl.SetRate(rate, rate) // bucket props changed, or cluster membership did
+	}
+	rl.used = mono.NanoTime()
+	rls.mu.Unlock()
+	return rl
+}
+
+// try-acquire bucket-wide and (if user is known) per-user tokens - both or neither;
+// `nodes` is the number of active gateways (requests) or targets (bytes)
+func (rls *ratelims) acquire(bck *meta.Bck, user, what string, nodes int, n int64) error {
+	var (
+		conf             = &bck.Props.RateLimit
+		limit, userLimit = conf.MaxRPS, conf.UserRPS
+		brl              *ratelim
+	)
+	if what == rlBytes {
+		limit, userLimit = int64(conf.MaxBPS), int64(conf.UserBPS)
+	}
+	nodes = max(nodes, 1)
+	if limit > 0 {
+		brl = rls.get(bck, "", what, max(limit/int64(nodes), 1))
+		if retry := brl.TryAcquire(n); retry > 0 {
+			return cmn.NewErrRateLimited(bck.Cname(""), "", what, retry)
+		}
+	}
+	if userLimit > 0 && user != "" {
+		if retry := rls.get(bck, user, what, max(userLimit/int64(nodes), 1)).TryAcquire(n); retry > 0 {
+			if brl != nil {
+				brl.Release(n) // roll back
+			}
+			return cmn.NewErrRateLimited(bck.Cname(""), user, what, retry)
+		}
+	}
+	return nil
+}
+
+// take actual (transferred) bytes
+func (rls *ratelims) charge(bck *meta.Bck, user string, nodes int, size int64) {
+	conf := &bck.Props.RateLimit
+	nodes = max(nodes, 1)
+	if conf.MaxBPS > 0 {
+		rls.get(bck, "", rlBytes, max(int64(conf.MaxBPS)/int64(nodes), 1)).Charge(size)
+	}
+	if conf.UserBPS > 0 && user != "" {
+		rls.get(bck, user, rlBytes, max(int64(conf.UserBPS)/int64(nodes), 1)).Charge(size)
+	}
+}
+
+func (rls *ratelims) housekeep(int64) time.Duration {
+	now := mono.NanoTime()
+	rls.mu.Lock()
+	for key, rl := range rls.m {
+		if time.Duration(now-rl.used) > ratelimIval {
+			delete(rls.m, key)
+		}
+	}
+	rls.mu.Unlock()
+	return ratelimIval
+}
+
+//
+// gateway: requests per second
+//
+
+// returns true if the request has been throttled (and the error already written)
+func (p *proxy) ratelim(w http.ResponseWriter, r *http.Request, bck *meta.Bck, isS3 bool) bool {
+	stripUserID(r) // never pass on client-specified one
+	conf := &bck.Props.RateLimit
+	if !conf.Enabled() {
+		return false
+	}
+	var user string
+	if (conf.UserRPS > 0 || conf.UserBPS > 0) && cmn.Rom.AuthEnabled() {
+		if tk, err := p.validateToken(r.Header); err == nil {
+			user = tk.UserID
+		}
+	}
+	if conf.MaxRPS > 0 || conf.UserRPS > 0 {
+		smap := p.owner.smap.get()
+		if err := p.ratelims.acquire(bck, user, rlRequests, smap.CountActivePs(), 1); err != nil {
+			p.statsT.Inc(stats.RatelimCount)
+			if isS3 {
+				s3.WriteErr(w, r, err, http.StatusTooManyRequests)
+			} else {
+				p.writeErr(w, r, err, http.StatusTooManyRequests)
+			}
+			return true
+		}
+	}
+	// pass it on (see redirectURL)
+	if user != "" && conf.UserBPS > 0 {
+		q := apc.QparamUserID + "=" + url.QueryEscape(user)
+		if r.URL.RawQuery == "" {
+			r.URL.RawQuery = q
+		} else {
+			r.URL.RawQuery += "&" + q
+		}
+	}
+	return false
+}
+
+// remove apc.QparamUserID (if any) from the request query
+func stripUserID(r *http.Request) {
+	if !strings.Contains(r.URL.RawQuery, apc.QparamUserID+"=") {
+		return
+	}
+	q := r.URL.Query()
+	q.Del(apc.QparamUserID)
+	r.URL.RawQuery = q.Encode()
+}
+
+//
+// target: data bytes per second
+//
+
+func (t *target) ratelimCheck(bck *meta.Bck, user string) error {
+	conf := &bck.Props.RateLimit
+	if conf.MaxBPS == 0 && conf.UserBPS == 0 {
+		return nil
+	}
+	smap := t.owner.smap.get()
+	err := t.ratelims.acquire(bck, user, rlBytes, smap.CountActiveTs(), 0 /*upon completion*/)
+	if err != nil {
+		t.statsT.Inc(stats.RatelimCount)
+	}
+	return err
+}
+
+func (t *target) ratelimCharge(bck *meta.Bck, user string, size int64) {
+	conf := &bck.Props.RateLimit
+	if conf.MaxBPS == 0 && conf.UserBPS == 0 {
+		return
+	}
+	smap := t.owner.smap.get()
+	t.ratelims.charge(bck, user, smap.CountActiveTs(), size)
+}
@@ -0,0 +1,55 @@
+// Package ais provides AIStore's proxy and target nodes.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestStripUserID(t *testing.T) {
+	for _, query := range []string{
+		"uid=admin",
+		"provider=ais&uid=admin",
+		"uid=admin&provider=ais&uid=other",
+		"provider=ais",
+		"",
+	} {
+		r := httptest.NewRequest("GET", "/v1/objects/bck/obj?"+query, nil)
+		provider := r.URL.Query().Get(apc.QparamProvider)
+		stripUserID(r)
+		q := r.URL.Query()
+		tassert.Errorf(t, !q.Has(apc.QparamUserID), "%q: expecting %q removed, got %q", query, apc.QparamUserID, r.URL.RawQuery)
+		tassert.Errorf(t, q.Get(apc.QparamProvider) == provider, "%q: expecting other query parameters intact, got %q", query, r.URL.RawQuery)
+	}
+}
+
+// both tokens or neither
+func TestRatelimAcquire(t *testing.T) {
+	const maxRPS = 10
+	bck := meta.NewBck("rl", apc.AIS, cmn.NsGlobal, &cmn.Bprops{
+		BID:       1,
+		RateLimit: cmn.RateLimitConf{MaxRPS: maxRPS, UserRPS: 1},
+	})
+	rls := &ratelims{m: make(map[string]*ratelim, 4)}
+
+	err := rls.acquire(bck, "user", rlRequests, 1, 1)
+	tassert.CheckFatal(t, err)
+	err = rls.acquire(bck, "user", rlRequests, 1, 1)
+	tassert.Fatalf(t, err != nil, "expecting per-user limit")
+
+	// the bucket-wide token must have been returned
+	for i := range maxRPS - 1 {
+		err := rls.acquire(bck, "", rlRequests, 1, 1)
+		tassert.Fatalf(t, err == nil, "acquisition %d: %v", i, err)
+	}
+	err = rls.acquire(bck, "", rlRequests, 1, 1)
+	tassert.Errorf(t, err != nil, "expecting bucket-wide limit")
+}
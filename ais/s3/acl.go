@@ -0,0 +1,129 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Bucket ACL and bucket policy: stored (see cmn.S3Props) and returned as is - not enforced.
+// AIS access control is separately provided by bucket access attributes (apc.AccessAttrs) and AuthN.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketAcl.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketPolicy.html
+
+const (
+	ErrNoSuchLifecycle = "NoSuchLifecycleConfiguration"
+	ErrNoSuchCORS      = "NoSuchCORSConfiguration"
+	ErrNoSuchPolicy    = "NoSuchBucketPolicy"
+)
+
+const (
+	HdrCannedACL = "x-amz-acl"
+
+	cannedPrivate      = "private"
+	cannedPublicRead   = "public-read"
+	cannedPublicRW     = "public-read-write"
+	cannedAuthRead     = "authenticated-read"
+	granteeAllUsers    = "http://acs.amazonaws.com/groups/global/AllUsers"
+	granteeAuthUsers   = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+	xmlnsXsi           = "http://www.w3.org/2001/XMLSchema-instance"
+	permFullControl    = "FULL_CONTROL"
+	permRead           = "READ"
+	permWrite          = "WRITE"
+	maxBucketPolicyLen = 20 * cos.KiB // (as per AWS)
+)
+
+type (
+	AccessControlPolicy struct {
+		XMLName xml.Name `xml:"AccessControlPolicy"`
+		Ns      string   `xml:"xmlns,attr,omitempty"`
+		Owner   BckOwner `xml:"Owner"`
+		Grants  []*Grant `xml:"AccessControlList>Grant"`
+	}
+	Grant struct {
+		Grantee    Grantee `xml:"Grantee"`
+		Permission string  `xml:"Permission"`
+	}
+	Grantee struct {
+		Xsi         string `xml:"xmlns:xsi,attr,omitempty"`
+		Type        string `xml:"xsi:type,attr,omitempty"`
+		ID          string `xml:"ID,omitempty"`
+		DisplayName string `xml:"DisplayName,omitempty"`
+		URI         string `xml:"URI,omitempty"`
+	}
+)
+
+func _owner() BckOwner { return BckOwner{ID: "1", Name: AISServer} }
+
+// NewCannedACL returns ACL document for a given canned ACL
+// (the default, when none was ever set, is "private")
+func NewCannedACL(canned string) (*AccessControlPolicy, error) {
+	var (
+		owner = _owner()
+		acp   = &AccessControlPolicy{Ns: s3Namespace, Owner: owner}
+	)
+	acp.Grants = append(acp.Grants, &Grant{
+		Grantee:    Grantee{Xsi: xmlnsXsi, Type: "CanonicalUser", ID: owner.ID, DisplayName: owner.Name},
+		Permission: permFullControl,
+	})
+	group := func(uri, perm string) *Grant {
+		return &Grant{Grantee: Grantee{Xsi: xmlnsXsi, Type: "Group", URI: uri}, Permission: perm}
+	}
+	switch canned {
+	case "", cannedPrivate:
+	case cannedPublicRead:
+		acp.Grants = append(acp.Grants, group(granteeAllUsers, permRead))
+	case cannedPublicRW:
+		acp.Grants = append(acp.Grants, group(granteeAllUsers, permRead), group(granteeAllUsers, permWrite))
+	case cannedAuthRead:
+		acp.Grants = append(acp.Grants, group(granteeAuthUsers, permRead))
+	default:
+		return nil, fmt.Errorf("invalid or unsupported canned ACL %q", canned)
+	}
+	return acp, nil
+}
+
+func ParseACL(doc []byte) (*AccessControlPolicy, error) {
+	acp := &AccessControlPolicy{}
+	if err := xml.Unmarshal(doc, acp); err != nil {
+		return nil, fmt.Errorf("malformed access control policy: %v", err)
+	}
+	for _, g := range acp.Grants {
+		if g.Permission == "" {
+			return nil, errors.New("access control policy: grant with no permission")
+		}
+	}
+	return acp, nil
+}
+
+func (acp *AccessControlPolicy) String() string {
+	b, err := xml.Marshal(acp)
+	debug.AssertNoErr(err)
+	return xml.Header + string(b)
+}
+
+// bucket policy is a JSON document - validating its (minimal) structure only
+func ValidatePolicy(doc []byte) error {
+	if len(doc) > maxBucketPolicyLen {
+		return fmt.Errorf("bucket policy exceeds maximum allowed size (%d > %d)", len(doc), maxBucketPolicyLen)
+	}
+	var policy struct {
+		Statement any    `json:"Statement"` // one or many
+		Version   string `json:"Version"`
+	}
+	if err := jsoniter.Unmarshal(doc, &policy); err != nil {
+		return fmt.Errorf("malformed bucket policy: %v", err)
+	}
+	if policy.Statement == nil {
+		return errors.New("bucket policy must contain at least one statement")
+	}
+	return nil
+}
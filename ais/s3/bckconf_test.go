@@ -0,0 +1,219 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3 //nolint:testpackage // We use private functions here...
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BucketConfiguration", func() {
+	Describe("lifecycle", func() {
+		const doc = `<LifecycleConfiguration>
+  <Rule>
+    <ID>tmp</ID>
+    <Filter><Prefix>tmp/</Prefix></Filter>
+    <Status>Enabled</Status>
+    <Expiration><Days>7</Days></Expiration>
+  </Rule>
+  <Rule>
+    <ID>logs</ID>
+    <Prefix>logs/</Prefix>
+    <Status>Disabled</Status>
+    <Expiration><Days>1</Days></Expiration>
+  </Rule>
+  <Rule>
+    <ID>tagged</ID>
+    <Filter><Tag><Key>k</Key><Value>v</Value></Tag></Filter>
+    <Status>Enabled</Status>
+    <Expiration><Days>1</Days></Expiration>
+  </Rule>
+  <Rule>
+    <ID>archive</ID>
+    <Filter><And><Prefix>archive/</Prefix></And></Filter>
+    <Status>Enabled</Status>
+    <Expiration><Date>2024-01-01T00:00:00Z</Date></Expiration>
+  </Rule>
+</LifecycleConfiguration>`
+
+		now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		It("should parse and evaluate expiration rules", func() {
+			lc, err := ParseLifecycle([]byte(doc))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lc.Rules).To(HaveLen(4))
+			Expect(lc.HasExpiration()).To(BeTrue())
+
+			week := 7 * 24 * time.Hour
+			Expect(lc.Expired("tmp/a", now.Add(-week), now)).To(BeTrue())
+			Expect(lc.Expired("tmp/a", now.Add(-week+time.Minute), now)).To(BeFalse())
+			Expect(lc.Expired("other/a", now.Add(-10*week), now)).To(BeFalse())
+
+			// disabled and tagged rules are never enforced
+			Expect(lc.Expired("logs/a", now.Add(-10*week), now)).To(BeFalse())
+
+			// date
+			Expect(lc.Expired("archive/a", now, now)).To(BeTrue())
+			Expect(lc.Expired("archive/a", now, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))).To(BeFalse())
+		})
+
+		DescribeTable("should reject invalid configuration", func(doc string) {
+			_, err := ParseLifecycle([]byte(doc))
+			Expect(err).To(HaveOccurred())
+		},
+			Entry("malformed", "<LifecycleConfiguration><Rule>"),
+			Entry("no rules", "<LifecycleConfiguration></LifecycleConfiguration>"),
+			Entry("bad status", "<LifecycleConfiguration><Rule><Status>On</Status></Rule></LifecycleConfiguration>"),
+			Entry("bad date", "<LifecycleConfiguration><Rule><Status>Enabled</Status>"+
+				"<Expiration><Date>tomorrow</Date></Expiration></Rule></LifecycleConfiguration>"),
+			Entry("days and date", "<LifecycleConfiguration><Rule><Status>Enabled</Status>"+
+				"<Expiration><Days>1</Days><Date>2024-01-01T00:00:00Z</Date></Expiration></Rule></LifecycleConfiguration>"),
+		)
+	})
+
+	Describe("CORS", func() {
+		const doc = `<CORSConfiguration>
+  <CORSRule>
+    <AllowedOrigin>https://*.example.com</AllowedOrigin>
+    <AllowedMethod>PUT</AllowedMethod>
+    <AllowedMethod>GET</AllowedMethod>
+    <AllowedHeader>x-amz-*</AllowedHeader>
+    <AllowedHeader>content-type</AllowedHeader>
+    <ExposeHeader>ETag</ExposeHeader>
+    <MaxAgeSeconds>600</MaxAgeSeconds>
+  </CORSRule>
+  <CORSRule>
+    <AllowedOrigin>*</AllowedOrigin>
+    <AllowedMethod>GET</AllowedMethod>
+  </CORSRule>
+</CORSConfiguration>`
+
+		var (
+			bck    = &cmn.Bck{Name: "bucket", Provider: apc.AIS}
+			bprops *cmn.Bprops
+		)
+
+		BeforeEach(func() {
+			bprops = &cmn.Bprops{S3: cmn.S3Props{CORS: doc}}
+		})
+
+		preflight := func(origin, method, headers string) *httptest.ResponseRecorder {
+			r := httptest.NewRequest(http.MethodOptions, "/s3/bucket/object", http.NoBody)
+			r.Header.Set(cos.HdrOrigin, origin)
+			r.Header.Set(cos.HdrAccessControlReqMethod, method)
+			if headers != "" {
+				r.Header.Set(cos.HdrAccessControlReqHeaders, headers)
+			}
+			w := httptest.NewRecorder()
+			Preflight(w, r, bck, bprops)
+			return w
+		}
+
+		It("should validate", func() {
+			_, err := ParseCORS([]byte(doc))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = ParseCORS([]byte("<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin>" +
+				"<AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should allow matching preflight", func() {
+			w := preflight("https://app.example.com", http.MethodPut, "Content-Type, X-Amz-Date")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get(cos.HdrAccessControlAllowOrig)).To(Equal("https://app.example.com"))
+			Expect(w.Header().Get(cos.HdrAccessControlAllowMeth)).To(Equal("PUT, GET"))
+			Expect(w.Header().Get(cos.HdrAccessControlExposeHdrs)).To(Equal("ETag"))
+			Expect(w.Header().Get(cos.HdrAccessControlMaxAge)).To(Equal("600"))
+
+			w = preflight("https://other.org", http.MethodGet, "")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Header().Get(cos.HdrAccessControlAllowOrig)).To(Equal("*"))
+		})
+
+		It("should reject non-matching preflight", func() {
+			Expect(preflight("https://other.org", http.MethodPut, "").Code).To(Equal(http.StatusForbidden))
+			Expect(preflight("https://app.example.com", http.MethodPut, "x-custom").Code).To(Equal(http.StatusForbidden))
+
+			bprops.S3.CORS = ""
+			Expect(preflight("https://app.example.com", http.MethodGet, "").Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should reparse updated configuration", func() {
+			Expect(preflight("https://app.example.com", http.MethodPut, "").Code).To(Equal(http.StatusOK))
+			Expect(bucketCORS(bck, bprops.S3.CORS)).To(BeIdenticalTo(bucketCORS(bck, bprops.S3.CORS)))
+
+			bprops = &cmn.Bprops{S3: cmn.S3Props{CORS: "<CORSConfiguration><CORSRule>" +
+				"<AllowedOrigin>https://other.org</AllowedOrigin><AllowedMethod>PUT</AllowedMethod>" +
+				"</CORSRule></CORSConfiguration>"}}
+			Expect(preflight("https://app.example.com", http.MethodPut, "").Code).To(Equal(http.StatusForbidden))
+			Expect(preflight("https://other.org", http.MethodPut, "").Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Describe("ACL and policy", func() {
+		It("should generate and parse canned ACL", func() {
+			acp, err := NewCannedACL("public-read")
+			Expect(err).NotTo(HaveOccurred())
+			parsed, err := ParseACL([]byte(acp.String()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Grants).To(HaveLen(2))
+
+			_, err = NewCannedACL("bucket-owner-read")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should validate policy", func() {
+			Expect(ValidatePolicy([]byte(`{"Version": "2012-10-17", "Statement": {"Effect": "Allow"}}`))).To(Succeed())
+			Expect(ValidatePolicy([]byte(`{"Version": "2012-10-17"}`))).NotTo(Succeed())
+			Expect(ValidatePolicy([]byte(`<Policy/>`))).NotTo(Succeed())
+		})
+	})
+
+	Describe("object lock", func() {
+		It("should parse and generate configuration", func() {
+			conf, err := ParseObjLockConf([]byte(`<ObjectLockConfiguration>
+  <ObjectLockEnabled>Enabled</ObjectLockEnabled>
+  <Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Years>2</Years></DefaultRetention></Rule>
+</ObjectLockConfiguration>`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*conf).To(Equal(cmn.ObjLockConf{Enabled: true, Mode: cmn.ObjLockCompliance, Days: 2 * daysPerYear}))
+
+			doc := ObjLockConfDoc(conf)
+			Expect(doc).To(ContainSubstring("<Mode>COMPLIANCE</Mode>"))
+			parsed, err := ParseObjLockConf([]byte(doc))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(conf))
+
+			conf, err = ParseObjLockConf([]byte(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*conf).To(Equal(cmn.ObjLockConf{Enabled: true}))
+
+			Expect(ObjLockConfDoc(&cmn.ObjLockConf{})).To(BeEmpty())
+		})
+
+		DescribeTable("should reject invalid configuration", func(doc string) {
+			_, err := ParseObjLockConf([]byte(doc))
+			Expect(err).To(HaveOccurred())
+		},
+			Entry("malformed", "<ObjectLockConfiguration>"),
+			Entry("not enabled", "<ObjectLockConfiguration></ObjectLockConfiguration>"),
+			Entry("bad mode", "<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled>"+
+				"<Rule><DefaultRetention><Mode>STRICT</Mode><Days>1</Days></DefaultRetention></Rule></ObjectLockConfiguration>"),
+			Entry("days and years", "<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled>"+
+				"<Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>1</Days><Years>1</Years></DefaultRetention></Rule></ObjectLockConfiguration>"),
+			Entry("no period", "<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled>"+
+				"<Rule><DefaultRetention><Mode>GOVERNANCE</Mode></DefaultRetention></Rule></ObjectLockConfiguration>"),
+		)
+	})
+})
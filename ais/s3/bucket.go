@@ -7,6 +7,7 @@ package s3
 import (
 	"encoding/xml"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -97,3 +98,14 @@ func (r *VersioningConfiguration) MustMarshal(sgl *memsys.SGL) {
 func (r *VersioningConfiguration) Enabled() bool {
 	return r.Status == versioningEnabled
 }
+
+// BckConfParam returns one of the bucket configuration subresources
+// (lifecycle, cors, policy, acl) if specified in the query; empty string otherwise
+func BckConfParam(q url.Values) string {
+	for _, what := range []string{QparamLifecycle, QparamCORS, QparamPolicy, QparamACL, QparamObjectLock} {
+		if q.Has(what) {
+			return what
+		}
+	}
+	return ""
+}
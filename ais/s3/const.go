@@ -11,6 +11,9 @@ const (
 	QparamCORS              = "cors"
 	QparamPolicy            = "policy"
 	QparamACL               = "acl"
+	QparamObjectLock        = "object-lock"
+	QparamRetention         = "retention"
+	QparamLegalHold         = "legal-hold"
 	QparamMultiDelete       = "delete"
 	QparamMaxKeys           = "max-keys"
 	QparamPrefix            = "prefix"
@@ -0,0 +1,224 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// Bucket CORS configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketCors.html
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/cors.html
+
+const maxCORSRules = 100
+
+type (
+	CORSConfiguration struct {
+		XMLName xml.Name    `xml:"CORSConfiguration"`
+		Rules   []*CORSRule `xml:"CORSRule"`
+	}
+	CORSRule struct {
+		ID             string   `xml:"ID,omitempty"`
+		AllowedOrigins []string `xml:"AllowedOrigin"`
+		AllowedMethods []string `xml:"AllowedMethod"`
+		AllowedHeaders []string `xml:"AllowedHeader"`
+		ExposeHeaders  []string `xml:"ExposeHeader"`
+		MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+	}
+)
+
+// parsed bucket CORS configurations, to avoid parsing XML on every request;
+// an entry is valid as long as its source (bucket property) does not change
+type corsEnt struct {
+	cc  *CORSConfiguration
+	doc string
+}
+
+var (
+	corsMethods = cos.NewStrSet(http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodHead)
+	corsCache   sync.Map // bucket cname => *corsEnt
+)
+
+// parse and validate
+func ParseCORS(doc []byte) (*CORSConfiguration, error) {
+	cc := &CORSConfiguration{}
+	if err := xml.Unmarshal(doc, cc); err != nil {
+		return nil, fmt.Errorf("malformed CORS configuration: %v", err)
+	}
+	if len(cc.Rules) == 0 {
+		return nil, errors.New("CORS configuration must contain at least one rule")
+	}
+	if len(cc.Rules) > maxCORSRules {
+		return nil, fmt.Errorf("too many CORS rules (%d > %d)", len(cc.Rules), maxCORSRules)
+	}
+	for i, rule := range cc.Rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("CORS rule #%d (%q): %v", i+1, rule.ID, err)
+		}
+	}
+	return cc, nil
+}
+
+// cached or parsed (and cached); nil if the bucket has no (valid) CORS configuration
+func bucketCORS(bck *cmn.Bck, doc string) *CORSConfiguration {
+	if doc == "" {
+		return nil
+	}
+	cname := bck.Cname("")
+	if v, ok := corsCache.Load(cname); ok {
+		if ent := v.(*corsEnt); ent.doc == doc {
+			return ent.cc
+		}
+	}
+	cc, err := ParseCORS(cos.UnsafeB(doc))
+	if err != nil {
+		return nil // (validated when set)
+	}
+	corsCache.Store(cname, &corsEnt{cc: cc, doc: doc})
+	return cc
+}
+
+func (rule *CORSRule) validate() error {
+	if len(rule.AllowedOrigins) == 0 || len(rule.AllowedMethods) == 0 {
+		return errors.New("expecting at least one allowed origin and one allowed method")
+	}
+	for _, m := range rule.AllowedMethods {
+		if !corsMethods.Contains(m) {
+			return fmt.Errorf("unsupported method %q (expecting one of %v)", m, corsMethods.ToSlice())
+		}
+	}
+	for _, o := range rule.AllowedOrigins {
+		if strings.Count(o, "*") > 1 {
+			return fmt.Errorf("allowed origin %q: at most one wildcard", o)
+		}
+	}
+	for _, h := range rule.AllowedHeaders {
+		if strings.Count(h, "*") > 1 {
+			return fmt.Errorf("allowed header %q: at most one wildcard", h)
+		}
+	}
+	if rule.MaxAgeSeconds < 0 {
+		return fmt.Errorf("invalid max-age %d", rule.MaxAgeSeconds)
+	}
+	return nil
+}
+
+// first matching rule, if any
+func (cc *CORSConfiguration) match(origin, method string, reqHeaders []string) *CORSRule {
+	for _, rule := range cc.Rules {
+		if !_wmatchAny(rule.AllowedOrigins, origin) || !cos.StringInSlice(method, rule.AllowedMethods) {
+			continue
+		}
+		ok := true
+		for _, h := range reqHeaders {
+			if !_wmatchAny(rule.AllowedHeaders, h) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return rule
+		}
+	}
+	return nil
+}
+
+// case-insensitive match with (at most one) '*' wildcard
+func _wmatchAny(patterns []string, s string) bool {
+	s = strings.ToLower(s)
+	for _, p := range patterns {
+		p = strings.ToLower(p)
+		i := strings.IndexByte(p, '*')
+		if i < 0 {
+			if p == s {
+				return true
+			}
+			continue
+		}
+		if len(s) >= len(p)-1 && strings.HasPrefix(s, p[:i]) && strings.HasSuffix(s, p[i+1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *CORSRule) toHeader(hdr http.Header, origin string) {
+	if cos.StringInSlice("*", rule.AllowedOrigins) {
+		hdr.Set(cos.HdrAccessControlAllowOrig, "*")
+	} else {
+		hdr.Set(cos.HdrAccessControlAllowOrig, origin)
+	}
+	hdr.Set(cos.HdrAccessControlAllowMeth, strings.Join(rule.AllowedMethods, ", "))
+	if len(rule.ExposeHeaders) > 0 {
+		hdr.Set(cos.HdrAccessControlExposeHdrs, strings.Join(rule.ExposeHeaders, ", "))
+	}
+	hdr.Add(cos.HdrVary, cos.HdrOrigin)
+}
+
+// CORS adds CORS response headers to a regular (non-preflight) cross-origin request
+// if (and only if) the bucket is configured for it and one of its rules matches
+func CORS(w http.ResponseWriter, r *http.Request, bucket string, bowner meta.Bowner) {
+	origin := r.Header.Get(cos.HdrOrigin)
+	if origin == "" {
+		return
+	}
+	bck, err, _ := meta.InitByNameOnly(bucket, bowner)
+	if err != nil {
+		return // (the request itself will fail, or not)
+	}
+	cc := bucketCORS(bck.Bucket(), bck.Props.S3.CORS)
+	if cc == nil {
+		return
+	}
+	if rule := cc.match(origin, r.Method, nil); rule != nil {
+		rule.toHeader(w.Header(), origin)
+	}
+}
+
+// Preflight handles OPTIONS /s3/<bucket-name>[/<object-name>]
+func Preflight(w http.ResponseWriter, r *http.Request, bck *cmn.Bck, bprops *cmn.Bprops) {
+	var (
+		origin = r.Header.Get(cos.HdrOrigin)
+		method = r.Header.Get(cos.HdrAccessControlReqMethod)
+	)
+	if origin == "" || method == "" {
+		WriteErr(w, r, errors.New("invalid CORS preflight request: missing origin and/or method"), http.StatusBadRequest)
+		return
+	}
+	var rule *CORSRule
+	if cc := bucketCORS(bck, bprops.S3.CORS); cc != nil {
+		var reqHeaders []string
+		if s := r.Header.Get(cos.HdrAccessControlReqHeaders); s != "" {
+			reqHeaders = strings.Split(s, ",")
+			for i := range reqHeaders {
+				reqHeaders[i] = strings.TrimSpace(reqHeaders[i])
+			}
+		}
+		rule = cc.match(origin, method, reqHeaders)
+		if rule != nil {
+			hdr := w.Header()
+			rule.toHeader(hdr, origin)
+			if len(reqHeaders) > 0 {
+				hdr.Set(cos.HdrAccessControlAllowHdrs, strings.Join(reqHeaders, ", "))
+			}
+			if rule.MaxAgeSeconds > 0 {
+				hdr.Set(cos.HdrAccessControlMaxAge, strconv.Itoa(rule.MaxAgeSeconds))
+			}
+		}
+	}
+	if rule == nil {
+		WriteErr(w, r, errors.New("CORSResponse: this CORS request is not allowed"), http.StatusForbidden)
+	}
+}
@@ -20,13 +20,26 @@ import (
 
 const ErrPrefix = "aws-error"
 
-type Error struct {
-	Code      string
-	Message   string
-	Resource  string
-	RequestID string `xml:"RequestId"`
+type (
+	Error struct {
+		Code      string
+		Message   string
+		Resource  string
+		RequestID string `xml:"RequestId"`
+	}
+	// error that maps to a specific S3 error code, e.g. "NoSuchCORSConfiguration"
+	ErrCode struct {
+		code string
+		msg  string
+	}
+)
+
+func NewErrNoSuchConfig(code, bucket string) *ErrCode {
+	return &ErrCode{code: code, msg: "bucket " + bucket + ": " + code}
 }
 
+func (e *ErrCode) Error() string { return e.msg }
+
 func (e *Error) mustMarshal(sgl *memsys.SGL) {
 	sgl.Write([]byte(xml.Header))
 	err := xml.NewEncoder(sgl).Encode(e)
@@ -60,11 +73,21 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, ecode int) {
 		allocated = true
 	}
 	out.Message = in.Message
+	errCode, isCode := err.(*ErrCode)
 	switch {
+	case isCode:
+		out.Code = errCode.code
 	case cmn.IsErrBucketAlreadyExists(err):
 		out.Code = "BucketAlreadyExists"
 	case cmn.IsErrBckNotFound(err):
 		out.Code = "NoSuchBucket"
+	case cmn.IsErrQuotaExceeded(err):
+		out.Code = "QuotaExceeded"
+	case cmn.IsErrObjLocked(err):
+		out.Code = "AccessDenied"
+	case cmn.IsErrRateLimited(err):
+		out.Code = "SlowDown"
+		w.Header().Set(cos.HdrRetryAfter, err.(*cmn.ErrRateLimited).RetryAfter())
 	case in.TypeCode != "":
 		out.Code = in.TypeCode
 	default:
@@ -0,0 +1,152 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Bucket lifecycle configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLifecycleConfiguration.html
+//
+// The entire document is stored verbatim (see cmn.S3Props) and returned as is;
+// of all the lifecycle actions, only `Expiration` is currently enforced (see xs/lifecycle.go).
+// Rules that filter by object tags are accepted but never match (AIS objects are not tagged).
+
+const (
+	ruleEnabled  = "Enabled"
+	ruleDisabled = "Disabled"
+
+	maxLifecycleRules = 1000
+)
+
+type (
+	LifecycleConfiguration struct {
+		XMLName xml.Name         `xml:"LifecycleConfiguration"`
+		Rules   []*LifecycleRule `xml:"Rule"`
+	}
+	LifecycleRule struct {
+		Filter     *LifecycleFilter     `xml:"Filter,omitempty"`
+		Expiration *LifecycleExpiration `xml:"Expiration,omitempty"`
+		ID         string               `xml:"ID,omitempty"`
+		Status     string               `xml:"Status"`
+		Prefix     string               `xml:"Prefix,omitempty"` // legacy (no filter)
+	}
+	LifecycleFilter struct {
+		And    *LifecycleAnd `xml:"And,omitempty"`
+		Tag    *Tag          `xml:"Tag,omitempty"`
+		Prefix string        `xml:"Prefix,omitempty"`
+	}
+	LifecycleAnd struct {
+		Prefix string `xml:"Prefix,omitempty"`
+		Tags   []Tag  `xml:"Tag"`
+	}
+	LifecycleExpiration struct {
+		date time.Time
+		Date string `xml:"Date,omitempty"`
+		Days int    `xml:"Days,omitempty"`
+	}
+	Tag struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	}
+)
+
+// parse and validate
+func ParseLifecycle(doc []byte) (*LifecycleConfiguration, error) {
+	lc := &LifecycleConfiguration{}
+	if err := xml.Unmarshal(doc, lc); err != nil {
+		return nil, fmt.Errorf("malformed lifecycle configuration: %v", err)
+	}
+	if len(lc.Rules) == 0 {
+		return nil, errors.New("lifecycle configuration must contain at least one rule")
+	}
+	if len(lc.Rules) > maxLifecycleRules {
+		return nil, fmt.Errorf("too many lifecycle rules (%d > %d)", len(lc.Rules), maxLifecycleRules)
+	}
+	for i, rule := range lc.Rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("lifecycle rule #%d (%q): %v", i+1, rule.ID, err)
+		}
+	}
+	return lc, nil
+}
+
+func (rule *LifecycleRule) validate() error {
+	if rule.Status != ruleEnabled && rule.Status != ruleDisabled {
+		return fmt.Errorf("invalid status %q (expecting %q or %q)", rule.Status, ruleEnabled, ruleDisabled)
+	}
+	exp := rule.Expiration
+	if exp == nil {
+		return nil
+	}
+	switch {
+	case exp.Days < 0:
+		return fmt.Errorf("invalid expiration days %d", exp.Days)
+	case exp.Days > 0 && exp.Date != "":
+		return errors.New("expiration days and date are mutually exclusive")
+	case exp.Date != "":
+		date, err := time.Parse(time.RFC3339, exp.Date)
+		if err != nil {
+			return fmt.Errorf("invalid expiration date %q: %v", exp.Date, err)
+		}
+		exp.date = date
+	}
+	return nil
+}
+
+func (rule *LifecycleRule) prefix() string {
+	if f := rule.Filter; f != nil {
+		if f.And != nil {
+			return f.And.Prefix
+		}
+		return f.Prefix
+	}
+	return rule.Prefix
+}
+
+func (rule *LifecycleRule) enforced() bool {
+	if rule.Status != ruleEnabled || rule.Expiration == nil {
+		return false
+	}
+	if f := rule.Filter; f != nil && (f.Tag != nil || (f.And != nil && len(f.And.Tags) > 0)) {
+		return false
+	}
+	return rule.Expiration.Days > 0 || rule.Expiration.Date != ""
+}
+
+// whether any of the rules can possibly expire anything
+func (lc *LifecycleConfiguration) HasExpiration() bool {
+	for _, rule := range lc.Rules {
+		if rule.enforced() {
+			return true
+		}
+	}
+	return false
+}
+
+// Expired returns true if the object with a given name and modification time
+// matches at least one enabled expiration rule
+func (lc *LifecycleConfiguration) Expired(objName string, mtime, now time.Time) bool {
+	for _, rule := range lc.Rules {
+		if !rule.enforced() || !strings.HasPrefix(objName, rule.prefix()) {
+			continue
+		}
+		exp := rule.Expiration
+		if exp.Days > 0 {
+			// (AWS rounds the resulting time to the next midnight UTC - not doing it here)
+			if now.Sub(mtime) >= time.Duration(exp.Days)*24*time.Hour {
+				return true
+			}
+		} else if !now.Before(exp.date) {
+			return true
+		}
+	}
+	return false
+}
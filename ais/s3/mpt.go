@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
@@ -21,15 +22,16 @@ import (
 )
 
 // NOTE: xattr stores only the (*) marked attributes
+// (all attributes are persisted in the target's kvdb while the upload is active - see mptdb.go)
 type (
 	MptPart struct {
-		MD5  string // MD5 of the part (*)
-		FQN  string // FQN of the corresponding workfile
-		Size int64  // part size in bytes (*)
-		Num  int32  // part number (*)
+		MD5  string `json:"md5"`  // MD5 of the part (*)
+		FQN  string `json:"fqn"`  // FQN of the corresponding workfile
+		Size int64  `json:"size"` // part size in bytes (*)
+		Num  int32  `json:"num"`  // part number (*)
 	}
 	mpt struct {
-		bckName string
+		bck     cmn.Bck
 		objName string
 		parts   []*MptPart // by part number
 		ctime   time.Time  // InitUpload time
@@ -43,18 +45,20 @@ var (
 )
 
 // Start miltipart upload
-func InitUpload(id, bckName, objName string) {
-	mu.Lock()
-	if ups == nil {
-		ups = make(uploads, 8)
-	}
-	ups[id] = &mpt{
-		bckName: bckName,
+func InitUpload(id string, bck *cmn.Bck, objName string) {
+	mpt := &mpt{
+		bck:     *bck,
 		objName: objName,
 		parts:   make([]*MptPart, 0, iniCapParts),
 		ctime:   time.Now(),
 	}
+	mu.Lock()
+	if ups == nil {
+		ups = make(uploads, 8)
+	}
+	ups[id] = mpt
 	mu.Unlock()
+	mpt.persist(id)
 }
 
 // Add part to an active upload.
@@ -69,6 +73,9 @@ func AddPart(id string, npart *MptPart) (err error) {
 		mpt.parts = append(mpt.parts, npart)
 	}
 	mu.Unlock()
+	if err == nil {
+		persistPart(id, npart)
+	}
 	return
 }
 
@@ -134,6 +141,7 @@ func CleanupUpload(id, fqn string, aborted bool) (exists bool) {
 	}
 	delete(ups, id)
 	mu.Unlock()
+	mpt.unpersist(id)
 
 	if !aborted {
 		if err := storeMptXattr(fqn, mpt); err != nil {
@@ -148,10 +156,13 @@ func CleanupUpload(id, fqn string, aborted bool) (exists bool) {
 	return true
 }
 
-func ListUploads(bckName, idMarker string, maxUploads int) (result *ListMptUploadsResult) {
+func ListUploads(bck *cmn.Bck, idMarker string, maxUploads int) (result *ListMptUploadsResult) {
 	mu.RLock()
 	results := make([]UploadInfoResult, 0, len(ups))
 	for id, mpt := range ups {
+		if !mpt.bck.Equal(bck) {
+			continue
+		}
 		results = append(results, UploadInfoResult{Key: mpt.objName, UploadID: id, Initiated: mpt.ctime})
 	}
 	mu.RUnlock()
@@ -160,22 +171,21 @@ func ListUploads(bckName, idMarker string, maxUploads int) (result *ListMptUploa
 		return results[i].Initiated.Before(results[j].Initiated)
 	})
 
-	var from int
 	if idMarker != "" {
 		// truncate
 		for i, res := range results {
 			if res.UploadID == idMarker {
-				from = i + 1
+				results = results[i+1:]
 				break
 			}
-			copy(results, results[from:])
-			results = results[:len(results)-from]
 		}
 	}
+	result = &ListMptUploadsResult{Bucket: bck.Name, UploadIDMarker: idMarker, MaxUploads: maxUploads}
 	if maxUploads > 0 && len(results) > maxUploads {
 		results = results[:maxUploads]
+		result.IsTruncated = true
 	}
-	result = &ListMptUploadsResult{Bucket: bckName, Uploads: results, IsTruncated: from > 0}
+	result.Uploads = results
 	return
 }
 
@@ -189,7 +199,7 @@ func ListParts(id string, lom *core.LOM) (parts []types.CompletedPart, ecode int
 			mu.RUnlock()
 			return nil, ecode, err
 		}
-		mpt.bckName, mpt.objName = lom.Bck().Name, lom.ObjName
+		mpt.bck, mpt.objName = *lom.Bucket(), lom.ObjName
 		mpt.ctime = lom.Atime()
 	}
 	parts = make([]types.CompletedPart, 0, len(mpt.parts))
@@ -0,0 +1,217 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Active multipart uploads are persisted in the target's kvdb, so that
+// a restarted target could resume (complete, list, abort) them:
+// - upload:  <upload ID>              => mptMeta
+// - part:    <upload ID>/<part num>   => MptPart
+// Completed and aborted uploads are removed from the db (see CleanupUpload);
+// abandoned ones get expired by space cleanup (see ExpireUploads).
+
+const mptCollection = "s3-mpt"
+
+const mptPartSepa = "/"
+
+type (
+	mptMeta struct {
+		Bck     cmn.Bck   `json:"bck"`
+		ObjName string    `json:"obj"`
+		Ctime   time.Time `json:"ctime"`
+	}
+	// implements fs.PartsFQN to (re)generate part workfile names
+	mptPartsFQN struct {
+		mi      *fs.Mountpath
+		bck     *cmn.Bck
+		objName string
+	}
+)
+
+var db kvdb.Driver
+
+// interface guard
+var _ fs.PartsFQN = (*mptPartsFQN)(nil)
+
+func (p *mptPartsFQN) ObjectName() string       { return p.objName }
+func (p *mptPartsFQN) Bucket() *cmn.Bck         { return p.bck }
+func (p *mptPartsFQN) Mountpath() *fs.Mountpath { return p.mi }
+
+// Init is called by the target at startup to reload (and resume) active multipart uploads.
+func Init(driver kvdb.Driver) {
+	db = driver
+	all, err := db.GetAll(mptCollection, "")
+	if err != nil {
+		if !cos.IsErrNotFound(err) {
+			nlog.Errorln("failed to load multipart uploads:", err)
+		}
+		return
+	}
+	var (
+		metas = make(map[string]*mptMeta, len(all))
+		parts = make(map[string][]*MptPart, len(all))
+	)
+	for key, val := range all {
+		if id, _, isPart := strings.Cut(key, mptPartSepa); isPart {
+			part := &MptPart{}
+			if err := jsoniter.UnmarshalFromString(val, part); err != nil {
+				nlog.Errorln("failed to unmarshal multipart upload part [", key, err, "]")
+				continue
+			}
+			parts[id] = append(parts[id], part)
+			continue
+		}
+		meta := &mptMeta{}
+		if err := jsoniter.UnmarshalFromString(val, meta); err != nil {
+			nlog.Errorln("failed to unmarshal multipart upload [", key, err, "]")
+			continue
+		}
+		metas[key] = meta
+	}
+
+	mu.Lock()
+	if ups == nil {
+		ups = make(uploads, max(8, len(metas)))
+	}
+	for id, meta := range metas {
+		mpt := &mpt{bck: meta.Bck, objName: meta.ObjName, ctime: meta.Ctime}
+		mpt.parts = make([]*MptPart, 0, max(iniCapParts, len(parts[id])))
+		for _, part := range parts[id] {
+			if mpt.reloadPart(id, part) {
+				mpt.parts = append(mpt.parts, part)
+			}
+		}
+		ups[id] = mpt
+	}
+	mu.Unlock()
+
+	// parts that belong to no upload
+	for id, pp := range parts {
+		if _, ok := metas[id]; ok {
+			continue
+		}
+		for _, part := range pp {
+			removePart(id, part)
+		}
+	}
+	if len(metas) > 0 {
+		nlog.Infoln("reloaded", len(metas), "multipart upload(s)")
+	}
+}
+
+// rename part's workfile to match the current process (or else space cleanup
+// will remove it as "old") and update persisted location
+func (mpt *mpt) reloadPart(id string, part *MptPart) bool {
+	var parsed fs.ParsedFQN
+	if err := parsed.Init(part.FQN); err != nil {
+		nlog.Warningln("upload", id, "part", part.Num, "is invalid:", err)
+		removePart(id, part)
+		return false
+	}
+	if _, err := os.Stat(part.FQN); err != nil {
+		nlog.Warningln("upload", id, "part", part.Num, "is missing:", err)
+		removePart(id, part)
+		return false
+	}
+	var (
+		parts  = &mptPartsFQN{mi: parsed.Mountpath, bck: &mpt.bck, objName: mpt.objName}
+		prefix = id + "." + part.partNum()
+		fqn    = fs.CSM.Gen(parts, fs.WorkfileType, prefix)
+	)
+	if err := os.Rename(part.FQN, fqn); err != nil {
+		nlog.Errorln("failed to rename upload", id, "part", part.Num, "err:", err)
+		removePart(id, part)
+		return false
+	}
+	part.FQN = fqn
+	persistPart(id, part)
+	return true
+}
+
+func (part *MptPart) partNum() string { return strconv.FormatInt(int64(part.Num), 10) }
+
+func partKey(id string, part *MptPart) string { return id + mptPartSepa + part.partNum() }
+
+func (mpt *mpt) persist(id string) {
+	if db == nil {
+		return
+	}
+	meta := &mptMeta{Bck: mpt.bck, ObjName: mpt.objName, Ctime: mpt.ctime}
+	if err := db.Set(mptCollection, id, meta); err != nil {
+		nlog.Errorln("failed to persist multipart upload [", id, err, "]")
+	}
+}
+
+func persistPart(id string, part *MptPart) {
+	if db == nil {
+		return
+	}
+	if err := db.Set(mptCollection, partKey(id, part), part); err != nil {
+		nlog.Errorln("failed to persist multipart upload part [", id, part.Num, err, "]")
+	}
+}
+
+func (mpt *mpt) unpersist(id string) {
+	if db == nil {
+		return
+	}
+	if err := db.Delete(mptCollection, id); err != nil && !cos.IsErrNotFound(err) {
+		nlog.Errorln("failed to remove multipart upload [", id, err, "]")
+	}
+	for _, part := range mpt.parts {
+		if err := db.Delete(mptCollection, partKey(id, part)); err != nil && !cos.IsErrNotFound(err) {
+			nlog.Errorln("failed to remove multipart upload part [", id, part.Num, err, "]")
+		}
+	}
+}
+
+func removePart(id string, part *MptPart) {
+	if err := cos.RemoveFile(part.FQN); err != nil {
+		nlog.Errorln("failed to remove part [", id, part.Num, err, "]")
+	}
+	if err := db.Delete(mptCollection, partKey(id, part)); err != nil && !cos.IsErrNotFound(err) {
+		nlog.Errorln("failed to remove multipart upload part [", id, part.Num, err, "]")
+	}
+}
+
+// ExpireUploads aborts multipart uploads that were initiated more than `maxAge` ago
+// (and were neither completed nor aborted by the client);
+// returns the number of expired uploads.
+func ExpireUploads(maxAge time.Duration) (n int) {
+	if maxAge <= 0 {
+		return 0
+	}
+	var (
+		ids []string
+		now = time.Now()
+	)
+	mu.RLock()
+	for id, mpt := range ups {
+		if now.Sub(mpt.ctime) > maxAge {
+			ids = append(ids, id)
+		}
+	}
+	mu.RUnlock()
+	for _, id := range ids {
+		if CleanupUpload(id, "", true /*aborted*/) {
+			nlog.Infoln("expired multipart upload", id)
+			n++
+		}
+	}
+	return n
+}
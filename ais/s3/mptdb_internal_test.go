@@ -0,0 +1,98 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/mock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MptDB", func() {
+	var bck = cmn.Bck{Name: "mpt-bucket", Provider: apc.AIS}
+
+	BeforeEach(func() {
+		ups = nil
+		Init(mock.NewDBDriver())
+	})
+	AfterEach(func() {
+		ups, db = nil, nil
+	})
+
+	It("should reload active uploads", func() {
+		InitUpload("id1", &bck, "obj1")
+		InitUpload("id2", &bck, "obj2")
+		Expect(CleanupUpload("id2", "", true /*aborted*/)).To(BeTrue())
+
+		// restart
+		ups = nil
+		Init(db)
+
+		res := ListUploads(&bck, "", 0)
+		Expect(res.Uploads).To(HaveLen(1))
+		Expect(res.Uploads[0].UploadID).To(Equal("id1"))
+		Expect(res.Uploads[0].Key).To(Equal("obj1"))
+		Expect(ListUploads(&cmn.Bck{Name: "another-bucket", Provider: apc.AIS}, "", 0).Uploads).To(BeEmpty())
+	})
+
+	It("should expire abandoned uploads", func() {
+		InitUpload("id1", &bck, "obj1")
+		time.Sleep(10 * time.Millisecond)
+		InitUpload("id2", &bck, "obj2")
+
+		Expect(ExpireUploads(-1)).To(BeZero())
+		Expect(ExpireUploads(5 * time.Millisecond)).To(Equal(1))
+
+		ups = nil
+		Init(db)
+		res := ListUploads(&bck, "", 0)
+		Expect(res.Uploads).To(HaveLen(1))
+		Expect(res.Uploads[0].UploadID).To(Equal("id2"))
+	})
+
+	It("should list only the specified bucket's uploads", func() {
+		var (
+			awsBck = cmn.Bck{Name: bck.Name, Provider: apc.AWS}
+			nsBck  = cmn.Bck{Name: bck.Name, Provider: apc.AIS, Ns: cmn.Ns{Name: "ns"}}
+		)
+		InitUpload("id1", &bck, "obj1")
+		InitUpload("id2", &awsBck, "obj2")
+		InitUpload("id3", &nsBck, "obj3")
+
+		for i, b := range []*cmn.Bck{&bck, &awsBck, &nsBck} {
+			res := ListUploads(b, "", 0)
+			Expect(res.Uploads).To(HaveLen(1))
+			Expect(res.Uploads[0].Key).To(Equal("obj" + strconv.Itoa(i+1)))
+		}
+	})
+
+	It("should paginate uploads", func() {
+		for i := range 5 {
+			InitUpload("id"+strconv.Itoa(i), &bck, "obj"+strconv.Itoa(i))
+			time.Sleep(time.Millisecond)
+		}
+		res := ListUploads(&bck, "", 2)
+		Expect(res.Uploads).To(HaveLen(2))
+		Expect(res.IsTruncated).To(BeTrue())
+		Expect(res.Uploads[1].UploadID).To(Equal("id1"))
+
+		res = ListUploads(&bck, "id1", 2)
+		Expect(res.Uploads).To(HaveLen(2))
+		Expect(res.IsTruncated).To(BeTrue())
+		Expect(res.Uploads[0].UploadID).To(Equal("id2"))
+		Expect(res.Uploads[1].UploadID).To(Equal("id3"))
+
+		res = ListUploads(&bck, "id3", 2)
+		Expect(res.Uploads).To(HaveLen(1))
+		Expect(res.IsTruncated).To(BeFalse())
+		Expect(res.Uploads[0].UploadID).To(Equal("id4"))
+	})
+})
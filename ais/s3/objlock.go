@@ -0,0 +1,260 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Object Lock: bucket configuration maps to cmn.ObjLockConf (bucket property "object_lock"),
+// object retention and legal hold - to object's custom attributes (see core/lretain.go).
+// Objects are not versioned - retention protects the one and only (latest) version.
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-lock.html
+
+const (
+	ErrNoSuchObjLockConfig = "ObjectLockConfigurationNotFoundError"
+	ErrNoSuchObjLock       = "NoSuchObjectLockConfiguration"
+)
+
+const (
+	HdrBckObjLockEnabled = "x-amz-bucket-object-lock-enabled"
+	HdrObjLockMode       = "x-amz-object-lock-mode"
+	HdrObjLockUntil      = "x-amz-object-lock-retain-until-date"
+	HdrObjLockLegalHold  = "x-amz-object-lock-legal-hold"
+	HdrBypassGovernance  = "x-amz-bypass-governance-retention"
+
+	objLockEnabled = "Enabled"
+	daysPerYear    = 365
+)
+
+type (
+	ObjectLockConfiguration struct {
+		XMLName           xml.Name        `xml:"ObjectLockConfiguration"`
+		Ns                string          `xml:"xmlns,attr,omitempty"`
+		Rule              *ObjectLockRule `xml:"Rule,omitempty"`
+		ObjectLockEnabled string          `xml:"ObjectLockEnabled,omitempty"`
+	}
+	ObjectLockRule struct {
+		DefaultRetention *DefaultRetention `xml:"DefaultRetention"`
+	}
+	DefaultRetention struct {
+		Mode  string `xml:"Mode"`
+		Days  int64  `xml:"Days,omitempty"`
+		Years int64  `xml:"Years,omitempty"`
+	}
+	ObjectRetention struct {
+		XMLName         xml.Name `xml:"Retention"`
+		Ns              string   `xml:"xmlns,attr,omitempty"`
+		Mode            string   `xml:"Mode,omitempty"`
+		RetainUntilDate string   `xml:"RetainUntilDate,omitempty"`
+	}
+	ObjectLegalHold struct {
+		XMLName xml.Name `xml:"LegalHold"`
+		Ns      string   `xml:"xmlns,attr,omitempty"`
+		Status  string   `xml:"Status"`
+	}
+)
+
+//
+// bucket
+//
+
+// GET ?object-lock (empty when not enabled)
+func ObjLockConfDoc(conf *cmn.ObjLockConf) string {
+	if !conf.Enabled {
+		return ""
+	}
+	olc := &ObjectLockConfiguration{Ns: s3Namespace, ObjectLockEnabled: objLockEnabled}
+	if conf.Days > 0 {
+		olc.Rule = &ObjectLockRule{
+			DefaultRetention: &DefaultRetention{Mode: strings.ToUpper(conf.Mode), Days: conf.Days},
+		}
+	}
+	b, err := xml.Marshal(olc)
+	if err != nil {
+		return ""
+	}
+	return xml.Header + string(b)
+}
+
+// PUT ?object-lock
+func ParseObjLockConf(doc []byte) (*cmn.ObjLockConf, error) {
+	olc := &ObjectLockConfiguration{}
+	if err := xml.Unmarshal(doc, olc); err != nil {
+		return nil, fmt.Errorf("malformed object lock configuration: %v", err)
+	}
+	if olc.ObjectLockEnabled != objLockEnabled {
+		return nil, fmt.Errorf("invalid ObjectLockEnabled %q (expecting %q)", olc.ObjectLockEnabled, objLockEnabled)
+	}
+	conf := &cmn.ObjLockConf{Enabled: true}
+	if olc.Rule == nil || olc.Rule.DefaultRetention == nil {
+		return conf, nil
+	}
+	dr := olc.Rule.DefaultRetention
+	switch {
+	case dr.Days > 0 && dr.Years > 0:
+		return nil, errors.New("default retention days and years are mutually exclusive")
+	case dr.Days > 0:
+		conf.Days = dr.Days
+	case dr.Years > 0:
+		conf.Days = dr.Years * daysPerYear
+	default:
+		return nil, errors.New("default retention requires either days or years")
+	}
+	conf.Mode = strings.ToLower(dr.Mode)
+	return conf, conf.ValidateAsProps()
+}
+
+//
+// object
+//
+
+// PUT object: optional object lock headers => custom attributes
+func ObjLockFromHeader(hdr http.Header, bck *meta.Bck, lom *core.LOM) error {
+	var (
+		mode  = hdr.Get(HdrObjLockMode)
+		until = hdr.Get(HdrObjLockUntil)
+		hold  = hdr.Get(HdrObjLockLegalHold)
+	)
+	if mode == "" && until == "" && hold == "" {
+		return nil
+	}
+	if !bck.Props.ObjLock.Enabled {
+		return &ErrCode{code: "InvalidRequest",
+			msg: "bucket " + bck.Cname("") + ": object lock is not enabled (see bucket property 'object_lock')"}
+	}
+	if mode != "" || until != "" {
+		m, u, err := parseRetention(mode, until)
+		if err != nil {
+			return err
+		}
+		lom.SetCustomKey(cmn.LockModeObjMD, m)
+		lom.SetCustomKey(cmn.RetainUntilObjMD, u)
+	}
+	if hold != "" {
+		lom.SetCustomKey(cmn.LegalHoldObjMD, hold)
+	}
+	_, err := core.ParseRetention(lom.GetCustomMD())
+	return err
+}
+
+// S3 => custom attributes
+func parseRetention(mode, until string) (string, string, error) {
+	if mode == "" || until == "" {
+		return "", "", errors.New("object lock mode and retain-until date must be specified together")
+	}
+	t, err := time.Parse(time.RFC3339, until)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid retain-until date %q: %v", until, err)
+	}
+	return strings.ToLower(mode), t.UTC().Format(time.RFC3339), nil
+}
+
+// GET and HEAD response
+func SetObjLock(hdr http.Header, lom *core.LOM) {
+	ret, err := core.ParseRetention(lom.GetCustomMD())
+	if err != nil {
+		return
+	}
+	if ret.Mode != "" {
+		hdr.Set(HdrObjLockMode, strings.ToUpper(ret.Mode))
+		hdr.Set(HdrObjLockUntil, ret.Until.UTC().Format(time.RFC3339))
+	}
+	if v, ok := lom.GetCustomKey(cmn.LegalHoldObjMD); ok {
+		hdr.Set(HdrObjLockLegalHold, v)
+	}
+}
+
+func BypassGovernance(hdr http.Header) bool {
+	return cos.IsParseBool(hdr.Get(HdrBypassGovernance))
+}
+
+// GET ?retention
+func NewObjectRetention(lom *core.LOM) *ObjectRetention {
+	ret, err := core.ParseRetention(lom.GetCustomMD())
+	if err != nil || ret.Mode == "" {
+		return nil
+	}
+	return &ObjectRetention{
+		Ns:              s3Namespace,
+		Mode:            strings.ToUpper(ret.Mode),
+		RetainUntilDate: ret.Until.UTC().Format(time.RFC3339),
+	}
+}
+
+func (r *ObjectRetention) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// PUT ?retention: returns updated custom metadata (that must be further validated)
+func RetentionMD(doc []byte, lom *core.LOM) (cos.StrKVs, error) {
+	r := &ObjectRetention{}
+	if err := xml.Unmarshal(doc, r); err != nil {
+		return nil, fmt.Errorf("malformed retention: %v", err)
+	}
+	md := _cloneMD(lom)
+	if r.Mode == "" && r.RetainUntilDate == "" {
+		delete(md, cmn.LockModeObjMD)
+		delete(md, cmn.RetainUntilObjMD)
+		return md, nil
+	}
+	mode, until, err := parseRetention(r.Mode, r.RetainUntilDate)
+	if err != nil {
+		return nil, err
+	}
+	md[cmn.LockModeObjMD], md[cmn.RetainUntilObjMD] = mode, until
+	return md, nil
+}
+
+// GET ?legal-hold
+func NewObjectLegalHold(lom *core.LOM) *ObjectLegalHold {
+	v, ok := lom.GetCustomKey(cmn.LegalHoldObjMD)
+	if !ok {
+		return nil
+	}
+	return &ObjectLegalHold{Ns: s3Namespace, Status: v}
+}
+
+func (r *ObjectLegalHold) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
+// PUT ?legal-hold: ditto
+func LegalHoldMD(doc []byte, lom *core.LOM) (cos.StrKVs, error) {
+	lh := &ObjectLegalHold{}
+	if err := xml.Unmarshal(doc, lh); err != nil {
+		return nil, fmt.Errorf("malformed legal hold: %v", err)
+	}
+	if lh.Status != cmn.LegalHoldOn && lh.Status != cmn.LegalHoldOff {
+		return nil, fmt.Errorf("invalid legal hold status %q (expecting %q or %q)", lh.Status, cmn.LegalHoldOn, cmn.LegalHoldOff)
+	}
+	md := _cloneMD(lom)
+	md[cmn.LegalHoldObjMD] = lh.Status
+	return md, nil
+}
+
+func _cloneMD(lom *core.LOM) cos.StrKVs {
+	md := make(cos.StrKVs, len(lom.GetCustomMD())+2)
+	for k, v := range lom.GetCustomMD() {
+		md[k] = v
+	}
+	return md
+}
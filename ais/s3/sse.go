@@ -0,0 +1,44 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// Server-side encryption: AIS encrypts objects at rest with the bucket-configured key
+// (see cmn.SSEConf) - the equivalent of SSE-S3. Hence, "AES256" is the only supported
+// value of the "x-amz-server-side-encryption" header; customer-provided keys (SSE-C)
+// and KMS (SSE-KMS) are not supported.
+
+// PUT and CreateMultipartUpload
+func ValidateSSE(hdr http.Header, bck *meta.Bck) error {
+	if hdr.Get(cos.S3HdrSSECustomerAlg) != "" {
+		return &ErrCode{code: "NotImplemented", msg: "server-side encryption with customer-provided keys (SSE-C) is not supported"}
+	}
+	v := hdr.Get(cos.S3HdrSSE)
+	switch {
+	case v == "":
+		return nil
+	case v != cos.S3SSEAES256:
+		return &ErrCode{code: "InvalidArgument",
+			msg: "server-side encryption " + v + " is not supported (expecting " + cos.S3SSEAES256 + ")"}
+	case !bck.Props.SSE.Enabled:
+		return &ErrCode{code: "InvalidArgument",
+			msg: "bucket " + bck.Cname("") + ": server-side encryption is not enabled (see bucket property 'sse')"}
+	}
+	return nil
+}
+
+// GET, HEAD, and PUT response
+func SetSSE(hdr http.Header, lom *core.LOM) {
+	if lom.IsEncrypted() {
+		hdr.Set(cos.S3HdrSSE, cos.S3SSEAES256)
+	}
+}
@@ -55,6 +55,12 @@ type (
 		ETag         string `xml:"ETag"`
 	}
 
+	// Response for upload-part-copy request
+	CopyPartResult struct {
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag"`
+	}
+
 	// Multipart upload start response
 	InitiateMptUploadResult struct {
 		Bucket   string `xml:"Bucket"`
@@ -202,6 +208,12 @@ func (r *CopyObjectResult) MustMarshal(sgl *memsys.SGL) {
 	debug.AssertNoErr(err)
 }
 
+func (r *CopyPartResult) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
+
 func (r *InitiateMptUploadResult) MustMarshal(sgl *memsys.SGL) {
 	sgl.Write([]byte(xml.Header))
 	err := xml.NewEncoder(sgl).Encode(r)
@@ -38,6 +38,7 @@ import (
 	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/fs/health"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/reb"
@@ -70,6 +71,8 @@ type (
 		res          *res.Res
 		transactions transactions
 		regstate     regstate
+		quotas       quotas
+		ratelims     ratelims
 	}
 )
 
@@ -328,9 +331,11 @@ func (t *target) Run() error {
 		nlog.Errorln("")
 	}
 
-	// register object type and workfile type
+	// register object type, workfile type, and soft-deleted objects
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	fs.CSM.Reg(fs.DeletedType, &fs.DeletedContentResolver{})
+	fs.CSM.Reg(fs.ChunkType, &fs.ChunkContentResolver{})
 
 	// Init meta-owners and load local instances
 	if prev := t.owner.bmd.init(); prev {
@@ -405,6 +410,10 @@ func (t *target) Run() error {
 
 	dsort.Tinit(t.statsT, db, config)
 	dload.Init(t.statsT, db, &config.Client)
+	s3.Init(db)
+	hk.Reg(apc.ActS3Lifecycle+hk.NameSuffix, t.lifecycleHK, lifecycleIval)
+	t.quotas.init(t)
+	t.ratelims.init()
 
 	err = t.htrun.run(config)
 
@@ -722,6 +731,18 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 		return lom, err
 	}
 
+	// bucket rate limit (bytes per second); not limiting get-from-neighbor
+	if !dpq.isGFN {
+		if err := t.ratelimCheck(bck, dpq.user); err != nil {
+			if dpq.isS3 {
+				s3.WriteErr(w, r, err, http.StatusTooManyRequests)
+			} else {
+				t.writeErr(w, r, err, http.StatusTooManyRequests)
+			}
+			return lom, nil
+		}
+	}
+
 	// GET: regular | archive | range
 	goi := allocGOI()
 	{
@@ -742,16 +763,13 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 		goi.latestVer = _validateWarmGet(goi.lom, dpq.latestVer) // apc.QparamLatestVer || versioning.*_warm_get
 	}
 	if dpq.isArch() {
-		if goi.ranges.Range != "" {
+		// range-reading is supported only for a single archived file
+		if goi.ranges.Range != "" && dpq.arch.path == "" {
 			details := fmt.Sprintf("range: %s, arch query: %s", goi.ranges.Range, goi.dpq._archstr())
-			return lom, cmn.NewErrUnsupp("range-read archived content", details)
+			return lom, cmn.NewErrUnsupp("range-read multiple archived files", details)
 		}
 		if dpq.arch.path != "" {
-			if strings.HasPrefix(dpq.arch.path, lom.ObjName) {
-				if rel, err := filepath.Rel(lom.ObjName, dpq.arch.path); err == nil {
-					dpq.arch.path = rel
-				}
-			}
+			dpq.arch.path = archRelPath(lom, dpq.arch.path)
 		}
 	}
 
@@ -776,6 +794,8 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 				t._erris(w, r, err, ecode, !goi.isIOErr /*silent*/)
 			}
 		}
+	} else if !dpq.isGFN {
+		t.ratelimCharge(bck, dpq.user, goi.sent)
 	}
 	lom = goi.lom
 	freeGOI(goi)
@@ -968,6 +988,18 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *api
 		} else {
 			t.statsT.IncErr(stats.ErrRenameCount)
 		}
+	case apc.ActUndeleteObject:
+		lom = core.AllocLOM(apireq.items[1])
+		if err = lom.InitBck(apireq.bck.Bucket()); err != nil {
+			break
+		}
+		var ecode int
+		if ecode, err = t.undelete(lom); err != nil {
+			t.writeErr(w, r, err, ecode)
+			err = nil
+		}
+		core.FreeLOM(lom)
+		lom = nil
 	case apc.ActBlobDl:
 		// TODO: add stats.GetBlobCount and *ErrCount
 		var (
@@ -1043,6 +1075,9 @@ func (t *target) objHead(r *http.Request, whdr http.Header, q url.Values, bck *m
 		}
 		return
 	}
+	if archpath := q.Get(apc.QparamArchpath); archpath != "" {
+		return t.headArch(lom, archpath, q.Get(apc.QparamArchmime), whdr, fltPresence)
+	}
 	err = lom.Load(true /*cache it*/, false /*locked*/)
 	if err == nil {
 		if apc.IsFltNoProps(fltPresence) {
@@ -1156,6 +1191,64 @@ func (t *target) objHead(r *http.Request, whdr http.Header, q url.Values, bck *m
 	return
 }
 
+// HEAD archived file (a.k.a. "presence check"):
+// - the containing shard must be present in the cluster (no cold HEAD)
+// - returns the properties of the shard except size and checksum, which are those of the archived file
+func (t *target) headArch(lom *core.LOM, archpath, archmime string, whdr http.Header, fltPresence int) (int, error) {
+	if fltPresence == apc.FltExistsOutside {
+		return 0, fmt.Errorf(fmtOutside, lom.Cname(), fltPresence)
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err != nil {
+		if cmn.IsErrObjNought(err) {
+			return http.StatusNotFound, cos.NewErrNotFound(t, lom.Cname())
+		}
+		return 0, err
+	}
+	archpath = archRelPath(lom, archpath)
+	lmfh, err := lom.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer cos.Close(lmfh)
+
+	mime, err := archive.MimeFile(lmfh, t.smm, archmime, lom.ObjName)
+	if err != nil {
+		return 0, err
+	}
+	ar, err := archive.NewReader(mime, lmfh, lom.Lsize())
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s: %w", lom.Cname(), err)
+	}
+	csl, err := ar.ReadOne(archpath)
+	if err != nil {
+		return 0, cmn.NewErrFailedTo(t, "read "+archpath+" from", lom.Cname(), err)
+	}
+	if csl == nil {
+		return http.StatusNotFound, cos.NewErrNotFound(t, archpath+" in "+lom.Cname())
+	}
+	size := csl.Size()
+	csl.Close()
+
+	if !apc.IsFltNoProps(fltPresence) {
+		cmn.ToHeader(lom.ObjAttrs(), whdr, size, cos.NoneCksum)
+		whdr.Set(cmn.PropToHeader("name"), lom.ObjName)
+		whdr.Set(cmn.PropToHeader("present"), "true")
+	}
+	return 0, nil
+}
+
+// archived pathname relative to the containing shard (`shard.tar/a/b` => `a/b`)
+func archRelPath(lom *core.LOM, archpath string) string {
+	if strings.HasPrefix(archpath, lom.ObjName) {
+		if rel, err := filepath.Rel(lom.ObjName, archpath); err == nil {
+			return rel
+		}
+	}
+	return archpath
+}
+
 // PATCH /v1/objects/<bucket-name>/<object-name>
 // By default, adds or updates existing custom keys. Will remove all existing keys and
 // replace them with the specified ones _iff_ `apc.QparamNewCustom` is set.
@@ -1196,13 +1289,19 @@ func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request, apireq *ap
 		return
 	}
 	delOldSetNew := cos.IsParseBool(apireq.query.Get(apc.QparamNewCustom))
-	if delOldSetNew {
-		lom.SetCustomMD(custom)
-	} else {
-		for key, val := range custom {
-			lom.SetCustomKey(key, val)
+	if !delOldSetNew {
+		for key, val := range lom.GetCustomMD() {
+			if _, ok := custom[key]; !ok {
+				custom[key] = val
+			}
 		}
 	}
+	// object lock attributes (if any) may change only in certain ways
+	if err := lom.ValidateRetention(lom.GetCustomMD(), custom, false); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	lom.SetCustomMD(custom)
 	lom.Persist()
 }
 
@@ -1213,6 +1312,11 @@ func (t *target) putApndArch(r *http.Request, lom *core.LOM, started int64, dpq
 		filename = dpq.arch.path // apc.QparamArchpath
 		flags    int64
 	)
+	if lom.Bprops().ObjLock.Enabled && lom.Load(false /*cache it*/, true /*locked*/) == nil {
+		if err := lom.CheckRetained(false); err != nil {
+			return http.StatusForbidden, err
+		}
+	}
 	if strings.HasPrefix(filename, lom.ObjName) {
 		if rel, err := filepath.Rel(lom.ObjName, filename); err == nil {
 			filename = rel
@@ -1257,10 +1361,14 @@ func (t *target) putApndArch(r *http.Request, lom *core.LOM, started int64, dpq
 	return a.do()
 }
 
-func (t *target) DeleteObject(lom *core.LOM, evict bool) (code int, err error) {
+func (t *target) DeleteObject(lom *core.LOM, evict bool) (int, error) {
+	return t.deleteObject(lom, evict, false /*bypass governance retention*/)
+}
+
+func (t *target) deleteObject(lom *core.LOM, evict, bypassGov bool) (code int, err error) {
 	var isback bool
 	lom.Lock(true)
-	code, err, isback = t.delobj(lom, evict)
+	code, err, isback = t.delobj(lom, evict, bypassGov)
 	lom.Unlock(true)
 
 	// special corner-case retry (quote):
@@ -1293,7 +1401,7 @@ func (t *target) DeleteObject(lom *core.LOM, evict bool) (code int, err error) {
 	return code, err
 }
 
-func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
+func (t *target) delobj(lom *core.LOM, evict, bypassGov bool) (int, error, bool) {
 	var (
 		aisErr, backendErr         error
 		aisErrCode, backendErrCode int
@@ -1309,6 +1417,9 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 			return http.StatusNotFound, err, false
 		}
 	} else {
+		if err := lom.CheckRetained(bypassGov); err != nil {
+			return http.StatusForbidden, err, false
+		}
 		delFromAIS = true
 	}
 
@@ -1318,7 +1429,11 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 	}
 	if delFromAIS {
 		size := lom.Lsize()
-		aisErr = lom.RemoveObj()
+		if !evict && lom.Bck().IsAIS() && cmn.GCO.Get().Space.DeletedRetention > 0 {
+			aisErr = lom.SoftDelete()
+		} else {
+			aisErr = lom.RemoveObj()
+		}
 		if aisErr != nil {
 			if !os.IsNotExist(aisErr) {
 				if backendErr != nil {
@@ -1329,12 +1444,15 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 				return 0, aisErr, false
 			}
 			debug.Assert(aisErr == nil) // expecting lom.RemoveObj() to return nil when IsNotExist
-		} else if evict {
-			debug.Assert(lom.Bck().IsRemote())
-			t.statsT.AddMany(
-				cos.NamedVal64{Name: stats.LruEvictCount, Value: 1},
-				cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
-			)
+		} else {
+			t.quotas.update(lom.Bck(), -size, -1)
+			if evict {
+				debug.Assert(lom.Bck().IsRemote())
+				t.statsT.AddMany(
+					cos.NamedVal64{Name: stats.LruEvictCount, Value: 1},
+					cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
+				)
+			}
 		}
 	}
 	if backendErr != nil {
@@ -1343,6 +1461,41 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 	return aisErrCode, aisErr, false
 }
 
+// restore soft-deleted object (see `space.deleted_retention`)
+func (t *target) undelete(lom *core.LOM) (int, error) {
+	if !lom.Bck().IsAIS() {
+		return http.StatusBadRequest, cmn.NewErrUnsupp("undelete object in", lom.Bck().Cname(""))
+	}
+	lom.Lock(true)
+	err := lom.Load(false /*cache it*/, true /*locked*/)
+	if err == nil {
+		lom.Unlock(true)
+		return http.StatusConflict, fmt.Errorf("cannot undelete %s: object exists", lom.Cname())
+	}
+	if !cos.IsNotExist(err, 0) {
+		lom.Unlock(true)
+		return http.StatusInternalServerError, err
+	}
+	err = lom.Undelete()
+	lom.Unlock(true)
+	if err != nil {
+		if cos.IsNotExist(err, 0) {
+			return http.StatusNotFound, err
+		}
+		return http.StatusInternalServerError, err
+	}
+	t.quotas.update(lom.Bck(), lom.Lsize(), 1)
+
+	// (slices and metafiles were removed upon deletion - see httpobjdelete)
+	if lom.ECEnabled() {
+		if err := ec.ECM.EncodeObject(lom, nil); err != nil && err != ec.ErrorECDisabled {
+			return http.StatusInternalServerError, err
+		}
+	}
+	t.putMirror(lom)
+	return 0, nil
+}
+
 // rename obj
 func (t *target) objMv(lom *core.LOM, msg *apc.ActMsg) (err error) {
 	if lom.Bck().IsRemote() {
@@ -1354,6 +1507,11 @@ func (t *target) objMv(lom *core.LOM, msg *apc.ActMsg) (err error) {
 	if msg.Name == lom.ObjName {
 		return fmt.Errorf("%s: cannot rename/move object %s onto itself", t.si, lom)
 	}
+	if lom.Bprops().ObjLock.Enabled && lom.Load(false /*cache it*/, false /*locked*/) == nil {
+		if err := lom.CheckRetained(false); err != nil {
+			return err
+		}
+	}
 
 	buf, slab := t.gmm.Alloc()
 	coiParams := core.AllocCOI()
@@ -8,6 +8,7 @@ import (
 	"archive/tar"
 	"fmt"
 	"math/rand/v2"
+	"net/http"
 	"net/url"
 	"os"
 	"path"
@@ -185,6 +186,47 @@ func TestGetFromArch(t *testing.T) {
 							tassert.CheckFatal(t, err)
 						}
 					}
+					if corrupted {
+						return
+					}
+
+					// range-read a single archived file and check its presence
+					var (
+						randomName = randomNames[rand.IntN(numArchived)]
+						offset     = int64(fsize / 2)
+						length     = int64(fsize) - offset
+						getArgs    = api.GetArgs{
+							Query:  url.Values{apc.QparamArchpath: []string{randomName}},
+							Header: http.Header{cos.HdrRange: []string{cmn.MakeRangeHdr(offset, length)}},
+						}
+					)
+					oah, err := api.GetObject(baseParams, m.bck, objname, &getArgs)
+					tassert.CheckFatal(t, err)
+					tassert.Errorf(t, oah.Size() == length, "range-read %s?%s=%s: expected %dB, got %dB",
+						m.bck.Cname(objname), apc.QparamArchpath, randomName, length, oah.Size())
+
+					// get with checksum validation (zip CRC32 or computed on the fly)
+					getArgs = api.GetArgs{Query: url.Values{apc.QparamArchpath: []string{randomName}}}
+					oah, err = api.GetObjectWithValidation(baseParams, m.bck, objname, &getArgs)
+					tassert.CheckFatal(t, err)
+					if test.ext == archive.ExtZip {
+						cksum := oah.Attrs().Cksum
+						tassert.Errorf(t, cksum.Type() == cos.ChecksumCRC32,
+							"%s?%s=%s: expected %s checksum, got %s", m.bck.Cname(objname), apc.QparamArchpath,
+							randomName, cos.ChecksumCRC32, cksum)
+					}
+
+					hargs := api.HeadArgs{FltPresence: apc.FltPresent, ArchPath: randomName}
+					props, err := api.HeadObject(baseParams, m.bck, objname, hargs)
+					tassert.CheckFatal(t, err)
+					tassert.Errorf(t, props.Size == int64(fsize), "HEAD %s?%s=%s: expected %dB, got %dB",
+						m.bck.Cname(objname), apc.QparamArchpath, randomName, fsize, props.Size)
+
+					hargs.ArchPath = trand.String(10) + ".txt"
+					hargs.Silent = true
+					_, err = api.HeadObject(baseParams, m.bck, objname, hargs)
+					tassert.Errorf(t, cmn.IsStatusNotFound(err), "expecting %s?%s=%s to be not found, got %v",
+						m.bck.Cname(objname), apc.QparamArchpath, hargs.ArchPath, err)
 				})
 			}
 		}
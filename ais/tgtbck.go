@@ -379,6 +379,11 @@ func (t *target) httpbckdelete(w http.ResponseWriter, r *http.Request, apireq *a
 		defer nlp.Unlock()
 		defer wg.Wait()
 
+		// object lock (WORM): ditto
+		if err := core.CheckBckRetained(apireq.bck); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
 		core.UncacheBcks(wg, apireq.bck)
 		err := fs.DestroyBucket(msg.Action, apireq.bck.Bucket(), apireq.bck.Props.BID)
 		if err != nil {
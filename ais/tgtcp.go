@@ -436,6 +436,12 @@ func (t *target) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		}
 		fs.DiskStats(tcdfExt.AllDiskStats, &tcdfExt.Tcdf, config, true)
 		t.writeJSON(w, r, tcdfExt, httpdaeWhat)
+	case apc.WhatRebEstimate:
+		msg := &apc.RebEstimateMsg{}
+		if err := cmn.ReadJSON(w, r, msg); err != nil {
+			return
+		}
+		t.rebEstimate(w, r, query, msg)
 
 	case apc.WhatRemoteAIS:
 		var (
@@ -461,6 +467,43 @@ func (t *target) httpdaeget(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// rebalance dry-run: start new job (with the given ID) or query the one that's running or finished
+// (compare with bsumm)
+func (t *target) rebEstimate(w http.ResponseWriter, r *http.Request, query url.Values, msg *apc.RebEstimateMsg) {
+	if !cos.IsValidUUID(msg.UUID) {
+		t.writeErrf(w, r, "%s: invalid job ID %q", apc.ActRebEstimate, msg.UUID)
+		return
+	}
+	if cos.IsParseBool(query.Get(apc.QparamPrepare)) {
+		rns := xreg.RenewRebEstimate(msg)
+		if rns.Err != nil {
+			t.writeErr(w, r, rns.Err)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	xctn, err := xreg.GetXact(msg.UUID)
+	if err != nil {
+		t.writeErr(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	if xctn == nil {
+		t.writeErr(w, r, cos.NewErrNotFound(t, apc.ActRebEstimate+" job "+msg.UUID), http.StatusNotFound)
+		return
+	}
+	est, err := xctn.(*reb.XactEstimate).Result()
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	if est == nil {
+		w.WriteHeader(http.StatusAccepted) // still running
+		return
+	}
+	t.writeJSON(w, r, est, apc.ActRebEstimate)
+}
+
 func _rebSnap() (rebSnap *core.Snap) {
 	if entry := xreg.GetLatest(xreg.Flt{Kind: apc.ActRebalance}); entry != nil {
 		if xctn := entry.Get(); xctn != nil {
@@ -22,11 +22,8 @@ import (
 )
 
 // [METHOD] /v1/etl
+// (when not deployed on K8s, ETL runs as local process - see ext/etl/proc.go)
 func (t *target) etlHandler(w http.ResponseWriter, r *http.Request) {
-	if !k8s.IsK8s() {
-		t.writeErr(w, r, k8s.ErrK8sRequired, 0, Silent)
-		return
-	}
 	switch {
 	case r.Method == http.MethodPut:
 		t.handleETLPut(w, r)
@@ -116,7 +113,9 @@ func (t *target) handleETLGet(w http.ResponseWriter, r *http.Request) {
 	case apc.ETLHealth:
 		t.healthETL(w, r, apiItems[0])
 	case apc.ETLMetrics:
-		k8s.InitMetricsClient()
+		if k8s.IsK8s() {
+			k8s.InitMetricsClient()
+		}
 		t.metricsETL(w, r, apiItems[0])
 	default:
 		t.writeErrURL(w, r)
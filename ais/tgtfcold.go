@@ -44,6 +44,13 @@ func (goi *getOI) coldReopen(res *core.GetReaderResult) error {
 		goi._cleanup(revert, nil, nil, nil, err, "(fcreate)")
 		return err
 	}
+	if ew, _, errE := lom.EncryptWriter(wfh); errE == nil {
+		wfh = ew
+	} else {
+		cos.Close(res.R)
+		goi._cleanup(revert, wfh, nil, nil, errE, "(encrypt)")
+		return errE
+	}
 
 	// read remote, write local
 	var (
@@ -120,6 +127,7 @@ func (goi *getOI) coldReopen(res *core.GetReaderResult) error {
 	if goi.dpq.isS3 {
 		// (expecting user to set bucket checksum = md5)
 		s3.SetEtag(whdr, goi.lom)
+		s3.SetSSE(whdr, goi.lom)
 	}
 
 	written, err = cos.CopyBuffer(goi.w, reader, buf)
@@ -221,6 +229,13 @@ func (goi *getOI) coldStream(res *core.GetReaderResult) error {
 		goi._cleanup(revert, nil, nil, nil, err, "(fcreate)")
 		return err
 	}
+	if ew, _, errE := lom.EncryptWriter(lmfh); errE == nil {
+		lmfh = ew
+	} else {
+		cos.Close(res.R)
+		goi._cleanup(revert, lmfh, nil, nil, errE, "(encrypt)")
+		return errE
+	}
 
 	// read remote, write local, transmit --
 
@@ -238,6 +253,7 @@ func (goi *getOI) coldStream(res *core.GetReaderResult) error {
 	if goi.dpq.isS3 {
 		// (expecting user to set bucket checksum = md5)
 		s3.SetEtag(whdr, goi.lom)
+		s3.SetSSE(whdr, goi.lom)
 	}
 
 	written, err = cos.CopyBuffer(mw, res.R, buf)
@@ -81,13 +81,16 @@ func (t *target) PutObject(lom *core.LOM, params *core.PutParams) error {
 		poi.owt = params.OWT
 		poi.skipEC = params.SkipEC
 		poi.coldGET = params.ColdGET
+		poi.asIs = params.AsIs
+		poi.sse = params.SSE
 	}
 	if poi.owt != cmn.OwtPut {
 		poi.cksumToUse = params.Cksum
 	}
 	_, err := poi.putObject()
 	freePOI(poi)
-	debug.Assert(err != nil || params.Size <= 0 || params.Size == lom.Lsize(true), lom.String(), params.Size, lom.Lsize(true))
+	debug.Assert(err != nil || params.Size <= 0 || params.Size == lom.Lsize(true) || (params.AsIs && params.Size == lom.RawSize()),
+		lom.String(), params.Size, lom.Lsize(true))
 	return err
 }
 
@@ -49,6 +49,7 @@ import (
 type (
 	putOI struct {
 		oreq       *http.Request
+		cw         *core.ChunkWriter
 		r          io.ReadCloser // content reader
 		xctn       core.Xact     // xaction that puts
 		t          *target       // this
@@ -56,7 +57,9 @@ type (
 		cksumToUse *cos.Cksum    // if available (not `none`), can be validated and will be stored
 		config     *cmn.Config   // (during this request)
 		resphdr    http.Header   // as implied
+		sgl        *memsys.SGL   // staged content (write_policy.data = (delayed | never)) - in lieu of workFQN
 		workFQN    string        // temp fqn to be renamed
+		sse        string        // encryption metadata of the content written as is (see asIs)
 		atime      int64         // access time.Now()
 		ltime      int64         // mono.NanoTime, to measure latency
 		rltime     int64         // mono.NanoTime, to measure remote bucket latency
@@ -68,6 +71,8 @@ type (
 		skipVC     bool          // skip loading existing Version and skip comparing Checksums (skip VC)
 		coldGET    bool          // (one implication: proceed to write)
 		remoteErr  bool          // to exclude `putRemote` errors when counting soft IO errors
+		asIs       bool          // store content as is (e.g., encrypted EC replica)
+		encrypted  bool          // written via lom.EncryptWriter
 	}
 
 	getOI struct {
@@ -82,6 +87,7 @@ type (
 		ltime      int64      // mono.NanoTime, to measure latency
 		rstarttime int64      // mono.NanoTime, mark start of remote GET to measure latency
 		rltime     int64      // mono.NanoTime, to measure remote bucket latency
+		sent       int64      // bytes transmitted (range and archived-file reads: less than object size)
 		chunked    bool       // chunked transfer (en)coding: https://tools.ietf.org/html/rfc7230#page-36
 		unlocked   bool       // internal
 		verchanged bool       // version changed
@@ -173,13 +179,33 @@ func (poi *putOI) do(resphdr http.Header, r *http.Request, dpq *dpq) (int, error
 			poi.size = size
 		}
 	}
-	return poi.putObject()
+	if poi.owt != cmn.OwtPut || poi.t2t {
+		return poi.putObject()
+	}
+
+	// bucket rate limit (bytes per second)
+	bck := poi.lom.Bck()
+	if err := poi.t.ratelimCheck(bck, dpq.user); err != nil {
+		return http.StatusTooManyRequests, err
+	}
+	ecode, err := poi.putObject()
+	if err == nil {
+		poi.t.ratelimCharge(bck, dpq.user, poi.lom.Lsize())
+	}
+	return ecode, err
 }
 
 func (poi *putOI) putObject() (ecode int, err error) {
+	var (
+		buf  []byte
+		slab *memsys.Slab
+		lmfh cos.LomWriter
+		erw  error
+		res  qres
+	)
 	poi.ltime = mono.NanoTime()
 	// PUT is a no-op if the checksums do match
-	if !poi.skipVC && !poi.coldGET && !poi.cksumToUse.IsEmpty() {
+	if !poi.skipVC && !poi.coldGET && !poi.cksumToUse.IsEmpty() && (!poi.asIs || poi.lom.SSE() == poi.sse) {
 		if poi.lom.EqCksum(poi.cksumToUse) {
 			if cmn.Rom.FastV(4, cos.SmoduleAIS) {
 				nlog.Infoln(poi.lom.String(), "has identical", poi.cksumToUse.String(), "- PUT is a no-op")
@@ -189,16 +215,44 @@ func (poi *putOI) putObject() (ecode int, err error) {
 		}
 	}
 
-	buf, slab, lmfh, erw := poi.write()
+	// object lock (ditto)
+	if poi.owt < cmn.OwtRebalance && poi.lom.Bprops().ObjLock.Enabled {
+		if err = poi.t.chkOverwrite(poi.lom); err != nil {
+			ecode = http.StatusForbidden
+			poi._cleanup(nil, nil, nil, err)
+			goto rerr
+		}
+	}
+
+	// bucket quota (not enforcing when rebalancing, replicating, et al.)
+	if poi.owt < cmn.OwtRebalance {
+		if res, ecode, err = poi.t.quotas.reserve(poi.lom, poi.size); err != nil {
+			poi._cleanup(nil, nil, nil, err)
+			goto rerr
+		}
+	}
+
+	buf, slab, lmfh, erw = poi.write()
 	poi._cleanup(buf, slab, lmfh, erw)
 	if erw != nil {
 		err, ecode = erw, http.StatusInternalServerError
+		if erw == core.ErrStageNoMem {
+			ecode = http.StatusInsufficientStorage
+		}
+		poi.t.quotas.release(&res)
+		goto rerr
+	}
+	if ecode, err = poi.t.quotas.adjust(poi.lom.Bck(), &res, poi.lom.Lsize()); err != nil {
+		poi.t.quotas.release(&res)
+		poi.discard(err)
 		goto rerr
 	}
 
 	if ecode, err = poi.finalize(); err != nil {
+		poi.t.quotas.release(&res)
 		goto rerr
 	}
+	poi.t.quotas.commit(poi.lom.Bck(), &res, poi.lom.Lsize())
 
 	// resp. header & stats
 	if !poi.t2t {
@@ -231,6 +285,20 @@ rerr:
 	return ecode, err
 }
 
+// object lock: (not holding the object's lock - compare with quotas.reserve)
+// - existing object under retention or legal hold cannot be overwritten
+// - new object's lock attributes, if any, must be valid
+func (t *target) chkOverwrite(lom *core.LOM) error {
+	tmp := core.AllocLOM(lom.ObjName)
+	defer core.FreeLOM(tmp)
+	if tmp.InitBck(lom.Bucket()) == nil && tmp.Load(false /*cache it*/, false /*locked*/) == nil {
+		if err := tmp.CheckRetained(false); err != nil {
+			return err
+		}
+	}
+	return lom.ValidateRetention(nil, lom.GetCustomMD(), false)
+}
+
 func (poi *putOI) stats() {
 	var (
 		bck   = poi.lom.Bck()
@@ -293,6 +361,14 @@ func (poi *putOI) finalize() (ecode int, err error) {
 				nlog.Errorf(fmtNested, poi.t, err1, "remove", poi.workFQN, err2)
 			}
 		}
+		if poi.cw != nil {
+			poi.cw.Abort()
+			poi.cw = nil
+		}
+		if poi.sgl != nil {
+			core.StageFree(poi.sgl)
+			poi.sgl = nil
+		}
 		poi.lom.Uncache()
 		if ecode != http.StatusInsufficientStorage && cmn.IsErrCapExceeded(err) {
 			ecode = http.StatusInsufficientStorage
@@ -319,7 +395,7 @@ func (poi *putOI) fini() (ecode int, err error) {
 		bck = lom.Bck()
 	)
 	// put remote
-	if bck.IsRemote() && poi.owt < cmn.OwtRebalance {
+	if poi.remotePut() {
 		ecode, err = poi.putRemote()
 		if err != nil {
 			loghdr := poi.loghdr()
@@ -372,8 +448,43 @@ func (poi *putOI) fini() (ecode int, err error) {
 		}
 	}
 
+	if poi.owt < cmn.OwtRebalance {
+		lom.SetDefaultRetention()
+	}
+
+	// encryption at rest
+	switch {
+	case poi.asIs:
+		err = lom.SetSSE(poi.sse)
+	case poi.sgl != nil:
+		err = lom.SetSSE("") // (staged content is never encrypted - see lom.StageOK)
+	case !poi.encrypted && poi.cw != nil:
+		poi.cw, err = lom.EncryptChunks(poi.cw)
+	case !poi.encrypted:
+		err = lom.EncryptWork(poi.workFQN)
+	}
+	if err != nil {
+		return 0, err
+	}
+
 	// done
-	if err = lom.RenameFinalize(poi.workFQN); err != nil {
+	if poi.sgl != nil {
+		lom.SetSize(poi.sgl.Size())
+		if err = lom.Stage(poi.sgl); err != nil {
+			return 0, err
+		}
+		poi.sgl = nil // (owned by lom)
+	} else if poi.cw != nil {
+		if err = lom.ChunkFinalize(poi.cw); err != nil {
+			return 0, err
+		}
+		poi.cw = nil
+	} else if chunks := &lom.Bprops().Chunks; chunks.Enabled() && lom.Lsize() > int64(chunks.ObjSizeLimit) {
+		// fully written work file (see FinalizeObj)
+		if err = lom.SplitFinalize(poi.workFQN); err != nil {
+			return 0, err
+		}
+	} else if err = lom.RenameFinalize(poi.workFQN); err != nil {
 		return 0, err
 	}
 	if lom.HasCopies() {
@@ -387,14 +498,21 @@ func (poi *putOI) fini() (ecode int, err error) {
 	return 0, lom.PersistMain()
 }
 
+func (poi *putOI) remotePut() bool { return poi.lom.Bck().IsRemote() && poi.owt < cmn.OwtRebalance }
+
 // via backend.PutObj()
 func (poi *putOI) putRemote() (int, error) {
 	var (
 		lom       = poi.lom
 		startTime = mono.NanoTime()
 	)
-	lmfh, err := cos.NewFileHandle(poi.workFQN)
-	if err != nil {
+	var (
+		lmfh cos.ReadOpenCloser
+		err  error
+	)
+	if poi.cw != nil {
+		lmfh = poi.cw.NewReader()
+	} else if lmfh, err = cos.NewFileHandle(poi.workFQN); err != nil {
 		return 0, cmn.NewErrFailedTo(poi.t, "open", poi.workFQN, err)
 	}
 	if poi.owt == cmn.OwtPut && !lom.Bck().IsRemoteAIS() {
@@ -428,9 +546,35 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 			finalized bool           // to avoid computing the same checksum type twice
 		}{}
 		ckconf = poi.lom.CksumConf()
+		w      io.Writer
+		sw     *stageW
 	)
-	if lmfh, err = poi.lom.CreateWork(poi.workFQN); err != nil {
-		return
+	// write_policy.data = (delayed | never)
+	if poi.owt <= cmn.OwtCopy && !poi.coldGET {
+		var stage bool
+		if stage, err = poi.lom.StageOK(poi.size); err != nil {
+			return
+		}
+		if stage {
+			poi.sgl = poi.t.gmm.NewSGL(max(poi.size, 0))
+		}
+	}
+	if poi.sgl != nil {
+		sw = &stageW{poi: poi}
+		w = sw
+	} else {
+		if lmfh, err = poi.createWork(); err != nil {
+			return
+		}
+		// encrypt on the fly unless the content is also destined for remote backend (see fini)
+		if !poi.asIs && !poi.remotePut() {
+			var ew cos.LomWriter
+			if ew, poi.encrypted, err = poi.lom.EncryptWriter(lmfh); err != nil {
+				return
+			}
+			lmfh = ew
+		}
+		w = lmfh
 	}
 	if poi.size <= 0 {
 		buf, slab = poi.t.gmm.Alloc()
@@ -439,17 +583,25 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 	}
 
 	switch {
+	case poi.asIs && poi.sse != "":
+		// encrypted content: can only store it along with the original checksum (if any)
+		if poi.cksumToUse.IsEmpty() {
+			poi.lom.SetCksum(cos.NoneCksum)
+		} else {
+			poi.lom.SetCksum(poi.cksumToUse)
+		}
+		written, err = cos.CopyBuffer(w, poi.r, buf)
 	case ckconf.Type == cos.ChecksumNone:
 		poi.lom.SetCksum(cos.NoneCksum)
 		// not using `ReadFrom` of the `*os.File` -
 		// ultimately, https://github.com/golang/go/blob/master/src/internal/poll/copy_file_range_linux.go#L100
-		written, err = cos.CopyBuffer(lmfh, poi.r, buf)
+		written, err = cos.CopyBuffer(w, poi.r, buf)
 	case !poi.cksumToUse.IsEmpty() && !poi.validateCksum(ckconf):
 		// if the corresponding validation is not configured/enabled we just go ahead
 		// and use the checksum that has arrived with the object
 		poi.lom.SetCksum(poi.cksumToUse)
 		// (ditto)
-		written, err = cos.CopyBuffer(lmfh, poi.r, buf)
+		written, err = cos.CopyBuffer(w, poi.r, buf)
 	default:
 		writers := make([]io.Writer, 0, 3)
 		cksums.store = cos.NewCksumHash(ckconf.Type) // always according to the bucket
@@ -464,9 +616,12 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 				writers = append(writers, cksums.compt.H)
 			}
 		}
-		writers = append(writers, lmfh)
+		writers = append(writers, w)
 		written, err = cos.CopyBuffer(cos.NewWriterMulti(writers...), poi.r, buf) // (ditto)
 	}
+	if sw != nil && sw.lmfh != nil {
+		lmfh = sw.lmfh // spilled
+	}
 	if err != nil {
 		return
 	}
@@ -486,14 +641,25 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 	}
 
 	// ok
-	if poi.lom.IsFeatureSet(feat.FsyncPUT) {
-		err = lmfh.Sync() // compare w/ cos.FlushClose
-		debug.AssertNoErr(err)
+	if lmfh != nil {
+		if poi.lom.IsFeatureSet(feat.FsyncPUT) {
+			err = lmfh.Sync() // compare w/ cos.FlushClose
+			debug.AssertNoErr(err)
+		}
+		// (encrypting writer seals the last segment upon close)
+		errC := lmfh.Close()
+		lmfh = nil
+		if err == nil {
+			err = errC
+		}
+		if err != nil {
+			return
+		}
 	}
 
-	cos.Close(lmfh)
-	lmfh = nil
-
+	if poi.asIs && poi.sse != "" {
+		written = core.SSEPlainSize(written) // (encrypted content is larger - see core.SSESize)
+	}
 	poi.lom.SetSize(written) // TODO: compare with non-zero lom.Lsize() that may have been set via oa.FromHeader()
 	if cksums.store != nil {
 		if !cksums.finalized {
@@ -504,6 +670,46 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 	return
 }
 
+// staging writer: writes into poi.sgl subject to staging limits (see core.StageReserve);
+// once exceeded, spills write-delayed content to the work file
+type stageW struct {
+	poi  *putOI
+	lmfh cos.LomWriter
+}
+
+func (sw *stageW) Write(p []byte) (int, error) {
+	if sw.lmfh == nil {
+		poi := sw.poi
+		if core.StageReserve(poi.sgl.Size(), int64(len(p))) {
+			return poi.sgl.Write(p)
+		}
+		if poi.lom.DataWritePolicy() == apc.WriteNever {
+			return 0, core.ErrStageNoMem
+		}
+		if err := sw.spill(); err != nil {
+			return 0, err
+		}
+	}
+	return sw.lmfh.Write(p)
+}
+
+func (sw *stageW) spill() error {
+	poi := sw.poi
+	lmfh, err := poi.createWork()
+	if err != nil {
+		return err
+	}
+	if _, err = poi.sgl.WriteTo(lmfh); err != nil {
+		cos.Close(lmfh)
+		poi.rmWork(err)
+		return err
+	}
+	core.StageFree(poi.sgl)
+	poi.sgl = nil
+	sw.lmfh = lmfh
+	return nil
+}
+
 // post-write close & cleanup
 func (poi *putOI) _cleanup(buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, err error) {
 	if buf != nil {
@@ -516,8 +722,54 @@ func (poi *putOI) _cleanup(buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 
 	// not ok
 	poi.r.Close()
-	if nerr := lmfh.Close(); nerr != nil {
-		nlog.Errorf(fmtNested, poi.t, err, "close", poi.workFQN, nerr)
+	if poi.sgl != nil {
+		core.StageFree(poi.sgl)
+		poi.sgl = nil
+		return
+	}
+	if lmfh != nil {
+		if nerr := lmfh.Close(); nerr != nil {
+			nlog.Errorf(fmtNested, poi.t, err, "close", poi.workFQN, nerr)
+		}
+	}
+	poi.rmWork(err)
+}
+
+// written but not finalized
+func (poi *putOI) discard(err error) {
+	if poi.sgl != nil {
+		core.StageFree(poi.sgl)
+		poi.sgl = nil
+	} else {
+		poi.rmWork(err)
+	}
+	poi.lom.Uncache()
+}
+
+// objects that may exceed `chunks.objsize_limit` are written directly as chunks,
+// each to its own HRW mountpath (see core.ChunkWriter)
+func (poi *putOI) createWork() (cos.LomWriter, error) {
+	lom := poi.lom
+	if chunks := &lom.Bprops().Chunks; !chunks.Enabled() || (poi.size >= 0 && poi.size <= int64(chunks.ObjSizeLimit)) {
+		return lom.CreateWork(poi.workFQN)
+	}
+	size := poi.size
+	if size > 0 && !poi.asIs && !poi.remotePut() && lom.Bprops().SSE.Enabled {
+		size = core.SSESize(size) // (see write)
+	}
+	cw, err := lom.NewChunkWriter(poi.workFQN, size)
+	if err != nil {
+		return nil, err
+	}
+	poi.cw = cw
+	return cw, nil
+}
+
+func (poi *putOI) rmWork(err error) {
+	if poi.cw != nil {
+		poi.cw.Abort()
+		poi.cw = nil
+		return
 	}
 	if nerr := cos.RemoveFile(poi.workFQN); nerr != nil && !os.IsNotExist(nerr) {
 		nlog.Errorf(fmtNested, poi.t, err, "remove", poi.workFQN, nerr)
@@ -983,17 +1235,24 @@ func (goi *getOI) getFromNeighbor(lom *core.LOM, tsi *meta.Snode) bool {
 
 func (goi *getOI) txfini() (ecode int, err error) {
 	var (
-		lmfh *os.File
+		lmfh cos.LomReader
 		hrng *htrange
 		fqn  = goi.lom.FQN
 		dpq  = goi.dpq
 	)
-	if !goi.cold && !dpq.isGFN && !goi.lom.IsChunked() {
-		fqn = goi.lom.LBGet() // best-effort GET load balancing (see also mirror.findLeastUtilized())
-	}
 	// open
-	// TODO -- FIXME: use lom.Open() instead of os.Open(); TestECChecksum
-	lmfh, err = os.Open(fqn)
+	switch {
+	case goi.lom.IsStaged():
+		lmfh, err = goi.lom.Open() // in-memory (write_policy.data = (delayed | never))
+	case goi.lom.IsChunked():
+		lmfh, err = goi.lom.Open()
+	default:
+		if !goi.cold && !dpq.isGFN && !goi.lom.IsChunked() {
+			fqn = goi.lom.LBGet() // best-effort GET load balancing (see also mirror.findLeastUtilized())
+		}
+		// TODO -- FIXME: use lom.Open() instead of os.Open(); TestECChecksum
+		lmfh, err = goi.lom.OpenFQN(fqn)
+	}
 	if err != nil {
 		if os.IsNotExist(err) {
 			// NOTE: retry only once and only when ec-enabled - see goi.restoreFromAny()
@@ -1009,10 +1268,11 @@ func (goi *getOI) txfini() (ecode int, err error) {
 
 	whdr := goi.w.Header()
 
-	// transmit (range, arch, regular)
+	// transmit (arch, range, regular)
 	switch {
+	case dpq.isArch():
+		ecode, err = goi._txarch(fqn, lmfh, whdr)
 	case goi.ranges.Range != "":
-		debug.Assert(!dpq.isArch())
 		rsize := goi.lom.Lsize()
 		if goi.ranges.Size > 0 {
 			rsize = goi.ranges.Size
@@ -1021,8 +1281,6 @@ func (goi *getOI) txfini() (ecode int, err error) {
 			break
 		}
 		err = goi._txrng(fqn, lmfh, whdr, hrng)
-	case dpq.isArch():
-		err = goi._txarch(fqn, lmfh, whdr)
 	default:
 		err = goi._txreg(fqn, lmfh, whdr)
 	}
@@ -1031,7 +1289,7 @@ func (goi *getOI) txfini() (ecode int, err error) {
 	return ecode, err
 }
 
-func (goi *getOI) _txrng(fqn string, lmfh *os.File, whdr http.Header, hrng *htrange) (err error) {
+func (goi *getOI) _txrng(fqn string, lmfh cos.LomReader, whdr http.Header, hrng *htrange) (err error) {
 	var (
 		r     io.Reader
 		lom   = goi.lom
@@ -1071,7 +1329,7 @@ func (goi *getOI) _txrng(fqn string, lmfh *os.File, whdr http.Header, hrng *htra
 }
 
 // in particular, setup reader and writer and set headers
-func (goi *getOI) _txreg(fqn string, lmfh *os.File, whdr http.Header) (err error) {
+func (goi *getOI) _txreg(fqn string, lmfh cos.LomReader, whdr http.Header) (err error) {
 	var (
 		dpq   = goi.dpq
 		lom   = goi.lom
@@ -1084,6 +1342,8 @@ func (goi *getOI) _txreg(fqn string, lmfh *os.File, whdr http.Header) (err error
 	if dpq.isS3 {
 		// (expecting user to set bucket checksum = md5)
 		s3.SetEtag(whdr, lom)
+		s3.SetSSE(whdr, lom)
+		s3.SetObjLock(whdr, lom)
 	}
 
 	buf, slab := goi.t.gmm.AllocSize(min(size, memsys.DefaultBuf2Size))
@@ -1092,8 +1352,10 @@ func (goi *getOI) _txreg(fqn string, lmfh *os.File, whdr http.Header) (err error
 	return err
 }
 
-// TODO: checksum
-func (goi *getOI) _txarch(fqn string, lmfh *os.File, whdr http.Header) error {
+// archived file's checksum: zip stores CRC32 (and the zip reader validates it upon EOF);
+// for all other formats the checksum (of the bucket-configured type) is computed on the fly
+// and sent as HTTP trailer
+func (goi *getOI) _txarch(fqn string, lmfh cos.LomReader, whdr http.Header) (int, error) {
 	var (
 		ar  archive.Reader
 		dpq = goi.dpq
@@ -1101,11 +1363,11 @@ func (goi *getOI) _txarch(fqn string, lmfh *os.File, whdr http.Header) error {
 	)
 	mime, err := archive.MimeFile(lmfh, goi.t.smm, dpq.arch.mime, lom.ObjName)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	ar, err = archive.NewReader(mime, lmfh, lom.Lsize())
 	if err != nil {
-		return fmt.Errorf("failed to open %s: %w", lom.Cname(), err)
+		return 0, fmt.Errorf("failed to open %s: %w", lom.Cname(), err)
 	}
 
 	// single
@@ -1115,18 +1377,47 @@ func (goi *getOI) _txarch(fqn string, lmfh *os.File, whdr http.Header) error {
 		csl, err = ar.ReadOne(dpq.arch.path)
 		if err != nil {
 			goi.isIOErr = true
-			return cmn.NewErrFailedTo(goi.t, "extract "+dpq._archstr()+" from", lom.Cname(), err)
+			return 0, cmn.NewErrFailedTo(goi.t, "extract "+dpq._archstr()+" from", lom.Cname(), err)
 		}
 		if csl == nil {
-			return cos.NewErrNotFound(goi.t, dpq._archstr()+" in "+lom.Cname())
+			return http.StatusNotFound, cos.NewErrNotFound(goi.t, dpq._archstr()+" in "+lom.Cname())
 		}
 		// found
+		var (
+			cksum  *cos.Cksum
+			ckconf = lom.CksumConf()
+			rng    = goi.ranges.Range != ""
+		)
+		if rng {
+			var ecode int
+			if csl, ecode, err = goi._archrng(csl, whdr); err != nil {
+				return ecode, err
+			}
+		} else {
+			cksum = archive.Cksum(csl)
+		}
 		whdr.Set(cos.HdrContentType, cos.ContentBinary)
+
+		var (
+			r      io.Reader = csl
+			cksumH *cos.CksumHash
+		)
+		switch {
+		case !cksum.IsEmpty():
+			whdr.Set(apc.HdrObjCksumType, cksum.Ty())
+			whdr.Set(apc.HdrObjCksumVal, cksum.Val())
+		case ckconf.Type != cos.ChecksumNone && (!rng || ckconf.EnableReadRange):
+			r, cksumH = _archcksum(csl, whdr, ckconf.Type)
+		}
 		buf, slab := goi.t.gmm.AllocSize(min(csl.Size(), memsys.DefaultBuf2Size))
-		err = goi.transmit(csl, buf, fqn)
+		err = goi.transmit(r, buf, fqn)
 		slab.Free(buf)
+		if err == nil && cksumH != nil {
+			cksumH.Finalize()
+			whdr.Set(apc.HdrObjCksumVal, cksumH.Val())
+		}
 		csl.Close()
-		return err
+		return 0, err
 	}
 
 	// multi match; writing & streaming tar =>(directly)=> response writer
@@ -1140,10 +1431,41 @@ func (goi *getOI) _txarch(fqn string, lmfh *os.File, whdr http.Header) error {
 	}
 	if err == nil && rcb.num == 0 {
 		// none found
-		return cos.NewErrNotFound(goi.t, dpq._archstr()+" in "+lom.Cname())
+		return http.StatusNotFound, cos.NewErrNotFound(goi.t, dpq._archstr()+" in "+lom.Cname())
 	}
 	rcb.fini()
-	return err
+	return 0, err
+}
+
+// range-read archived file: validate user-specified range against the file's (not the shard's) size
+func (goi *getOI) _archrng(csl cos.ReadCloseSizer, whdr http.Header) (cos.ReadCloseSizer, int, error) {
+	hrng, ecode, err := goi.rngToHeader(whdr, csl.Size())
+	if err != nil {
+		csl.Close()
+		return nil, ecode, err
+	}
+	if hrng == nil {
+		return csl, 0, nil
+	}
+	rsl, err := archive.ReadRange(csl, hrng.Start, hrng.Length)
+	if err != nil {
+		goi.isIOErr = true
+		csl.Close()
+		return nil, 0, cmn.NewErrFailedTo(goi.t, "range-read "+goi.dpq._archstr()+" from", goi.lom.Cname(), err)
+	}
+	whdr.Set(cos.HdrContentLength, strconv.FormatInt(hrng.Length, 10))
+	return rsl, 0, nil
+}
+
+// the archive doesn't store archived file's checksum - compute it on the fly
+// and send the value as HTTP trailer (which also requires chunked transfer encoding,
+// hence no Content-Length)
+func _archcksum(csl cos.ReadCloseSizer, whdr http.Header, cksumType string) (io.Reader, *cos.CksumHash) {
+	cksumH := cos.NewCksumHash(cksumType)
+	whdr.Set(apc.HdrObjCksumType, cksumType)
+	whdr.Set(cos.HdrTrailer, apc.HdrObjCksumVal)
+	whdr.Del(cos.HdrContentLength)
+	return io.TeeReader(csl, cksumH.H), cksumH
 }
 
 func (goi *getOI) transmit(r io.Reader, buf []byte, fqn string) error {
@@ -1182,6 +1504,7 @@ func (goi *getOI) transmit(r io.Reader, buf []byte, fqn string) error {
 }
 
 func (goi *getOI) stats(written int64) {
+	goi.sent = written
 	delta := mono.SinceNano(goi.ltime)
 	goi.t.statsT.AddMany(
 		cos.NamedVal64{Name: stats.GetCount, Value: 1},
@@ -1236,12 +1559,6 @@ func (goi *getOI) rngToHeader(resphdr http.Header, size int64) (hrng *htrange, e
 		ecode = http.StatusRequestedRangeNotSatisfiable
 		return
 	}
-	if goi.dpq.arch.path != "" {
-		err = cmn.NewErrUnsupp("range-read archived file", goi.dpq.arch.path)
-		ecode = http.StatusRequestedRangeNotSatisfiable
-		return
-	}
-
 	// set response header
 	hrng = &ranges[0]
 	resphdr.Set(cos.HdrAcceptRanges, "bytes")
@@ -1272,9 +1589,18 @@ func (a *apndOI) do(r *http.Request) (packedHdl string, ecode int, err error) {
 
 	switch a.op {
 	case apc.AppendOp:
+		var res qres
+		if res, ecode, err = a.t.quotas.reserveApnd(a.lom, a.size, a.hdl.workFQN == "" /*first*/); err != nil {
+			return packedHdl, ecode, err
+		}
 		buf, slab := a.t.gmm.Alloc()
 		packedHdl, ecode, err = a.apnd(buf)
 		slab.Free(buf)
+		if err != nil {
+			a.t.quotas.release(&res)
+		} else {
+			a.t.quotas.commit(a.lom.Bck(), &res, res.size)
+		}
 	case apc.FlushOp:
 		ecode, err = a.flush()
 	default:
@@ -1294,7 +1620,7 @@ func (a *apndOI) apnd(buf []byte) (packedHdl string, ecode int, err error) {
 		workFQN = fs.CSM.Gen(a.lom, fs.WorkfileType, fs.WorkfileAppend)
 		a.lom.Lock(false)
 		if a.lom.Load(false /*cache it*/, false /*locked*/) == nil {
-			_, a.hdl.partialCksum, err = cos.CopyFile(a.lom.FQN, workFQN, buf, a.lom.CksumType())
+			a.hdl.partialCksum, err = a.lom.CopyContent(workFQN, buf, a.lom.CksumType())
 			a.lom.Unlock(false)
 			if err != nil {
 				ecode = http.StatusInternalServerError
@@ -1525,12 +1851,29 @@ func (coi *copyOI) _regular(t *target, lom, dst *core.LOM) (size int64, _ error)
 			if lom.EqCksum(dst.Checksum()) {
 				return 0, nil
 			}
+			if err := dst.CheckRetained(false); err != nil {
+				return 0, err
+			}
 		} else if cmn.IsErrBucketNought(err) {
 			return 0, err
 		}
 	}
+	// bucket quota (compare with putObject)
+	var res qres
+	if !lcopy && coi.OWT < cmn.OwtRebalance {
+		var err error
+		if res, _, err = t.quotas.reserve(dst, lom.Lsize()); err != nil {
+			return 0, err
+		}
+	}
 	dst2, err := lom.Copy2FQN(dst.FQN, coi.Buf)
-	if err == nil {
+	if err == nil && !lcopy && dst2.SetDefaultRetention() {
+		err = dst2.Persist()
+	}
+	if err != nil {
+		t.quotas.release(&res)
+	} else {
+		t.quotas.commit(dst.Bck(), &res, lom.Lsize())
 		size = lom.Lsize()
 		if coi.Finalize {
 			t.putMirror(dst2)
@@ -1721,9 +2064,11 @@ func (a *putA2I) do() (int, error) {
 		}
 		// do - fast
 		if size, err = a.fast(fh, tarFormat, offset); err == nil {
-			// TODO: checksum NIY
-			if err = a.finalize(size, cos.NoneCksum, workFQN); err == nil {
-				return http.StatusInternalServerError, nil // ok
+			var cksum *cos.Cksum
+			if cksum, err = a.cksum(workFQN, size); err == nil {
+				if err = a.finalize(size, cksum, workFQN); err == nil {
+					return http.StatusInternalServerError, nil // ok
+				}
 			}
 		} else if errV := a.lom.RenameToMain(workFQN); errV != nil {
 			nlog.Errorf(fmtNested, a.t, err, "append and rename back", workFQN, errV)
@@ -1748,8 +2093,8 @@ cpap: // copy + append
 	// currently, arch writers only use size and time but it may change
 	oah := cos.SimpleOAH{Size: a.size, Atime: a.started}
 	if a.put {
-		// when append becomes PUT (TODO: checksum type)
-		cksum.Init(cos.ChecksumXXHash)
+		// when append becomes PUT
+		cksum.Init(a.lom.CksumType())
 		aw = archive.NewWriter(a.mime, wfh, &cksum, nil /*opts*/)
 		err = aw.Write(a.filename, oah, a.r)
 		aw.Fini()
@@ -1807,6 +2152,15 @@ func (a *putA2I) fast(rwfh *os.File, tarFormat tar.Format, offset int64) (size i
 	return
 }
 
+// recompute checksum of the entire (appended-to) TAR
+func (a *putA2I) cksum(workFQN string, size int64) (*cos.Cksum, error) {
+	n, cksum, err := cos.ChecksumFile(workFQN, a.lom.CksumType())
+	if err == nil && n != size {
+		err = fmt.Errorf("%s: size mismatch upon append (%d vs %d)", a.lom.Cname(), n, size)
+	}
+	return cksum, err
+}
+
 func (*putA2I) reterr(err error) (int, error) {
 	ecode := http.StatusInternalServerError
 	if cmn.IsErrCapExceeded(err) {
@@ -1822,6 +2176,9 @@ func (a *putA2I) finalize(size int64, cksum *cos.Cksum, fqn string) error {
 		debug.Assertf(finfo.Size() == size, "%d != %d", finfo.Size(), size)
 	})
 	// done
+	if err := a.lom.EncryptWork(fqn); err != nil {
+		return err
+	}
 	if err := a.lom.RenameFinalize(fqn); err != nil {
 		return err
 	}
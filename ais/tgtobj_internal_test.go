@@ -5,9 +5,13 @@
 package ais
 
 import (
+	"bytes"
 	"flag"
+	"fmt"
+	"hash/crc32"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"testing"
@@ -15,12 +19,14 @@ import (
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/core/mock"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/tools/readers"
+	"github.com/NVIDIA/aistore/tools/tassert"
 )
 
 const (
@@ -265,3 +271,76 @@ func BenchmarkObjGetDiscard(b *testing.B) {
 		})
 	}
 }
+
+// archived file's checksum: stored by the archive (zip) - in the header;
+// otherwise, computed on the fly - in the trailer
+func TestGetArchCksum(t *testing.T) {
+	const (
+		archBucket = "arch-bck"
+		content    = "archived file content"
+	)
+	tgt := testTarget()
+	bck := meta.NewBck(archBucket, apc.AIS, cmn.NsGlobal)
+	if _, present := tgt.owner.bmd.get().Get(bck); !present {
+		bmd := tgt.owner.bmd.get().clone()
+		bmd.add(bck, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}})
+		tgt.owner.bmd.putPersist(bmd, nil)
+		errs := fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+		tassert.Fatalf(t, len(errs) == 0, "failed to create %s: %v", bck, errs)
+	}
+	expected := cos.NewCksumHash(cos.ChecksumXXHash)
+	expected.H.Write([]byte(content))
+	expected.Finalize()
+
+	for _, ext := range []string{archive.ExtTar, archive.ExtTgz, archive.ExtZip} {
+		lom := core.AllocLOM("shard" + ext)
+		tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+		fh, err := cos.CreateFile(lom.FQN)
+		tassert.CheckFatal(t, err)
+		aw := archive.NewWriter(ext, fh, nil, nil)
+		err = aw.Write("a/b.txt", cos.SimpleOAH{Size: int64(len(content))}, bytes.NewReader([]byte(content)))
+		tassert.CheckFatal(t, err)
+		aw.Fini()
+		size, err := fh.Seek(0, io.SeekCurrent)
+		tassert.CheckFatal(t, err)
+		tassert.CheckFatal(t, fh.Close())
+		lom.SetSize(size)
+		lom.SetAtimeUnix(time.Now().UnixNano())
+		tassert.CheckFatal(t, lom.Persist())
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			lmfh, err := lom.Open()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			goi := &getOI{t: tgt, lom: lom, w: w, dpq: &dpq{}}
+			goi.dpq.arch.path = "a/b.txt"
+			if _, err := goi._txarch(lom.FQN, lmfh, w.Header()); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			cos.Close(lmfh)
+		}))
+		resp, err := http.Get(srv.URL)
+		tassert.CheckFatal(t, err)
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		srv.Close()
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, string(b) == content, "%s: expected %q, got %q", ext, content, b)
+
+		if ext == archive.ExtZip {
+			crc := fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(content)))
+			tassert.Errorf(t, resp.Header.Get(apc.HdrObjCksumType) == cos.ChecksumCRC32 && resp.Header.Get(apc.HdrObjCksumVal) == crc,
+				"%s: expected %s %s in the header, got %v", ext, cos.ChecksumCRC32, crc, resp.Header)
+		} else {
+			tassert.Errorf(t, resp.Header.Get(apc.HdrObjCksumType) == cos.ChecksumXXHash, "%s: expected %s checksum type, got %v",
+				ext, cos.ChecksumXXHash, resp.Header)
+			tassert.Errorf(t, resp.ContentLength == -1, "%s: expected chunked response, got content-length %d", ext, resp.ContentLength)
+			val := resp.Trailer.Get(apc.HdrObjCksumVal)
+			tassert.Errorf(t, val == expected.Val(), "%s: expected %s in the trailer, got %q", ext, expected.Val(), val)
+		}
+		lom.RemoveMain()
+		core.FreeLOM(lom)
+	}
+}
@@ -0,0 +1,325 @@
+// Package ais provides AIStore's proxy and target nodes.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+)
+
+// Per-bucket capacity and object-count quotas (see cmn.QuotaConf).
+//
+// Each target enforces its share of the configured limits - that is, the limit
+// divided by the number of active targets (which, given uniform HRW distribution,
+// is also the expected per-target share of the bucket's content).
+// Local usage is computed the same way bucket summary computes on-disk size
+// (see fs.OnDiskSize), in the background and periodically (see quotas.housekeep).
+// In-between, PUT, APPEND, and copy maintain it incrementally (as do DELETE, evict,
+// soft-delete, and undelete - see quotas.update): each write first reserves
+// its size (and, when creating new object, object count) - atomically, via add-then-rollback -
+// and then either commits or releases the reservation.
+// When the size is not known in advance (e.g., streaming PUT), the write reserves the
+// (actual) size once the object is written but prior to finalizing it (see quotas.adjust).
+// Until the bucket's first refresh completes, local usage includes only the writes
+// since this target has started.
+//
+// Writes that would exceed the share fail with cmn.ErrQuotaExceeded (http.StatusForbidden);
+// crossing the soft limit (`quota.soft_pct`) raises cos.BucketQuota node alert.
+
+const (
+	quotaName = "bucket-quota"
+	quotaIval = time.Minute
+)
+
+type (
+	quotas struct {
+		t  *target
+		m  map[uint64]*bquota // by bucket ID
+		mu sync.RWMutex
+	}
+	bquota struct {
+		size       atomic.Int64 // local on-disk size (as of the last refresh, plus committed writes)
+		objs       atomic.Int64 // local number of objects (ditto)
+		rsize      atomic.Int64 // reserved by writes in progress
+		robjs      atomic.Int64 // ditto
+		refreshed  atomic.Int64 // mono time (zero - never)
+		refreshing atomic.Bool
+		soft       atomic.Bool // over the soft limit
+	}
+	// reserved by a write in progress (zero value: nothing reserved)
+	qres struct {
+		bq   *bquota
+		size int64 // (negative when overwriting with a smaller object)
+		prev int64 // size of the object that is being overwritten (PUT only)
+		objs int64
+	}
+)
+
+func (q *quotas) init(t *target) {
+	q.t = t
+	q.m = make(map[uint64]*bquota, 4)
+	hk.Reg(quotaName+hk.NameSuffix, q.housekeep, quotaIval)
+}
+
+func (q *quotas) get(bck *meta.Bck) *bquota {
+	bid := bck.Props.BID
+	q.mu.RLock()
+	bq, ok := q.m[bid]
+	q.mu.RUnlock()
+	if !ok {
+		q.mu.Lock()
+		if bq, ok = q.m[bid]; !ok {
+			bq = &bquota{}
+			q.m[bid] = bq
+		}
+		q.mu.Unlock()
+	}
+	if bq.refreshed.Load() == 0 {
+		q.refresh(bck, bq)
+	}
+	return bq
+}
+
+// this target's share of the limit (non-zero)
+func (q *quotas) share(limit int64) int64 {
+	nat := int64(q.t.owner.smap.get().CountActiveTs())
+	if nat <= 1 {
+		return limit
+	}
+	return max((limit+nat-1)/nat, 1)
+}
+
+// PUT: reserve for the object that is about to be written (and may overwrite existing one);
+// the caller must either commit or release the reservation
+func (q *quotas) reserve(lom *core.LOM, size int64) (qres, int, error) {
+	bck := lom.Bck()
+	if !bck.Props.Quota.Enabled() {
+		return qres{}, 0, nil
+	}
+	prev := _lsize(lom)
+	res, ecode, err := q._reserve(bck, max(size, 0)-max(prev, 0), prev < 0)
+	res.prev = max(prev, 0)
+	return res, ecode, err
+}
+
+// APPEND: reserve for the bytes that are about to be appended;
+// first append to a new object also counts as object
+func (q *quotas) reserveApnd(lom *core.LOM, size int64, first bool) (qres, int, error) {
+	bck := lom.Bck()
+	if !bck.Props.Quota.Enabled() {
+		return qres{}, 0, nil
+	}
+	return q._reserve(bck, max(size, 0), first && _lsize(lom) < 0)
+}
+
+// returns -1 if the object does not exist
+func _lsize(lom *core.LOM) (size int64) {
+	size = -1
+	tmp := core.AllocLOM(lom.ObjName)
+	if tmp.InitBck(lom.Bucket()) == nil && tmp.Load(false /*cache it*/, false /*locked*/) == nil {
+		size = tmp.Lsize()
+	}
+	core.FreeLOM(tmp)
+	return size
+}
+
+// add-then-rollback
+func (q *quotas) _reserve(bck *meta.Bck, size int64, newObj bool) (qres, int, error) {
+	var (
+		conf = &bck.Props.Quota
+		bq   = q.get(bck)
+	)
+	if err := q._size(bck, bq, size); err != nil {
+		return qres{}, http.StatusForbidden, err
+	}
+	res := qres{bq: bq, size: size}
+	if !newObj {
+		return res, 0, nil
+	}
+	res.objs = 1
+	used := bq.robjs.Inc() + bq.objs.Load()
+	if conf.MaxObjs > 0 {
+		if share := q.share(conf.MaxObjs); used > share {
+			q.release(&res)
+			return qres{}, http.StatusForbidden, cmn.NewErrQuotaExceeded(bck.Cname(""), "max_objs", conf.MaxObjs, used-1, share)
+		}
+	}
+	return res, 0, nil
+}
+
+// (ditto)
+func (q *quotas) _size(bck *meta.Bck, bq *bquota, size int64) error {
+	conf := &bck.Props.Quota
+	used := bq.rsize.Add(size) + bq.size.Load()
+	if conf.MaxSize > 0 {
+		limit := int64(conf.MaxSize)
+		if share := q.share(limit); used > share {
+			bq.rsize.Sub(size)
+			return cmn.NewErrQuotaExceeded(bck.Cname(""), "max_size", limit, used-size, share)
+		}
+	}
+	return nil
+}
+
+// PUT: reserve the remaining (actual) size of the written object - the size that was not known
+// in advance or was understated; the caller must not finalize the object if this fails
+func (q *quotas) adjust(bck *meta.Bck, res *qres, size int64) (int, error) {
+	if res.bq == nil {
+		return 0, nil
+	}
+	extra := size - res.prev - res.size
+	if extra <= 0 {
+		return 0, nil
+	}
+	if err := q._size(bck, res.bq, extra); err != nil {
+		return http.StatusForbidden, err
+	}
+	res.size += extra
+	return 0, nil
+}
+
+// account for a successfully written object or appended bytes (PUT: the resulting
+// object's size; APPEND: the number of appended bytes)
+func (q *quotas) commit(bck *meta.Bck, res *qres, size int64) {
+	bq := res.bq
+	if bq == nil {
+		return
+	}
+	bq.size.Add(size - res.prev)
+	bq.objs.Add(res.objs)
+	q.release(res)
+	q.soft(bck, bq)
+}
+
+// account for a successfully removed (deleted, evicted, or soft-deleted: negative size
+// and count) or restored (undeleted) object
+func (q *quotas) update(bck *meta.Bck, size, objs int64) {
+	if !bck.Props.Quota.Enabled() {
+		return
+	}
+	bq := q.get(bck)
+	bq.size.Add(size)
+	bq.objs.Add(objs)
+	q.soft(bck, bq)
+}
+
+func (*quotas) release(res *qres) {
+	if bq := res.bq; bq != nil {
+		bq.rsize.Sub(res.size)
+		bq.robjs.Sub(res.objs)
+		res.bq = nil
+	}
+}
+
+// raise (or clear) node alert when crossing the soft limit
+func (q *quotas) soft(bck *meta.Bck, bq *bquota) {
+	var (
+		conf = &bck.Props.Quota
+		over bool
+	)
+	if conf.MaxSize > 0 {
+		over = bq.size.Load()*100 >= q.share(int64(conf.MaxSize))*conf.Soft()
+	}
+	if conf.MaxObjs > 0 && !over {
+		over = bq.objs.Load()*100 >= q.share(conf.MaxObjs)*conf.Soft()
+	}
+	if over == bq.soft.Load() {
+		return
+	}
+	bq.soft.Store(over)
+	if over {
+		nlog.Warningln(q.t.String(), bck.Cname(""), "is over the soft quota limit:", conf.String(),
+			"[ local size", cos.ToSizeIEC(bq.size.Load(), 2), "objects", bq.objs.Load(), "]")
+		q.t.statsT.SetFlag(cos.NodeAlerts, cos.BucketQuota)
+	}
+}
+
+// in the background (no-op if already refreshing)
+func (q *quotas) refresh(bck *meta.Bck, bq *bquota) {
+	if !bq.refreshing.CAS(false, true) {
+		return
+	}
+	go func() {
+		bq.refresh(bck)
+		q.soft(bck, bq)
+		bq.refreshing.Store(false)
+	}()
+}
+
+// the walk takes time: writes committed in the meantime are accounted for
+// by adding the difference (rather than storing the walked values as is)
+func (bq *bquota) refresh(bck *meta.Bck) {
+	var (
+		objs  int64
+		size  = bq.size.Load() // (snapshot)
+		nobjs = bq.objs.Load()
+		avail = fs.GetAvail()
+		cb    = func(_ string, de fs.DirEntry) error {
+			if !de.IsDir() {
+				objs++
+			}
+			return nil
+		}
+	)
+	for _, mi := range avail {
+		opts := &fs.WalkOpts{Mi: mi, CTs: []string{fs.ObjectType}, Callback: cb}
+		opts.Bck.Copy(bck.Bucket())
+		if err := fs.Walk(opts); err != nil {
+			nlog.Warningln("failed to count objects:", err, "["+mi.String(), bck.Cname("")+"]")
+		}
+	}
+	bq.size.Add(int64(fs.OnDiskSize(bck.Bucket(), "")) - size)
+	bq.objs.Add(objs - nobjs)
+	bq.refreshed.Store(mono.NanoTime())
+}
+
+// periodically refresh local usage of all buckets with quotas;
+// cleanup removed buckets (and disabled quotas), update node alert
+func (q *quotas) housekeep(int64) time.Duration {
+	if !q.t.ClusterStarted() || nlog.Stopping() {
+		return quotaIval
+	}
+	var (
+		soft bool
+		bids = make(map[uint64]struct{}, 4)
+		bmd  = q.t.owner.bmd.get()
+	)
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		if !bck.Props.Quota.Enabled() {
+			return false
+		}
+		bids[bck.Props.BID] = struct{}{}
+		bq := q.get(bck)
+		if mono.Since(bq.refreshed.Load()) >= quotaIval {
+			q.refresh(bck, bq)
+		}
+		soft = soft || bq.soft.Load()
+		return false
+	})
+
+	q.mu.Lock()
+	for bid := range q.m {
+		if _, ok := bids[bid]; !ok {
+			delete(q.m, bid)
+		}
+	}
+	q.mu.Unlock()
+
+	if !soft {
+		q.t.statsT.ClrFlag(cos.NodeAlerts, cos.BucketQuota)
+	}
+	return quotaIval
+}
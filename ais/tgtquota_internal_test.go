@@ -0,0 +1,270 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+const quotaBucket = "quota-bck"
+
+// two active targets, 1KiB and 4 objects per target
+func newTestQuotas(t *testing.T) (*quotas, *meta.Bck) {
+	tgt := testTarget()
+	if tgt.owner.smap.get() == nil || tgt.owner.smap.get().CountActiveTs() != 2 {
+		smap := newSmap()
+		smap.addTarget(tgt.si)
+		tsi := &meta.Snode{}
+		tsi.Init("quota-other", apc.Target)
+		smap.addTarget(tsi)
+		tgt.owner.smap.put(smap)
+	}
+	bck := meta.NewBck(quotaBucket, apc.AIS, cmn.NsGlobal)
+	if _, present := tgt.owner.bmd.get().Get(bck); !present {
+		bmd := tgt.owner.bmd.get().clone()
+		bmd.add(bck, &cmn.Bprops{
+			Cksum: cmn.CksumConf{Type: cos.ChecksumNone},
+			Quota: cmn.QuotaConf{MaxSize: 2 * cos.KiB, MaxObjs: 8},
+		})
+		tgt.owner.bmd.putPersist(bmd, nil)
+		errs := fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+		tassert.Fatalf(t, len(errs) == 0, "failed to create %s: %v", bck, errs)
+	}
+	tassert.CheckFatal(t, bck.Init(tgt.owner.bmd))
+
+	q := &tgt.quotas // (see delobj)
+	q.t, q.m = tgt, make(map[uint64]*bquota, 1)
+	// (no refresh)
+	q.m[bck.Props.BID] = &bquota{}
+	q.m[bck.Props.BID].refreshed.Store(1)
+	return q, bck
+}
+
+func newQuotaLOM(t *testing.T, objName string) *core.LOM {
+	lom := core.AllocLOM(objName)
+	tassert.CheckFatal(t, lom.InitBck(&cmn.Bck{Name: quotaBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}))
+	return lom
+}
+
+func TestQuotaReserve(t *testing.T) {
+	q, bck := newTestQuotas(t)
+	lom := newQuotaLOM(t, "obj")
+	defer core.FreeLOM(lom)
+
+	res, _, err := q.reserve(lom, 600)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, res.size == 600 && res.objs == 1, "expected (600, 1), got (%d, %d)", res.size, res.objs)
+
+	// max_size
+	_, ecode, err := q.reserve(lom, 500)
+	tassert.Errorf(t, cmn.IsErrQuotaExceeded(err) && ecode == 403, "expected quota exceeded, got (%d, %v)", ecode, err)
+
+	// commit the actual size
+	q.commit(bck, &res, 100)
+	bq := q.get(bck)
+	tassert.Errorf(t, bq.size.Load() == 100 && bq.objs.Load() == 1, "expected (100, 1), got (%d, %d)", bq.size.Load(), bq.objs.Load())
+	tassert.Errorf(t, bq.rsize.Load() == 0 && bq.robjs.Load() == 0, "expected nothing reserved, got (%d, %d)", bq.rsize.Load(), bq.robjs.Load())
+	q.commit(bck, &res, 100) // (no-op)
+	tassert.Errorf(t, bq.size.Load() == 100, "expected 100, got %d", bq.size.Load())
+
+	// release
+	res, _, err = q.reserve(lom, 900)
+	tassert.CheckFatal(t, err)
+	q.release(&res)
+	q.release(&res) // (no-op)
+	tassert.Errorf(t, bq.rsize.Load() == 0 && bq.robjs.Load() == 0, "expected nothing reserved, got (%d, %d)", bq.rsize.Load(), bq.robjs.Load())
+	tassert.Errorf(t, bq.size.Load() == 100 && bq.objs.Load() == 1, "expected (100, 1), got (%d, %d)", bq.size.Load(), bq.objs.Load())
+}
+
+func TestQuotaOverwrite(t *testing.T) {
+	q, bck := newTestQuotas(t)
+	lom := newQuotaLOM(t, "obj-overwrite")
+	defer core.FreeLOM(lom)
+	fh, err := cos.CreateFile(lom.FQN)
+	tassert.CheckFatal(t, err)
+	_, err = fh.Write(make([]byte, 800))
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, fh.Close())
+	lom.SetSize(800)
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	tassert.CheckFatal(t, lom.Persist())
+	defer lom.RemoveMain()
+
+	bq := q.get(bck)
+	bq.size.Store(800)
+	bq.objs.Store(1)
+
+	// replacing 800 bytes with 1000 (and not counting the object)
+	res, _, err := q.reserve(lom, 1000)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, res.size == 200 && res.objs == 0 && res.prev == 800, "expected (200, 0, 800), got (%d, %d, %d)", res.size, res.objs, res.prev)
+	q.commit(bck, &res, 1000)
+	tassert.Errorf(t, bq.size.Load() == 1000 && bq.objs.Load() == 1, "expected (1000, 1), got (%d, %d)", bq.size.Load(), bq.objs.Load())
+}
+
+func TestQuotaMaxObjs(t *testing.T) {
+	q, bck := newTestQuotas(t)
+	bq := q.get(bck)
+	bq.objs.Store(4)
+
+	lom := newQuotaLOM(t, "obj-new")
+	defer core.FreeLOM(lom)
+	_, ecode, err := q.reserve(lom, 1)
+	tassert.Errorf(t, cmn.IsErrQuotaExceeded(err) && ecode == 403, "expected quota exceeded, got (%d, %v)", ecode, err)
+
+	// rolled back (both)
+	tassert.Errorf(t, bq.rsize.Load() == 0 && bq.robjs.Load() == 0, "expected nothing reserved, got (%d, %d)", bq.rsize.Load(), bq.robjs.Load())
+}
+
+func TestQuotaReserveApnd(t *testing.T) {
+	q, bck := newTestQuotas(t)
+	lom := newQuotaLOM(t, "obj-apnd")
+	defer core.FreeLOM(lom)
+	bq := q.get(bck)
+
+	for i, first := range []bool{true, false, false} {
+		res, _, err := q.reserveApnd(lom, 100, first)
+		tassert.CheckFatal(t, err)
+		q.commit(bck, &res, res.size)
+		tassert.Errorf(t, bq.size.Load() == int64(100*(i+1)) && bq.objs.Load() == 1,
+			"append #%d: expected (%d, 1), got (%d, %d)", i, 100*(i+1), bq.size.Load(), bq.objs.Load())
+	}
+}
+
+func TestQuotaConcurrent(t *testing.T) {
+	const (
+		numWriters = 32
+		size       = 100
+	)
+	q, bck := newTestQuotas(t)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		reserved []qres
+		bq       = q.get(bck)
+	)
+	for i := range numWriters {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			lom := newQuotaLOM(t, "obj-concurrent-"+strconv.Itoa(i))
+			res, _, err := q.reserveApnd(lom, size, false)
+			core.FreeLOM(lom)
+			if err == nil {
+				mu.Lock()
+				reserved = append(reserved, res)
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// share: 1KiB
+	tassert.Errorf(t, len(reserved) == cos.KiB/size, "expected %d reservations, got %d", cos.KiB/size, len(reserved))
+	tassert.Errorf(t, bq.rsize.Load() <= cos.KiB, "reserved %d over the share", bq.rsize.Load())
+	for i := range reserved {
+		q.release(&reserved[i])
+	}
+	tassert.Errorf(t, bq.rsize.Load() == 0, "expected nothing reserved, got %d", bq.rsize.Load())
+}
+
+func TestQuotaRefresh(t *testing.T) {
+	q, bck := newTestQuotas(t)
+	clear(q.m)
+
+	lom := newQuotaLOM(t, "obj-refresh")
+	defer core.FreeLOM(lom)
+	fh, err := cos.CreateFile(lom.FQN)
+	tassert.CheckFatal(t, err)
+	_, err = fh.Write(make([]byte, 300))
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, fh.Close())
+	lom.SetSize(300)
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	tassert.CheckFatal(t, lom.Persist())
+	defer lom.RemoveMain()
+
+	// does not block
+	bq := q.get(bck)
+	deadline := time.Now().Add(10 * time.Second)
+	for bq.refreshed.Load() == 0 || bq.refreshing.Load() {
+		tassert.Fatalf(t, time.Now().Before(deadline), "timed out waiting for refresh")
+		time.Sleep(10 * time.Millisecond)
+	}
+	tassert.Errorf(t, bq.objs.Load() == 1, "expected 1 object, got %d", bq.objs.Load())
+	tassert.Errorf(t, bq.size.Load() >= 300, "expected at least 300 bytes, got %d", bq.size.Load())
+}
+
+// streaming PUT (size unknown in advance)
+func TestQuotaAdjust(t *testing.T) {
+	q, bck := newTestQuotas(t)
+	lom := newQuotaLOM(t, "obj-adjust")
+	defer core.FreeLOM(lom)
+	bq := q.get(bck)
+
+	res, _, err := q.reserve(lom, -1)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, res.size == 0 && res.objs == 1, "expected (0, 1), got (%d, %d)", res.size, res.objs)
+
+	_, err = q.adjust(bck, &res, 600)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bq.rsize.Load() == 600, "expected 600 reserved, got %d", bq.rsize.Load())
+
+	// max_size
+	other, _, err := q.reserve(lom, -1)
+	tassert.CheckFatal(t, err)
+	ecode, err := q.adjust(bck, &other, 500)
+	tassert.Errorf(t, cmn.IsErrQuotaExceeded(err) && ecode == 403, "expected quota exceeded, got (%d, %v)", ecode, err)
+	q.release(&other)
+
+	q.commit(bck, &res, 600)
+	tassert.Errorf(t, bq.size.Load() == 600 && bq.objs.Load() == 1, "expected (600, 1), got (%d, %d)", bq.size.Load(), bq.objs.Load())
+	tassert.Errorf(t, bq.rsize.Load() == 0 && bq.robjs.Load() == 0, "expected nothing reserved, got (%d, %d)", bq.rsize.Load(), bq.robjs.Load())
+}
+
+// deleting makes room for the next PUT
+func TestQuotaDelete(t *testing.T) {
+	q, bck := newTestQuotas(t)
+	bq := q.get(bck)
+	lom := newQuotaLOM(t, "obj-delete")
+	defer core.FreeLOM(lom)
+
+	// at the limit (share: 1KiB)
+	res, _, err := q.reserve(lom, cos.KiB)
+	tassert.CheckFatal(t, err)
+	fh, err := cos.CreateFile(lom.FQN)
+	tassert.CheckFatal(t, err)
+	_, err = fh.Write(make([]byte, cos.KiB))
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, fh.Close())
+	lom.SetSize(cos.KiB)
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	tassert.CheckFatal(t, lom.Persist())
+	q.commit(bck, &res, cos.KiB)
+
+	other := newQuotaLOM(t, "obj-after-delete")
+	defer core.FreeLOM(other)
+	_, ecode, err := q.reserve(other, cos.KiB)
+	tassert.Errorf(t, cmn.IsErrQuotaExceeded(err) && ecode == 403, "expected quota exceeded, got (%d, %v)", ecode, err)
+
+	_, err = q.t.DeleteObject(lom, false /*evict*/)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bq.size.Load() == 0 && bq.objs.Load() == 0, "expected (0, 0), got (%d, %d)", bq.size.Load(), bq.objs.Load())
+
+	res, _, err = q.reserve(other, cos.KiB)
+	tassert.CheckFatal(t, err)
+	q.release(&res)
+}
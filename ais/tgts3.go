@@ -5,7 +5,6 @@
 package ais
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -22,6 +21,7 @@ import (
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact/xreg"
 )
 
 const fmtErrBckObj = "invalid %s request: expecting bucket and object (names) in the URL, have %v"
@@ -40,6 +40,7 @@ func (t *target) s3Handler(w http.ResponseWriter, r *http.Request) {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	s3.CORS(w, r, apiItems[0], t.owner.bmd)
 
 	switch r.Method {
 	case http.MethodHead:
@@ -77,14 +78,13 @@ func (t *target) putCopyMpt(w http.ResponseWriter, r *http.Request, config *cmn.
 		return
 	}
 	q := r.URL.Query()
+	if what := objLockParam(r); what != "" {
+		t.putObjLockS3(w, r, bck, s3.ObjName(items), what)
+		return
+	}
 	switch {
 	case q.Has(s3.QparamMptPartNo) && q.Has(s3.QparamMptUploadID):
-		if r.Header.Get(cos.S3HdrObjSrc) != "" {
-			// TODO: copy another object (or its range) => part of the specified multipart upload.
-			// https://docs.aws.amazon.com/AmazonS3/latest/API/API_UploadPartCopy.html
-			s3.WriteErr(w, r, errors.New("UploadPartCopy not implemented yet"), http.StatusNotImplemented)
-			return
-		}
+		// (including UploadPartCopy - when cos.S3HdrObjSrc is present)
 		if cmn.Rom.FastV(5, cos.SmoduleS3) {
 			nlog.Infoln("putMptPart", bck.String(), items, q)
 		}
@@ -102,34 +102,10 @@ func (t *target) putCopyMpt(w http.ResponseWriter, r *http.Request, config *cmn.
 // Copy object (maybe from another bucket)
 // https://docs.aws.amazon.com/AmazonS3/latest/API/API_CopyObject.html
 func (t *target) copyObjS3(w http.ResponseWriter, r *http.Request, config *cmn.Config, items []string) {
-	src := r.Header.Get(cos.S3HdrObjSrc)
-	src = strings.Trim(src, "/") // in AWS examples the path starts with "/"
-	parts := strings.SplitN(src, "/", 2)
-	if len(parts) < 2 {
-		s3.WriteErr(w, r, errS3Obj, 0)
-		return
-	}
-	// src
-	bckSrc, err, ecode := meta.InitByNameOnly(parts[0], t.owner.bmd)
-	if err != nil {
-		s3.WriteErr(w, r, err, ecode)
-		return
-	}
-	objSrc := strings.Trim(parts[1], "/")
-	if err := bckSrc.Init(t.owner.bmd); err != nil {
-		s3.WriteErr(w, r, err, 0)
-		return
-	}
-	lom := core.AllocLOM(objSrc)
+	lom := core.AllocLOM("")
 	defer core.FreeLOM(lom)
-	if err := lom.InitBck(bckSrc.Bucket()); err != nil {
-		if cmn.IsErrRemoteBckNotFound(err) {
-			t.BMDVersionFixup(r)
-			err = lom.InitBck(bckSrc.Bucket())
-		}
-		if err != nil {
-			s3.WriteErr(w, r, err, 0)
-		}
+	if ecode, err := t.initCopySrc(r, lom); err != nil {
+		s3.WriteErr(w, r, err, ecode)
 		return
 	}
 	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
@@ -179,6 +155,34 @@ func (t *target) copyObjS3(w http.ResponseWriter, r *http.Request, config *cmn.C
 	sgl.Free()
 }
 
+// parse cos.S3HdrObjSrc and initialize source LOM
+func (t *target) initCopySrc(r *http.Request, lom *core.LOM) (int, error) {
+	src := r.Header.Get(cos.S3HdrObjSrc)
+	src = strings.Trim(src, "/") // in AWS examples the path starts with "/"
+	parts := strings.SplitN(src, "/", 2)
+	if len(parts) < 2 {
+		return 0, errS3Obj
+	}
+	bckSrc, err, ecode := meta.InitByNameOnly(parts[0], t.owner.bmd)
+	if err != nil {
+		return ecode, err
+	}
+	if err := bckSrc.Init(t.owner.bmd); err != nil {
+		return 0, err
+	}
+	lom.ObjName = strings.Trim(parts[1], "/")
+	if err := lom.InitBck(bckSrc.Bucket()); err != nil {
+		if cmn.IsErrRemoteBckNotFound(err) {
+			t.BMDVersionFixup(r)
+			err = lom.InitBck(bckSrc.Bucket())
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return 0, nil
+}
+
 func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck, config *cmn.Config, lom *core.LOM) {
 	if err := lom.InitBck(bck.Bucket()); err != nil {
 		if cmn.IsErrRemoteBckNotFound(err) {
@@ -195,6 +199,15 @@ func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck,
 
 	// TODO: dual checksumming, e.g. lom.SetCustom(apc.AWS, ...)
 
+	if err := s3.ValidateSSE(r.Header, bck); err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if err := s3.ObjLockFromHeader(r.Header, bck, lom); err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+
 	dpq := dpqAlloc()
 	if err := dpq.parse(r.URL.RawQuery); err != nil {
 		s3.WriteErr(w, r, err, 0)
@@ -217,6 +230,7 @@ func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck,
 		s3.WriteErr(w, r, err, ecode)
 	} else {
 		s3.SetEtag(w.Header(), lom)
+		s3.SetSSE(w.Header(), lom)
 	}
 	dpqFree(dpq)
 }
@@ -243,6 +257,10 @@ func (t *target) getObjS3(w http.ResponseWriter, r *http.Request, items []string
 		return
 	}
 	objName := s3.ObjName(items)
+	if what := objLockParam(r); what != "" {
+		t.getObjLockS3(w, r, bck, objName, what)
+		return
+	}
 	if q.Has(s3.QparamMptPartNo) {
 		if cmn.Rom.FastV(5, cos.SmoduleS3) {
 			nlog.Infoln("getMptPart", bck.String(), objName, q)
@@ -329,6 +347,8 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 		hdr.Set(cos.HdrETag, v)
 	}
 	s3.SetEtag(hdr, lom)
+	s3.SetSSE(hdr, lom)
+	s3.SetObjLock(hdr, lom)
 	hdr.Set(cos.HdrContentLength, strconv.FormatInt(op.Size, 10))
 	if v, ok := custom[cos.HdrContentType]; ok {
 		hdr.Set(cos.HdrContentType, v)
@@ -356,12 +376,15 @@ func (t *target) delObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	ecode, err = t.DeleteObject(lom, false)
+	ecode, err = t.deleteObject(lom, false /*evict*/, s3.BypassGovernance(r.Header))
 	if err != nil {
 		name := lom.Cname()
-		if ecode == http.StatusNotFound {
+		switch {
+		case ecode == http.StatusNotFound:
 			s3.WriteErr(w, r, cos.NewErrNotFound(t, name), http.StatusNotFound)
-		} else {
+		case cmn.IsErrObjLocked(err):
+			s3.WriteErr(w, r, err, ecode)
+		default:
 			s3.WriteErr(w, r, fmt.Errorf("error deleting %s: %v", name, err), ecode)
 		}
 		return
@@ -396,3 +419,48 @@ func (t *target) postObjS3(w http.ResponseWriter, r *http.Request, items []strin
 		s3.QparamMptUploads, s3.QparamMptUploadID)
 	s3.WriteErr(w, r, err, 0)
 }
+
+//
+// bucket lifecycle (expiration)
+//
+
+const lifecycleIval = time.Hour
+
+// periodically execute lifecycle rules of all buckets that have them
+func (t *target) lifecycleHK(int64) time.Duration {
+	if !t.ClusterStarted() || nlog.Stopping() {
+		return lifecycleIval
+	}
+	bmd := t.owner.bmd.get()
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		if bck.Props.S3.Lifecycle == "" || bck.IsRemote() {
+			return false
+		}
+		if err := t.runLifecycle("", bck); err != nil {
+			nlog.Warningln(t.String(), bck.Cname(""), "lifecycle:", err)
+		}
+		return false
+	})
+	return lifecycleIval
+}
+
+func (t *target) runLifecycle(uuid string, bck *meta.Bck) error {
+	if bck.Props.S3.Lifecycle == "" {
+		return fmt.Errorf("%s: no lifecycle configuration", bck.Cname(""))
+	}
+	if bck.IsRemote() { // (e.g., backend added after the fact - see _lifecycleDoc)
+		return cmn.NewErrUnsupp("execute lifecycle rules of remote (or remote-backed) bucket", bck.Cname(""))
+	}
+	lc, err := s3.ParseLifecycle(cos.UnsafeB(bck.Props.S3.Lifecycle))
+	if err != nil {
+		return err
+	}
+	if !lc.HasExpiration() {
+		return nil
+	}
+	if uuid == "" {
+		uuid = cos.GenUUID()
+	}
+	rns := xreg.RenewLifecycle(uuid, bck, lc)
+	return rns.Err
+}
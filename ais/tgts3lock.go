@@ -0,0 +1,114 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// S3 object retention and legal hold (see s3/objlock.go and core/lretain.go)
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectRetention.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectRetention.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectLegalHold.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLegalHold.html
+
+const maxObjLockS3 = 4 * cos.KiB
+
+func objLockParam(r *http.Request) string {
+	q := r.URL.Query()
+	switch {
+	case q.Has(s3.QparamRetention):
+		return s3.QparamRetention
+	case q.Has(s3.QparamLegalHold):
+		return s3.QparamLegalHold
+	}
+	return ""
+}
+
+// GET /s3/<bucket-name>/<object-name>?retention|legal-hold
+func (t *target) getObjLockS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck, objName, what string) {
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	sgl := t.gmm.NewSGL(0)
+	defer sgl.Free()
+	switch what {
+	case s3.QparamRetention:
+		ret := s3.NewObjectRetention(lom)
+		if ret == nil {
+			s3.WriteErr(w, r, s3.NewErrNoSuchConfig(s3.ErrNoSuchObjLock, lom.Cname()), http.StatusNotFound)
+			return
+		}
+		ret.MustMarshal(sgl)
+	default:
+		lh := s3.NewObjectLegalHold(lom)
+		if lh == nil {
+			s3.WriteErr(w, r, s3.NewErrNoSuchConfig(s3.ErrNoSuchObjLock, lom.Cname()), http.StatusNotFound)
+			return
+		}
+		lh.MustMarshal(sgl)
+	}
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+}
+
+// PUT /s3/<bucket-name>/<object-name>?retention|legal-hold
+func (t *target) putObjLockS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck, objName, what string) {
+	doc, err := cos.ReadAll(io.LimitReader(r.Body, maxObjLockS3))
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if !bck.Props.ObjLock.Enabled {
+		err := s3.NewErrNoSuchConfig(s3.ErrNoSuchObjLockConfig, bck.Cname(""))
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	var md cos.StrKVs
+	if what == s3.QparamRetention {
+		md, err = s3.RetentionMD(doc, lom)
+	} else {
+		md, err = s3.LegalHoldMD(doc, lom)
+	}
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if err := lom.ValidateRetention(lom.GetCustomMD(), md, s3.BypassGovernance(r.Header)); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	lom.SetCustomMD(md)
+	if err := lom.Persist(); err != nil {
+		s3.WriteErr(w, r, cmn.NewErrFailedTo(t, "persist", lom.Cname(), err), 0)
+	}
+}
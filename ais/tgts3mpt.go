@@ -5,6 +5,7 @@
 package ais
 
 import (
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -14,10 +15,12 @@ import (
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/ais/backend"
 	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -63,6 +66,15 @@ func (t *target) startMpt(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if err := s3.ValidateSSE(r.Header, bck); err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if r.Header.Get(s3.HdrObjLockMode) != "" || r.Header.Get(s3.HdrObjLockLegalHold) != "" {
+		err := cmn.NewErrUnsupp("specify object lock when initiating", "multipart upload (use PUT ?retention upon completion)")
+		s3.WriteErr(w, r, err, http.StatusNotImplemented)
+		return
+	}
 	if bck.IsRemoteS3() {
 		uploadID, ecode, err = backend.StartMpt(lom, r, q)
 		if err != nil {
@@ -73,7 +85,7 @@ func (t *target) startMpt(w http.ResponseWriter, r *http.Request, items []string
 		uploadID = cos.GenUUID()
 	}
 
-	s3.InitUpload(uploadID, bck.Name, objName)
+	s3.InitUpload(uploadID, bck.Bucket(), objName)
 	result := &s3.InitiateMptUploadResult{Bucket: bck.Name, Key: objName, UploadID: uploadID}
 
 	sgl := t.gmm.NewSGL(0)
@@ -89,7 +101,11 @@ func (t *target) startMpt(w http.ResponseWriter, r *http.Request, items []string
 // "Content-MD5" in the part headers seems be to be deprecated:
 // either not present (s3cmd) or cannot be trusted (aws s3api).
 //
+// When `x-amz-copy-source` is specified, the part is copied from an existing object
+// (optionally, its `x-amz-copy-source-range`) - see mptSrc below.
+//
 // https://docs.aws.amazon.com/AmazonS3/latest/API/API_UploadPart.html
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_UploadPartCopy.html
 func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []string, q url.Values, bck *meta.Bck) {
 	var (
 		remotePutLatency int64
@@ -125,6 +141,21 @@ func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []stri
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	var (
+		body io.Reader = r.Body
+		clen           = r.ContentLength
+		src  *mptSrc
+	)
+	if r.Header.Get(cos.S3HdrObjSrc) != "" {
+		var ecode int
+		src, ecode, err = t.openMptSrc(r)
+		if err != nil {
+			s3.WriteMptErr(w, r, err, ecode, lom, uploadID)
+			return
+		}
+		defer src.Close()
+		body, clen = src, src.size
+	}
 	// workfile name format: <upload-id>.<part-number>.<obj-name>
 	prefix := uploadID + "." + strconv.FormatInt(int64(partNum), 10)
 	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, prefix)
@@ -139,7 +170,7 @@ func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []stri
 		size         int64
 		ecode        int
 		partSHA      = r.Header.Get(cos.S3HdrContentSHA256)
-		checkPartSHA = partSHA != "" && partSHA != cos.S3UnsignedPayload
+		checkPartSHA = partSHA != "" && partSHA != cos.S3UnsignedPayload && src == nil
 		cksumSHA     = &cos.CksumHash{}
 		cksumMD5     = &cos.CksumHash{}
 		remote       = bck.IsRemoteS3()
@@ -157,15 +188,19 @@ func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []stri
 	if !remote {
 		// write locally
 		buf, slab := t.gmm.Alloc()
-		size, err = io.CopyBuffer(mw, r.Body, buf)
+		size, err = io.CopyBuffer(mw, body, buf)
 		slab.Free(buf)
 	} else {
 		// write locally and utilize TeeReader to simultaneously send data to S3
-		tr := io.NopCloser(io.TeeReader(r.Body, mw))
-		size = r.ContentLength
+		tr := io.NopCloser(io.TeeReader(body, mw))
+		size = clen
 		debug.Assert(size > 0, "mpt upload: expecting positive content-length")
 		remoteStart := mono.NanoTime()
-		etag, ecode, err = backend.PutMptPart(lom, tr, r, q, uploadID, size, partNum)
+		oreq := r
+		if src != nil {
+			oreq = nil // (not presigning copy requests)
+		}
+		etag, ecode, err = backend.PutMptPart(lom, tr, oreq, q, uploadID, size, partNum)
 		remotePutLatency = mono.SinceNano(remoteStart)
 	}
 
@@ -206,6 +241,17 @@ func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []stri
 		return
 	}
 	w.Header().Set(cos.S3CksumHeader, md5) // s3cmd checks this one
+	if src != nil {
+		result := s3.CopyPartResult{
+			LastModified: cos.FormatNanoTime(time.Now().UnixNano(), cos.ISO8601),
+			ETag:         md5,
+		}
+		sgl := t.gmm.NewSGL(0)
+		result.MustMarshal(sgl)
+		w.Header().Set(cos.HdrContentType, cos.ContentXML)
+		sgl.WriteTo2(w)
+		sgl.Free()
+	}
 
 	delta := mono.SinceNano(startTime)
 	t.statsT.AddMany(
@@ -262,6 +308,12 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 		s3.WriteMptErr(w, r, errN, 0, lom, uploadID)
 		return
 	}
+	if bck.Props.ObjLock.Enabled {
+		if err := t.chkOverwrite(lom); err != nil {
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+	}
 
 	// call s3
 	var (
@@ -348,6 +400,7 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 	// .5 finalize
 	lom.SetSize(size)
 	lom.SetCustomKey(cmn.ETag, etag)
+	lom.SetDefaultRetention()
 
 	poi := allocPOI()
 	{
@@ -379,6 +432,7 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 	result.MustMarshal(sgl)
 	w.Header().Set(cos.HdrContentType, cos.ContentXML)
 	w.Header().Set(cos.S3CksumHeader, etag)
+	s3.SetSSE(w.Header(), lom)
 	sgl.WriteTo2(w)
 	sgl.Free()
 
@@ -491,7 +545,7 @@ func (t *target) listMptUploads(w http.ResponseWriter, bck *meta.Bck, q url.Valu
 		}
 	}
 	idMarker = q.Get(s3.QparamMptUploadIDMarker)
-	result := s3.ListUploads(bck.Name, idMarker, maxUploads)
+	result := s3.ListUploads(bck.Bucket(), idMarker, maxUploads)
 	sgl := t.gmm.NewSGL(0)
 	result.MustMarshal(sgl)
 	w.Header().Set(cos.HdrContentType, cos.ContentXML)
@@ -538,3 +592,183 @@ func (t *target) getMptPart(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 		cos.NamedVal64{Name: stats.GetLatencyTotal, Value: mono.SinceNano(startTime)},
 	)
 }
+
+////////////
+// mptSrc //
+////////////
+
+// UploadPartCopy source: the entire object or its `x-amz-copy-source-range`;
+// the object is read either locally (rlocked for the duration) or from the target that has it
+type mptSrc struct {
+	io.Reader
+	lom    *core.LOM
+	fh     cos.LomReader
+	resp   *http.Response
+	cancel context.CancelFunc
+	size   int64
+}
+
+func (t *target) openMptSrc(r *http.Request) (src *mptSrc, ecode int, err error) {
+	rng := r.Header.Get(cos.S3HdrObjSrcRange)
+	if rng != "" {
+		if _, _, err := parseMptSrcRange(rng); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+	}
+	lom := core.AllocLOM("")
+	if ecode, err = t.initCopySrc(r, lom); err != nil {
+		core.FreeLOM(lom)
+		return nil, ecode, err
+	}
+	smap := t.owner.smap.get()
+	tsi, local, err := lom.HrwTarget(&smap.Smap)
+	if err != nil {
+		core.FreeLOM(lom)
+		return nil, 0, err
+	}
+	if local {
+		return t._mptSrcLocal(lom, rng)
+	}
+	src, ecode, err = t._mptSrcT2T(lom, tsi, rng)
+	core.FreeLOM(lom)
+	return src, ecode, err
+}
+
+func (t *target) _mptSrcLocal(lom *core.LOM, rng string) (*mptSrc, int, error) {
+	lom.Lock(false)
+	err := lom.Load(true /*cache it*/, true /*locked*/)
+	if cos.IsNotExist(err, 0) && lom.Bck().IsRemote() {
+		lom.Unlock(false)
+		ecode, errCold := t.GetCold(context.Background(), lom, cmn.OwtGetLock)
+		if errCold != nil {
+			core.FreeLOM(lom)
+			return nil, ecode, errCold
+		}
+		lom.Lock(false)
+		err = lom.Load(true /*cache it*/, true /*locked*/)
+	}
+	if err != nil {
+		lom.Unlock(false)
+		core.FreeLOM(lom)
+		if cos.IsNotExist(err, 0) {
+			return nil, http.StatusNotFound, err
+		}
+		return nil, 0, err
+	}
+	off, length, err := mptSrcRange(rng, lom.Lsize())
+	if err != nil {
+		lom.Unlock(false)
+		core.FreeLOM(lom)
+		return nil, http.StatusRequestedRangeNotSatisfiable, err
+	}
+	fh, err := lom.Open()
+	if err != nil {
+		lom.Unlock(false)
+		core.FreeLOM(lom)
+		return nil, 0, err
+	}
+	src := &mptSrc{Reader: io.NewSectionReader(fh, off, length), lom: lom, fh: fh, size: length}
+	return src, 0, nil
+}
+
+// GET (range) from the target that has the object (and that will cold-GET it, if need be)
+func (t *target) _mptSrcT2T(lom *core.LOM, tsi *meta.Snode, rng string) (*mptSrc, int, error) {
+	reqArgs := cmn.AllocHra()
+	{
+		reqArgs.Method = http.MethodGet
+		reqArgs.Base = tsi.URL(cmn.NetIntraData)
+		reqArgs.Header = http.Header{
+			apc.HdrCallerID:   []string{t.SID()},
+			apc.HdrCallerName: []string{t.callerName()},
+		}
+		if rng != "" {
+			reqArgs.Header.Set(cos.HdrRange, rng)
+		}
+		reqArgs.Path = apc.URLPathObjects.Join(lom.Bck().Name, lom.ObjName)
+		reqArgs.Query = lom.Bck().NewQuery()
+	}
+	req, _, cancel, err := reqArgs.ReqWithTimeout(cmn.GCO.Get().Timeout.SendFile.D())
+	cmn.FreeHra(reqArgs)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := g.client.data.Do(req) //nolint:bodyclose // closed by mptSrc.Close
+	if err != nil {
+		cancel()
+		return nil, 0, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		b := cmn.NewBuffer()
+		b.ReadFrom(resp.Body)
+		err = fmt.Errorf("%s: failed to read %s from %s: %s", t, lom.Cname(), tsi.StringEx(), b.String())
+		cmn.FreeBuffer(b)
+		resp.Body.Close()
+		cancel()
+		return nil, resp.StatusCode, err
+	}
+	if resp.ContentLength < 0 {
+		resp.Body.Close()
+		cancel()
+		return nil, 0, fmt.Errorf("%s: unknown size of %s (from %s)", t, lom.Cname(), tsi.StringEx())
+	}
+	// (GET clamps the range to the object size)
+	if rng != "" {
+		first, last, _ := parseMptSrcRange(rng)
+		if resp.ContentLength != last-first+1 {
+			resp.Body.Close()
+			cancel()
+			err = fmt.Errorf("%s %q is out of bounds (%s from %s)", cos.S3HdrObjSrcRange, rng, lom.Cname(), tsi.StringEx())
+			return nil, http.StatusRequestedRangeNotSatisfiable, cmn.NewErrRangeNotSatisfiable(err, []string{rng}, 0)
+		}
+	}
+	src := &mptSrc{Reader: resp.Body, resp: resp, cancel: cancel, size: resp.ContentLength}
+	return src, 0, nil
+}
+
+func (src *mptSrc) Close() {
+	if src.fh != nil {
+		cos.Close(src.fh)
+	}
+	if src.lom != nil {
+		src.lom.Unlock(false)
+		core.FreeLOM(src.lom)
+	}
+	if src.resp != nil {
+		src.resp.Body.Close()
+		src.cancel()
+	}
+}
+
+// x-amz-copy-source-range: bytes=first-last (zero-based, inclusive);
+// unlike GET range, neither open-ended nor suffix range, and must be within the source object
+func mptSrcRange(rng string, size int64) (off, length int64, err error) {
+	if rng == "" {
+		return 0, size, nil
+	}
+	first, last, err := parseMptSrcRange(rng)
+	if err != nil {
+		return 0, 0, err
+	}
+	if last >= size {
+		return 0, 0, cmn.NewErrRangeNotSatisfiable(nil, []string{rng}, size)
+	}
+	return first, last - first + 1, nil
+}
+
+func parseMptSrcRange(rng string) (first, last int64, err error) {
+	s, ok := strings.CutPrefix(rng, cos.HdrRangeValPrefix)
+	if ok {
+		var a, b string
+		if a, b, ok = strings.Cut(s, "-"); ok {
+			first, err = strconv.ParseInt(a, 10, 64)
+			if err == nil {
+				last, err = strconv.ParseInt(b, 10, 64)
+			}
+			ok = err == nil && first >= 0 && last >= first
+		}
+	}
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid %s %q (expecting bytes=first-last)", cos.S3HdrObjSrcRange, rng)
+	}
+	return first, last, nil
+}
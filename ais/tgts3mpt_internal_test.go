@@ -0,0 +1,196 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+const mptSrcContent = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// (see TestMain)
+func testTarget() *target { return t }
+
+func TestMptSrcRange(t *testing.T) {
+	const size = 100
+	tests := []struct {
+		rng    string
+		off    int64
+		length int64
+		inval  bool // 400
+		oob    bool // out of bounds (416)
+	}{
+		{rng: "", off: 0, length: size},
+		{rng: "bytes=0-99", off: 0, length: size},
+		{rng: "bytes=10-19", off: 10, length: 10},
+		{rng: "bytes=99-99", off: 99, length: 1},
+
+		// invalid
+		{rng: "0-9", inval: true},
+		{rng: "items=0-9", inval: true},
+		{rng: "bytes=", inval: true},
+		{rng: "bytes=a-9", inval: true},
+		{rng: "bytes=9-0", inval: true},
+		{rng: "bytes=0-1,5-6", inval: true},
+		{rng: "bytes=-1-5", inval: true},
+
+		// open-ended and suffix ranges (valid GET ranges)
+		{rng: "bytes=10-", inval: true},
+		{rng: "bytes=-10", inval: true},
+
+		// out of bounds
+		{rng: "bytes=0-100", oob: true},
+		{rng: "bytes=50-1000", oob: true},
+		{rng: "bytes=100-100", oob: true},
+	}
+	for _, test := range tests {
+		off, length, err := mptSrcRange(test.rng, size)
+		switch {
+		case test.inval:
+			tassert.Errorf(t, err != nil && !cmn.IsErrRangeNotSatisfiable(err), "%q: expected invalid range, got %v", test.rng, err)
+		case test.oob:
+			tassert.Errorf(t, cmn.IsErrRangeNotSatisfiable(err), "%q: expected range not satisfiable, got %v", test.rng, err)
+		default:
+			tassert.Errorf(t, err == nil && off == test.off && length == test.length,
+				"%q: expected (%d, %d), got (%d, %d, %v)", test.rng, test.off, test.length, off, length, err)
+		}
+	}
+}
+
+func TestMptSrcLocal(t *testing.T) {
+	tgt := testTarget()
+	lom := putMptSrc(t, "mpt-src-local")
+	core.FreeLOM(lom)
+
+	tests := []struct {
+		rng   string
+		exp   string
+		ecode int
+	}{
+		{"", mptSrcContent, 0},
+		{"bytes=0-9", mptSrcContent[:10], 0},
+		{"bytes=10-35", mptSrcContent[10:], 0},
+		{"bytes=10-36", "", http.StatusRequestedRangeNotSatisfiable},
+	}
+	for _, test := range tests {
+		src, ecode, err := tgt._mptSrcLocal(newMptSrcLOM(t, "mpt-src-local"), test.rng)
+		if test.ecode != 0 {
+			tassert.Errorf(t, err != nil && ecode == test.ecode, "%q: expected status %d, got (%d, %v)", test.rng, test.ecode, ecode, err)
+			continue
+		}
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, src.size == int64(len(test.exp)), "%q: expected size %d, got %d", test.rng, len(test.exp), src.size)
+		b, err := io.ReadAll(src)
+		src.Close()
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, string(b) == test.exp, "%q: expected %q, got %q", test.rng, test.exp, b)
+	}
+
+	// not found
+	_, ecode, err := tgt._mptSrcLocal(newMptSrcLOM(t, "mpt-src-none"), "")
+	tassert.Errorf(t, err != nil && ecode == http.StatusNotFound, "expected not found, got (%d, %v)", ecode, err)
+
+	// (source lock released)
+	lom = newMptSrcLOM(t, "mpt-src-local")
+	tassert.Fatalf(t, lom.TryLock(true), "expected source object to be unlocked")
+	lom.Unlock(true)
+	core.FreeLOM(lom)
+}
+
+func TestMptSrcT2T(t *testing.T) {
+	var (
+		tgt  = testTarget()
+		path = apc.URLPathObjects.Join(testBucket, "mpt-src-t2t")
+		modt = time.Now()
+		srv  = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != path || r.Header.Get(apc.HdrCallerID) != tgt.SID() {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+			http.ServeContent(w, r, "", modt, bytes.NewReader([]byte(mptSrcContent)))
+		}))
+		tsi = &meta.Snode{}
+	)
+	defer srv.Close()
+	tsi.Init("t2t-mock", apc.Target)
+	tsi.DataNet.URL = srv.URL
+
+	config := cmn.GCO.BeginUpdate()
+	config.Timeout.SendFile = cos.Duration(10 * time.Second)
+	cmn.GCO.CommitUpdate(config)
+
+	tests := []struct {
+		rng   string
+		exp   string
+		ecode int
+	}{
+		{"", mptSrcContent, 0},
+		{"bytes=0-9", mptSrcContent[:10], 0},
+		{"bytes=35-35", mptSrcContent[35:], 0},
+		{"bytes=10-36", "", http.StatusRequestedRangeNotSatisfiable},   // (clamped by GET)
+		{"bytes=36-40", "", http.StatusRequestedRangeNotSatisfiable},   // (rejected by GET)
+		{"bytes=100-200", "", http.StatusRequestedRangeNotSatisfiable}, // ditto
+	}
+	for _, test := range tests {
+		lom := newMptSrcLOM(t, "mpt-src-t2t")
+		src, ecode, err := tgt._mptSrcT2T(lom, tsi, test.rng)
+		core.FreeLOM(lom)
+		if test.ecode != 0 {
+			tassert.Errorf(t, err != nil && ecode == test.ecode, "%q: expected status %d, got (%d, %v)", test.rng, test.ecode, ecode, err)
+			continue
+		}
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, src.size == int64(len(test.exp)), "%q: expected size %d, got %d", test.rng, len(test.exp), src.size)
+		b, err := io.ReadAll(src)
+		src.Close()
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, string(b) == test.exp, "%q: expected %q, got %q", test.rng, test.exp, b)
+	}
+
+	// not found
+	lom := newMptSrcLOM(t, "mpt-src-none")
+	_, ecode, err := tgt._mptSrcT2T(lom, tsi, "")
+	core.FreeLOM(lom)
+	tassert.Errorf(t, err != nil && ecode == http.StatusNotFound, "expected not found, got (%d, %v)", ecode, err)
+}
+
+func TestMptSrcInvalidRange(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPut, "/s3/"+testBucket+"/obj", http.NoBody)
+	r.Header.Set(cos.S3HdrObjSrc, "/"+testBucket+"/mpt-src-local")
+	r.Header.Set(cos.S3HdrObjSrcRange, "bytes=10-")
+	_, ecode, err := testTarget().openMptSrc(r)
+	tassert.Errorf(t, err != nil && ecode == http.StatusBadRequest, "expected bad request, got (%d, %v)", ecode, err)
+}
+
+func newMptSrcLOM(t *testing.T, objName string) *core.LOM {
+	lom := core.AllocLOM(objName)
+	tassert.CheckFatal(t, lom.InitBck(&cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}))
+	return lom
+}
+
+func putMptSrc(t *testing.T, objName string) *core.LOM {
+	lom := newMptSrcLOM(t, objName)
+	fh, err := cos.CreateFile(lom.FQN)
+	tassert.CheckFatal(t, err)
+	_, err = fh.WriteString(mptSrcContent)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, fh.Close())
+	lom.SetSize(int64(len(mptSrcContent)))
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	tassert.CheckFatal(t, lom.Persist())
+	return lom
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/ais/s3"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/atomic"
@@ -71,7 +72,7 @@ func (t *target) OOS(csRefreshed *fs.CapStatus, config *cmn.Config, tcdf *fs.Tcd
 	nlog.Warningln(t.String(), "running store cleanup:", cs.String())
 	// run serially, cleanup first and LRU second, iff out-of-space persists
 	go func() {
-		cs := t.runStoreCleanup("" /*uuid*/, nil /*wg*/)
+		cs := t.runStoreCleanup("" /*uuid*/, nil /*wg*/, false /*explicit*/)
 		lastTrigOOS.Store(mono.NanoTime())
 		if cs.Err() != nil {
 			nlog.Warningln(t.String(), "still out of space, running LRU eviction now:", cs.String())
@@ -118,7 +119,7 @@ func (t *target) runLRU(id string, wg *sync.WaitGroup, force bool, bcks ...cmn.B
 	space.RunLRU(&ini)
 }
 
-func (t *target) runStoreCleanup(id string, wg *sync.WaitGroup, bcks ...cmn.Bck) fs.CapStatus {
+func (t *target) runStoreCleanup(id string, wg *sync.WaitGroup, explicit bool, bcks ...cmn.Bck) fs.CapStatus {
 	regToIC := id == ""
 	if regToIC {
 		id = cos.GenUUID()
@@ -138,12 +139,15 @@ func (t *target) runStoreCleanup(id string, wg *sync.WaitGroup, bcks ...cmn.Bck)
 		msg := t.newAmsgActVal(apc.ActRegGlobalXaction, regMsg)
 		t.bcastAsyncIC(msg)
 	}
+	config := cmn.GCO.Get()
+	t.expireMpt(config)
 	ini := space.IniCln{
-		Xaction: xcln.(*space.XactCln),
-		Config:  cmn.GCO.Get(),
-		StatsT:  t.statsT,
-		Buckets: bcks,
-		WG:      wg,
+		Xaction:  xcln.(*space.XactCln),
+		Config:   config,
+		StatsT:   t.statsT,
+		Buckets:  bcks,
+		WG:       wg,
+		Explicit: explicit,
 	}
 	xcln.AddNotif(&xact.NotifXact{
 		Base: nl.Base{When: core.UponTerm, Dsts: []string{equalIC}, F: t.notifyTerm},
@@ -151,3 +155,14 @@ func (t *target) runStoreCleanup(id string, wg *sync.WaitGroup, bcks ...cmn.Bck)
 	})
 	return space.RunCleanup(&ini)
 }
+
+// abort abandoned S3 multipart uploads (and remove their parts)
+func (t *target) expireMpt(config *cmn.Config) {
+	maxAge := config.Timeout.MptUpload.D()
+	if maxAge == 0 {
+		maxAge = cmn.MptUploadDflt
+	}
+	if n := s3.ExpireUploads(maxAge); n > 0 {
+		nlog.Infoln(t.String(), "expired", n, "multipart upload(s) older than", maxAge)
+	}
+}
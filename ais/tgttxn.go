@@ -20,7 +20,6 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
-	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -508,9 +507,6 @@ func (t *target) validateBckRenTxn(bckFrom, bckTo *meta.Bck, msg *aisMsg) error
 }
 
 func etlDP(msg *apc.TCBMsg) (core.DP, error) {
-	if !k8s.IsK8s() {
-		return nil, k8s.ErrK8sRequired
-	}
 	if err := msg.Validate(true); err != nil {
 		return nil, err
 	}
@@ -898,6 +894,11 @@ func (t *target) destroyBucket(c *txnSrv) error {
 		if !nlp.TryLock(c.timeout.netw / 2) {
 			return cmn.NewErrBusy("bucket", c.bck.Cname(""))
 		}
+		// object lock (WORM): refuse to destroy (or evict) retained objects
+		if err := core.CheckBckRetained(c.bck); err != nil {
+			nlp.Unlock()
+			return err
+		}
 		txn := newTxnBckBase(c.bck)
 		txn.fillFromCtx(c)
 		if err := t.transactions.begin(txn, nlp); err != nil {
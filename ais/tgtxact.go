@@ -205,7 +205,7 @@ func (t *target) xstart(args *xact.ArgsMsg, bck *meta.Bck, msg *apc.ActMsg) (xid
 	case apc.ActStoreCleanup:
 		wg := &sync.WaitGroup{}
 		wg.Add(1)
-		go t.runStoreCleanup(args.ID, wg, args.Buckets...)
+		go t.runStoreCleanup(args.ID, wg, true /*explicit*/, args.Buckets...)
 		wg.Wait()
 	case apc.ActResilver:
 		if bck != nil {
@@ -229,6 +229,8 @@ func (t *target) xstart(args *xact.ArgsMsg, bck *meta.Bck, msg *apc.ActMsg) (xid
 	case apc.ActLoadLomCache:
 		rns := xreg.RenewBckLoadLomCache(args.ID, bck)
 		return xid, rns.Err
+	case apc.ActS3Lifecycle:
+		return xid, t.runLifecycle(args.ID, bck)
 	case apc.ActBlobDl:
 		debug.Assert(msg.Name != "")
 		lom := core.AllocLOM(msg.Name)
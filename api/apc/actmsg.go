@@ -39,8 +39,9 @@ const (
 	ActMakeNCopies = "make-n-copies"
 	ActPutCopies   = "put-copies"
 
-	ActRebalance = "rebalance"
-	ActMoveBck   = "move-bck"
+	ActRebalance   = "rebalance"
+	ActRebEstimate = "rebalance-estimate" // dry-run (see RebEstimateMsg)
+	ActMoveBck     = "move-bck"
 
 	ActResilver = "resilver"
 
@@ -53,9 +54,11 @@ const (
 	ActInvalListCache = "inval-listobj-cache"
 	ActList           = "list"
 	ActLoadLomCache   = "load-lom-cache"
+	ActS3Lifecycle    = "s3-lifecycle" // execute S3 bucket lifecycle (expiration) rules
 	ActNewPrimary     = "new-primary"
 	ActPromote        = "promote"
 	ActRenameObject   = "rename-obj"
+	ActUndeleteObject = "undelete-obj"
 
 	// cp (reverse)
 	ActResetStats  = "reset-stats"
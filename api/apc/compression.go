@@ -6,19 +6,33 @@ package apc
 
 // NOTE:
 // LZ4 block and frame formats: http://fastcompression.blogspot.com/2013/04/lz4-streaming-format-final.html
+// Zstandard: https://github.com/facebook/zstd/blob/dev/doc/zstd_compression_format.md
 
 // Compression enum
 const (
-	CompressAlways = "always"
+	CompressAlways = "always" // LZ4
 	CompressNever  = "never"
+	CompressZstd   = "zstd" // at the configured level (see transport.zstd_level)
+	CompressAuto   = "auto" // zstd, when and only when sampled payload is compressible
 )
 
 // sent via req.Header.Set(apc.HdrCompress, LZ4Compression)
 // (alternative to lz4 compressions upon popular request)
-const LZ4Compression = "lz4"
+const (
+	LZ4Compression  = "lz4"
+	ZstdCompression = "zstd"
+)
 
-var SupportedCompression = [...]string{CompressNever, CompressAlways}
+var SupportedCompression = [...]string{CompressNever, CompressAlways, CompressZstd, CompressAuto}
 
 func IsValidCompression(c string) bool {
-	return c == "" || c == SupportedCompression[0] || c == SupportedCompression[1]
+	if c == "" {
+		return true
+	}
+	for _, s := range SupportedCompression {
+		if c == s {
+			return true
+		}
+	}
+	return false
 }
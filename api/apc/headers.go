@@ -110,7 +110,7 @@ const (
 
 	// intra-cluster streams
 	HdrSessID   = aisPrefix + "Session-Id"
-	HdrCompress = aisPrefix + "Compress" // LZ4
+	HdrCompress = aisPrefix + "Compress" // LZ4Compression or ZstdCompression
 
 	// Promote(dir)
 	HdrPromoteNamesHash = aisPrefix + "Promote-Names-Hash"
@@ -24,7 +24,7 @@ const (
 
 	LsMissing // include missing main obj (with copy existing)
 
-	LsDeleted // include obj-s marked for deletion (TODO: not implemented yet)
+	LsDeleted // include soft-deleted obj-s (see `space.deleted_retention`; status: LocIsDeleted)
 
 	LsArchDir // expand archives as directories
 
@@ -96,6 +96,7 @@ const (
 	LocMisplacedMountpath
 	LocIsCopy
 	LocIsCopyMissingObj
+	LocIsDeleted // soft-deleted (can be undeleted)
 
 	// LsoEntry Flags
 	EntryIsCached   = 1 << (EntryStatusBits + 1)
@@ -150,6 +151,7 @@ type LsoMsg struct {
 	SID               string      `json:"target"`                // selected target to solely execute backend.list-objects
 	Flags             uint64      `json:"flags,string"`          // enum {LsObjCached, ...} - "LsoMsg flags" above
 	PageSize          int64       `json:"pagesize"`              // max entries returned by list objects call
+	Where             string      `json:"where,omitempty"`       // filtering predicate, e.g. "size>1GiB and mtime<1d" (see cmn/lsowhere.go)
 	Header            http.Header `json:"hdr,omitempty"`         // (for pointers, see `ListArgs` in api/ls.go)
 }
 
@@ -190,6 +190,7 @@ const (
 	QparamRebData          = "rbd" // true: get EC rebalance data (pulling data if push way fails)
 	QparamClusterInfo      = "cii" // true: /Health to return `cos.NodeStateInfo` including cluster metadata versions and state flags
 	QparamOWT              = "owt" // object write transaction enum { OwtPut, ..., OwtGet* }
+	QparamUserID           = "uid" // AuthN user ID (as validated by the redirecting proxy) - for per-user rate limiting
 
 	QparamDontResilver = "dntres" // true: do not resilver data off of mountpaths that are being disabled/detached
 
@@ -241,6 +242,9 @@ const (
 	WhatSysInfo    = "sysinfo"
 	WhatTargetIPs  = "target_ips" // comma-separated list of all target IPs (compare w/ GetWhatSnode)
 
+	// rebalance dry-run: data movement upon (hypothetical) cluster map change - see RebEstimateMsg
+	WhatRebEstimate = "reb_estimate"
+
 	// log
 	WhatLog = "log"
 
@@ -0,0 +1,106 @@
+// Package apc: API constant and control messages
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import "time"
+
+// Rebalance dry-run: given a hypothetical cluster map change, estimate
+// how much data would move (see also: WhatRebEstimate)
+type (
+	// all node IDs; joining targets are identified by the IDs they will have
+	RebEstimateMsg struct {
+		UUID   string   `json:"uuid,omitempty"`   // job ID: empty to start new, non-empty to query (see ActRebEstimate)
+		Join   []string `json:"join,omitempty"`   // targets to join
+		Remove []string `json:"remove,omitempty"` // targets to leave (decommission)
+		Maint  []string `json:"maint,omitempty"`  // targets to enter maintenance (or shut down)
+	}
+	RebEstimateCnt struct {
+		Objs  int64 `json:"objs,string"`
+		Bytes int64 `json:"bytes,string"`
+	}
+	// per target (as the source of migration)
+	RebEstimate struct {
+		Bcks    map[string]*RebEstimateCnt `json:"bcks"`            // outgoing, by bucket (cname)
+		Dsts    map[string]*RebEstimateCnt `json:"dsts"`            // outgoing, by destination target ID
+		Scanned RebEstimateCnt             `json:"scanned"`         // all local objects
+		Out     RebEstimateCnt             `json:"out"`             // total outgoing
+		DiskBps int64                      `json:"disk_bps,string"` // estimated disk throughput (zero - unknown)
+		NetBps  int64                      `json:"net_bps,string"`  // observed rebalance throughput (ditto)
+	}
+	// by target ID
+	RebEstimates map[string]*RebEstimate
+)
+
+func (msg *RebEstimateMsg) IsEmpty() bool {
+	return len(msg.Join) == 0 && len(msg.Remove) == 0 && len(msg.Maint) == 0
+}
+
+func (cnt *RebEstimateCnt) Add(size int64) {
+	cnt.Objs++
+	cnt.Bytes += size
+}
+
+func (cnt *RebEstimateCnt) Merge(other *RebEstimateCnt) {
+	cnt.Objs += other.Objs
+	cnt.Bytes += other.Bytes
+}
+
+// bytes/s to send (or receive) - the slowest of the known rates
+func (e *RebEstimate) bps() int64 {
+	switch {
+	case e.DiskBps == 0:
+		return e.NetBps
+	case e.NetBps == 0:
+		return e.DiskBps
+	default:
+		return min(e.DiskBps, e.NetBps)
+	}
+}
+
+// incoming, by target ID
+func (ests RebEstimates) In() map[string]*RebEstimateCnt {
+	in := make(map[string]*RebEstimateCnt, len(ests))
+	for _, e := range ests {
+		for tid, cnt := range e.Dsts {
+			if in[tid] == nil {
+				in[tid] = &RebEstimateCnt{}
+			}
+			in[tid].Merge(cnt)
+		}
+	}
+	return in
+}
+
+// by bucket, cluster-wide
+func (ests RebEstimates) Bcks() map[string]*RebEstimateCnt {
+	out := make(map[string]*RebEstimateCnt, 4)
+	for _, e := range ests {
+		for cname, cnt := range e.Bcks {
+			if out[cname] == nil {
+				out[cname] = &RebEstimateCnt{}
+			}
+			out[cname].Merge(cnt)
+		}
+	}
+	return out
+}
+
+// Duration estimates the time it'll take (all targets running in parallel) as
+// the max of the per-target times to send and receive; zero when unknown
+func (ests RebEstimates) Duration() (d time.Duration) {
+	for _, e := range ests {
+		if bps := e.bps(); bps > 0 {
+			d = max(d, time.Duration(float64(e.Out.Bytes)/float64(bps)*float64(time.Second)))
+		}
+	}
+	for tid, cnt := range ests.In() {
+		if e, ok := ests[tid]; ok { // (joining targets have no stats)
+			if bps := e.bps(); bps > 0 {
+				d = max(d, time.Duration(float64(cnt.Bytes)/float64(bps)*float64(time.Second)))
+			}
+		}
+	}
+	return d
+}
@@ -64,3 +64,26 @@ func (msg *TCBMsg) ToName(name string) string {
 	}
 	return name
 }
+
+// Reverse `ToName` (not including `Prepend` that cannot be reversed and is, therefore,
+// incompatible with `Sync`): return all source names that could have produced
+// the given destination name - more than one when multiple extensions map onto the same.
+func (msg *TCBMsg) FromNames(name string) []string {
+	idx := strings.LastIndexByte(name, '.')
+	if msg.Ext == nil || idx < 0 {
+		return []string{name}
+	}
+	var (
+		names []string
+		ext   = name[idx+1:]
+	)
+	if replacement, exists := msg.Ext[ext]; !exists || strings.TrimLeft(replacement, ".") == ext {
+		names = append(names, name) // unchanged
+	}
+	for from, replacement := range msg.Ext {
+		if from != ext && strings.TrimLeft(replacement, ".") == ext {
+			names = append(names, name[:idx+1]+from)
+		}
+	}
+	return names
+}
@@ -31,6 +31,7 @@ const (
 	Users     = "users"    // AuthN
 	Clusters  = "clusters" // AuthN
 	Roles     = "roles"    // AuthN
+	JWKS      = "jwks"     // AuthN (public keys)
 	IC        = "ic"       // information center
 
 	// l3 ---
@@ -163,6 +164,7 @@ var (
 	URLPathETLObject = urlpath(Version, ETL, ETLObject)
 
 	URLPathTokens   = urlpath(Version, Tokens) // authn
+	URLPathJWKS     = urlpath(Version, Tokens, JWKS)
 	URLPathUsers    = urlpath(Version, Users)
 	URLPathClusters = urlpath(Version, Clusters)
 	URLPathRoles    = urlpath(Version, Roles)
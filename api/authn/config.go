@@ -39,12 +39,31 @@ type (
 		UseHTTPS    bool   `json:"use_https"`
 	}
 	ServerConf struct {
-		Secret string       `json:"secret"`
-		Expire cos.Duration `json:"expiration_time"`
+		Secret  string       `json:"secret"`
+		Expire  cos.Duration `json:"expiration_time"`
+		Signing SigningConf  `json:"signing,omitempty"`
 		// private
 		psecret *string       `json:"-"`
 		pexpire *cos.Duration `json:"-"`
 	}
+	// Token signing: HS256 (default) uses the shared `secret` (above);
+	// RS256 and ES256 use the private key from `key_file` - AIS gateways then validate
+	// tokens with the corresponding public key(s) published at apc.URLPathJWKS
+	SigningConf struct {
+		Alg     string `json:"alg,omitempty"`      // enum { "HS256", "RS256", "ES256" }
+		KeyFile string `json:"key_file,omitempty"` // PEM-encoded private key
+		Kid     string `json:"kid,omitempty"`      // key ID (JWT header and JWKS)
+		// (optional) `iss` and `aud` claims - see AIS config: auth.issuer and auth.audience
+		Issuer   string `json:"issuer,omitempty"`
+		Audience string `json:"audience,omitempty"`
+		// previous (rotated-out) keys that continue to be published in the JWKS,
+		// so that tokens signed with those keys remain valid until they expire
+		PrevKeys []PubKeyConf `json:"prev_keys,omitempty"`
+	}
+	PubKeyConf struct {
+		Kid     string `json:"kid"`
+		KeyFile string `json:"key_file"` // PEM-encoded public or private key
+	}
 	TimeoutConf struct {
 		Default cos.Duration `json:"default_timeout"`
 	}
@@ -86,6 +105,7 @@ func (c *Config) Verbose() bool {
 }
 
 func (c *Config) Secret() string        { return *c.Server.psecret }
+func (c *Config) IsHMAC() bool          { return c.Server.Signing.Alg == "" || c.Server.Signing.Alg == "HS256" }
 func (c *Config) Expire() time.Duration { return time.Duration(*c.Server.pexpire) }
 
 func (c *Config) SetSecret(val *string) {
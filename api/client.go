@@ -299,9 +299,18 @@ func (reqParams *ReqParams) rwResp(resp *http.Response, w io.Writer) (*wrappedRe
 	// NOTE: Content-Length == -1 (unknown) for transformed objects
 	debug.Assertf(n == resp.ContentLength || resp.ContentLength == -1, "%d vs %d", n, wresp.n)
 	wresp.n = n
+	cksumTrailer(resp)
 	return wresp, nil
 }
 
+// checksum value computed on the fly (e.g., archived file) arrives as HTTP trailer
+// (available upon reading the body) - move it to the header
+func cksumTrailer(resp *http.Response) {
+	if val := resp.Trailer.Get(apc.HdrObjCksumVal); val != "" && resp.Header.Get(apc.HdrObjCksumVal) == "" {
+		resp.Header.Set(apc.HdrObjCksumVal, val)
+	}
+}
+
 // end-to-end protection (compare w/ rwResp above)
 func (reqParams *ReqParams) readValidate(resp *http.Response, w io.Writer) (*wrappedResp, error) {
 	var (
@@ -315,7 +324,7 @@ func (reqParams *ReqParams) readValidate(resp *http.Response, w io.Writer) (*wra
 	if err != nil {
 		return nil, err
 	}
-	if n != resp.ContentLength {
+	if resp.ContentLength >= 0 && n != resp.ContentLength { // (-1 when chunked)
 		return nil, fmt.Errorf("read length (%d) != (%d) content-length", n, resp.ContentLength)
 	}
 	if cksum == nil {
@@ -327,6 +336,7 @@ func (reqParams *ReqParams) readValidate(resp *http.Response, w io.Writer) (*wra
 
 	// compare
 	wresp.cksumValue = cksum.Value()
+	cksumTrailer(resp)
 	hdrCksumValue := wresp.Header.Get(apc.HdrObjCksumVal)
 	if wresp.cksumValue != hdrCksumValue {
 		return nil, cmn.NewErrInvalidCksum(hdrCksumValue, wresp.cksumValue)
@@ -9,11 +9,13 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/xact"
 )
 
 // to be used by external watchdogs (Kubernetes, etc.)
@@ -157,6 +159,56 @@ func GetClusterSysInfo(bp BaseParams) (info apc.ClusterSysInfo, err error) {
 	return
 }
 
+// EstimateRebalance returns per-target estimates of the data that would be moved
+// by rebalance upon a given (hypothetical) cluster map change - a dry run.
+// Starts apc.ActRebEstimate job (whereby each target traverses its local objects)
+// and polls until all targets are done.
+func EstimateRebalance(bp BaseParams, msg *apc.RebEstimateMsg) (ests apc.RebEstimates, err error) {
+	var (
+		xid    string
+		status int
+		sleep  = xact.MinPollTime
+		qmsg   = *msg
+	)
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.WhatRebEstimate}}
+	}
+	defer FreeRp(reqParams)
+
+	// start
+	qmsg.UUID = ""
+	reqParams.Body = cos.MustMarshal(&qmsg)
+	if status, err = reqParams.doReqStr(&xid); err != nil {
+		return nil, err
+	}
+	if status != http.StatusAccepted {
+		return nil, _invalidStatus(status)
+	}
+
+	// poll
+	qmsg.UUID = xid
+	reqParams.Body = cos.MustMarshal(&qmsg)
+	for {
+		time.Sleep(sleep)
+		if status, err = reqParams.DoReqAny(&ests); err != nil {
+			return nil, err
+		}
+		switch status {
+		case http.StatusOK:
+			return ests, nil
+		case http.StatusAccepted:
+			sleep = min(sleep+sleep/2, xact.MaxPollTime)
+		default:
+			return nil, _invalidStatus(status)
+		}
+	}
+}
+
 func GetRemoteAIS(bp BaseParams) (remais meta.RemAisVec, err error) {
 	bp.Method = http.MethodGet
 	reqParams := AllocRp()
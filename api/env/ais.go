@@ -43,6 +43,9 @@ var (
 		K8sPod       string
 		K8sNode      string
 		K8sNamespace string
+
+		// ETL: process-based runtime (no K8s)
+		ETLPython string
 	}{
 		// the way to designate primary when cluster's starting up
 		Endpoint:  "AIS_ENDPOINT",
@@ -83,5 +86,9 @@ var (
 		K8sPod:       "MY_POD",
 		K8sNode:      "MY_NODE",
 		K8sNamespace: "K8S_NS",
+
+		// python interpreter to run `init code` transformers when not deployed on K8s
+		// (default: "python3" from the target's PATH)
+		ETLPython: "AIS_ETL_PYTHON",
 	}
 )
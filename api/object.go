@@ -103,6 +103,10 @@ type (
 		Silent        bool // `apc.QparamSilent`       - when true, do not log (not-found) error
 		LatestVer     bool // `apc.QparamLatestVer`    - check (with remote backend) whether in-cluster version is the latest
 		ValidateCksum bool // `apc.QparamValidateCksum`- validate (ie., recompute and check) in-cluster object's checksums
+
+		// `apc.QparamArchpath` - check presence of the named file inside (in-cluster) object formatted
+		// as one of the supported archives; when found, returned size is the size of the archived file
+		ArchPath string
 	}
 )
 
@@ -330,6 +334,9 @@ func HeadObject(bp BaseParams, bck cmn.Bck, objName string, args HeadArgs) (*cmn
 	if args.ValidateCksum {
 		q.Set(apc.QparamValidateCksum, "true")
 	}
+	if args.ArchPath != "" {
+		q.Set(apc.QparamArchpath, args.ArchPath)
+	}
 
 	reqParams := AllocRp()
 	defer FreeRp(reqParams)
@@ -576,6 +583,24 @@ func RenameObject(bp BaseParams, bck cmn.Bck, oldName, newName string) error {
 	return err
 }
 
+// Undelete(object) ============================================================================
+// restores soft-deleted object (see `space.deleted_retention` config).
+
+func UndeleteObject(bp BaseParams, bck cmn.Bck, objName string) error {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, objName)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActUndeleteObject})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // Promote =========================================================================================
 // promote POSIX files and/or directories to (become) in-cluster objects.
 
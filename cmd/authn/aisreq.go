@@ -25,8 +25,12 @@ const (
 	retry503   = time.Minute
 )
 
+// (no shared secret when signing with private key - clusters validate tokens via JWKS)
 func (m *mgr) validateSecret(clu *authn.CluACL) (err error) {
 	const tag = "validate-secret"
+	if !Conf.IsHMAC() {
+		return nil
+	}
 	var (
 		secret = Conf.Secret()
 		cksum  = cos.NewCksumHash(cos.ChecksumSHA256)
@@ -133,8 +133,10 @@ func (h *hserv) tokenHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodDelete:
 		h.httpRevokeToken(w, r)
+	case http.MethodGet:
+		httpJWKS(w, r)
 	default:
-		cmn.WriteErr405(w, r, http.MethodDelete)
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet)
 	}
 }
 
@@ -166,8 +168,7 @@ func (h *hserv) httpRevokeToken(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErrMsg(w, r, "empty token")
 		return
 	}
-	secret := Conf.Secret()
-	if _, err := tok.DecryptToken(msg.Token, secret); err != nil {
+	if _, err := decryptToken(msg.Token); err != nil {
 		cmn.WriteErr(w, r, err)
 		return
 	}
@@ -286,8 +287,7 @@ func validateAdminPerms(w http.ResponseWriter, r *http.Request) error {
 		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
 		return err
 	}
-	secret := Conf.Secret()
-	tk, err := tok.DecryptToken(token, secret)
+	tk, err := decryptToken(token)
 	if err != nil {
 		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
 		return err
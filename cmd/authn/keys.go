@@ -0,0 +1,101 @@
+// Package authn is authentication server for AIStore.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+)
+
+// asymmetric (RS256 | ES256) signing - see authn.SigningConf
+// (nil signer and verifier when using HMAC secret)
+var keys struct {
+	signer   *tok.Signer
+	verifier *tok.Verifier
+	jwks     tok.JWKS
+}
+
+func initKeys() error {
+	keys.jwks.Keys = []tok.JWK{}
+	if Conf.IsHMAC() {
+		return nil
+	}
+	conf := &Conf.Server.Signing
+	if conf.KeyFile == "" {
+		return fmt.Errorf("signing algorithm %s requires private key (key_file)", conf.Alg)
+	}
+	pemKey, err := os.ReadFile(conf.KeyFile)
+	if err != nil {
+		return err
+	}
+	signer, err := tok.NewSigner(conf.Alg, conf.Kid, pemKey)
+	if err != nil {
+		return fmt.Errorf("%s: %v", conf.KeyFile, err)
+	}
+	signer.SetClaims(conf.Issuer, conf.Audience)
+	verifier := tok.NewVerifier("")
+	if err := _addKey(verifier, conf.Kid, conf.Alg, pemKey); err != nil {
+		return fmt.Errorf("%s: %v", conf.KeyFile, err)
+	}
+	for _, prev := range conf.PrevKeys {
+		if prev.Kid == "" || prev.Kid == conf.Kid {
+			return fmt.Errorf("previous key %q: kid must be non-empty and differ from the current one (%q)",
+				prev.KeyFile, conf.Kid)
+		}
+		b, err := os.ReadFile(prev.KeyFile)
+		if err != nil {
+			return err
+		}
+		if err := _addKey(verifier, prev.Kid, "", b); err != nil {
+			return fmt.Errorf("%s: %v", prev.KeyFile, err)
+		}
+	}
+	keys.signer, keys.verifier = signer, verifier
+	nlog.Infof("signing tokens with %s (kid %q), publishing %d key(s)", conf.Alg, conf.Kid, len(keys.jwks.Keys))
+	return nil
+}
+
+func _addKey(verifier *tok.Verifier, kid, alg string, pemKey []byte) error {
+	if err := verifier.AddKey(kid, pemKey); err != nil {
+		return err
+	}
+	pub, err := tok.ParsePublicKey(pemKey)
+	if err != nil {
+		return err
+	}
+	jwk, err := tok.NewJWK(kid, alg, pub)
+	if err != nil {
+		return err
+	}
+	keys.jwks.Keys = append(keys.jwks.Keys, *jwk)
+	return nil
+}
+
+func signer() *tok.Signer {
+	if keys.signer != nil {
+		return keys.signer
+	}
+	return tok.NewHMACSigner(Conf.Secret())
+}
+
+func decryptToken(token string) (*tok.Token, error) {
+	if keys.verifier != nil {
+		return keys.verifier.Decrypt(token)
+	}
+	return tok.DecryptToken(token, Conf.Secret())
+}
+
+// GET /v1/tokens/jwks (public)
+func httpJWKS(w http.ResponseWriter, r *http.Request) {
+	if _, err := parseURL(w, r, 0, apc.URLPathJWKS.L); err != nil {
+		return
+	}
+	writeJSON(w, &keys.jwks, "get jwks")
+}
@@ -77,6 +77,9 @@ func main() {
 	if err := updateLogOptions(); err != nil {
 		cos.ExitLogf("Failed to set up logger: %v", err)
 	}
+	if err := initKeys(); err != nil {
+		cos.ExitLogf("Failed to initialize signing keys: %v", err)
+	}
 	if Conf.Verbose() {
 		nlog.Infof("Loaded configuration from %s", configPath)
 	}
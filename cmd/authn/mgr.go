@@ -394,10 +394,10 @@ func (m *mgr) _token(msg *authn.LoginMsg, uInfo *authn.User, cluACLs []*authn.Cl
 	expires := time.Now().Add(expDelta)
 	uid := uInfo.ID
 	if uInfo.IsAdmin() {
-		token, err = tok.AdminJWT(expires, uid, Conf.Secret())
+		token, err = tok.AdminJWT(expires, uid, signer())
 	} else {
 		m.fixClusterIDs(cluACLs)
-		token, err = tok.JWT(expires, uid, bckACLs, cluACLs, Conf.Secret())
+		token, err = tok.JWT(expires, uid, bckACLs, cluACLs, signer())
 	}
 	return token, err
 }
@@ -446,9 +446,8 @@ func (m *mgr) generateRevokedTokenList() ([]string, error) {
 
 	now := time.Now()
 	revokeList := make([]string, 0, len(tokens))
-	secret := Conf.Secret()
 	for _, token := range tokens {
-		tk, err := tok.DecryptToken(token, secret)
+		tk, err := decryptToken(token)
 		if err != nil {
 			m.db.Delete(revokedCollection, token)
 			continue
@@ -0,0 +1,133 @@
+// Package tok provides AuthN token (structure and methods)
+// for validation by AIS gateways
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tok
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// JSON Web Key Set (RFC 7517) - public keys only: RSA and EC (P-256)
+
+const (
+	ktyRSA  = "RSA"
+	ktyEC   = "EC"
+	crvP256 = "P-256"
+)
+
+type (
+	JWK struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid,omitempty"`
+		Use string `json:"use,omitempty"`
+		Alg string `json:"alg,omitempty"`
+		// RSA
+		N string `json:"n,omitempty"`
+		E string `json:"e,omitempty"`
+		// EC
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+		Y   string `json:"y,omitempty"`
+	}
+	JWKS struct {
+		Keys []JWK `json:"keys"`
+	}
+)
+
+func NewJWK(kid, alg string, pub crypto.PublicKey) (*JWK, error) {
+	jwk := &JWK{Kid: kid, Alg: alg, Use: "sig"}
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = ktyRSA
+		jwk.N = b64(k.N.Bytes())
+		jwk.E = b64(big.NewInt(int64(k.E)).Bytes())
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("unsupported EC curve %s", k.Curve.Params().Name)
+		}
+		const size = 32 // P-256 coordinate size
+		jwk.Kty, jwk.Crv = ktyEC, crvP256
+		jwk.X = b64(k.X.FillBytes(make([]byte, size)))
+		jwk.Y = b64(k.Y.FillBytes(make([]byte, size)))
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+	return jwk, nil
+}
+
+func (jwk *JWK) PublicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case ktyRSA:
+		n, err := unb64(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := unb64(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if len(n) == 0 || len(e) == 0 {
+			return nil, errors.New("invalid RSA JWK: missing modulus or exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case ktyEC:
+		if jwk.Crv != crvP256 {
+			return nil, fmt.Errorf("unsupported EC curve %q", jwk.Crv)
+		}
+		x, err := unb64(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := unb64(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, errors.New("invalid EC JWK: point is not on curve")
+		}
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("unsupported JWK key type %q", jwk.Kty)
+	}
+}
+
+func FetchJWKS(client *http.Client, url string) (*JWKS, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Get(url) //nolint:noctx // client timeout
+	if err != nil {
+		return nil, err
+	}
+	b, err := cos.ReadAllN(resp.Body, resp.ContentLength)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("GET %s: %s (%d)", url, cos.SHead(string(b)), resp.StatusCode)
+	}
+	jwks := &JWKS{}
+	if err := jsoniter.Unmarshal(b, jwks); err != nil {
+		return nil, fmt.Errorf("GET %s: invalid JWKS: %v", url, err)
+	}
+	return jwks, nil
+}
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func unb64(s string) ([]byte, error) { return base64.RawURLEncoding.DecodeString(s) }
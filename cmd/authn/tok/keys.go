@@ -0,0 +1,331 @@
+// Package tok provides AuthN token (structure and methods)
+// for validation by AIS gateways
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tok
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// supported signing algorithms
+const (
+	AlgHS256 = "HS256" // HMAC w/ shared secret (default)
+	AlgRS256 = "RS256" // RSA private key => public key (JWKS)
+	AlgES256 = "ES256" // ECDSA P-256 private key => public key (JWKS)
+)
+
+// JWKS refresh: upon unknown `kid` (but not more often than jwksMinRefresh)
+// and when the cached set gets older than jwksMaxAge
+const (
+	jwksMinRefresh = 10 * time.Second
+	jwksMaxAge     = time.Hour
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+type (
+	// Signer signs tokens with either HMAC secret or (RSA | ECDSA) private key;
+	// in the latter case, the key ID (`kid`) goes into the token header
+	Signer struct {
+		method jwt.SigningMethod
+		key    any
+		kid    string
+		iss    string // (optional) `iss` claim
+		aud    string // (optional) `aud` claim
+	}
+
+	// Verifier validates tokens signed with:
+	// - HMAC secret (shared with AuthN), and/or
+	// - (RSA | ECDSA) private keys whose public counterparts are either
+	//   statically added (see AddKey) or fetched from the JWKS endpoint (see SetJWKS)
+	// (RSA | ECDSA) signed tokens must also carry the required issuer and audience, if configured
+	// (see RequireClaims)
+	Verifier struct {
+		client  *http.Client
+		keys    map[string]crypto.PublicKey // by kid ("" when not specified): static and JWKS-fetched
+		static  map[string]crypto.PublicKey // statically added (see AddKey)
+		parser  *jwt.Parser                 // HMAC (and asymmetric when no claims are required)
+		strict  *jwt.Parser                 // asymmetric
+		secret  string
+		jwksURL string
+		fetched time.Time
+		mu      sync.RWMutex
+	}
+)
+
+////////////
+// Signer //
+////////////
+
+func NewHMACSigner(secret string) *Signer {
+	return &Signer{method: jwt.SigningMethodHS256, key: []byte(secret)}
+}
+
+// NewSigner parses PEM-encoded private key (PKCS #1, PKCS #8, or SEC 1)
+// and validates it against the specified algorithm
+func NewSigner(alg, kid string, pemKey []byte) (*Signer, error) {
+	priv, err := parsePrivateKey(pemKey)
+	if err != nil {
+		return nil, err
+	}
+	s := &Signer{key: priv, kid: kid}
+	switch alg {
+	case AlgRS256:
+		if _, ok := priv.(*rsa.PrivateKey); !ok {
+			return nil, fmt.Errorf("%s requires RSA private key, got %T", alg, priv)
+		}
+		s.method = jwt.SigningMethodRS256
+	case AlgES256:
+		k, ok := priv.(*ecdsa.PrivateKey)
+		if !ok || k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("%s requires ECDSA P-256 private key, got %T", alg, priv)
+		}
+		s.method = jwt.SigningMethodES256
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q (expecting one of: %s, %s, %s)",
+			alg, AlgHS256, AlgRS256, AlgES256)
+	}
+	return s, nil
+}
+
+func (s *Signer) Alg() string { return s.method.Alg() }
+func (s *Signer) Kid() string { return s.kid }
+
+// SetClaims sets `iss` and `aud` claims of the signed tokens (see Verifier.RequireClaims)
+func (s *Signer) SetClaims(iss, aud string) { s.iss, s.aud = iss, aud }
+
+// returns nil for HMAC
+func (s *Signer) PublicKey() crypto.PublicKey {
+	switch k := s.key.(type) {
+	case *rsa.PrivateKey:
+		return &k.PublicKey
+	case *ecdsa.PrivateKey:
+		return &k.PublicKey
+	default:
+		return nil
+	}
+}
+
+func (s *Signer) sign(claims jwt.MapClaims) (string, error) {
+	if s.iss != "" {
+		claims["iss"] = s.iss
+	}
+	if s.aud != "" {
+		claims["aud"] = s.aud
+	}
+	t := jwt.NewWithClaims(s.method, claims)
+	if s.kid != "" {
+		t.Header["kid"] = s.kid
+	}
+	return t.SignedString(s.key)
+}
+
+//////////////
+// Verifier //
+//////////////
+
+func NewVerifier(secret string) *Verifier {
+	v := &Verifier{
+		secret: secret,
+		keys:   make(map[string]crypto.PublicKey, 2),
+		static: make(map[string]crypto.PublicKey, 1),
+		parser: jwt.NewParser(),
+	}
+	v.strict = v.parser
+	return v
+}
+
+// RequireClaims makes the verifier reject (RSA | ECDSA) signed tokens
+// that are not issued by `iss` or not intended for `aud` (empty - don't check);
+// must be called prior to validating tokens
+func (v *Verifier) RequireClaims(iss, aud string) {
+	opts := make([]jwt.ParserOption, 0, 2)
+	if iss != "" {
+		opts = append(opts, jwt.WithIssuer(iss))
+	}
+	if aud != "" {
+		opts = append(opts, jwt.WithAudience(aud))
+	}
+	v.strict = jwt.NewParser(opts...)
+}
+
+// AddKey adds PEM-encoded public key (or certificate) with a given `kid`;
+// empty `kid` designates the key to use for tokens that do not specify one
+func (v *Verifier) AddKey(kid string, pemKey []byte) error {
+	pub, err := ParsePublicKey(pemKey)
+	if err != nil {
+		return err
+	}
+	v.mu.Lock()
+	v.static[kid] = pub
+	v.keys[kid] = pub
+	v.mu.Unlock()
+	return nil
+}
+
+func (v *Verifier) SetJWKS(url string, client *http.Client) {
+	v.mu.Lock()
+	v.jwksURL, v.client = url, client
+	v.mu.Unlock()
+}
+
+func (v *Verifier) HasKeys() bool {
+	v.mu.RLock()
+	ok := len(v.keys) > 0 || v.jwksURL != ""
+	v.mu.RUnlock()
+	return ok
+}
+
+func (v *Verifier) Decrypt(tokenStr string) (*Token, error) {
+	parser := v.strict
+	if parser != v.parser {
+		// HMAC secret is not shared with anyone but AuthN
+		t, _, err := v.parser.ParseUnverified(tokenStr, jwt.MapClaims{})
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok {
+			parser = v.parser
+		}
+	}
+	jwtToken, err := parser.Parse(tokenStr, v.keyfunc)
+	if err != nil {
+		return nil, err
+	}
+	return fromJWT(jwtToken)
+}
+
+func (v *Verifier) keyfunc(t *jwt.Token) (any, error) {
+	switch t.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if v.secret == "" {
+			return nil, errors.New("HMAC-signed token: secret not configured")
+		}
+		return []byte(v.secret), nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		kid, _ := t.Header["kid"].(string)
+		return v.pubKey(kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+}
+
+func (v *Verifier) pubKey(kid string) (crypto.PublicKey, error) {
+	v.mu.RLock()
+	pub, ok := v.keys[kid]
+	stale := v.jwksURL != "" && time.Since(v.fetched) > jwksMaxAge
+	v.mu.RUnlock()
+	if ok && !stale {
+		return pub, nil
+	}
+	if v.refresh() {
+		v.mu.RLock()
+		pub, ok = v.keys[kid]
+		v.mu.RUnlock()
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w (kid %q)", ErrUnknownKey, kid)
+	}
+	return pub, nil
+}
+
+// (re)load JWKS: keys that are no longer listed (rotated out or compromised) get removed;
+// statically added keys are retained unless overridden by the same `kid`
+func (v *Verifier) refresh() bool {
+	v.mu.Lock()
+	if v.jwksURL == "" || time.Since(v.fetched) < jwksMinRefresh {
+		v.mu.Unlock()
+		return false
+	}
+	v.fetched = time.Now()
+	url, client := v.jwksURL, v.client
+	v.mu.Unlock()
+
+	jwks, err := FetchJWKS(client, url)
+	if err != nil {
+		nlog.Errorln("failed to fetch JWKS:", err)
+		return false
+	}
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys)+len(v.static))
+	for i := range jwks.Keys {
+		jwk := &jwks.Keys[i]
+		pub, err := jwk.PublicKey()
+		if err != nil {
+			nlog.Warningln("skipping JWK", jwk.Kid, "err:", err)
+			continue
+		}
+		keys[jwk.Kid] = pub
+	}
+	v.mu.Lock()
+	for kid, pub := range v.static {
+		if _, ok := keys[kid]; !ok {
+			keys[kid] = pub
+		}
+	}
+	v.keys = keys
+	v.mu.Unlock()
+	return true
+}
+
+//
+// PEM
+//
+
+func parsePrivateKey(pemKey []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM private key")
+	}
+	if k, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return k, nil
+	}
+	if k, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return k, nil
+	}
+	if k, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return k, nil
+	}
+	return nil, fmt.Errorf("failed to parse private key (PEM type %q)", block.Type)
+}
+
+// ParsePublicKey accepts PEM-encoded PKIX or PKCS #1 public key, X.509 certificate,
+// or private key (in which case the corresponding public key is returned)
+func ParsePublicKey(pemKey []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM public key")
+	}
+	if k, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return k, nil
+	}
+	if k, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return k, nil
+	}
+	if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+		return cert.PublicKey, nil
+	}
+	if priv, err := parsePrivateKey(pemKey); err == nil {
+		switch k := priv.(type) {
+		case *rsa.PrivateKey:
+			return &k.PublicKey, nil
+		case *ecdsa.PrivateKey:
+			return &k.PublicKey, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to parse public key (PEM type %q)", block.Type)
+}
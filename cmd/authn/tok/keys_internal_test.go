@@ -0,0 +1,103 @@
+// Package tok provides AuthN token (structure and methods)
+// for validation by AIS gateways
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tok
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func genSigner(t *testing.T, kid string) (*Signer, []byte) {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tassert.CheckFatal(t, err)
+	b, err := x509.MarshalECPrivateKey(k)
+	tassert.CheckFatal(t, err)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b})
+	s, err := NewSigner(AlgES256, kid, pemKey)
+	tassert.CheckFatal(t, err)
+	return s, pemKey
+}
+
+// keys dropped from JWKS are no longer trusted; statically added ones are
+func TestJWKSRotation(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		jwks    = &JWKS{}
+		expires = time.Now().Add(time.Hour)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		w.Write(cos.MustMarshal(jwks))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	setKeys := func(signers ...*Signer) {
+		mu.Lock()
+		jwks.Keys = jwks.Keys[:0]
+		for _, s := range signers {
+			jwk, err := NewJWK(s.Kid(), s.Alg(), s.PublicKey())
+			tassert.CheckFatal(t, err)
+			jwks.Keys = append(jwks.Keys, *jwk)
+		}
+		mu.Unlock()
+	}
+	decrypt := func(v *Verifier, s *Signer) error {
+		token, err := AdminJWT(expires, "admin", s)
+		tassert.CheckFatal(t, err)
+		_, err = v.Decrypt(token)
+		return err
+	}
+
+	s1, _ := genSigner(t, "k1")
+	s2, _ := genSigner(t, "k2")
+	static, pemKey := genSigner(t, "static")
+
+	v := NewVerifier("")
+	tassert.CheckFatal(t, v.AddKey("static", pemKey))
+	v.SetJWKS(srv.URL, srv.Client())
+
+	setKeys(s1)
+	tassert.CheckFatal(t, decrypt(v, s1))
+
+	// rotate k1 => k2
+	setKeys(s2)
+	v.mu.Lock()
+	v.fetched = time.Time{} // (skip jwksMinRefresh)
+	v.mu.Unlock()
+	tassert.CheckFatal(t, decrypt(v, s2))
+
+	err := decrypt(v, s1)
+	tassert.Errorf(t, errors.Is(err, ErrUnknownKey), "expecting rotated-out key to fail with %v, got %v", ErrUnknownKey, err)
+	tassert.CheckError(t, decrypt(v, static))
+}
+
+func TestExpires(t *testing.T) {
+	s, _ := genSigner(t, "k1")
+	for _, expires := range []time.Time{time.Now().Add(-time.Hour), time.Now().Add(time.Hour)} {
+		token, err := AdminJWT(expires, "admin", s)
+		tassert.CheckFatal(t, err)
+
+		// not verifying - no keys
+		exp, err := Expires(token)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, exp.Unix() == expires.Unix(), "expected %v, got %v", expires, exp)
+	}
+	_, err := Expires("invalid")
+	tassert.Errorf(t, err != nil, "expecting invalid token to fail")
+}
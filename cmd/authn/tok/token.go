@@ -1,7 +1,7 @@
 // Package tok provides AuthN token (structure and methods)
 // for validation by AIS gateways
 /*
- * Copyright (c) 2018-2022, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
 package tok
 
@@ -16,7 +16,7 @@ import (
 	"github.com/NVIDIA/aistore/api/authn"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
-	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 type Token struct {
@@ -37,26 +37,27 @@ var (
 	ErrTokenRevoked  = errors.New("token revoked")
 )
 
+// Token fields (see fromJWT)
+var tokenClaims = [...]string{"username", "expires", "token", "clusters", "buckets", "admin"}
+
 // TODO: cos.Unsafe* and other micro-optimization and refactoring
 
-func AdminJWT(expires time.Time, userID, secret string) (string, error) {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+func AdminJWT(expires time.Time, userID string, s *Signer) (string, error) {
+	return s.sign(jwt.MapClaims{
 		"expires":  expires,
 		"username": userID,
 		"admin":    true,
 	})
-	return t.SignedString([]byte(secret))
 }
 
 func JWT(expires time.Time, userID string, bucketACLs []*authn.BckACL, clusterACLs []*authn.CluACL,
-	secret string) (string, error) {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	s *Signer) (string, error) {
+	return s.sign(jwt.MapClaims{
 		"expires":  expires,
 		"username": userID,
 		"buckets":  bucketACLs,
 		"clusters": clusterACLs,
 	})
-	return t.SignedString([]byte(secret))
 }
 
 // Header format: 'Authorization: Bearer <token>'
@@ -72,6 +73,7 @@ func ExtractToken(hdr http.Header) (string, error) {
 	return s[idx+1:], nil
 }
 
+// DecryptToken validates HMAC-signed token (compare w/ Verifier.Decrypt)
 func DecryptToken(tokenStr, secret string) (*Token, error) {
 	jwtToken, err := jwt.Parse(tokenStr, func(t *jwt.Token) (any, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -82,12 +84,58 @@ func DecryptToken(tokenStr, secret string) (*Token, error) {
 	if err != nil {
 		return nil, err
 	}
+	return fromJWT(jwtToken)
+}
+
+// in addition to AuthN-issued tokens, accept standard (e.g., OIDC) claims:
+// "sub" (or "preferred_username") and "exp"
+func fromJWT(jwtToken *jwt.Token) (*Token, error) {
 	claims, ok := jwtToken.Claims.(jwt.MapClaims)
 	if !ok || !jwtToken.Valid {
 		return nil, ErrInvalidToken
 	}
+	return fromClaims(claims)
+}
+
+// Expires returns the token's expiration time _without_ verifying its signature
+// (e.g., to prune the revoked tokens that have expired - compare with Verifier.Decrypt)
+func Expires(tokenStr string) (time.Time, error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tokenStr, claims); err != nil {
+		return time.Time{}, err
+	}
+	tk, err := fromClaims(claims)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return tk.Expires, nil
+}
+
+func fromClaims(claims jwt.MapClaims) (*Token, error) {
+	// (AuthN claims only - skip standard ones, such as "iss" and "aud")
+	authnClaims := make(jwt.MapClaims, len(tokenClaims))
+	for _, name := range tokenClaims {
+		if v, ok := claims[name]; ok {
+			authnClaims[name] = v
+		}
+	}
 	tk := &Token{}
-	if err := cos.MorphMarshal(claims, tk); err != nil {
+	if err := cos.MorphMarshal(authnClaims, tk); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if tk.UserID == "" {
+		if name, ok := claims["preferred_username"].(string); ok {
+			tk.UserID = name
+		} else if sub, ok := claims["sub"].(string); ok {
+			tk.UserID = sub
+		}
+	}
+	if tk.Expires.IsZero() {
+		if exp, ok := claims["exp"].(float64); ok {
+			tk.Expires = time.Unix(int64(exp), 0)
+		}
+	}
+	if tk.UserID == "" || tk.Expires.IsZero() {
 		return nil, ErrInvalidToken
 	}
 	return tk, nil
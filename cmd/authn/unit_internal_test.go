@@ -9,6 +9,14 @@ package main
 // NOTE go:build debug (above) =====================================
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -637,3 +645,106 @@ func TestMergeBckACLS(t *testing.T) {
 		}
 	}
 }
+
+func TestAsymmetricTokens(t *testing.T) {
+	genRSA := func() []byte {
+		k, err := rsa.GenerateKey(rand.Reader, 2048)
+		tassert.CheckFatal(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)})
+	}
+	genEC := func() []byte {
+		k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		tassert.CheckFatal(t, err)
+		b, err := x509.MarshalECPrivateKey(k)
+		tassert.CheckFatal(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b})
+	}
+	var (
+		rsaKey  = genRSA()
+		ecKey   = genEC()
+		expires = time.Now().Add(time.Hour)
+		jwks    = &tok.JWKS{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(cos.MustMarshal(jwks))
+	}))
+	defer srv.Close()
+
+	rsaSigner, err := tok.NewSigner(tok.AlgRS256, "k1", rsaKey)
+	tassert.CheckFatal(t, err)
+	ecSigner, err := tok.NewSigner(tok.AlgES256, "k2", ecKey)
+	tassert.CheckFatal(t, err)
+	_, err = tok.NewSigner(tok.AlgES256, "k3", rsaKey)
+	tassert.Fatalf(t, err != nil, "expecting key/algorithm mismatch")
+
+	for _, s := range []*tok.Signer{rsaSigner, ecSigner} {
+		jwk, err := tok.NewJWK(s.Kid(), s.Alg(), s.PublicKey())
+		tassert.CheckFatal(t, err)
+		jwks.Keys = append(jwks.Keys, *jwk)
+	}
+
+	// public key only (no secret)
+	verifier := tok.NewVerifier("")
+	verifier.SetJWKS(srv.URL, srv.Client())
+	for _, s := range []*tok.Signer{rsaSigner, ecSigner} {
+		token, err := tok.JWT(expires, users[0], nil, nil, s)
+		tassert.CheckFatal(t, err)
+		tk, err := verifier.Decrypt(token)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, tk.UserID == users[0], "%s: expected user %q, got %q", s.Alg(), users[0], tk.UserID)
+		_, err = tok.DecryptToken(token, "secret")
+		tassert.Errorf(t, err != nil, "%s: HMAC-only validation must fail", s.Alg())
+	}
+
+	// unknown kid
+	other, err := tok.NewSigner(tok.AlgRS256, "k4", genRSA())
+	tassert.CheckFatal(t, err)
+	token, err := tok.AdminJWT(expires, users[1], other)
+	tassert.CheckFatal(t, err)
+	_, err = verifier.Decrypt(token)
+	tassert.Errorf(t, err != nil, "expecting unknown kid to fail")
+
+	// known kid, wrong key
+	forged, err := tok.NewSigner(tok.AlgRS256, "k1", genRSA())
+	tassert.CheckFatal(t, err)
+	token, err = tok.AdminJWT(expires, users[1], forged)
+	tassert.CheckFatal(t, err)
+	_, err = verifier.Decrypt(token)
+	tassert.Errorf(t, err != nil, "expecting forged token to fail")
+
+	// required issuer and audience
+	verifier.RequireClaims("https://idp.example.com", "ais")
+	token, err = tok.JWT(expires, users[0], nil, nil, rsaSigner)
+	tassert.CheckFatal(t, err)
+	_, err = verifier.Decrypt(token)
+	tassert.Errorf(t, err != nil, "expecting token with no issuer and audience to fail")
+	for _, test := range []struct {
+		iss, aud string
+		ok       bool
+	}{
+		{"https://idp.example.com", "ais", true},
+		{"https://other.example.com", "ais", false},
+		{"https://idp.example.com", "other-client", false},
+		{"", "ais", false},
+		{"https://idp.example.com", "", false},
+	} {
+		rsaSigner.SetClaims(test.iss, test.aud)
+		token, err := tok.JWT(expires, users[0], nil, nil, rsaSigner)
+		tassert.CheckFatal(t, err)
+		tk, err := verifier.Decrypt(token)
+		if test.ok {
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, tk.UserID == users[0], "expected user %q, got %q", users[0], tk.UserID)
+		} else {
+			tassert.Errorf(t, err != nil, "(iss %q, aud %q): expecting token to fail", test.iss, test.aud)
+		}
+	}
+
+	// HMAC (AuthN-issued) tokens are not subject to the issuer and audience check
+	hmacVerifier := tok.NewVerifier("secret")
+	hmacVerifier.RequireClaims("https://idp.example.com", "ais")
+	token, err = tok.AdminJWT(expires, users[1], tok.NewHMACSigner("secret"))
+	tassert.CheckFatal(t, err)
+	_, err = hmacVerifier.Decrypt(token)
+	tassert.CheckError(t, err)
+}
@@ -164,12 +164,14 @@ var (
 			noFooterFlag,
 			maxPagesFlag,
 			startAfterFlag,
+			whereFlag,
 			bckSummaryFlag,
 			noRecursFlag,
 			noDirsFlag,
 			dontHeadRemoteFlag,
 			dontAddRemoteFlag,
 			listArchFlag,
+			listDeletedFlag,
 			unitsFlag,
 			silentFlag,
 			dontWaitFlag,
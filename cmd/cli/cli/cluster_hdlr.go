@@ -55,6 +55,7 @@ var (
 		},
 		cmdStartMaint: {
 			noRebalanceFlag,
+			rebDryRunFlag,
 			yesFlag,
 		},
 		cmdStopMaint: {
@@ -64,6 +65,7 @@ var (
 		cmdShutdown + ".node": {
 			noRebalanceFlag,
 			rmUserDataFlag,
+			rebDryRunFlag,
 			yesFlag,
 		},
 		cmdNodeDecommission + ".node": {
@@ -71,8 +73,15 @@ var (
 			noShutdownFlag,
 			rmUserDataFlag,
 			keepInitialConfigFlag,
+			rebDryRunFlag,
 			yesFlag,
 		},
+		cmdEstimateReb: {
+			estJoinFlag,
+			estRemoveFlag,
+			estMaintFlag,
+			unitsFlag,
+		},
 		cmdClusterDecommission: {
 			rmUserDataFlag,
 			yesFlag,
@@ -209,6 +218,14 @@ var (
 						Action:       nodeMaintShutDecommHandler,
 						BashComplete: suggestAllNodes,
 					},
+					{
+						Name: cmdEstimateReb,
+						Usage: "estimate how much data global rebalance would move upon (hypothetical) cluster membership change, e.g.:\n" +
+							indent4 + "\t - 'estimate-rebalance --join t[newID1],t[newID2]' - adding two new targets;\n" +
+							indent4 + "\t - 'estimate-rebalance --remove t[ID1] --maint t[ID2]' - decommissioning one target and putting another in maintenance",
+						Flags:  clusterCmdsFlags[cmdEstimateReb],
+						Action: estimateRebHandler,
+					},
 				},
 			},
 			{
@@ -402,6 +419,16 @@ func nodeMaintShutDecommHandler(c *cli.Context) error {
 	if smap.IsPrimary(node) {
 		return fmt.Errorf("%s is primary (cannot %s the primary node)", sname, action)
 	}
+	if flagIsSet(c, rebDryRunFlag) {
+		if !node.IsTarget() || flagIsSet(c, noRebalanceFlag) {
+			return fmt.Errorf("option %s requires target node with global rebalance (%s is not)", qflprn(rebDryRunFlag), sname)
+		}
+		msg := &apc.RebEstimateMsg{Maint: []string{node.ID()}}
+		if action == cmdNodeDecommission {
+			msg = &apc.RebEstimateMsg{Remove: []string{node.ID()}}
+		}
+		return estimateReb(c, msg)
+	}
 	var (
 		xid               string
 		skipRebalance     = flagIsSet(c, noRebalanceFlag) || node.IsProxy()
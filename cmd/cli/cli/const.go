@@ -64,6 +64,7 @@ const (
 	commandPut       = "put"
 	commandRemove    = "rm"
 	commandRename    = "mv"
+	commandUndelete  = "undelete"
 	commandSet       = "set"
 	commandStart     = apc.ActXactStart
 	commandStop      = apc.ActXactStop
@@ -141,6 +142,7 @@ const (
 	cmdStopMaint           = "stop-maintenance"
 	cmdNodeDecommission    = "decommission"
 	cmdClusterDecommission = "decommission"
+	cmdEstimateReb         = "estimate-rebalance"
 
 	// Show subcommands (not all)
 	cmdShowRemoteAIS  = "remote-cluster"
@@ -516,6 +518,15 @@ var (
 		Name:  "start-after",
 		Usage: "list bucket's content alphabetically starting with the first name _after_ the specified",
 	}
+	whereFlag = cli.StringFlag{
+		Name: "where",
+		Usage: "list only objects that satisfy the specified (server-side evaluated) predicate, e.g.:\n" +
+			indent4 + "\t--where 'size>1GiB and mtime<1d and custom.label=cat'\n" +
+			indent4 + "\t--where 'name~\\.tar$ and atime>30d'\t- tarballs not accessed in 30 days\n" +
+			indent4 + "\t--where 'copies<2 and ec=none'\t- objects that are neither mirrored nor erasure coded\n" +
+			indent4 + "\tproperties: name, size, atime, mtime, custom.<key>, cksum, copies, ec (none|replicated|encoded)\n" +
+			indent4 + "\t(see docs/cli/bucket.md for details)",
+	}
 
 	//
 	// list-objects sizing and limiting
@@ -740,7 +751,7 @@ var (
 	// read range (aka range read)
 	offsetFlag = cli.StringFlag{
 		Name:  "offset",
-		Usage: "object read offset; must be used together with '--length'; default formatting: IEC (use '--units' to override)"}
+		Usage: "object (or archived file, when used with '--archpath') read offset; must be used together with '--length'; default formatting: IEC (use '--units' to override)"}
 	lengthFlag = cli.StringFlag{
 		Name:  "length",
 		Usage: "object read length; default formatting: IEC (use '--units' to override)",
@@ -756,7 +767,8 @@ var (
 	headObjPresentFlag = cli.BoolFlag{
 		Name: "check-cached",
 		Usage: "check whether a given named object is present in cluster\n" +
-			indent1 + "\t(applies only to buckets with remote backend)",
+			indent1 + "\t(applies only to buckets with remote backend;\n" +
+			indent1 + "\twhen used with '--archpath', checks whether the shard is present and contains the archived file)",
 	}
 	listObjCachedFlag = cli.BoolFlag{
 		Name:  "cached",
@@ -903,6 +915,12 @@ var (
 	// archive
 	listArchFlag = cli.BoolFlag{Name: "archive", Usage: "list archived content (see docs/archive.md for details)"}
 
+	listDeletedFlag = cli.BoolFlag{
+		Name: "deleted",
+		Usage: "include soft-deleted objects that can be restored with 'ais object undelete'\n" +
+			indent4 + "\t(see 'space.deleted_retention' configuration)",
+	}
+
 	archpathFlag = cli.StringFlag{ // for apc.QparamArchpath; PUT/append => shard
 		Name:  "archpath",
 		Usage: "filename in an object (\"shard\") formatted as: " + archFormats,
@@ -1018,8 +1036,9 @@ var (
 		Usage: "absolute path to the file with dependencies that must be installed before running the code",
 	}
 	runtimeFlag = cli.StringFlag{
-		Name:     "runtime",
-		Usage:    "environment used to run the provided code (currently supported: python3.8v2, python3.10v2, python3.11v2)",
+		Name: "runtime",
+		Usage: "environment used to run the provided code (currently supported: python3.8v2, python3.10v2, python3.11v2, wasm);\n" +
+			indent4 + "\t'wasm' - compiled WebAssembly module executed in-process by each target (see docs/etl.md for the module ABI)",
 		Required: true,
 	}
 	commTypeFlag = cli.StringFlag{
@@ -1031,6 +1050,8 @@ var (
 			indent4 + "\t - 'hrev' or 'hrev://' - same, but aistore nodes will reverse-proxy requests to their respective ETL containers)\n" +
 			indent4 + "\t - 'io' or 'io://' - for each request an aistore node will: run ETL container locally, write data\n" +
 			indent4 + "\t   to its standard input and then read transformed data from the standard output\n" +
+			indent4 + "\t - 'ws' or 'ws://' - aistore nodes keep long-lived WebSocket connections with their respective ETL containers\n" +
+			indent4 + "\t   and pipeline (many) objects concurrently over each connection\n" +
 			indent4 + "\t For more defails, see https://aiatscale.org/docs/etl#communication-mechanisms\n",
 	}
 
@@ -1060,6 +1081,24 @@ var (
 		Name:  "no-rebalance",
 		Usage: "do _not_ run global rebalance after putting node in maintenance (caution: advanced usage only!)",
 	}
+	rebDryRunFlag = cli.BoolFlag{
+		Name:  dryRunFlag.Name,
+		Usage: "do nothing - estimate how much data global rebalance would move (see also: 'ais cluster add-remove-nodes " + cmdEstimateReb + "')",
+	}
+
+	// rebalance estimate (hypothetical cluster map change)
+	estJoinFlag = cli.StringFlag{
+		Name:  "join",
+		Usage: "comma-separated IDs of the targets to join the cluster (IDs the new targets will have)",
+	}
+	estRemoveFlag = cli.StringFlag{
+		Name:  "remove",
+		Usage: "comma-separated IDs of the targets to leave the cluster (decommission)",
+	}
+	estMaintFlag = cli.StringFlag{
+		Name:  "maint",
+		Usage: "comma-separated IDs of the targets to enter maintenance mode (or shut down)",
+	}
 	mountpathLabelFlag = cli.StringFlag{
 		Name: "label",
 		Usage: "an optional _mountpath label_ to facilitate extended functionality and context, including:\n" +
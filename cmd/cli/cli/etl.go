@@ -55,6 +55,8 @@ var (
 			forceFlag,
 			copyPrependFlag,
 			copyDryRunFlag,
+			latestVerFlag,
+			syncFlag,
 			etlBucketRequestTimeout,
 			listFlag,
 			templateFlag,
@@ -413,6 +415,9 @@ func stopETLs(c *cli.Context, name string) (err error) {
 			return V(err)
 		}
 		for _, etlInfo := range res {
+			if len(etlInfo.Stages) > 0 {
+				continue // pipeline (stopping its stages is sufficient)
+			}
 			etlNames = append(etlNames, etlInfo.Name)
 		}
 	default:
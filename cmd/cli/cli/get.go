@@ -403,7 +403,7 @@ func getObject(c *cli.Context, bck cmn.Bck, objName, outFile string, a qparamArc
 
 	// just check if a remote object is present (do not GET)
 	if flagIsSet(c, headObjPresentFlag) {
-		return isObjPresent(c, bck, objName)
+		return isObjPresent(c, bck, objName, a.archpath)
 	}
 
 	units, err := parseUnitsFlag(c, unitsFlag)
@@ -609,19 +609,22 @@ func (a *qparamArch) init(c *cli.Context) error {
 }
 
 func (a *qparamArch) validate(c *cli.Context) error {
+	if a.archregx != "" {
+		// (range-read and presence check are supported only for a single archived file)
+		if flagIsSet(c, headObjPresentFlag) {
+			return fmt.Errorf("cannot check presence (%s) of multiple archived files (%s) - "+NIY,
+				qflprn(headObjPresentFlag), qflprn(archregxFlag))
+		}
+		if flagIsSet(c, lengthFlag) {
+			return errRangeReadArch(qflprn(archregxFlag))
+		}
+	}
 	if a.archpath == "" {
 		return nil
 	}
 	if flagIsSet(c, getObjPrefixFlag) {
 		return fmt.Errorf(errFmtExclusive, qflprn(getObjPrefixFlag), qflprn(archpathGetFlag))
 	}
-	if flagIsSet(c, headObjPresentFlag) {
-		return fmt.Errorf("cannot check presence (%s) of archived file(s) (%s) - "+NIY,
-			qflprn(headObjPresentFlag), qflprn(archpathGetFlag))
-	}
-	if flagIsSet(c, lengthFlag) {
-		return errRangeReadArch(qflprn(archpathGetFlag))
-	}
 	return nil
 }
 
@@ -311,6 +311,12 @@ func listObjects(c *cli.Context, bck cmn.Bck, prefix string, listArch, printEmpt
 	if listArch {
 		msg.SetFlag(apc.LsArchDir)
 	}
+	if flagIsSet(c, listDeletedFlag) {
+		if !bck.IsAIS() {
+			return fmt.Errorf("flag %s requires ais bucket (have: %s)", qflprn(listDeletedFlag), bck)
+		}
+		msg.SetFlag(apc.LsDeleted)
+	}
 	if flagIsSet(c, noRecursFlag) {
 		msg.SetFlag(apc.LsNoRecursion)
 	}
@@ -358,9 +364,10 @@ func listObjects(c *cli.Context, bck cmn.Bck, prefix string, listArch, printEmpt
 			msg.AddProps(props...)
 		}
 	}
-	if flagIsSet(c, allObjsOrBcksFlag) {
+	if flagIsSet(c, allObjsOrBcksFlag) || flagIsSet(c, listDeletedFlag) {
 		// Show status. Object name can then be displayed multiple times
-		// (due to mirroring, EC). The status helps to tell an object from its replica(s).
+		// (due to mirroring, EC). The status helps to tell an object from its replica(s)
+		// and a live object from its soft-deleted counterpart.
 		msg.AddProps(apc.GetPropsStatus)
 	}
 	propsStr = msg.Props // show these and _only_ these props
@@ -378,6 +385,9 @@ func listObjects(c *cli.Context, bck cmn.Bck, prefix string, listArch, printEmpt
 	if flagIsSet(c, startAfterFlag) {
 		msg.StartAfter = parseStrFlag(c, startAfterFlag)
 	}
+	if flagIsSet(c, whereFlag) {
+		msg.Where = parseStrFlag(c, whereFlag)
+	}
 	pageSize, maxPages, limit, err := _setPage(c, bck)
 	if err != nil {
 		return err
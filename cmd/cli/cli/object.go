@@ -224,9 +224,13 @@ func concatObject(c *cli.Context, bck cmn.Bck, objName string, fileNames []strin
 	return nil
 }
 
-func isObjPresent(c *cli.Context, bck cmn.Bck, objName string) error {
+// (when archpath is specified, check presence of the archived file)
+func isObjPresent(c *cli.Context, bck cmn.Bck, objName, archpath string) error {
 	name := bck.Cname(objName)
-	hargs := api.HeadArgs{FltPresence: apc.FltPresentNoProps, Silent: true}
+	if archpath != "" {
+		name = archpath + " in " + name
+	}
+	hargs := api.HeadArgs{FltPresence: apc.FltPresentNoProps, Silent: true, ArchPath: archpath}
 	_, err := api.HeadObject(apiBP, bck, objName, hargs)
 	if err != nil {
 		if cmn.IsStatusNotFound(err) {
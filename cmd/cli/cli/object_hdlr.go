@@ -83,7 +83,8 @@ var (
 			nonverboseFlag,
 			yesFlag,
 		),
-		commandRename: {},
+		commandRename:   {},
+		commandUndelete: {},
 		commandGet: {
 			offsetFlag,
 			lengthFlag,
@@ -245,6 +246,17 @@ var (
 				Action:       mvObjectHandler,
 				BashComplete: bucketCompletions(bcmplop{multiple: true, separator: true}),
 			},
+			{
+				Name: commandUndelete,
+				Usage: "restore soft-deleted object, e.g.:\n" +
+					indent1 + "\t- 'ais ls ais://nnn --deleted'\t- list objects including soft-deleted ones;\n" +
+					indent1 + "\t- 'ais object undelete ais://nnn/obj'\t- restore soft-deleted ais://nnn/obj\n" +
+					indent1 + "(see 'space.deleted_retention' configuration)",
+				ArgsUsage:    objectArgument,
+				Flags:        objectCmdsFlags[commandUndelete],
+				Action:       undeleteObjectHandler,
+				BashComplete: bucketCompletions(bcmplop{separator: true}),
+			},
 			{
 				Name:         commandCat,
 				Usage:        "cat an object (i.e., print its contents to STDOUT)",
@@ -304,6 +316,31 @@ func mvObjectHandler(c *cli.Context) (err error) {
 	return
 }
 
+func undeleteObjectHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	if c.NArg() > 1 {
+		return incorrectUsageMsg(c, "", c.Args()[1:])
+	}
+	uri := c.Args().Get(0)
+	bck, objName, err := parseBckObjURI(c, uri, false)
+	if err != nil {
+		return err
+	}
+	if objName == "" {
+		return incorrectUsageMsg(c, "no object specified in %q", uri)
+	}
+	if !bck.IsAIS() {
+		return incorrectUsageMsg(c, "provider %q not supported", bck.Provider)
+	}
+	if err := api.UndeleteObject(apiBP, bck, objName); err != nil {
+		return V(err)
+	}
+	actionDone(c, "Restored "+bck.Cname(objName))
+	return nil
+}
+
 // main PUT handler: cases 1 through 4
 func putHandler(c *cli.Context) error {
 	if flagIsSet(c, appendConcatFlag) {
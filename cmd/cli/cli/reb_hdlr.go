@@ -18,6 +18,7 @@ import (
 	"github.com/NVIDIA/aistore/cmd/cli/teb"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/urfave/cli"
 )
@@ -204,3 +205,117 @@ func displayRebStats(tw *tabwriter.Writer, st *targetRebSnap, units string, date
 		startTime, endTime, teb.FmtXactStatus(st.snap),
 	)
 }
+
+//
+// rebalance estimate (dry-run)
+//
+
+func estimateRebHandler(c *cli.Context) error {
+	var (
+		msg = &apc.RebEstimateMsg{}
+		err error
+	)
+	for _, name := range splitCsv(parseStrFlag(c, estJoinFlag)) {
+		msg.Join = append(msg.Join, meta.N2ID(name))
+	}
+	if msg.Remove, err = _estTargets(c, estRemoveFlag); err != nil {
+		return err
+	}
+	if msg.Maint, err = _estTargets(c, estMaintFlag); err != nil {
+		return err
+	}
+	if msg.IsEmpty() {
+		return fmt.Errorf("expecting at least one of the options: %s, %s, or %s",
+			qflprn(estJoinFlag), qflprn(estRemoveFlag), qflprn(estMaintFlag))
+	}
+	return estimateReb(c, msg)
+}
+
+func _estTargets(c *cli.Context, flag cli.StringFlag) (tids []string, _ error) {
+	for _, name := range splitCsv(parseStrFlag(c, flag)) {
+		node, sname, err := getNode(c, name)
+		if err != nil {
+			return nil, err
+		}
+		if !node.IsTarget() {
+			return nil, fmt.Errorf("%s is not a target (option %s)", sname, qflprn(flag))
+		}
+		tids = append(tids, node.ID())
+	}
+	return tids, nil
+}
+
+func estimateReb(c *cli.Context, msg *apc.RebEstimateMsg) error {
+	units, err := parseUnitsFlag(c, unitsFlag)
+	if err != nil {
+		return err
+	}
+	ests, err := api.EstimateRebalance(apiBP, msg)
+	if err != nil {
+		return V(err)
+	}
+	displayRebEstimate(c, ests, units)
+	return nil
+}
+
+func displayRebEstimate(c *cli.Context, ests apc.RebEstimates, units string) {
+	var (
+		total apc.RebEstimateCnt
+		in    = ests.In()
+		tids  = make([]string, 0, len(in)+len(ests))
+		tw    = &tabwriter.Writer{}
+	)
+	for tid := range ests {
+		tids = append(tids, tid)
+	}
+	for tid := range in {
+		if _, ok := ests[tid]; !ok {
+			tids = append(tids, tid) // joining
+		}
+	}
+	sort.Strings(tids)
+
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NODE\t SCANNED\t OBJECTS OUT\t SIZE OUT\t OBJECTS IN\t SIZE IN")
+	for _, tid := range tids {
+		var (
+			scanned, out = "-", apc.RebEstimateCnt{}
+			cin          = in[tid]
+		)
+		if e, ok := ests[tid]; ok {
+			scanned = teb.FmtSize(e.Scanned.Bytes, units, 2)
+			out = e.Out
+			total.Merge(&e.Out)
+		}
+		if cin == nil {
+			cin = &apc.RebEstimateCnt{}
+		}
+		fmt.Fprintf(tw, "%s\t %s\t %d\t %s\t %d\t %s\n", meta.Tname(tid), scanned,
+			out.Objs, teb.FmtSize(out.Bytes, units, 2), cin.Objs, teb.FmtSize(cin.Bytes, units, 2))
+	}
+	tw.Flush()
+
+	bcks := ests.Bcks()
+	if len(bcks) > 0 {
+		names := make([]string, 0, len(bcks))
+		for cname := range bcks {
+			names = append(names, cname)
+		}
+		sort.Strings(names)
+		fmt.Fprintln(c.App.Writer)
+		tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, "BUCKET\t OBJECTS\t SIZE")
+		for _, cname := range names {
+			cnt := bcks[cname]
+			fmt.Fprintf(tw, "%s\t %d\t %s\n", cname, cnt.Objs, teb.FmtSize(cnt.Bytes, units, 2))
+		}
+		tw.Flush()
+	}
+
+	dur := "unknown"
+	if d := ests.Duration(); d > 0 {
+		dur = teb.FmtDuration(int64(d), units)
+	}
+	fmt.Fprintf(c.App.Writer, "\nGlobal rebalance would move %d objects (total size %s), estimated duration: %s\n",
+		total.Objs, teb.FmtSize(total.Bytes, units, 1), dur)
+}
@@ -578,6 +578,18 @@ func bckPropList(props *cmn.Bprops, verbose bool) (propList nvpairList) {
 			{"lru", props.LRU.String()},
 			{"versioning", props.Versioning.String()},
 		}
+		if props.Quota.Enabled() {
+			propList = append(propList, nvpair{Name: "quota", Value: props.Quota.String()})
+		}
+		if props.RateLimit.Enabled() {
+			propList = append(propList, nvpair{Name: "rate_limit", Value: props.RateLimit.String()})
+		}
+		if props.SSE.Enabled {
+			propList = append(propList, nvpair{Name: "sse", Value: props.SSE.String()})
+		}
+		if props.ObjLock.Enabled {
+			propList = append(propList, nvpair{Name: "object_lock", Value: props.ObjLock.String()})
+		}
 		if props.Provider == apc.HT {
 			origURL := props.Extra.HTTP.OrigURLBck
 			if origURL != "" {
@@ -102,6 +102,8 @@ func fmtLsObjStatus(e *cmn.LsoEnt) string {
 		return "replica"
 	case apc.LocIsCopyMissingObj:
 		return "replica(object-is-missing)"
+	case apc.LocIsDeleted:
+		return fred("soft-deleted")
 	default:
 		debug.Assertf(false, "%#v", e)
 		return "invalid"
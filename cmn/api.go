@@ -9,8 +9,11 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/url"
+	"path/filepath"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -46,6 +49,12 @@ type (
 		BackendBck  Bck             `json:"backend_bck,omitempty"` // makes remote bucket out of a given ais bucket
 		Extra       ExtraProps      `json:"extra,omitempty" list:"omitempty"`
 		WritePolicy WritePolicyConf `json:"write_policy"`
+		Chunks      ChunksConf      `json:"chunks"`
+		Quota       QuotaConf       `json:"quota"`
+		RateLimit   RateLimitConf   `json:"rate_limit"`
+		SSE         SSEConf         `json:"sse"`
+		ObjLock     ObjLockConf     `json:"object_lock"`
+		S3          S3Props         `json:"s3,omitempty" list:"omit"`       // S3 bucket configuration (see ais/s3)
 		Provider    string          `json:"provider" list:"readonly"`       // backend provider
 		Renamed     string          `list:"omit"`                           // non-empty if the bucket has been renamed
 		Cksum       CksumConf       `json:"checksum"`                       // the bucket's checksum
@@ -113,6 +122,71 @@ type (
 		RefDirectory *string `json:"ref_directory"`
 	}
 
+	// S3 bucket-level configuration documents, as set and returned via S3 API
+	// (e.g., PUT /s3/<bucket>?lifecycle); stored verbatim (XML or, in case of policy, JSON)
+	// and parsed on demand by ais/s3
+	S3Props struct {
+		Lifecycle string `json:"lifecycle,omitempty"`
+		CORS      string `json:"cors,omitempty"`
+		Policy    string `json:"policy,omitempty"`
+		ACL       string `json:"acl,omitempty"`
+	}
+
+	// bucket capacity and object-count quotas (not inherited from cluster config);
+	// zero MaxSize and MaxObjs (default) mean unlimited
+	QuotaConf struct {
+		MaxSize cos.SizeIEC `json:"max_size"` // max total size (on disk) of the bucket's content
+		MaxObjs int64       `json:"max_objs"` // max number of objects
+		SoftPct int64       `json:"soft_pct"` // percentage of either limit that triggers alerts; zero means default (90%)
+	}
+	QuotaConfToSet struct {
+		MaxSize *cos.SizeIEC `json:"max_size,omitempty"`
+		MaxObjs *int64       `json:"max_objs,omitempty"`
+		SoftPct *int64       `json:"soft_pct,omitempty"`
+	}
+
+	// bucket request and bandwidth rate limits (not inherited from cluster config);
+	// zero (default) means unlimited; per-user limits require AuthN
+	RateLimitConf struct {
+		MaxRPS  int64       `json:"max_rps"`  // max object requests per second - all users
+		MaxBPS  cos.SizeIEC `json:"max_bps"`  // max GET and PUT bytes per second - all users
+		UserRPS int64       `json:"user_rps"` // max object requests per second - each AuthN user
+		UserBPS cos.SizeIEC `json:"user_bps"` // max GET and PUT bytes per second - each AuthN user
+	}
+	RateLimitConfToSet struct {
+		MaxRPS  *int64       `json:"max_rps,omitempty"`
+		MaxBPS  *cos.SizeIEC `json:"max_bps,omitempty"`
+		UserRPS *int64       `json:"user_rps,omitempty"`
+		UserBPS *cos.SizeIEC `json:"user_bps,omitempty"`
+	}
+
+	// server-side encryption at rest (not inherited from cluster config; see core/lsse.go);
+	// key source is either "file:///path/to/key" (the same file on each target)
+	// or Vault-compatible "https://..." endpoint - either way, permitted by the
+	// cluster configuration (see SSEKeysConf)
+	SSEConf struct {
+		KeySource string `json:"key_source"` // bucket key location
+		Enabled   bool   `json:"enabled"`    // encrypt new and overwritten objects
+	}
+	SSEConfToSet struct {
+		KeySource *string `json:"key_source,omitempty"`
+		Enabled   *bool   `json:"enabled,omitempty"`
+	}
+
+	// object lock aka WORM (not inherited from cluster config; see core/lretain.go);
+	// once enabled, cannot be disabled; default retention applies to new objects
+	// that do not specify their own
+	ObjLockConf struct {
+		Mode    string `json:"mode"`    // default retention mode: "governance" or "compliance"
+		Days    int64  `json:"days"`    // default retention period; zero means no default retention
+		Enabled bool   `json:"enabled"` // enforce object retention and legal hold
+	}
+	ObjLockConfToSet struct {
+		Mode    *string `json:"mode,omitempty"`
+		Days    *int64  `json:"days,omitempty"`
+		Enabled *bool   `json:"enabled,omitempty"`
+	}
+
 	// Once validated, BpropsToSet are copied to Bprops.
 	// The struct may have extra fields that do not exist in Bprops.
 	// Add tag 'copy:"skip"' to ignore those fields when copying values.
@@ -126,6 +200,11 @@ type (
 		Access      *apc.AccessAttrs      `json:"access,string,omitempty"`
 		Features    *feat.Flags           `json:"features,string,omitempty"`
 		WritePolicy *WritePolicyConfToSet `json:"write_policy,omitempty"`
+		Chunks      *ChunksConfToSet      `json:"chunks,omitempty"`
+		Quota       *QuotaConfToSet       `json:"quota,omitempty"`
+		RateLimit   *RateLimitConfToSet   `json:"rate_limit,omitempty"`
+		SSE         *SSEConfToSet         `json:"sse,omitempty"`
+		ObjLock     *ObjLockConfToSet     `json:"object_lock,omitempty"`
 		Extra       *ExtraToSet           `json:"extra,omitempty"`
 		Force       bool                  `json:"force,omitempty" copy:"skip" list:"omit"`
 	}
@@ -165,8 +244,8 @@ func (bck *Bck) DefaultProps(c *ClusterConfig) *Bprops {
 	if wp.MD.IsImmediate() {
 		wp.MD = apc.WriteImmediate
 	}
-	if wp.Data.IsImmediate() {
-		wp.Data = apc.WriteImmediate
+	if wp.Data.IsImmediate() || !bck.IsAIS() {
+		wp.Data = apc.WriteImmediate // (delayed and never data policies: ais buckets only - see Validate below)
 	}
 	return &Bprops{
 		Cksum:       cksum,
@@ -176,6 +255,7 @@ func (bck *Bck) DefaultProps(c *ClusterConfig) *Bprops {
 		Access:      apc.AccessAll,
 		EC:          c.EC,
 		WritePolicy: wp,
+		Chunks:      c.Chunks,
 		Features:    c.Features,
 	}
 }
@@ -220,7 +300,7 @@ func (bp *Bprops) Validate(targetCnt int) error {
 
 	// run assorted props validators
 	var softErr error
-	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy} {
+	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy, &bp.Chunks, &bp.Quota, &bp.RateLimit, &bp.SSE, &bp.ObjLock} {
 		var err error
 		if pv == &bp.EC {
 			err = bp.EC.ValidateAsProps(targetCnt)
@@ -239,6 +319,21 @@ func (bp *Bprops) Validate(targetCnt int) error {
 	if bp.Mirror.Enabled && bp.EC.Enabled {
 		nlog.Warningln("n-way mirroring and EC are both enabled at the same time on the same bucket")
 	}
+	if bp.Chunks.Enabled() && bp.Mirror.Enabled {
+		// (mirroring replicates the first chunk only)
+		return errors.New("chunked layout cannot be used together with n-way mirroring")
+	}
+	if wp := bp.WritePolicy.Data; !wp.IsImmediate() {
+		// staged (in-memory) object data is not mirrored, erasure coded, or written to remote backends
+		switch {
+		case bp.Provider != apc.AIS || !bp.BackendBck.IsEmpty():
+			return fmt.Errorf("write policy %q for data is only supported for ais:// buckets with no remote backend", wp)
+		case bp.Mirror.Enabled:
+			return fmt.Errorf("write policy %q for data cannot be used together with n-way mirroring", wp)
+		case bp.EC.Enabled:
+			return fmt.Errorf("write policy %q for data cannot be used together with erasure coding", wp)
+		}
+	}
 
 	// not inheriting cluster-scope features
 	names := bp.Features.Names()
@@ -287,6 +382,158 @@ func (c *ExtraProps) ValidateAsProps(arg ...any) error {
 	return nil
 }
 
+///////////////
+// QuotaConf //
+///////////////
+
+const quotaSoftPctDflt = 90
+
+func (c *QuotaConf) Enabled() bool { return c.MaxSize > 0 || c.MaxObjs > 0 }
+
+func (c *QuotaConf) Soft() int64 {
+	if c.SoftPct == 0 {
+		return quotaSoftPctDflt
+	}
+	return c.SoftPct
+}
+
+func (c *QuotaConf) ValidateAsProps(...any) error {
+	if c.MaxSize < 0 || c.MaxObjs < 0 {
+		return fmt.Errorf("invalid quota.max_size=%d, quota.max_objs=%d (expecting non-negative)", c.MaxSize, c.MaxObjs)
+	}
+	if c.SoftPct < 0 || c.SoftPct > 100 {
+		return fmt.Errorf("invalid quota.soft_pct=%d (expected range [0, 100])", c.SoftPct)
+	}
+	return nil
+}
+
+func (c *QuotaConf) String() string {
+	if !c.Enabled() {
+		return "Disabled"
+	}
+	var s string
+	if c.MaxSize > 0 {
+		s = "max size " + c.MaxSize.String()
+	}
+	if c.MaxObjs > 0 {
+		if s != "" {
+			s += ", "
+		}
+		s += "max objects " + strconv.FormatInt(c.MaxObjs, 10)
+	}
+	return s + ", soft " + strconv.FormatInt(c.Soft(), 10) + "%"
+}
+
+///////////////////
+// RateLimitConf //
+///////////////////
+
+func (c *RateLimitConf) Enabled() bool {
+	return c.MaxRPS > 0 || c.MaxBPS > 0 || c.UserRPS > 0 || c.UserBPS > 0
+}
+
+func (c *RateLimitConf) ValidateAsProps(...any) error {
+	if c.MaxRPS < 0 || c.MaxBPS < 0 || c.UserRPS < 0 || c.UserBPS < 0 {
+		return fmt.Errorf("invalid rate_limit %+v (expecting non-negative values)", *c)
+	}
+	return nil
+}
+
+func (c *RateLimitConf) String() string {
+	if !c.Enabled() {
+		return "Disabled"
+	}
+	var sb []string
+	if c.MaxRPS != 0 {
+		sb = append(sb, "max "+strconv.FormatInt(c.MaxRPS, 10)+" req/s")
+	}
+	if c.MaxBPS != 0 {
+		sb = append(sb, "max "+c.MaxBPS.String()+"/s")
+	}
+	if c.UserRPS != 0 {
+		sb = append(sb, "per-user "+strconv.FormatInt(c.UserRPS, 10)+" req/s")
+	}
+	if c.UserBPS != 0 {
+		sb = append(sb, "per-user "+c.UserBPS.String()+"/s")
+	}
+	return strings.Join(sb, ", ")
+}
+
+/////////////
+// SSEConf //
+/////////////
+
+const (
+	SSEKeyFile  = "file://"
+	SSEKeyHTTPS = "https://"
+)
+
+func (c *SSEConf) ValidateAsProps(...any) error {
+	switch {
+	case c.KeySource == "":
+		if c.Enabled {
+			return errors.New("sse.key_source must be specified to enable server-side encryption")
+		}
+		return nil
+	case strings.HasPrefix(c.KeySource, SSEKeyFile):
+		if !filepath.IsAbs(strings.TrimPrefix(c.KeySource, SSEKeyFile)) {
+			return fmt.Errorf("invalid sse.key_source %q (expecting absolute path, e.g. file:///etc/ais/bucket.key)", c.KeySource)
+		}
+	case strings.HasPrefix(c.KeySource, SSEKeyHTTPS):
+		if _, err := url.ParseRequestURI(c.KeySource); err != nil {
+			return fmt.Errorf("invalid sse.key_source %q: %v", c.KeySource, err)
+		}
+	default:
+		return fmt.Errorf("invalid sse.key_source %q (expecting %q or %q prefix)", c.KeySource, SSEKeyFile, SSEKeyHTTPS)
+	}
+	if conf := &GCO.Get().SSE; !conf.Allowed(c.KeySource) {
+		return fmt.Errorf("sse.key_source %q is not permitted by the cluster configuration (sse.key_sources %v)",
+			c.KeySource, conf.KeySources)
+	}
+	return nil
+}
+
+func (c *SSEConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return "AES-256 (key: " + c.KeySource + ")"
+}
+
+/////////////////
+// ObjLockConf //
+/////////////////
+
+// retention modes
+const (
+	ObjLockGovernance = "governance" // can be bypassed (see core.LOM.CheckRetained)
+	ObjLockCompliance = "compliance" // cannot be bypassed, shortened, or removed
+)
+
+func (c *ObjLockConf) ValidateAsProps(...any) error {
+	switch {
+	case c.Mode != "" && c.Mode != ObjLockGovernance && c.Mode != ObjLockCompliance:
+		return fmt.Errorf("invalid object_lock.mode %q (expecting %q or %q)", c.Mode, ObjLockGovernance, ObjLockCompliance)
+	case c.Days < 0:
+		return fmt.Errorf("invalid object_lock.days %d", c.Days)
+	case (c.Days > 0) != (c.Mode != ""):
+		return errors.New("object_lock.mode and object_lock.days must be specified together")
+	case c.Days > 0 && !c.Enabled:
+		return errors.New("default retention requires object_lock.enabled=true")
+	}
+	return nil
+}
+
+func (c *ObjLockConf) String() string {
+	switch {
+	case !c.Enabled:
+		return "Disabled"
+	case c.Days == 0:
+		return "Enabled"
+	}
+	return c.Mode + " " + strconv.FormatInt(c.Days, 10) + "d"
+}
+
 //
 // Bucket Summary - result for a given bucket, and all results -------------------------------------------------
 //
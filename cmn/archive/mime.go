@@ -13,7 +13,6 @@ import (
 	"strings"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
-	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/memsys"
 )
@@ -127,17 +126,8 @@ func MimeFile(file cos.LomReader, smm *memsys.MMSA, mime, archname string) (m st
 		buf, slab = smm.AllocSize(sizeDetectMime)
 	)
 	m, n, err = _detect(file, archname, m, buf)
-	if n > 0 {
-		fh, ok := file.(*os.File)
-		cos.Assertf(ok, "expecting os.File, got %T", file)
-		_, errV := fh.Seek(0, io.SeekStart)
-		debug.AssertNoErr(errV)
-		if err == nil {
-			err = errV
-		}
-		if err == nil {
-			nlog.Infoln("archname", archname, "is in fact", m, "(via magic sign)")
-		}
+	if n > 0 && err == nil {
+		nlog.Infoln("archname", archname, "is in fact", m, "(via magic sign)")
 	}
 	slab.Free(buf)
 	return
@@ -166,9 +156,11 @@ func MimeFQN(smm *memsys.MMSA, mime, archname string) (m string, err error) {
 	return
 }
 
-func _detect(file cos.LomReader, archname, mime string, buf []byte) (string, int, error) {
-	n, err := file.Read(buf)
-	if err != nil {
+// reading at offset zero leaves the reader's position intact - works with any
+// cos.LomReader: file, chunked (ChunksHandle), encrypted (SSEHandle), in-memory
+func _detect(file io.ReaderAt, archname, mime string, buf []byte) (string, int, error) {
+	n, err := file.ReadAt(buf, 0)
+	if err != nil && (err != io.EOF || n == 0) {
 		return "", 0, err
 	}
 	switch mime {
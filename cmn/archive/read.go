@@ -9,6 +9,7 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -78,7 +79,8 @@ type (
 	}
 	tarReader struct {
 		baseR
-		tr *tar.Reader
+		tr  *tar.Reader
+		pos *posReader // (when fh is io.ReaderAt)
 	}
 	tgzReader struct {
 		tr  tarReader
@@ -168,6 +170,15 @@ func (m *matcher) do(filename string) bool {
 
 func (tr *tarReader) init(fh io.Reader) error {
 	tr.baseR.init(fh)
+	if _, ok := fh.(io.ReaderAt); ok {
+		// track current position to provide for random access (see ReadRange)
+		tr.pos = &posReader{r: fh}
+		if sr, ok := fh.(io.Seeker); ok {
+			tr.pos.off, _ = sr.Seek(0, io.SeekCurrent)
+		}
+		tr.tr = tar.NewReader(tr.pos)
+		return nil
+	}
 	tr.tr = tar.NewReader(fh)
 	return nil
 }
@@ -206,7 +217,12 @@ func (tr *tarReader) ReadOne(filename string) (cos.ReadCloseSizer, error) {
 			return nil, err
 		}
 		if hdr.Name == filename || namesEq(hdr.Name, filename) {
-			return &cslLimited{LimitedReader: io.LimitedReader{R: tr.tr, N: hdr.Size}}, nil
+			csl := &cslLimited{LimitedReader: io.LimitedReader{R: tr.tr, N: hdr.Size}}
+			if tr.pos != nil && isContiguous(hdr) {
+				// archived file's content starts at the current position
+				csl.ra, csl.off = tr.fh.(io.ReaderAt), tr.pos.off
+			}
+			return csl, nil
 		}
 	}
 }
@@ -275,7 +291,7 @@ func (zr *zipReader) ReadUntil(rcb ArchRCB, regex, mmode string) (err error) {
 			continue
 		}
 
-		csf := &cslFile{size: int64(f.FileHeader.UncompressedSize64)}
+		csf := &cslFile{size: int64(f.FileHeader.UncompressedSize64), crc: f.FileHeader.CRC32}
 		if csf.file, err = f.Open(); err != nil {
 			return err
 		}
@@ -297,7 +313,14 @@ func (zr *zipReader) ReadOne(filename string) (reader cos.ReadCloseSizer, err er
 			"%d vs %d", finfo.Size(), f.FileHeader.UncompressedSize64)
 
 		if f.FileHeader.Name == filename || namesEq(f.FileHeader.Name, filename) {
-			csf := &cslFile{size: finfo.Size()}
+			csf := &cslFile{size: finfo.Size(), crc: f.FileHeader.CRC32}
+			if f.Method == zip.Store {
+				// stored (uncompressed) content can be read at its offset (see ReadRange)
+				if csf.off, err = f.DataOffset(); err != nil {
+					return nil, err
+				}
+				csf.ra = zr.fh.(io.ReaderAt)
+			}
 			csf.file, err = f.Open()
 			return csf, err
 		}
@@ -328,7 +351,9 @@ func (lzr *lz4Reader) ReadOne(filename string) (cos.ReadCloseSizer, error) {
 
 type (
 	cslLimited struct {
+		ra io.ReaderAt // when not nil: archive's reader and archived file's offset in it
 		io.LimitedReader
+		off int64
 	}
 	cslClose struct {
 		gzr io.ReadCloser
@@ -337,10 +362,62 @@ type (
 	}
 	cslFile struct {
 		file io.ReadCloser
+		ra   io.ReaderAt // stored (uncompressed) file: same as cslLimited
 		size int64
+		off  int64
+		crc  uint32 // as stored in the zip header (and validated by the zip reader upon EOF)
+	}
+	cslRange struct {
+		io.LimitedReader
+		csl  cos.ReadCloseSizer
+		size int64
+	}
+	// tracks tar reader's position in the underlying archive
+	posReader struct {
+		r   io.Reader
+		off int64
 	}
 )
 
+// ReadRange positions archived file's reader (as returned by `ReadOne`) at a given offset
+// and limits it to the specified length; closing the returned reader closes the original one.
+// Uncompressed tar and stored (not deflated) zip content is read directly at its offset
+// in the archive (provided the archive's reader is io.ReaderAt); all other formats and
+// compression methods require reading (and discarding) the archived file up to `offset`.
+func ReadRange(csl cos.ReadCloseSizer, offset, length int64) (cos.ReadCloseSizer, error) {
+	debug.Assert(offset >= 0 && length >= 0 && offset+length <= csl.Size(), offset, " ", length, " ", csl.Size())
+	var (
+		ra  io.ReaderAt
+		off int64
+	)
+	switch v := csl.(type) {
+	case *cslLimited:
+		ra, off = v.ra, v.off
+	case *cslFile:
+		ra, off = v.ra, v.off
+	}
+	if ra != nil {
+		sr := io.NewSectionReader(ra, off+offset, length)
+		return &cslRange{LimitedReader: io.LimitedReader{R: sr, N: length}, csl: csl, size: length}, nil
+	}
+	if offset > 0 {
+		if _, err := io.CopyN(io.Discard, csl, offset); err != nil {
+			return nil, err
+		}
+	}
+	return &cslRange{LimitedReader: io.LimitedReader{R: csl, N: length}, csl: csl, size: length}, nil
+}
+
+// Cksum returns archived file's checksum if (and only if) the archive itself stores one
+// (currently, zip CRC32); otherwise, returns nil
+func Cksum(csl cos.ReadCloseSizer) *cos.Cksum {
+	csf, ok := csl.(*cslFile)
+	if !ok {
+		return nil
+	}
+	return cos.NewCksum(cos.ChecksumCRC32, fmt.Sprintf("%08x", csf.crc))
+}
+
 //
 // assorted 'limited' readers
 //
@@ -356,6 +433,41 @@ func (csf *cslFile) Read(b []byte) (int, error) { return csf.file.Read(b) }
 func (csf *cslFile) Size() int64                { return csf.size }
 func (csf *cslFile) Close() error               { return csf.file.Close() }
 
+func (csr *cslRange) Size() int64  { return csr.size }
+func (csr *cslRange) Close() error { return csr.csl.Close() }
+
+func (pr *posReader) Read(b []byte) (n int, err error) {
+	n, err = pr.r.Read(b)
+	pr.off += int64(n)
+	return n, err
+}
+
+// tar reader uses Seek (when supported) to skip archived files; otherwise, reads and discards
+func (pr *posReader) Seek(offset int64, whence int) (int64, error) {
+	sr, ok := pr.r.(io.Seeker)
+	if !ok {
+		return -1, errors.ErrUnsupported
+	}
+	off, err := sr.Seek(offset, whence)
+	if err == nil {
+		pr.off = off
+	}
+	return off, err
+}
+
+// regular (non-sparse) file's content is stored contiguously, right after its header(s)
+func isContiguous(hdr *tar.Header) bool {
+	if hdr.Typeflag != tar.TypeReg {
+		return false
+	}
+	for k := range hdr.PAXRecords {
+		if strings.HasPrefix(k, "GNU.sparse.") {
+			return false
+		}
+	}
+	return true
+}
+
 // in re `--absolute-names` (simplified)
 func namesEq(n1, n2 string) bool {
 	if n1[0] == filepath.Separator {
@@ -123,6 +123,12 @@ type (
 		// metadata write policy: (immediate | delayed | never)
 		WritePolicy WritePolicyConf `json:"write_policy"`
 
+		// chunked layout of (very) large objects
+		Chunks ChunksConf `json:"chunks"`
+
+		// server-side encryption at rest: permitted bucket key sources (see SSEConf)
+		SSE SSEKeysConf `json:"sse"`
+
 		// standalone enumerated features that can be configured
 		// to flip assorted global defaults (see cmn/feat/feat.go)
 		Features feat.Flags `json:"features,string" allow:"cluster"`
@@ -159,6 +165,8 @@ type (
 		Memsys      *MemsysConfToSet      `json:"memsys,omitempty"`
 		TCB         *TCBConfToSet         `json:"tcb,omitempty"`
 		WritePolicy *WritePolicyConfToSet `json:"write_policy,omitempty"`
+		Chunks      *ChunksConfToSet      `json:"chunks,omitempty"`
+		SSE         *SSEKeysConfToSet     `json:"sse,omitempty"`
 		Proxy       *ProxyConfToSet       `json:"proxy,omitempty"`
 		Features    *feat.Flags           `json:"features,string,omitempty"`
 
@@ -307,6 +315,8 @@ type (
 		EcStreams cos.Duration `json:"ec_streams_time,omitempty"`
 		// object metadata timeout; for training apps an approx. duration of 2 (two) epochs
 		ObjectMD cos.Duration `json:"object_md"`
+		// abort (and cleanup) S3 multipart uploads that are older than; default=MptUploadDflt; never expire when negative
+		MptUpload cos.Duration `json:"mpt_upload_time,omitempty"`
 	}
 	TimeoutConfToSet struct {
 		CplaneOperation *cos.Duration `json:"cplane_operation,omitempty"`
@@ -317,6 +327,7 @@ type (
 		SendFile        *cos.Duration `json:"send_file_time,omitempty"`
 		EcStreams       *cos.Duration `json:"ec_streams_time,omitempty"`
 		ObjectMD        *cos.Duration `json:"object_md"`
+		MptUpload       *cos.Duration `json:"mpt_upload_time,omitempty"`
 	}
 
 	ClientConf struct {
@@ -359,12 +370,18 @@ type (
 		// Out-of-Space: if exceeded, the target starts failing new PUTs and keeps
 		// failing them until its local used-cap gets back below HighWM (see above)
 		OOS int64 `json:"out_of_space"`
+
+		// DeletedRetention: when non-zero, objects deleted from ais buckets are soft-deleted
+		// (and can be listed and undeleted) for at least this long; storage cleanup
+		// purges the ones that are older, or all of them when running low on space
+		DeletedRetention cos.Duration `json:"deleted_retention,omitempty"`
 	}
 	SpaceConfToSet struct {
-		CleanupWM *int64 `json:"cleanupwm,omitempty"`
-		LowWM     *int64 `json:"lowwm,omitempty"`
-		HighWM    *int64 `json:"highwm,omitempty"`
-		OOS       *int64 `json:"out_of_space,omitempty"`
+		CleanupWM        *int64        `json:"cleanupwm,omitempty"`
+		LowWM            *int64        `json:"lowwm,omitempty"`
+		HighWM           *int64        `json:"highwm,omitempty"`
+		OOS              *int64        `json:"out_of_space,omitempty"`
+		DeletedRetention *cos.Duration `json:"deleted_retention,omitempty"`
 	}
 
 	LRUConf struct {
@@ -401,22 +418,30 @@ type (
 
 	RebalanceConf struct {
 		Compression   string       `json:"compression"`       // enum { CompressAlways, ... } in api/apc/compression.go
+		Window        string       `json:"window"`            // time-of-day window(s) to run at full speed, e.g. "22:00-06:00"
 		DestRetryTime cos.Duration `json:"dest_retry_time"`   // max wait for ACKs & neighbors to complete
+		SendBps       cos.SizeIEC  `json:"send_bps"`          // per-target send bandwidth cap (0 - unlimited)
+		RecvBps       cos.SizeIEC  `json:"recv_bps"`          // per-target receive bandwidth cap (0 - unlimited)
 		SbundleMult   int          `json:"bundle_multiplier"` // stream-bundle multiplier: num streams to destination
 		Enabled       bool         `json:"enabled"`           // true=auto-rebalance | manual rebalancing
 	}
 	RebalanceConfToSet struct {
 		DestRetryTime *cos.Duration `json:"dest_retry_time,omitempty"`
 		Compression   *string       `json:"compression,omitempty"`
+		Window        *string       `json:"window,omitempty"`
+		SendBps       *cos.SizeIEC  `json:"send_bps,omitempty"`
+		RecvBps       *cos.SizeIEC  `json:"recv_bps,omitempty"`
 		SbundleMult   *int          `json:"bundle_multiplier"`
 		Enabled       *bool         `json:"enabled,omitempty"`
 	}
 
 	ResilverConf struct {
-		Enabled bool `json:"enabled"` // true=auto-resilver | manual resilvering
+		MaxBps  cos.SizeIEC `json:"max_bps"` // per-target bandwidth cap (0 - unlimited)
+		Enabled bool        `json:"enabled"` // true=auto-resilver | manual resilvering
 	}
 	ResilverConfToSet struct {
-		Enabled *bool `json:"enabled,omitempty"`
+		MaxBps  *cos.SizeIEC `json:"max_bps,omitempty"`
+		Enabled *bool        `json:"enabled,omitempty"`
 	}
 
 	CksumConf struct {
@@ -556,12 +581,24 @@ type (
 	FSHCConfRC3 FSHCConf
 
 	AuthConf struct {
-		Secret  string `json:"secret"`
-		Enabled bool   `json:"enabled"`
+		Secret string `json:"secret"` // HS256
+		// RS256 and ES256: PEM-encoded public key and/or JWKS endpoint
+		// (e.g., AuthN's /v1/tokens/jwks or the one of an OIDC issuer) to fetch the keys by `kid`
+		PubKey  string `json:"public_key,omitempty"`
+		JWKSURL string `json:"jwks_url,omitempty"`
+		// RS256 and ES256: required `iss` claim and (one of the) `aud` claim(s);
+		// must be set when the keys are shared with other relying parties (e.g., OIDC issuer)
+		Issuer   string `json:"issuer,omitempty"`
+		Audience string `json:"audience,omitempty"`
+		Enabled  bool   `json:"enabled"`
 	}
 	AuthConfToSet struct {
-		Secret  *string `json:"secret,omitempty"`
-		Enabled *bool   `json:"enabled,omitempty"`
+		Secret   *string `json:"secret,omitempty"`
+		PubKey   *string `json:"public_key,omitempty"`
+		JWKSURL  *string `json:"jwks_url,omitempty"`
+		Issuer   *string `json:"issuer,omitempty"`
+		Audience *string `json:"audience,omitempty"`
+		Enabled  *bool   `json:"enabled,omitempty"`
 	}
 
 	// keepalive
@@ -629,6 +666,9 @@ type (
 		// fastcompression.blogspot.com/2013/04/lz4-streaming-format-final.html
 		LZ4BlockMaxSize  cos.SizeIEC `json:"lz4_block"`
 		LZ4FrameChecksum bool        `json:"lz4_frame_checksum"`
+		// zstd: compression level, one of [1(fastest, default), 2, 3, 4(best compression)]
+		// (applies to "zstd" and "auto" compression - see api/apc/compression.go)
+		ZstdLevel int `json:"zstd_level,omitempty"`
 	}
 	TransportConfToSet struct {
 		MaxHeaderSize    *int          `json:"max_header,omitempty"`
@@ -637,6 +677,7 @@ type (
 		QuiesceTime      *cos.Duration `json:"quiescent,omitempty"`
 		LZ4BlockMaxSize  *cos.SizeIEC  `json:"lz4_block,omitempty"`
 		LZ4FrameChecksum *bool         `json:"lz4_frame_checksum,omitempty"`
+		ZstdLevel        *int          `json:"zstd_level,omitempty"`
 	}
 
 	MemsysConf struct {
@@ -670,9 +711,30 @@ type (
 		MD   apc.WritePolicy `json:"md"`
 	}
 	WritePolicyConfToSet struct {
-		Data *apc.WritePolicy `json:"data,omitempty" list:"readonly"` // NOTE: NIY
+		Data *apc.WritePolicy `json:"data,omitempty"`
 		MD   *apc.WritePolicy `json:"md,omitempty"`
 	}
+
+	// objects larger than ObjSizeLimit are stored as a sequence of ChunkSize chunks
+	// distributed across mountpaths (see core/lchunk.go); zero ObjSizeLimit disables chunking
+	ChunksConf struct {
+		ObjSizeLimit cos.SizeIEC `json:"objsize_limit"`
+		ChunkSize    cos.SizeIEC `json:"chunk_size"`
+	}
+	ChunksConfToSet struct {
+		ObjSizeLimit *cos.SizeIEC `json:"objsize_limit,omitempty"`
+		ChunkSize    *cos.SizeIEC `json:"chunk_size,omitempty"`
+	}
+
+	// bucket key sources (prefixes) that buckets are permitted to use, e.g.:
+	// "file:///etc/ais/keys/" and/or "https://vault.example.com/v1/secret/ais/";
+	// none - server-side encryption cannot be enabled
+	SSEKeysConf struct {
+		KeySources []string `json:"key_sources"`
+	}
+	SSEKeysConfToSet struct {
+		KeySources *[]string `json:"key_sources,omitempty"`
+	}
 )
 
 // assorted named fields that require (cluster | node) restart for changes to make an effect
@@ -724,6 +786,8 @@ var (
 	_ Validator = (*MemsysConf)(nil)
 	_ Validator = (*TCBConf)(nil)
 	_ Validator = (*WritePolicyConf)(nil)
+	_ Validator = (*ChunksConf)(nil)
+	_ Validator = (*SSEKeysConf)(nil)
 	_ Validator = (*TracingConf)(nil)
 
 	_ PropsValidator = (*CksumConf)(nil)
@@ -731,6 +795,11 @@ var (
 	_ PropsValidator = (*MirrorConf)(nil)
 	_ PropsValidator = (*ECConf)(nil)
 	_ PropsValidator = (*WritePolicyConf)(nil)
+	_ PropsValidator = (*ChunksConf)(nil)
+	_ PropsValidator = (*QuotaConf)(nil)
+	_ PropsValidator = (*RateLimitConf)(nil)
+	_ PropsValidator = (*SSEConf)(nil)
+	_ PropsValidator = (*ObjLockConf)(nil)
 
 	_ json.Marshaler   = (*BackendConf)(nil)
 	_ json.Unmarshaler = (*BackendConf)(nil)
@@ -1031,6 +1100,8 @@ func (c *DiskConf) Validate() (err error) {
 func (c *SpaceConf) Validate() (err error) {
 	if c.CleanupWM <= 0 || c.LowWM < c.CleanupWM || c.HighWM < c.LowWM || c.OOS < c.HighWM || c.OOS > 100 {
 		err = fmt.Errorf("invalid %s (expecting: 0 < cleanup < low < high < OOS < 100)", c)
+	} else if c.DeletedRetention < 0 {
+		err = fmt.Errorf("invalid space.deleted_retention=%s (expecting non-negative duration)", c.DeletedRetention)
 	}
 	return
 }
@@ -1234,18 +1305,84 @@ func (c *ECConf) RequiredRestoreTargets() int {
 /////////////////////
 
 func (c *WritePolicyConf) Validate() (err error) {
-	err = c.Data.Validate()
-	if err == nil {
-		if !c.Data.IsImmediate() {
-			return fmt.Errorf("invalid write policy for data: %q not implemented yet", c.Data)
-		}
-		err = c.MD.Validate()
+	if err = c.Data.Validate(); err != nil {
+		return err
 	}
-	return
+	if err = c.MD.Validate(); err != nil {
+		return err
+	}
+	// flushing write-delayed data without ever persisting its metadata would make it unreadable
+	if c.Data == apc.WriteDelayed && c.MD == apc.WriteNever {
+		return fmt.Errorf("invalid write policy: data %q requires metadata write policy other than %q", c.Data, c.MD)
+	}
+	return nil
 }
 
 func (c *WritePolicyConf) ValidateAsProps(...any) error { return c.Validate() }
 
+////////////////
+// ChunksConf //
+////////////////
+
+const (
+	chunkSizeMin = cos.MiB
+	chunkSizeMax = 64 * cos.GiB
+)
+
+func (c *ChunksConf) Enabled() bool { return c.ObjSizeLimit > 0 }
+
+func (c *ChunksConf) Validate() error {
+	if c.ObjSizeLimit < 0 {
+		return fmt.Errorf("invalid chunks.objsize_limit=%d (expecting non-negative)", c.ObjSizeLimit)
+	}
+	if !c.Enabled() {
+		return nil
+	}
+	if c.ChunkSize < chunkSizeMin || c.ChunkSize > chunkSizeMax {
+		return fmt.Errorf("invalid chunks.chunk_size=%s (expected range [%s, %s])",
+			c.ChunkSize, cos.SizeIEC(chunkSizeMin), cos.SizeIEC(chunkSizeMax))
+	}
+	if c.ObjSizeLimit < c.ChunkSize {
+		return fmt.Errorf("invalid chunks.objsize_limit=%s (expecting greater or equal chunk_size=%s)",
+			c.ObjSizeLimit, c.ChunkSize)
+	}
+	return nil
+}
+
+func (c *ChunksConf) ValidateAsProps(...any) error { return c.Validate() }
+
+/////////////////
+// SSEKeysConf //
+/////////////////
+
+func (c *SSEKeysConf) Validate() error {
+	for _, src := range c.KeySources {
+		switch {
+		case strings.HasPrefix(src, SSEKeyFile):
+			if !filepath.IsAbs(strings.TrimPrefix(src, SSEKeyFile)) {
+				return fmt.Errorf("invalid sse.key_sources entry %q (expecting absolute path, e.g. file:///etc/ais/keys/)", src)
+			}
+		case strings.HasPrefix(src, SSEKeyHTTPS):
+			if _, err := url.ParseRequestURI(src); err != nil {
+				return fmt.Errorf("invalid sse.key_sources entry %q: %v", src, err)
+			}
+		default:
+			return fmt.Errorf("invalid sse.key_sources entry %q (expecting %q or %q prefix)", src, SSEKeyFile, SSEKeyHTTPS)
+		}
+	}
+	return nil
+}
+
+// whether a given bucket key source is permitted
+func (c *SSEKeysConf) Allowed(src string) bool {
+	for _, prefix := range c.KeySources {
+		if strings.HasPrefix(src, prefix) && !strings.Contains(strings.TrimPrefix(src, prefix), "..") {
+			return true
+		}
+	}
+	return false
+}
+
 ///////////////////
 // KeepaliveConf //
 ///////////////////
@@ -1669,6 +1806,9 @@ const (
 
 	DfltTransportBurst = 256
 	MaxTransportBurst  = 4096
+
+	DfltZstdLevel = 1 // fastest
+	MaxZstdLevel  = 4 // best compression
 )
 
 // NOTE: uncompressed block sizes - the enum currently supported by the github.com/pierrec/lz4
@@ -1678,6 +1818,9 @@ func (c *TransportConf) Validate() (err error) {
 		return fmt.Errorf("invalid transport.block_size %s, expecting one of: [64K, 256K, 1MB, 4MB]",
 			c.LZ4BlockMaxSize)
 	}
+	if c.ZstdLevel < 0 || c.ZstdLevel > MaxZstdLevel {
+		return fmt.Errorf("invalid transport.zstd_level %d, expecting [1, %d] range or 0 (default)", c.ZstdLevel, MaxZstdLevel)
+	}
 	if c.Burst != 0 {
 		if c.Burst < 32 || c.Burst > MaxTransportBurst {
 			return fmt.Errorf("invalid transport.burst_buffer: %d, expecting [32, 4KiB] range or 0 (default)", c.Burst)
@@ -1720,6 +1863,9 @@ const (
 	EcStreamsEver = -time.Second
 	EcStreamsDflt = 10 * time.Minute
 	EcStreamsMini = 5 * time.Minute
+
+	MptUploadDflt = 7 * 24 * time.Hour
+	MptUploadMini = time.Hour
 )
 
 func (c *TimeoutConf) Validate() error {
@@ -1752,6 +1898,10 @@ func (c *TimeoutConf) Validate() error {
 		return fmt.Errorf("invalid timeout.object_md=%s (expecting 0 (zero) for system default or a value greater or equal 20m)",
 			c.ObjectMD)
 	}
+	if c.MptUpload > 0 && c.MptUpload.D() < MptUploadMini {
+		return fmt.Errorf("invalid timeout.mpt_upload_time=%s (never expire: negative; minimum: %s; default: %s)",
+			c.MptUpload, MptUploadMini, MptUploadDflt)
+	}
 	return nil
 }
 
@@ -1781,6 +1931,15 @@ func (c *RebalanceConf) Validate() error {
 		return fmt.Errorf("invalid rebalance.compression: %q (expecting one of: %v)",
 			c.Compression, apc.SupportedCompression)
 	}
+	if c.SendBps < 0 || c.RecvBps < 0 {
+		return fmt.Errorf("invalid rebalance.send_bps (%d) or rebalance.recv_bps (%d): expecting non-negative",
+			c.SendBps, c.RecvBps)
+	}
+	if c.Window != "" {
+		if err := cos.ValidateTimeWindows(c.Window); err != nil {
+			return fmt.Errorf("invalid rebalance.window: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -1791,7 +1950,26 @@ func (c *RebalanceConf) String() string {
 	return "Disabled"
 }
 
-func (*ResilverConf) Validate() error { return nil }
+// Bps returns the current send and receive bandwidth caps - zero (unlimited)
+// within configured time-of-day window(s)
+func (c *RebalanceConf) Bps(now time.Time) (send, recv int64) {
+	if c.SendBps == 0 && c.RecvBps == 0 {
+		return 0, 0
+	}
+	if c.Window != "" {
+		if in, _ := cos.InTimeWindows(c.Window, now); in {
+			return 0, 0
+		}
+	}
+	return int64(c.SendBps), int64(c.RecvBps)
+}
+
+func (c *ResilverConf) Validate() error {
+	if c.MaxBps < 0 {
+		return fmt.Errorf("invalid resilver.max_bps %d: expecting non-negative", c.MaxBps)
+	}
+	return nil
+}
 
 func (c *ResilverConf) String() string {
 	if c.Enabled {
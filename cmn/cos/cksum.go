@@ -32,6 +32,10 @@ const (
 	ChecksumCRC32C = "crc32c"
 	ChecksumSHA256 = "sha256" // crypto.SHA512_256 (SHA-2)
 	ChecksumSHA512 = "sha512" // crypto.SHA512 (SHA-2)
+
+	// not a bucket checksum type - used to convey (and validate) CRC32 (IEEE)
+	// stored by the archive itself, e.g. zip
+	ChecksumCRC32 = "crc32"
 )
 
 const (
@@ -115,6 +119,8 @@ func (ck *CksumHash) Init(ty string) {
 		ck.H = md5.New()
 	case ChecksumCRC32C:
 		ck.H = NewCRC32C()
+	case ChecksumCRC32:
+		ck.H = crc32.NewIEEE()
 	case ChecksumSHA256:
 		ck.H = sha256.New()
 	case ChecksumSHA512:
@@ -149,6 +155,9 @@ func (ck *CksumHashSize) Write(b []byte) (n int, err error) {
 func (ck *Cksum) IsEmpty() bool { return ck == nil || ck.ty == "" || ck.ty == ChecksumNone }
 
 func NewCksum(ty, value string) *Cksum {
+	if ty == ChecksumCRC32 { // (not a bucket checksum type - see above)
+		return &Cksum{ty, value}
+	}
 	if err := ValidateCksumType(ty, true /*empty OK*/); err != nil {
 		AssertMsg(false, err.Error())
 	}
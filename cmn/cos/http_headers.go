@@ -38,13 +38,26 @@ const (
 	HdrContentLength      = "Content-Length"
 
 	// misc. gen
-	HdrUserAgent = "User-Agent"
-	HdrAccept    = "Accept"
-	HdrLocation  = "Location"
-	HdrServer    = "Server"
-	HdrETag      = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
+	HdrUserAgent  = "User-Agent"
+	HdrAccept     = "Accept"
+	HdrLocation   = "Location"
+	HdrServer     = "Server"
+	HdrETag       = "ETag"        // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
+	HdrRetryAfter = "Retry-After" // seconds (e.g., with http.StatusTooManyRequests)
+	HdrTrailer    = "Trailer"     // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Trailer
 
 	HdrHSTS = "Strict-Transport-Security"
+
+	// CORS; Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/CORS
+	HdrOrigin                  = "Origin"
+	HdrVary                    = "Vary"
+	HdrAccessControlReqMethod  = "Access-Control-Request-Method"
+	HdrAccessControlReqHeaders = "Access-Control-Request-Headers"
+	HdrAccessControlAllowOrig  = "Access-Control-Allow-Origin"
+	HdrAccessControlAllowMeth  = "Access-Control-Allow-Methods"
+	HdrAccessControlAllowHdrs  = "Access-Control-Allow-Headers"
+	HdrAccessControlExposeHdrs = "Access-Control-Expose-Headers"
+	HdrAccessControlMaxAge     = "Access-Control-Max-Age"
 )
 
 //
@@ -64,8 +77,9 @@ const (
 	S3VersionHeader = "x-amz-version-id"
 
 	// s3 api request headers
-	S3HdrObjSrc = "x-amz-copy-source"
-	S3HdrMptCnt = "x-amz-mp-parts-count"
+	S3HdrObjSrc      = "x-amz-copy-source"
+	S3HdrObjSrcRange = "x-amz-copy-source-range" // UploadPartCopy only
+	S3HdrMptCnt      = "x-amz-mp-parts-count"
 
 	// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
 	S3UnsignedPayload  = "UNSIGNED-PAYLOAD"
@@ -73,6 +87,11 @@ const (
 
 	S3HdrBckRegion = "x-amz-bucket-region"
 
+	// https://docs.aws.amazon.com/AmazonS3/latest/userguide/UsingServerSideEncryption.html
+	S3HdrSSE            = "x-amz-server-side-encryption"
+	S3HdrSSECustomerAlg = "x-amz-server-side-encryption-customer-algorithm"
+	S3SSEAES256         = "AES256"
+
 	S3ChecksumCRC32  = "x-amz-checksum-crc32"
 	S3ChecksumCRC32C = "x-amz-checksum-crc32c"
 	S3ChecksumSHA1   = "x-amz-checksum-sha1"
@@ -313,6 +313,24 @@ func ChecksumBytes(b []byte, cksumType string) (cksum *Cksum, err error) {
 	return &hash.Cksum, nil
 }
 
+// ChecksumFile computes checksum of the entire file, e.g. upon in-place append;
+// returns the file size and NoneCksum when checksumming is disabled.
+func ChecksumFile(fqn, cksumType string) (size int64, cksum *Cksum, err error) {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return 0, nil, err
+	}
+	size, hash, err := CopyAndChecksum(io.Discard, fh, nil, cksumType)
+	Close(fh)
+	if err != nil {
+		return 0, nil, err
+	}
+	if hash == nil {
+		return size, NoneCksum, nil
+	}
+	return size, hash.Clone(), nil
+}
+
 // DrainReader reads and discards all the data from a reader.
 // No need for `io.CopyBuffer` as `io.Discard` has efficient `io.ReaderFrom` implementation.
 func DrainReader(r io.Reader) {
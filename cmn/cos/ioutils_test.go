@@ -0,0 +1,47 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestChecksumFile(t *testing.T) {
+	var (
+		fqn  = filepath.Join(t.TempDir(), "obj")
+		data = []byte(cos.CryptoRandS(4096))
+	)
+	tassert.CheckFatal(t, os.WriteFile(fqn, data, cos.PermRWR))
+
+	for _, ty := range []string{cos.ChecksumXXHash, cos.ChecksumMD5, cos.ChecksumSHA256} {
+		size, cksum, err := cos.ChecksumFile(fqn, ty)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, size == int64(len(data)), "%s: expected size %d, got %d", ty, len(data), size)
+		expected, err := cos.ChecksumBytes(data, ty)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, cksum.Equal(expected), "%s: expected %s, got %s", ty, expected, cksum)
+	}
+
+	// (in-place) append must result in a different checksum
+	fh, err := os.OpenFile(fqn, os.O_APPEND|os.O_WRONLY, 0)
+	tassert.CheckFatal(t, err)
+	_, err = fh.Write([]byte("appended"))
+	fh.Close()
+	tassert.CheckFatal(t, err)
+	_, cksum, err := cos.ChecksumFile(fqn, cos.ChecksumXXHash)
+	tassert.CheckFatal(t, err)
+	prev, _ := cos.ChecksumBytes(data, cos.ChecksumXXHash)
+	tassert.Errorf(t, !cksum.Equal(prev), "expected checksum to change upon append")
+
+	// checksumming disabled
+	size, cksum, err := cos.ChecksumFile(fqn, cos.ChecksumNone)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, size == int64(len(data))+8 && cksum.IsEmpty(), "unexpected %d, %s", size, cksum)
+}
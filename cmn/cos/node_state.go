@@ -36,6 +36,7 @@ const (
 	CertificateExpired                               // red --/--
 	CertificateInvalid                               // red --/--
 	KeepAliveErrors                                  // warning (new keep-alive errors during the last 5m)
+	BucketQuota                                      // warning: bucket(s) over the soft quota limit (see cmn.QuotaConf)
 )
 
 func (f NodeStateFlags) IsOK() bool { return f == NodeStarted|ClusterStarted }
@@ -50,7 +51,7 @@ func (f NodeStateFlags) IsWarn() bool {
 		f.IsSet(Resilvering) || f.IsSet(ResilverInterrupted) ||
 		f.IsSet(Restarted) || f.IsSet(MaintenanceMode) ||
 		f.IsSet(LowCapacity) || f.IsSet(LowMemory) ||
-		f.IsSet(CertWillSoonExpire)
+		f.IsSet(CertWillSoonExpire) || f.IsSet(BucketQuota)
 }
 
 func (f NodeStateFlags) IsSet(flag NodeStateFlags) bool { return BitFlags(f).IsSet(BitFlags(flag)) }
@@ -133,6 +134,9 @@ func (f NodeStateFlags) String() string {
 	if f&KeepAliveErrors == KeepAliveErrors {
 		sb = append(sb, "keep-alive-errors")
 	}
+	if f&BucketQuota == BucketQuota {
+		sb = append(sb, "bucket-quota-soft-limit")
+	}
 
 	l := len(sb)
 	switch l {
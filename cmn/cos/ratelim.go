@@ -0,0 +1,142 @@
+// Package cos provides common low-level types and utilities for all aistore projects.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// RateLim is a token bucket that refills at `rate` tokens per second up to `burst`.
+// Acquisition succeeds as long as there's at least one token (or, for zero-size
+// acquisition, as long as the bucket is not in debt) - i.e., a single acquisition
+// may take more tokens than currently available (e.g., a large object), in which case
+// subsequent acquisitions fail until the debt is repaid. This makes it possible to
+// throttle requests of unknown size: try-acquire zero tokens upfront, and charge
+// the actual size upon completion.
+type RateLim struct {
+	tokens float64
+	rate   float64 // tokens per second
+	burst  float64
+	last   int64 // mono time of the last refill
+	mu     sync.Mutex
+}
+
+func NewRateLim(rate, burst int64) *RateLim {
+	rl := &RateLim{last: mono.NanoTime()}
+	rl.SetRate(rate, burst)
+	rl.tokens = rl.burst
+	return rl
+}
+
+// (limits can change at runtime)
+func (rl *RateLim) SetRate(rate, burst int64) {
+	rl.mu.Lock()
+	rl.rate, rl.burst = float64(max(rate, 1)), float64(max(burst, rate, 1))
+	rl.tokens = min(rl.tokens, rl.burst)
+	rl.mu.Unlock()
+}
+
+func (rl *RateLim) Rate() int64 {
+	rl.mu.Lock()
+	rate := rl.rate
+	rl.mu.Unlock()
+	return int64(rate)
+}
+
+// returns zero upon success; otherwise, the time to wait before retrying
+func (rl *RateLim) TryAcquire(n int64) time.Duration { return rl.acquire(n, mono.NanoTime()) }
+
+// unconditionally take `n` tokens (possibly, going into debt)
+func (rl *RateLim) Charge(n int64) {
+	rl.mu.Lock()
+	rl.refill(mono.NanoTime())
+	rl.tokens -= float64(n)
+	rl.mu.Unlock()
+}
+
+// return `n` previously acquired tokens (e.g., when the request didn't proceed)
+func (rl *RateLim) Release(n int64) {
+	rl.mu.Lock()
+	rl.refill(mono.NanoTime())
+	rl.tokens = min(rl.tokens+float64(n), rl.burst)
+	rl.mu.Unlock()
+}
+
+func (rl *RateLim) acquire(n, now int64) (retry time.Duration) {
+	rl.mu.Lock()
+	rl.refill(now)
+	if need := min(float64(n), 1); rl.tokens < need {
+		retry = time.Duration((need - rl.tokens) / rl.rate * float64(time.Second))
+	} else {
+		rl.tokens -= float64(n)
+	}
+	rl.mu.Unlock()
+	return retry
+}
+
+func (rl *RateLim) refill(now int64) {
+	if elapsed := now - rl.last; elapsed > 0 {
+		rl.tokens = min(rl.tokens+float64(elapsed)*rl.rate/float64(time.Second), rl.burst)
+		rl.last = now
+	}
+}
+
+// Throttle limits average throughput (bytes per second) to a value that may change at runtime:
+// the `limit` callback is re-evaluated at most once per second; zero limit means unlimited.
+type Throttle struct {
+	limit func() int64
+	rl    *RateLim
+	next  atomic.Int64 // mono time of the next re-evaluation
+	bps   atomic.Int64 // current limit
+	mu    sync.Mutex
+}
+
+const throttleRefresh = time.Second
+
+func NewThrottle(limit func() int64) *Throttle { return &Throttle{limit: limit} }
+
+// Charge accounts for `n` transferred bytes and returns the time the caller
+// must wait (sleep) to stay within the limit
+func (th *Throttle) Charge(n int64) time.Duration {
+	now := mono.NanoTime()
+	if now >= th.next.Load() {
+		th.refresh(now)
+	}
+	if th.bps.Load() == 0 {
+		return 0
+	}
+	th.mu.Lock()
+	rl := th.rl
+	th.mu.Unlock()
+	rl.Charge(n)
+	return rl.TryAcquire(0)
+}
+
+func (th *Throttle) Bps() int64 { return th.bps.Load() }
+
+func (th *Throttle) refresh(now int64) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	if now < th.next.Load() {
+		return
+	}
+	th.next.Store(now + int64(throttleRefresh))
+	bps := max(th.limit(), 0)
+	if bps == th.bps.Load() {
+		return
+	}
+	switch {
+	case bps == 0:
+	case th.rl == nil:
+		th.rl = NewRateLim(bps, bps)
+	default:
+		th.rl.SetRate(bps, bps)
+	}
+	th.bps.Store(bps)
+}
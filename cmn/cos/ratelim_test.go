@@ -0,0 +1,81 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestRateLim(t *testing.T) {
+	const rate = 10
+	rl := cos.NewRateLim(rate, rate)
+
+	// full bucket (burst)
+	for i := range rate {
+		retry := rl.TryAcquire(1)
+		tassert.Errorf(t, retry == 0, "acquisition %d: unexpected retry %v", i, retry)
+	}
+	retry := rl.TryAcquire(1)
+	tassert.Errorf(t, retry > 0 && retry <= 2*time.Second/rate, "expected retry in (0, %v], got %v", 2*time.Second/rate, retry)
+
+	// refill
+	time.Sleep(retry)
+	retry = rl.TryAcquire(1)
+	tassert.Errorf(t, retry == 0, "expected success after refill, got retry %v", retry)
+}
+
+func TestRateLimDebt(t *testing.T) {
+	const rate = 1000
+	rl := cos.NewRateLim(rate, rate)
+
+	// acquiring more than available succeeds but puts the bucket in debt
+	retry := rl.TryAcquire(3 * rate)
+	tassert.Errorf(t, retry == 0, "unexpected retry %v", retry)
+	retry = rl.TryAcquire(0)
+	tassert.Errorf(t, retry > time.Second && retry <= 2*time.Second, "expected retry in (1s, 2s], got %v", retry)
+
+	// ditto charge
+	rl = cos.NewRateLim(rate, rate)
+	rl.Charge(2 * rate)
+	retry = rl.TryAcquire(1)
+	tassert.Errorf(t, retry > 0 && retry <= time.Second+time.Second/rate, "expected retry in (0, 1s], got %v", retry)
+
+	// release (up to burst)
+	rl = cos.NewRateLim(rate, rate)
+	retry = rl.TryAcquire(rate)
+	tassert.Errorf(t, retry == 0, "unexpected retry %v", retry)
+	rl.Release(rate)
+	rl.Release(rate)
+	retry = rl.TryAcquire(rate)
+	tassert.Errorf(t, retry == 0, "expected success after release, got retry %v", retry)
+	retry = rl.TryAcquire(1)
+	tassert.Errorf(t, retry > 0, "expected release capped at burst")
+
+	// limits can change at runtime
+	rl.SetRate(10*rate, 10*rate)
+	tassert.Errorf(t, rl.Rate() == 10*rate, "expected rate %d, got %d", 10*rate, rl.Rate())
+}
+
+func TestThrottle(t *testing.T) {
+	const bps = 1000
+	limit := int64(0)
+	th := cos.NewThrottle(func() int64 { return limit })
+
+	// unlimited
+	wait := th.Charge(100 * bps)
+	tassert.Errorf(t, wait == 0, "unlimited: unexpected wait %v", wait)
+
+	// limited (takes effect upon the next re-evaluation)
+	limit = bps
+	time.Sleep(time.Second + 10*time.Millisecond)
+	wait = th.Charge(bps / 2)
+	tassert.Errorf(t, wait == 0 && th.Bps() == bps, "expected no wait within burst, got %v (bps %d)", wait, th.Bps())
+	wait = th.Charge(2 * bps)
+	tassert.Errorf(t, wait > time.Second && wait <= 2*time.Second, "expected wait in (1s, 2s], got %v", wait)
+}
@@ -85,3 +85,64 @@ func IsValidAtime(atime int64) bool {
 	return atime > 946771140000000000 ||
 		(atime < -946771140000000000 && atime != -6795364578871345152) // time.IsZero()
 }
+
+// InTimeWindows returns true if the local time-of-day of `now` falls into one of the
+// comma-separated "HH:MM-HH:MM" windows (e.g., "22:00-06:00,12:00-13:00");
+// window that ends before it starts wraps around midnight
+func InTimeWindows(spec string, now time.Time) (bool, error) {
+	var (
+		h, m, _ = now.Clock()
+		minute  = h*60 + m
+	)
+	for _, w := range strings.Split(spec, ",") {
+		begin, end, err := parseTimeWindow(strings.TrimSpace(w))
+		if err != nil {
+			return false, err
+		}
+		if begin <= end {
+			if minute >= begin && minute < end {
+				return true, nil
+			}
+		} else if minute >= begin || minute < end {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ValidateTimeWindows checks all comma-separated windows (compare with InTimeWindows
+// that stops at the first match)
+func ValidateTimeWindows(spec string) error {
+	for _, w := range strings.Split(spec, ",") {
+		if _, _, err := parseTimeWindow(strings.TrimSpace(w)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// returns minutes since midnight
+func parseTimeWindow(w string) (begin, end int, err error) {
+	b, e, ok := strings.Cut(w, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid time window %q (expecting HH:MM-HH:MM)", w)
+	}
+	if begin, err = _parseHHMM(b); err == nil {
+		end, err = _parseHHMM(e)
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time window %q: %v", w, err)
+	}
+	if begin == end {
+		return 0, 0, fmt.Errorf("invalid time window %q: empty", w)
+	}
+	return begin, end, nil
+}
+
+func _parseHHMM(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
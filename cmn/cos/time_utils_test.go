@@ -0,0 +1,43 @@
+// Package cos provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestInTimeWindows(t *testing.T) {
+	at := func(hhmm string) time.Time {
+		tm, err := time.Parse("15:04", hhmm)
+		tassert.CheckFatal(t, err)
+		return tm
+	}
+	tests := []struct {
+		spec string
+		now  string
+		in   bool
+	}{
+		{"01:00-05:00", "03:30", true},
+		{"01:00-05:00", "05:00", false},
+		{"22:00-06:00", "23:59", true},
+		{"22:00-06:00", "00:10", true},
+		{"22:00-06:00", "12:00", false},
+		{"22:00-06:00, 12:00-13:00", "12:30", true},
+	}
+	for _, test := range tests {
+		in, err := cos.InTimeWindows(test.spec, at(test.now))
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, in == test.in, "%q at %s: expected %t", test.spec, test.now, test.in)
+	}
+	for _, spec := range []string{"01:00", "01:00-01:00", "25:00-01:00", "1-2", "00:00-23:59,bogus", "01:00-02:00, "} {
+		err := cos.ValidateTimeWindows(spec)
+		tassert.Errorf(t, err != nil, "expected error for %q", spec)
+	}
+	tassert.CheckError(t, cos.ValidateTimeWindows("22:00-06:00, 12:00-13:00"))
+}
@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -112,6 +113,24 @@ type (
 	ErrGetCap struct {
 		err error
 	}
+	ErrRateLimited struct {
+		bname string
+		user  string // AuthN user ID (empty when the limit is bucket-wide)
+		what  string // "requests" | "bytes"
+		retry time.Duration
+	}
+	ErrQuotaExceeded struct {
+		bname string
+		what  string // "max_size" | "max_objs"
+		limit int64
+		used  int64 // this target's usage
+		share int64 // this target's share of the limit
+	}
+	ErrObjLocked struct {
+		until time.Time // zero when under legal hold
+		cname string
+		what  string // "legal hold" | "<mode> retention"
+	}
 
 	ErrBucketAccessDenied struct{ errAccessDenied }
 	ErrObjectAccessDenied struct{ errAccessDenied }
@@ -498,6 +517,68 @@ func IsErrCapExceeded(err error) bool {
 	return ok || cos.IsErrOOS(err) // NOTE: a superset
 }
 
+// ErrRateLimited
+
+func NewErrRateLimited(bname, user, what string, retry time.Duration) *ErrRateLimited {
+	return &ErrRateLimited{bname: bname, user: user, what: what, retry: retry}
+}
+
+func (e *ErrRateLimited) Error() string {
+	s := "bucket " + e.bname
+	if e.user != "" {
+		s += ", user " + e.user
+	}
+	return s + ": " + e.what + " rate limit exceeded, retry after " + e.retry.String()
+}
+
+// (whole seconds, at least one)
+func (e *ErrRateLimited) RetryAfter() string {
+	return strconv.FormatInt(max(int64((e.retry+time.Second-1)/time.Second), 1), 10)
+}
+
+func IsErrRateLimited(err error) bool {
+	_, ok := err.(*ErrRateLimited)
+	return ok
+}
+
+// ErrQuotaExceeded
+
+func NewErrQuotaExceeded(bname, what string, limit, used, share int64) *ErrQuotaExceeded {
+	return &ErrQuotaExceeded{bname: bname, what: what, limit: limit, used: used, share: share}
+}
+
+func (e *ErrQuotaExceeded) Error() string {
+	if e.what == "max_objs" {
+		return fmt.Sprintf("bucket %s: exceeded quota.max_objs=%d (%d objects out of %d per target)",
+			e.bname, e.limit, e.used, e.share)
+	}
+	return fmt.Sprintf("bucket %s: exceeded quota.max_size=%s (used %s out of %s per target)",
+		e.bname, cos.ToSizeIEC(e.limit, 0), cos.ToSizeIEC(e.used, 2), cos.ToSizeIEC(e.share, 2))
+}
+
+func IsErrQuotaExceeded(err error) bool {
+	_, ok := err.(*ErrQuotaExceeded)
+	return ok
+}
+
+// ErrObjLocked
+
+func NewErrObjLocked(cname, what string, until time.Time) *ErrObjLocked {
+	return &ErrObjLocked{cname: cname, what: what, until: until}
+}
+
+func (e *ErrObjLocked) Error() string {
+	if e.until.IsZero() {
+		return fmt.Sprintf("%s is locked (%s)", e.cname, e.what)
+	}
+	return fmt.Sprintf("%s is locked (%s until %s)", e.cname, e.what, e.until.UTC().Format(time.RFC3339))
+}
+
+func IsErrObjLocked(err error) bool {
+	_, ok := err.(*ErrObjLocked)
+	return ok
+}
+
 // ErrGetCap
 
 func NewErrGetCap(err error) *ErrGetCap {
@@ -1232,6 +1313,10 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, opts ...int /*[
 			status = http.StatusNotFound
 		case IsErrCapExceeded(err):
 			status = http.StatusInsufficientStorage
+		case IsErrQuotaExceeded(err), IsErrObjLocked(err):
+			status = http.StatusForbidden
+		case IsErrRateLimited(err):
+			status = http.StatusTooManyRequests
 		case IsErrRangeNotSatisfiable(err):
 			status = http.StatusRequestedRangeNotSatisfiable
 		case isErrUnsupp(err), isErrNotImpl(err):
@@ -1239,6 +1324,9 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, opts ...int /*[
 		}
 	}
 
+	if e, ok := err.(*ErrRateLimited); ok {
+		w.Header().Set(cos.HdrRetryAfter, e.RetryAfter())
+	}
 	herr.init(r, err, status)
 	herr.write(w, r, l > 1)
 	FreeHterr(herr)
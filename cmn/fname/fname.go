@@ -33,6 +33,9 @@ const (
 	Vmd         = ".ais.vmd"    // vmd persistent file basename
 	Emd         = ".ais.emd"    // emd persistent file basename
 
+	// rebalance progress: per mountpath (and, unlike markers, never moved to other mountpaths)
+	RebProgress = ".ais.reb_progress"
+
 	// CLI config
 	CliConfig = "cli.json" // see jsp/app.go
 
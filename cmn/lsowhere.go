@@ -0,0 +1,345 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// List-objects filtering predicates (apc.LsoMsg.Where) evaluated by targets
+// prior to pagination. Syntax: one or more clauses joined by "and" (or "&&"), e.g.:
+// "size>1GiB and mtime<1d and custom.label=cat"
+//
+// - name ~ <regex>              | name = <string>       (operators: = != ~ !~)
+// - size > 1MiB                 | copies >= 2           (operators: = != < <= > >=)
+// - atime < 7d                  | mtime > 2024-06-01    (operators: < <= > >=)
+//   (duration means age - time elapsed since; otherwise, RFC3339 time or YYYY-MM-DD date)
+// - custom.<key> = <value>      | custom.<key> ~ <regex>
+// - cksum = xxhash              (checksum type)
+// - ec = none|replicated|encoded
+//
+// A clause that refers to an unknown (not listed, not present) property evaluates to false.
+
+const (
+	LsoECNone       = "none"       // bucket is not erasure coded
+	LsoECReplicated = "replicated" // erasure coded bucket; object is replicated (below EC size limit)
+	LsoECEncoded    = "encoded"    // erasure coded bucket; object is sliced
+)
+
+const (
+	whereName   = "name"
+	whereSize   = "size"
+	whereAtime  = "atime"
+	whereMtime  = "mtime"
+	whereCksum  = "cksum"
+	whereCopies = "copies"
+	whereEC     = "ec"
+	whereCustom = "custom."
+)
+
+const (
+	opEq    = "="
+	opNe    = "!="
+	opLt    = "<"
+	opLe    = "<="
+	opGt    = ">"
+	opGe    = ">="
+	opMatch = "~"
+	opNoMat = "!~"
+)
+
+// longest first
+var whereOps = [...]string{opLe, opGe, opNe, opNoMat, opEq, opLt, opGt, opMatch}
+
+var whereSepa = regexp.MustCompile(`(?i)\s+and\s+|&&`)
+
+type (
+	// object (entry) being evaluated; boolean is false when the property is unknown
+	LsoWhereObj interface {
+		Name() string
+		Size() (int64, bool)
+		Atime() (int64, bool) // unix nano
+		Mtime() (int64, bool) // ditto
+		CksumType() (string, bool)
+		Copies() (int64, bool)
+		ECStatus() (string, bool)
+		CustomKey(key string) (string, bool)
+	}
+	LsoWhere struct {
+		clauses []*whereClause
+	}
+	whereClause struct {
+		re    *regexp.Regexp
+		field string
+		key   string // custom key
+		op    string
+		sval  string
+		ival  int64 // size, copies; time (unix nano)
+	}
+)
+
+func ParseLsoWhere(expr string) (*LsoWhere, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, errors.New("empty list-objects predicate")
+	}
+	var (
+		parts = whereSepa.Split(expr, -1)
+		where = &LsoWhere{clauses: make([]*whereClause, 0, len(parts))}
+		now   = time.Now()
+	)
+	for _, s := range parts {
+		c, err := parseClause(strings.TrimSpace(s), now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid list-objects predicate %q: %v", expr, err)
+		}
+		where.clauses = append(where.clauses, c)
+	}
+	return where, nil
+}
+
+func parseClause(s string, now time.Time) (*whereClause, error) {
+	i := strings.IndexAny(s, "=!<>~")
+	if i <= 0 {
+		return nil, fmt.Errorf("clause %q: expecting <property> <operator> <value>", s)
+	}
+	c := &whereClause{field: strings.TrimSpace(s[:i])}
+	for _, op := range whereOps {
+		if strings.HasPrefix(s[i:], op) {
+			c.op = op
+			break
+		}
+	}
+	if c.op == "" {
+		return nil, fmt.Errorf("clause %q: invalid operator", s)
+	}
+	c.sval = unquote(strings.TrimSpace(s[i+len(c.op):]))
+	if c.sval == "" {
+		return nil, fmt.Errorf("clause %q: missing value", s)
+	}
+	if strings.HasPrefix(c.field, whereCustom) {
+		c.key = c.field[len(whereCustom):]
+		c.field = whereCustom
+		if c.key == "" {
+			return nil, fmt.Errorf("clause %q: missing custom key", s)
+		}
+	}
+	if err := c.init(now); err != nil {
+		return nil, fmt.Errorf("clause %q: %v", s, err)
+	}
+	return c, nil
+}
+
+func unquote(s string) string {
+	if l := len(s); l >= 2 && (s[0] == '"' || s[0] == '\'') && s[l-1] == s[0] {
+		return s[1 : l-1]
+	}
+	return s
+}
+
+func (c *whereClause) init(now time.Time) (err error) {
+	switch c.field {
+	case whereName, whereCustom:
+		if c.op == opMatch || c.op == opNoMat {
+			c.re, err = regexp.Compile(c.sval)
+			return err
+		}
+		return c.checkOp(opEq, opNe)
+	case whereSize:
+		c.ival, err = cos.ParseSize(c.sval, cos.UnitsIEC)
+		if err != nil {
+			return err
+		}
+		return c.checkOp(opEq, opNe, opLt, opLe, opGt, opGe)
+	case whereCopies:
+		c.ival, err = strconv.ParseInt(c.sval, 10, 64)
+		if err != nil {
+			return err
+		}
+		return c.checkOp(opEq, opNe, opLt, opLe, opGt, opGe)
+	case whereAtime, whereMtime:
+		if err = c.checkOp(opLt, opLe, opGt, opGe); err != nil {
+			return err
+		}
+		return c.initTime(now)
+	case whereCksum:
+		if err = cos.ValidateCksumType(c.sval); err != nil {
+			return err
+		}
+		return c.checkOp(opEq, opNe)
+	case whereEC:
+		if c.sval != LsoECNone && c.sval != LsoECReplicated && c.sval != LsoECEncoded {
+			return fmt.Errorf("invalid EC status %q (expecting one of: %s, %s, %s)",
+				c.sval, LsoECNone, LsoECReplicated, LsoECEncoded)
+		}
+		return c.checkOp(opEq, opNe)
+	default:
+		return fmt.Errorf("unknown property %q", c.field)
+	}
+}
+
+func (c *whereClause) checkOp(ops ...string) error {
+	if cos.StringInSlice(c.op, ops) {
+		return nil
+	}
+	return fmt.Errorf("operator %q not supported for %q (expecting one of: %s)", c.op, c.field, strings.Join(ops, " "))
+}
+
+// age (e.g. "1d", "90m") or point in time
+func (c *whereClause) initTime(now time.Time) error {
+	if age, err := parseAge(c.sval); err == nil {
+		// older than <=> before
+		c.ival = now.Add(-age).UnixNano()
+		switch c.op {
+		case opLt:
+			c.op = opGt
+		case opLe:
+			c.op = opGe
+		case opGt:
+			c.op = opLt
+		case opGe:
+			c.op = opLe
+		}
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, c.sval); err == nil {
+			c.ival = t.UnixNano()
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid time %q (expecting duration, e.g. 36h or 7d, RFC3339 time, or YYYY-MM-DD date)", c.sval)
+}
+
+// time.ParseDuration with days
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid number of days %q", s)
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err == nil && d < 0 {
+		err = fmt.Errorf("negative duration %q", s)
+	}
+	return d, err
+}
+
+//////////////
+// LsoWhere //
+//////////////
+
+// list-objects properties that must be listed to evaluate (remote) entries
+func (where *LsoWhere) Props() (props []string) {
+	for _, c := range where.clauses {
+		var prop string
+		switch c.field {
+		case whereSize:
+			prop = apc.GetPropsSize
+		case whereAtime:
+			prop = apc.GetPropsAtime
+		case whereMtime, whereCustom:
+			prop = apc.GetPropsCustom // (remote LastModified)
+		case whereCksum:
+			prop = apc.GetPropsChecksum
+		case whereCopies:
+			prop = apc.GetPropsCopies
+		case whereEC:
+			prop = apc.GetPropsEC
+		default:
+			continue
+		}
+		if !cos.StringInSlice(prop, props) {
+			props = append(props, prop)
+		}
+	}
+	return props
+}
+
+// true when only names are needed (no need to load object metadata)
+func (where *LsoWhere) NameOnly() bool {
+	for _, c := range where.clauses {
+		if c.field != whereName {
+			return false
+		}
+	}
+	return true
+}
+
+func (where *LsoWhere) Match(obj LsoWhereObj) bool {
+	for _, c := range where.clauses {
+		if !c.match(obj) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *whereClause) match(obj LsoWhereObj) bool {
+	var (
+		ival int64
+		sval string
+		ok   bool
+	)
+	switch c.field {
+	case whereName:
+		sval, ok = obj.Name(), true
+	case whereSize:
+		ival, ok = obj.Size()
+	case whereCopies:
+		ival, ok = obj.Copies()
+	case whereAtime:
+		ival, ok = obj.Atime()
+	case whereMtime:
+		ival, ok = obj.Mtime()
+	case whereCksum:
+		sval, ok = obj.CksumType()
+	case whereEC:
+		sval, ok = obj.ECStatus()
+	case whereCustom:
+		sval, ok = obj.CustomKey(c.key)
+	}
+	if !ok {
+		return false
+	}
+	switch c.field {
+	case whereName, whereCustom, whereCksum, whereEC:
+		switch c.op {
+		case opMatch:
+			return c.re.MatchString(sval)
+		case opNoMat:
+			return !c.re.MatchString(sval)
+		case opEq:
+			return sval == c.sval
+		default:
+			return sval != c.sval
+		}
+	}
+	switch c.op {
+	case opEq:
+		return ival == c.ival
+	case opNe:
+		return ival != c.ival
+	case opLt:
+		return ival < c.ival
+	case opLe:
+		return ival <= c.ival
+	case opGt:
+		return ival > c.ival
+	default:
+		return ival >= c.ival
+	}
+}
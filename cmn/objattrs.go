@@ -35,6 +35,14 @@ const (
 
 	OrigURLObjMD = "orig_url"
 
+	// object lock (see cmn.ObjLockConf and core/lretain.go)
+	LockModeObjMD    = "lock-mode"    // ObjLockGovernance | ObjLockCompliance
+	RetainUntilObjMD = "retain-until" // RFC3339
+	LegalHoldObjMD   = "legal-hold"   // LegalHoldOn | LegalHoldOff
+
+	LegalHoldOn  = "ON"
+	LegalHoldOff = "OFF"
+
 	// additional backend
 	LastModified = "LastModified"
 )
@@ -184,4 +184,45 @@ var _ = Describe("API", func() {
 			),
 		)
 	})
+
+	Describe("Validate write policy", func() {
+		DescribeTable("should validate data and metadata write policies",
+			func(bprops cmn.Bprops, valid bool) {
+				if bprops.Provider == "" {
+					bprops.Provider = apc.AIS
+				}
+				bprops.Cksum.Type = "xxhash"
+				err := bprops.Validate(3 /*targetCnt*/)
+				if valid {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(HaveOccurred())
+				}
+			},
+			Entry("default",
+				cmn.Bprops{}, true,
+			),
+			Entry("delayed data",
+				cmn.Bprops{WritePolicy: cmn.WritePolicyConf{Data: apc.WriteDelayed}}, true,
+			),
+			Entry("transient data",
+				cmn.Bprops{WritePolicy: cmn.WritePolicyConf{Data: apc.WriteNever, MD: apc.WriteNever}}, true,
+			),
+			Entry("delayed data with transient metadata",
+				cmn.Bprops{WritePolicy: cmn.WritePolicyConf{Data: apc.WriteDelayed, MD: apc.WriteNever}}, false,
+			),
+			Entry("invalid data policy",
+				cmn.Bprops{WritePolicy: cmn.WritePolicyConf{Data: "sometimes"}}, false,
+			),
+			Entry("delayed data in remote bucket",
+				cmn.Bprops{Provider: apc.AWS, WritePolicy: cmn.WritePolicyConf{Data: apc.WriteDelayed}}, false,
+			),
+			Entry("transient data and mirroring",
+				cmn.Bprops{
+					Mirror:      cmn.MirrorConf{Enabled: true, Copies: 2},
+					WritePolicy: cmn.WritePolicyConf{Data: apc.WriteNever},
+				}, false,
+			),
+		)
+	})
 })
@@ -0,0 +1,170 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/tools/trand"
+)
+
+// counts bytes read sequentially (as opposed to ReadAt)
+type seqCounter struct {
+	*bytes.Reader
+	n int64
+}
+
+func (sc *seqCounter) Read(b []byte) (int, error) {
+	n, err := sc.Reader.Read(b)
+	sc.n += int64(n)
+	return n, err
+}
+
+func TestArchReadRange(t *testing.T) {
+	const (
+		filename = "dir/file.bin"
+		size     = 64 * 1024
+		offset   = 40000
+		length   = 10000
+	)
+	var (
+		content = []byte(trand.String(size))
+		other   = []byte(trand.String(size))
+		tests   = []struct {
+			name       string
+			mime       string
+			data       []byte
+			seekToData bool // expecting to read archived file's content directly at its offset
+		}{
+			{"tar", archive.ExtTar, mkTar(t, content, other, false), true},
+			{"tgz", archive.ExtTgz, mkTar(t, content, other, true), false},
+			{"zip-store", archive.ExtZip, mkZip(t, content, other, zip.Store), true},
+			{"zip-deflate", archive.ExtZip, mkZip(t, content, other, zip.Deflate), false},
+		}
+	)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sc := &seqCounter{Reader: bytes.NewReader(test.data)}
+			ar, err := archive.NewReader(test.mime, sc, int64(len(test.data)))
+			tassert.CheckFatal(t, err)
+			csl, err := ar.ReadOne(filename)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, csl != nil, "%s not found", filename)
+
+			rsl, err := archive.ReadRange(csl, offset, length)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, rsl.Size() == length, "expected size %d, got %d", length, rsl.Size())
+			b, err := io.ReadAll(rsl)
+			tassert.CheckFatal(t, err)
+			tassert.CheckFatal(t, rsl.Close())
+			tassert.Fatalf(t, bytes.Equal(b, content[offset:offset+length]), "range content mismatch")
+
+			if test.seekToData {
+				tassert.Errorf(t, sc.n < offset, "expecting random access, read %d bytes sequentially", sc.n)
+			}
+		})
+	}
+
+	// not io.ReaderAt
+	ar, err := archive.NewReader(archive.ExtTar, bytes.NewBuffer(mkTar(t, content, other, false)))
+	tassert.CheckFatal(t, err)
+	csl, err := ar.ReadOne(filename)
+	tassert.CheckFatal(t, err)
+	rsl, err := archive.ReadRange(csl, offset, length)
+	tassert.CheckFatal(t, err)
+	b, err := io.ReadAll(rsl)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, bytes.Equal(b, content[offset:offset+length]), "range content mismatch")
+}
+
+// in-memory (non-file) cos.LomReader
+type memLomReader struct {
+	*bytes.Reader
+}
+
+func (*memLomReader) Close() error { return nil }
+
+func TestArchMimeReader(t *testing.T) {
+	var (
+		content = []byte(trand.String(1024))
+		other   = []byte(trand.String(1024))
+		smm     = memsys.ByteMM()
+		tests   = []struct {
+			mime string
+			data []byte
+		}{
+			{archive.ExtTar, mkTar(t, content, other, false)},
+			{archive.ExtTarGz, mkTar(t, content, other, true)},
+			{archive.ExtZip, mkZip(t, content, other, zip.Deflate)},
+		}
+	)
+	for _, test := range tests {
+		t.Run(test.mime, func(t *testing.T) {
+			r := &memLomReader{bytes.NewReader(test.data)}
+			// no extension: detecting by magic
+			m, err := archive.MimeFile(r, smm, "", "archive-without-extension")
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, m == test.mime, "expected %q, got %q", test.mime, m)
+
+			// the reader remains positioned at the beginning
+			b, err := io.ReadAll(r)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, bytes.Equal(b, test.data), "expecting to read the entire archive (%d), got %d", len(test.data), len(b))
+		})
+	}
+}
+
+// archived `content` is preceded by `other` (to be skipped)
+func mkTar(t *testing.T, content, other []byte, gz bool) []byte {
+	var (
+		buf bytes.Buffer
+		w   io.Writer = &buf
+		gzw *gzip.Writer
+	)
+	if gz {
+		gzw = gzip.NewWriter(&buf)
+		w = gzw
+	}
+	tw := tar.NewWriter(w)
+	add := func(hdr *tar.Header, data []byte) {
+		hdr.Size, hdr.Mode, hdr.Typeflag = int64(len(data)), 0o644, tar.TypeReg
+		tassert.CheckFatal(t, tw.WriteHeader(hdr))
+		_, err := tw.Write(data)
+		tassert.CheckFatal(t, err)
+	}
+	add(&tar.Header{Name: "other.bin"}, other)
+	add(&tar.Header{Name: "dir/" + strings.Repeat("x", 200)}, []byte{'x'}) // (long name => PAX header)
+	add(&tar.Header{Name: "dir/file.bin", PAXRecords: map[string]string{"comment": "test"}}, content)
+	tassert.CheckFatal(t, tw.Close())
+	if gzw != nil {
+		tassert.CheckFatal(t, gzw.Close())
+	}
+	return buf.Bytes()
+}
+
+func mkZip(t *testing.T, content, other []byte, method uint16) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{{"other.bin", other}, {"dir/file.bin", content}} {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: f.name, Method: method})
+		tassert.CheckFatal(t, err)
+		_, err = w.Write(f.data)
+		tassert.CheckFatal(t, err)
+	}
+	tassert.CheckFatal(t, zw.Close())
+	return buf.Bytes()
+}
@@ -72,3 +72,33 @@ func TestValidateMpath(t *testing.T) {
 		}
 	}
 }
+
+func TestSSEKeySources(t *testing.T) {
+	oldConfig := cmn.GCO.Get()
+	defer func() {
+		cmn.GCO.BeginUpdate()
+		cmn.GCO.CommitUpdate(oldConfig)
+	}()
+
+	conf := &cmn.SSEKeysConf{KeySources: []string{"http://vault.example.com/"}}
+	tassert.Errorf(t, conf.Validate() != nil, "expecting plain http key source to fail validation")
+	conf.KeySources = []string{"file:///etc/ais/keys/", "https://vault.example.com/v1/secret/ais/"}
+	tassert.CheckFatal(t, conf.Validate())
+
+	config := cmn.GCO.BeginUpdate()
+	config.SSE = *conf
+	cmn.GCO.CommitUpdate(config)
+
+	for src, ok := range map[string]bool{
+		"file:///etc/ais/keys/bucket.key":                  true,
+		"https://vault.example.com/v1/secret/ais/bucket":   true,
+		"file:///etc/ais/keys/../../passwd":                false,
+		"file:///etc/passwd":                               false,
+		"https://attacker.example.com/v1/secret/ais/":      false,
+		"http://vault.example.com/v1/secret/ais/bucket":    false,
+		"https://vault.example.com/v1/secret/ais/../other": false,
+	} {
+		err := (&cmn.SSEConf{Enabled: true, KeySource: src}).ValidateAsProps()
+		tassert.Errorf(t, (err == nil) == ok, "key source %q: expecting ok=%t, got %v", src, ok, err)
+	}
+}
@@ -102,6 +102,25 @@ var _ = Describe("IterFields", func() {
 
 					"write_policy.data": apc.WritePolicy(""),
 					"write_policy.md":   apc.WritePolicy(""),
+
+					"chunks.objsize_limit": cos.SizeIEC(0),
+					"chunks.chunk_size":    cos.SizeIEC(0),
+
+					"quota.max_size": cos.SizeIEC(0),
+					"quota.max_objs": int64(0),
+					"quota.soft_pct": int64(0),
+
+					"rate_limit.max_rps":  int64(0),
+					"rate_limit.max_bps":  cos.SizeIEC(0),
+					"rate_limit.user_rps": int64(0),
+					"rate_limit.user_bps": cos.SizeIEC(0),
+
+					"sse.key_source": "",
+					"sse.enabled":    false,
+
+					"object_lock.mode":    "",
+					"object_lock.days":    int64(0),
+					"object_lock.enabled": false,
 				},
 			),
 			Entry("list BpropsToSet fields",
@@ -156,6 +175,25 @@ var _ = Describe("IterFields", func() {
 					"write_policy.data": (*apc.WritePolicy)(nil),
 					"write_policy.md":   apc.Ptr(apc.WriteDelayed),
 
+					"chunks.objsize_limit": (*cos.SizeIEC)(nil),
+					"chunks.chunk_size":    (*cos.SizeIEC)(nil),
+
+					"quota.max_size": (*cos.SizeIEC)(nil),
+					"quota.max_objs": (*int64)(nil),
+					"quota.soft_pct": (*int64)(nil),
+
+					"rate_limit.max_rps":  (*int64)(nil),
+					"rate_limit.max_bps":  (*cos.SizeIEC)(nil),
+					"rate_limit.user_rps": (*int64)(nil),
+					"rate_limit.user_bps": (*cos.SizeIEC)(nil),
+
+					"sse.key_source": (*string)(nil),
+					"sse.enabled":    (*bool)(nil),
+
+					"object_lock.mode":    (*string)(nil),
+					"object_lock.days":    (*int64)(nil),
+					"object_lock.enabled": (*bool)(nil),
+
 					"extra.hdfs.ref_directory": (*string)(nil),
 					"extra.aws.cloud_region":   (*string)(nil),
 					"extra.aws.endpoint":       (*string)(nil),
@@ -0,0 +1,116 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+type whereObj struct {
+	custom cos.StrKVs
+	name   string
+	cksum  string
+	ec     string
+	size   int64
+	atime  int64
+	mtime  int64
+	copies int64
+}
+
+func (o *whereObj) Name() string                        { return o.name }
+func (o *whereObj) Size() (int64, bool)                 { return o.size, true }
+func (o *whereObj) Atime() (int64, bool)                { return o.atime, o.atime != 0 }
+func (o *whereObj) Mtime() (int64, bool)                { return o.mtime, o.mtime != 0 }
+func (o *whereObj) CksumType() (string, bool)           { return o.cksum, o.cksum != "" }
+func (o *whereObj) Copies() (int64, bool)               { return o.copies, o.copies != 0 }
+func (o *whereObj) ECStatus() (string, bool)            { return o.ec, o.ec != "" }
+func (o *whereObj) CustomKey(key string) (string, bool) { v, ok := o.custom[key]; return v, ok }
+
+func TestLsoWhereMatch(t *testing.T) {
+	var (
+		now = time.Now()
+		obj = &whereObj{
+			name:   "images/cat-001.jpg",
+			size:   2 * cos.GiB,
+			atime:  now.Add(-time.Hour).UnixNano(),
+			mtime:  now.Add(-3 * time.Hour).UnixNano(),
+			cksum:  cos.ChecksumXXHash,
+			copies: 2,
+			ec:     cmn.LsoECNone,
+			custom: cos.StrKVs{"label": "cat"},
+		}
+		tests = []struct {
+			expr  string
+			match bool
+		}{
+			{"size>1GiB", true},
+			{"size <= 1GiB", false},
+			{"size=2GiB", true},
+			{"mtime<1d", true},
+			{"mtime<2h", false},
+			{"mtime>2h and atime<90m", true},
+			{"atime>=2024-01-01", true},
+			{"mtime<2024-01-01T00:00:00Z", false},
+			{"name~^images/cat-\\d+\\.jpg$", true},
+			{"name !~ \\.jpg$", false},
+			{"name='images/cat-001.jpg'", true},
+			{"custom.label=cat", true},
+			{"custom.label!=cat", false},
+			{"custom.label~^c", true},
+			{"custom.color=black", false}, // unknown
+			{"cksum=" + cos.ChecksumXXHash, true},
+			{"cksum!=" + cos.ChecksumMD5, true},
+			{"copies>=2 && ec=none", true},
+			{"ec=encoded", false},
+			{"size>1GiB AND mtime<1d and custom.label=cat", true},
+			{"size>1GiB and custom.label=dog", false},
+		}
+	)
+	for _, test := range tests {
+		where, err := cmn.ParseLsoWhere(test.expr)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, where.Match(obj) == test.match, "%q: expected match=%t", test.expr, test.match)
+	}
+}
+
+func TestLsoWhereParse(t *testing.T) {
+	invalid := []string{
+		"",
+		"size",
+		"size>",
+		"size~1GiB",
+		"size>abc",
+		"mtime=1d",
+		"mtime>yesterday",
+		"atime<-1d",
+		"name~[",
+		"cksum=crc64",
+		"ec=maybe",
+		"custom.=cat",
+		"color=black",
+		"size>1GiB and",
+	}
+	for _, expr := range invalid {
+		_, err := cmn.ParseLsoWhere(expr)
+		tassert.Errorf(t, err != nil, "%q: expected error", expr)
+	}
+
+	where, err := cmn.ParseLsoWhere("name~^a and custom.label=cat and size>1 and mtime<1d")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !where.NameOnly(), "expected metadata to be required")
+	props := where.Props()
+	tassert.Errorf(t, len(props) == 2 && props[0] == apc.GetPropsCustom && props[1] == apc.GetPropsSize,
+		"unexpected props %v", props)
+
+	where, err = cmn.ParseLsoWhere("name~^a && name!~z$")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, where.NameOnly() && len(where.Props()) == 0, "expected name-only predicate")
+}
@@ -0,0 +1,50 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestTCBMsgFromNames(t *testing.T) {
+	tests := []struct {
+		ext   cos.StrKVs
+		name  string
+		names []string
+	}{
+		// no extensions to replace
+		{nil, "a/b.png", []string{"a/b.png"}},
+		{cos.StrKVs{"jpg": "png"}, "a/b", []string{"a/b"}},
+
+		// one-to-one
+		{cos.StrKVs{"jpg": "png"}, "a/b.png", []string{"a/b.jpg", "a/b.png"}},
+		{cos.StrKVs{"jpg": ".png"}, "a/b.png", []string{"a/b.jpg", "a/b.png"}}, // (leading dot)
+		{cos.StrKVs{"jpg": "png"}, "a/b.gif", []string{"a/b.gif"}},
+		{cos.StrKVs{"jpg": "png"}, "a/b.jpg", nil}, // cannot be a destination name
+
+		// many-to-one
+		{cos.StrKVs{"jpg": "png", "jpeg": "png", "gif": "png"}, "a.b/c.png", []string{"a.b/c.gif", "a.b/c.jpeg", "a.b/c.jpg", "a.b/c.png"}},
+		{cos.StrKVs{"jpg": "png", "jpeg": "png", "png": "webp"}, "c.png", []string{"c.jpeg", "c.jpg"}},
+		{cos.StrKVs{"jpg": "png", "jpeg": "png", "png": "webp"}, "c.webp", []string{"c.png", "c.webp"}},
+
+		// identity
+		{cos.StrKVs{"png": "png"}, "c.png", []string{"c.png"}},
+		{cos.StrKVs{"tar": "tar", "tgz": "tar"}, "c.tar", []string{"c.tar", "c.tgz"}},
+	}
+	for _, test := range tests {
+		msg := &apc.TCBMsg{Ext: test.ext}
+		names := msg.FromNames(test.name)
+		tassert.Errorf(t, cos.StrSlicesEqual(names, test.names), "%v: %q => %v (expected %v)", test.ext, test.name, names, test.names)
+
+		// must be reversible
+		for _, name := range names {
+			tassert.Errorf(t, msg.ToName(name) == test.name, "%v: %q => %q (expected %q)", test.ext, name, msg.ToName(name), test.name)
+		}
+	}
+}
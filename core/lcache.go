@@ -213,7 +213,7 @@ func (*term) f(_, value any) bool {
 	if err != nil {
 		return true
 	}
-	if lom.WritePolicy() == apc.WriteNever {
+	if lom.WritePolicy() == apc.WriteNever || lom.IsStaged() {
 		return true
 	}
 	if md.Atime < 0 {
@@ -352,7 +352,7 @@ func (evct *evct) f(hkey, value any) bool {
 
 	atime := time.Unix(0, mdTime)
 	elapsed := evct.now.Sub(atime)
-	if elapsed < evct.d {
+	if elapsed < evct.d || g.stg.has(md.uname) {
 		return evct.parent.rc.Load() == 0
 	}
 
@@ -386,7 +386,7 @@ func _flushAtime(md *lmeta, atime time.Time, mdTime int64) {
 	if err != nil {
 		return
 	}
-	if lom.WritePolicy() == apc.WriteNever {
+	if lom.WritePolicy() == apc.WriteNever || lom.IsStaged() {
 		return
 	}
 	if err = lom.flushAtime(atime); err != nil {
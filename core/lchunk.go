@@ -0,0 +1,655 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Chunked layout
+// - objects larger than (bucket-configurable) `chunks.objsize_limit` are stored as
+//   an ordered sequence of `chunks.chunk_size` chunks (the last one possibly smaller);
+// - PUT writes chunks directly - each to its own location (see ChunkWriter); if the number
+//   of chunks would exceed the maximum (chunksMaxMD), the chunk size is increased when the
+//   size is known in advance; otherwise, the last chunk takes the rest;
+// - the first chunk is lom.FQN itself - the file that also carries object metadata,
+//   including the chunk manifest (see lchunks);
+// - each of the remaining chunks is `fs.ChunkType` content named `<object-name>.<num>-<generation>`
+//   and located on its own HRW mountpath (see chunkMpath) - the same way objects are;
+// - generation is unique per write, so that a new version never collides with chunks
+//   of the soft-deleted (and restorable) one;
+// - object checksum is computed over the entire content; in addition, each chunk is checksummed
+//   (using the same checksum type).
+
+const (
+	// manifest must fit into (and share) LOM xattr (see xattrMaxSize);
+	// the number of chunks is limited accordingly, by increasing chunk size if need be
+	chunksMaxMD = xattrMaxSize / 2
+)
+
+type (
+	// chunk manifest (part of lmeta)
+	lchunks struct {
+		gen    string   // generation (see above)
+		cksums []string // per-chunk checksum values, in order
+		size   int64    // chunk size (all chunks except the last one)
+	}
+
+	// reads chunked object as a whole (compare with cos.FileHandle)
+	ChunksHandle struct {
+		fqns  []string   // fqns[0] = lom.FQN
+		fhs   []*os.File // (opened on demand)
+		csize int64      // chunk size
+		size  int64      // object size
+		off   int64
+	}
+
+	// writes new content directly as chunks (see ChunkFinalize)
+	ChunkWriter struct {
+		lom       *LOM
+		fh        *os.File       // the current chunk
+		cksum     *cos.CksumHash // ditto
+		works     []string       // work files by chunk number (the first one being the work file)
+		mis       []*fs.Mountpath
+		cksums    []string
+		gen       string
+		cksumType string
+		csize     int64 // chunk size
+		off       int64 // written into the current chunk
+		size      int64 // total
+		maxNum    int   // the last chunk takes the rest (size unknown in advance)
+	}
+
+	// file (cos.FileHandle), ChunksHandle, or SSEHandle (see lsse.go)
+	LomHandle interface {
+		cos.ReadOpenCloser
+		io.ReaderAt
+	}
+
+	// location of a given chunk (implements fs.PartsFQN)
+	chunkLoc struct {
+		lom *LOM
+		mi  *fs.Mountpath
+	}
+)
+
+// interface guard
+var (
+	_ LomHandle     = (*ChunksHandle)(nil)
+	_ LomHandle     = (*cos.FileHandle)(nil)
+	_ cos.LomReader = (*ChunksHandle)(nil)
+	_ cos.LomWriter = (*ChunkWriter)(nil)
+	_ fs.PartsFQN   = (*chunkLoc)(nil)
+)
+
+func (loc *chunkLoc) ObjectName() string       { return loc.lom.ObjName }
+func (loc *chunkLoc) Bucket() *cmn.Bck         { return loc.lom.Bucket() }
+func (loc *chunkLoc) Mountpath() *fs.Mountpath { return loc.mi }
+
+func (lc *lchunks) num() int { return len(lc.cksums) }
+
+// (as per the per-chunk checksum type - see chunksMaxNum)
+func (lc *lchunks) maxNum() int { return chunksMaxMD / (len(lc.cksums[0]) + 1) }
+
+func (lom *LOM) NumChunks() int {
+	if lom.md.chunks == nil {
+		return 0
+	}
+	return lom.md.chunks.num()
+}
+
+// NewHandle opens the object for reading (caller must rlock)
+func (lom *LOM) NewHandle() (LomHandle, error) {
+	fh, err := lom.NewRawHandle()
+	if err != nil || lom.md.sse == nil {
+		return fh, err
+	}
+	return lom.decrypt(fh)
+}
+
+// NewRawHandle reads stored content as is - that is, without decrypting (see lsse.go)
+func (lom *LOM) NewRawHandle() (LomHandle, error) {
+	if lom.md.chunks != nil {
+		return lom.openChunks()
+	}
+	return cos.NewFileHandle(lom.FQN)
+}
+
+func (lom *LOM) openChunks() (*ChunksHandle, error) {
+	lc := lom.md.chunks
+	fqns := make([]string, lc.num())
+	fqns[0] = lom.FQN
+	for i := 1; i < len(fqns); i++ {
+		fqn, _, err := lom.findChunk(i, lc.gen)
+		if err != nil {
+			return nil, err
+		}
+		fqns[i] = fqn
+	}
+	return &ChunksHandle{fqns: fqns, csize: lc.size, size: lom.md.rawSize()}, nil
+}
+
+// HRW mountpath of a given chunk (num > 0)
+func (lom *LOM) chunkMpath(num int) (*fs.Mountpath, error) {
+	mi, _, err := fs.Hrw(cos.UnsafeB(*lom.md.uname + "." + strconv.Itoa(num)))
+	return mi, err
+}
+
+func (lom *LOM) chunkFQN(mi *fs.Mountpath, num int, gen string) string {
+	return fs.CSM.Gen(&chunkLoc{lom, mi}, fs.ChunkType, strconv.Itoa(num)+"-"+gen)
+}
+
+// HRW location first and, if not found, all other available mountpaths
+// (e.g., when resilvering hasn't finished yet)
+func (lom *LOM) findChunk(num int, gen string) (fqn string, isHrw bool, err error) {
+	mi, err := lom.chunkMpath(num)
+	if err != nil {
+		return "", false, err
+	}
+	fqn = lom.chunkFQN(mi, num, gen)
+	if err = cos.Stat(fqn); err == nil {
+		return fqn, true, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", false, err
+	}
+	avail := fs.GetAvail()
+	for path, mj := range avail {
+		if path == mi.Path {
+			continue
+		}
+		if fqn := lom.chunkFQN(mj, num, gen); cos.Stat(fqn) == nil {
+			return fqn, false, nil
+		}
+	}
+	return "", false, fmt.Errorf("%s: chunk #%d not found: %w", lom.Cname(), num, os.ErrNotExist)
+}
+
+// IsChunkRef returns true if the manifest references a given chunk file (see space cleanup);
+// a non-HRW replica of the chunk is referenced only when there's no HRW one
+func (lom *LOM) IsChunkRef(fqn string) bool {
+	lc := lom.md.chunks
+	if lc == nil {
+		return false
+	}
+	id := fqn[strings.LastIndexByte(fqn, '.')+1:]
+	snum, gen, ok := strings.Cut(id, "-")
+	if !ok || gen != lc.gen {
+		return false
+	}
+	num, err := strconv.Atoi(snum)
+	if err != nil || num < 1 || num >= lc.num() {
+		return false
+	}
+	mi, err := lom.chunkMpath(num)
+	if err != nil {
+		return true // (can't tell)
+	}
+	hrwFQN := lom.chunkFQN(mi, num, gen)
+	return fqn == hrwFQN || cos.Stat(hrwFQN) != nil
+}
+
+// max number of chunks (see chunksMaxMD)
+func chunksMaxNum(cksumType string) int {
+	return chunksMaxMD / (2*cos.NewCksumHash(cksumType).H.Size() + 1)
+}
+
+// NewChunkWriter creates the work file - the first chunk - of a new version
+// that will get chunked if it exceeds `chunks.objsize_limit` (see ChunkFinalize);
+// size is the expected size of the stored content or -1 when unknown
+func (lom *LOM) NewChunkWriter(wfqn string, size int64) (*ChunkWriter, error) {
+	fh, err := lom._cf(wfqn)
+	if err != nil {
+		return nil, err
+	}
+	cw := lom.newChunkWriter(wfqn, size)
+	cw.fh, cw.cksum = fh, cos.NewCksumHash(cw.cksumType)
+	return cw, nil
+}
+
+func (lom *LOM) newChunkWriter(wfqn string, size int64) *ChunkWriter {
+	var (
+		cksumType = lom.CksumType()
+		csize     = int64(lom.Bprops().Chunks.ChunkSize)
+		maxNum    = chunksMaxNum(cksumType)
+	)
+	if size > 0 {
+		if num := (size + csize - 1) / csize; num > int64(maxNum) {
+			csize = (size + int64(maxNum) - 1) / int64(maxNum)
+		}
+	}
+	return &ChunkWriter{
+		lom:       lom,
+		works:     []string{wfqn},
+		mis:       []*fs.Mountpath{lom.mi},
+		gen:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		cksumType: cksumType,
+		csize:     csize,
+		maxNum:    maxNum,
+	}
+}
+
+func (cw *ChunkWriter) Write(b []byte) (n int, err error) {
+	for len(b) > 0 {
+		last := len(cw.works) == cw.maxNum
+		if cw.off >= cw.csize && !last {
+			if err = cw.next(); err != nil {
+				return n, err
+			}
+		}
+		l := len(b)
+		if !last {
+			l = int(min(int64(l), cw.csize-cw.off))
+		}
+		m, err := cw.fh.Write(b[:l])
+		cw.cksum.H.Write(b[:m])
+		n += m
+		cw.off += int64(m)
+		cw.size += int64(m)
+		if err != nil {
+			return n, err
+		}
+		b = b[m:]
+	}
+	return n, nil
+}
+
+// close the current chunk and create the next one on its HRW mountpath
+func (cw *ChunkWriter) next() error {
+	if cw.fh != nil {
+		if err := cw.closeChunk(true /*sync*/); err != nil {
+			return err
+		}
+	}
+	num := len(cw.works)
+	mi, err := cw.lom.chunkMpath(num)
+	if err != nil {
+		return err
+	}
+	work := fs.CSM.Gen(&chunkLoc{cw.lom, mi}, fs.WorkfileType, fs.WorkfileChunk+strconv.Itoa(num))
+	fh, err := cos.CreateFile(work)
+	if err != nil {
+		return err
+	}
+	cw.works = append(cw.works, work)
+	cw.mis = append(cw.mis, mi)
+	cw.fh, cw.cksum, cw.off = fh, cos.NewCksumHash(cw.cksumType), 0
+	return nil
+}
+
+func (cw *ChunkWriter) closeChunk(sync bool) (err error) {
+	if sync && cw.lom.IsFeatureSet(feat.FsyncPUT) {
+		err = cw.fh.Sync()
+	}
+	if errC := cw.fh.Close(); err == nil {
+		err = errC
+	}
+	cw.fh = nil
+	cw.cksum.Finalize()
+	cw.cksums = append(cw.cksums, cw.cksum.Val())
+	return err
+}
+
+// (the current chunk; the previous ones are synced upon completion - see next)
+func (cw *ChunkWriter) Sync() error {
+	if cw.fh == nil {
+		return nil
+	}
+	return cw.fh.Sync()
+}
+
+func (cw *ChunkWriter) Close() error {
+	if cw.fh == nil {
+		return nil
+	}
+	return cw.closeChunk(false)
+}
+
+// NewReader reads the written content (e.g., to PUT it to remote backend)
+func (cw *ChunkWriter) NewReader() cos.ReadOpenCloser {
+	return &ChunksHandle{fqns: cw.works, csize: cw.csize, size: cw.size}
+}
+
+// Abort closes and removes all work files
+func (cw *ChunkWriter) Abort() {
+	if cw.fh != nil {
+		cw.fh.Close()
+		cw.fh = nil
+	}
+	for _, work := range cw.works {
+		if err := cos.RemoveFile(work); err != nil && !os.IsNotExist(err) {
+			nlog.Errorln("nested err:", err)
+		}
+	}
+}
+
+// ChunkFinalize is the chunked alternative to RenameFinalize: renames chunks
+// written by ChunkWriter to their respective locations, and the first one
+// (that is, the work file) to become lom.FQN. Content that ends up not exceeding
+// `chunks.objsize_limit` (when written with unknown size) is not chunked - the chunks
+// get appended to the work file instead.
+// Chunks of the previous (overwritten) version, if any, are removed upon success.
+// Caller must wlock and then persist metadata.
+func (lom *LOM) ChunkFinalize(cw *ChunkWriter) error {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	debug.Assert(cw.fh == nil, "not closed: ", cw.works[0])
+	var (
+		prev *lchunks
+		num  = len(cw.works)
+		err  error
+	)
+	if num > 1 && lom.md.Size <= int64(lom.Bprops().Chunks.ObjSizeLimit) {
+		if err = cw.merge(); err != nil {
+			return cmn.NewErrFailedTo(T, "finalize", lom.Cname(), err)
+		}
+		num = 1
+	}
+	if num == 1 {
+		return lom.RenameFinalize(cw.works[0])
+	}
+
+	if md, err := lom.lmfsReload(false); err == nil {
+		prev = md.chunks
+	}
+	var (
+		lc   = &lchunks{gen: cw.gen, cksums: cw.cksums, size: cw.csize}
+		fqns = make([]string, num)
+	)
+	for i := 1; i < num; i++ {
+		fqns[i] = lom.chunkFQN(cw.mis[i], i, lc.gen) // (not necessarily HRW by now - see RelocateChunks)
+		if err = cos.Rename(cw.works[i], fqns[i]); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = lom.RenameFinalize(cw.works[0])
+	}
+	if err != nil {
+		for i := 1; i < num; i++ {
+			for _, fqn := range []string{cw.works[i], fqns[i]} {
+				if errRm := cos.RemoveFile(fqn); errRm != nil && !os.IsNotExist(errRm) {
+					nlog.Errorln("nested err:", errRm)
+				}
+			}
+		}
+		return cmn.NewErrFailedTo(T, "chunk", lom.Cname(), err)
+	}
+
+	lom.md.chunks = lc
+	if prev != nil {
+		if err := lom.rmChunks(prev); err != nil {
+			nlog.Errorln("failed to remove chunks of the previous version of", lom.Cname(), "err:", err)
+		}
+	}
+	return nil
+}
+
+// append the remaining chunks to the work file (and remove them)
+func (cw *ChunkWriter) merge() error {
+	fh, err := os.OpenFile(cw.works[0], os.O_WRONLY|os.O_APPEND, cos.PermRWR)
+	if err != nil {
+		return err
+	}
+	buf, slab := g.pmm.AllocSize(cw.csize)
+	for _, work := range cw.works[1:] {
+		var src *os.File
+		if src, err = os.Open(work); err != nil {
+			break
+		}
+		_, err = io.CopyBuffer(cos.WriterOnly{Writer: fh}, src, buf)
+		cos.Close(src)
+		if err != nil {
+			break
+		}
+	}
+	slab.Free(buf)
+	if errC := fh.Close(); err == nil {
+		err = errC
+	}
+	if err != nil {
+		return err
+	}
+	for _, work := range cw.works[1:] {
+		if err := cos.RemoveFile(work); err != nil {
+			nlog.Errorln("failed to remove merged chunk", work, "err:", err)
+		}
+	}
+	cw.works, cw.mis, cw.cksums = cw.works[:1], cw.mis[:1], nil
+	return nil
+}
+
+// SplitFinalize is ChunkFinalize for content that has already been fully written into
+// a single work file (e.g., downloaded blob or archive): the first chunk stays in place,
+// the remaining ones are copied to their respective HRW mountpaths.
+// Caller must wlock and then persist metadata.
+func (lom *LOM) SplitFinalize(wfqn string) error {
+	var (
+		size = lom.md.rawSize()
+		cw   = lom.newChunkWriter(wfqn, size)
+	)
+	debug.Assert(size > cw.csize, size, " vs ", cw.csize)
+	fh, err := os.Open(wfqn)
+	if err != nil {
+		return err
+	}
+	buf, slab := g.pmm.AllocSize(cw.csize)
+	_, cksum, err := cos.CopyAndChecksum(io.Discard, io.NewSectionReader(fh, 0, cw.csize), nil, cw.cksumType)
+	if err == nil {
+		if cksum != nil {
+			cw.cksums = append(cw.cksums, cksum.Val())
+		} else {
+			cw.cksums = append(cw.cksums, "")
+		}
+		cw.off, cw.size = cw.csize, cw.csize
+		_, err = cos.CopyBuffer(cw, io.NewSectionReader(fh, cw.csize, size-cw.csize), buf)
+		if errC := cw.Close(); err == nil {
+			err = errC
+		}
+	}
+	slab.Free(buf)
+	cos.Close(fh)
+	if err == nil {
+		err = os.Truncate(wfqn, cw.csize)
+	}
+	if err != nil {
+		for _, work := range cw.works[1:] {
+			if errRm := cos.RemoveFile(work); errRm != nil && !os.IsNotExist(errRm) {
+				nlog.Errorln("nested err:", errRm)
+			}
+		}
+		return cmn.NewErrFailedTo(T, "chunk", lom.Cname(), err)
+	}
+	return lom.ChunkFinalize(cw)
+}
+
+// CopyContent copies object's content into a single (e.g., work) file,
+// computing the checksum of the specified type and decrypting
+// encrypted content, if need be (caller must rlock)
+func (lom *LOM) CopyContent(dstFQN string, buf []byte, cksumType string) (cksum *cos.CksumHash, err error) {
+	if lom.md.chunks == nil && lom.md.sse == nil {
+		_, cksum, err = cos.CopyFile(lom.FQN, dstFQN, buf, cksumType)
+		return cksum, err
+	}
+	fh, err := lom.NewHandle()
+	if err != nil {
+		return nil, err
+	}
+	cksum, err = cos.SaveReader(dstFQN, fh, buf, cksumType, lom.md.Size)
+	cos.Close(fh)
+	return cksum, err
+}
+
+// remove all chunks except the first one (that is, lom.FQN)
+func (lom *LOM) rmChunks(lc *lchunks) (err error) {
+	for i := 1; i < lc.num(); i++ {
+		fqn, _, erc := lom.findChunk(i, lc.gen)
+		if erc == nil {
+			erc = cos.RemoveFile(fqn)
+		}
+		if erc != nil && !errors.Is(erc, os.ErrNotExist) && err == nil {
+			err = erc
+		}
+	}
+	return err
+}
+
+// new content replaces chunked one: remove the latter's chunks (best effort)
+func (lom *LOM) dropChunks() {
+	lc := lom.md.chunks
+	if lc == nil {
+		return
+	}
+	lom.md.chunks = nil
+	if err := lom.rmChunks(lc); err != nil {
+		nlog.Errorln("failed to remove chunks of the previous version of", lom.Cname(), "err:", err)
+	}
+}
+
+// RelocateChunks moves misplaced chunks to their respective (current) HRW mountpaths;
+// returns the number of relocated chunks (caller must wlock)
+func (lom *LOM) RelocateChunks(buf []byte) (n int, _ error) {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	lc := lom.md.chunks
+	if lc == nil {
+		return 0, nil
+	}
+	var errs []error
+	for i := 1; i < lc.num(); i++ {
+		fqn, isHrw, err := lom.findChunk(i, lc.gen)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if isHrw {
+			continue
+		}
+		mi, err := lom.chunkMpath(i)
+		if err != nil {
+			return n, err
+		}
+		var (
+			hrwFQN = lom.chunkFQN(mi, i, lc.gen)
+			work   = fs.CSM.Gen(&chunkLoc{lom, mi}, fs.WorkfileType, fs.WorkfileChunk+strconv.Itoa(i))
+		)
+		// validate chunk checksum while copying
+		var cksum *cos.CksumHash
+		if _, cksum, err = cos.CopyFile(fqn, work, buf, lom.CksumType()); err == nil {
+			if cksum != nil && cksum.Val() != lc.cksums[i] {
+				err = cos.NewErrDataCksum(&cksum.Cksum, cos.NewCksum(cksum.Ty(), lc.cksums[i]), fqn)
+			} else {
+				err = cos.Rename(work, hrwFQN)
+			}
+		}
+		if err != nil {
+			if errRm := cos.RemoveFile(work); errRm != nil {
+				nlog.Errorln("nested err:", errRm)
+			}
+			errs = append(errs, err)
+			continue
+		}
+		n++
+		if err := cos.RemoveFile(fqn); err != nil {
+			nlog.Errorln("failed to remove relocated chunk", fqn, "err:", err)
+		}
+	}
+	if len(errs) > 0 {
+		return n, fmt.Errorf("%s: failed to relocate chunks: %w", lom.Cname(), errors.Join(errs...))
+	}
+	return n, nil
+}
+
+//////////////////
+// ChunksHandle //
+//////////////////
+
+func (h *ChunksHandle) Open() (cos.ReadOpenCloser, error) {
+	return &ChunksHandle{fqns: h.fqns, csize: h.csize, size: h.size}, nil
+}
+
+func (h *ChunksHandle) Read(b []byte) (n int, err error) {
+	n, err = h.ReadAt(b, h.off)
+	h.off += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (h *ChunksHandle) ReadAt(b []byte, off int64) (n int, err error) {
+	if off >= h.size {
+		return 0, io.EOF
+	}
+	var (
+		i    = min(int(off/h.csize), len(h.fqns)-1) // (the last chunk may be larger - see ChunkWriter)
+		coff = off - int64(i)*h.csize               // offset within chunk
+	)
+	for len(b) > 0 && i < len(h.fqns) {
+		var (
+			fh    *os.File
+			m     int
+			csize = h.csize
+		)
+		if i == len(h.fqns)-1 {
+			csize = h.size - int64(i)*h.csize
+		}
+		l := min(int64(len(b)), csize-coff)
+		if fh, err = h.open(i); err != nil {
+			return n, err
+		}
+		m, err = fh.ReadAt(b[:l], coff)
+		n += m
+		b = b[m:]
+		if err != nil && (err != io.EOF || int64(m) < l) {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF // chunk is shorter than expected
+			}
+			return n, err
+		}
+		i, coff = i+1, 0
+	}
+	if len(b) > 0 {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (h *ChunksHandle) open(i int) (fh *os.File, err error) {
+	if h.fhs == nil {
+		h.fhs = make([]*os.File, len(h.fqns))
+	}
+	if fh = h.fhs[i]; fh != nil {
+		return fh, nil
+	}
+	if fh, err = os.Open(h.fqns[i]); err == nil {
+		h.fhs[i] = fh
+	}
+	return fh, err
+}
+
+func (h *ChunksHandle) Close() (err error) {
+	for i, fh := range h.fhs {
+		if fh == nil {
+			continue
+		}
+		if erc := fh.Close(); erc != nil && err == nil {
+			err = erc
+		}
+		h.fhs[i] = nil
+	}
+	return err
+}
@@ -301,8 +301,24 @@ func (lom *LOM) copy2fqn(dst *LOM, buf []byte) (err error) {
 	}
 
 	workFQN := fs.CSM.Gen(dst, fs.WorkfileType, fs.WorkfileCopy)
-	_, dstCksum, err = cos.CopyFile(lom.FQN, workFQN, buf, cksumType)
+	if sd := lom.staged(); sd != nil {
+		dstCksum, err = sd.toFile(dst, workFQN, cksumType)
+	} else {
+		dstCksum, err = lom.CopyContent(workFQN, buf, cksumType)
+		dst.md.chunks = nil // (the copy is never chunked)
+	}
+	if err == nil {
+		// mirror copy must be identical; otherwise, encrypt (or not) as per destination bucket
+		if lom.md.sse != nil && dst.isMirror(lom) {
+			err = dst.reencryptWork(workFQN, lom.md.sse)
+		} else {
+			err = dst.EncryptWork(workFQN)
+		}
+	}
 	if err != nil {
+		if errRemove := cos.RemoveFile(workFQN); errRemove != nil && !os.IsNotExist(errRemove) {
+			nlog.Errorln("nested err:", errRemove)
+		}
 		return
 	}
 
@@ -0,0 +1,449 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/sys"
+)
+
+// Staged object data: in-memory (SGL) content of objects that belong to buckets
+// configured with `write_policy.data` = (delayed | never).
+// - delayed: flushed to stable storage when not accessed for a while (see `stageFlushIdle`),
+//   under high memory pressure, and upon termination
+// - never:   transient, in-memory only; dropped under extreme memory pressure
+// Either way, object metadata is kept in (and restored to) lcache - see LOM.Load.
+// Staging is limited in two ways: objects larger than `stageMaxObjSize` are never staged,
+// and the total (staged and being staged) must not exceed `stageMaxPct` of the memory.
+// A write that exceeds either limit spills to disk (delayed) or fails with ErrStageNoMem (never);
+// near the total limit, housekeeping flushes write-delayed content regardless of idle time.
+// Staged objects are not on disk and are, therefore, invisible to fs.Walk - hence:
+// - list-objects merges StagedNames into its (sorted) walk
+// - rebalance and resilver call FlushAllStaged prior to walking
+
+// tunables
+const (
+	stageHKTime     = 20 * time.Second
+	stageFlushIdle  = time.Minute
+	stageMaxObjSize = 64 * cos.MiB // per object
+	stageMaxPct     = 10           // total, as percentage of the memory
+	stageHighPct    = 80           // of the total limit: flush when exceeded
+)
+
+var ErrStageNoMem = errors.New("insufficient memory to stage object data (write_policy.data = never)")
+
+type (
+	sdata struct {
+		sgl    *memsys.SGL
+		md     lmeta        // snapshot at the time of staging (see Load)
+		atime  atomic.Int64 // mono-time of the last access
+		policy apc.WritePolicy
+	}
+	stager struct {
+		m       sync.Map     // uname => *sdata
+		size    atomic.Int64 // staged and reserved (being staged) - see StageReserve
+		limit   int64        // max size
+		running atomic.Bool
+	}
+)
+
+func (stg *stager) has(uname *string) bool {
+	if uname == nil {
+		return false
+	}
+	_, ok := stg.m.Load(*uname)
+	return ok
+}
+
+// stats (pending bytes) - see stats.Trunner.log
+func StagedSize() int64 { return g.stg.size.Load() }
+
+// StagedNames returns sorted names of the bucket's staged objects
+// that have a given prefix (empty prefix: all)
+func StagedNames(bck *cmn.Bck, prefix string) (names []string) {
+	if g.stg.size.Load() == 0 {
+		return nil
+	}
+	bprefix := string(bck.MakeUname(""))
+	g.stg.m.Range(func(k, _ any) bool {
+		uname := k.(string)
+		if strings.HasPrefix(uname, bprefix) && cmn.ObjHasPrefix(uname[len(bprefix):], prefix) {
+			names = append(names, uname[len(bprefix):])
+		}
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+// FlushAllStaged flushes write-delayed content, and also write-never content that
+// belongs to another target given the new cluster map (nil smap: write-delayed only);
+// called by rebalance and resilver prior to walking mountpaths
+func FlushAllStaged(smap *meta.Smap) {
+	if g.stg.size.Load() == 0 {
+		return
+	}
+	g.stg.acquire()
+	defer g.stg.running.Store(false)
+
+	var flushed int
+	g.stg.m.Range(func(k, v any) bool {
+		var (
+			uname = k.(string)
+			sd    = v.(*sdata)
+		)
+		if sd.policy == apc.WriteNever && !g.stg.misplaced(uname, sd, smap) {
+			return true
+		}
+		if g.stg.flush(uname, sd, true /*wait*/) {
+			flushed++
+		}
+		return true
+	})
+	if flushed > 0 {
+		nlog.Infoln("staged data: flushed", flushed)
+	}
+}
+
+func (lom *LOM) DataWritePolicy() (p apc.WritePolicy) {
+	if bprops := lom.Bprops(); bprops == nil || bprops.WritePolicy.Data.IsImmediate() {
+		p = apc.WriteImmediate
+	} else {
+		p = bprops.WritePolicy.Data
+	}
+	return
+}
+
+// whether to stage (rather than write) new content of a given size (non-positive if unknown)
+// given staging limits and current memory pressure;
+// returns ErrStageNoMem when transient (write-never) content cannot be accommodated
+func (lom *LOM) StageOK(size int64) (bool, error) {
+	wp := lom.DataWritePolicy()
+	if wp.IsImmediate() || lom.Bprops().SSE.Enabled { // (encrypted buckets never stage plaintext)
+		return false, nil
+	}
+	return stageOK(wp, size, g.pmm.Pressure())
+}
+
+func stageOK(wp apc.WritePolicy, size int64, pressure int) (bool, error) {
+	switch {
+	case size <= stageMaxObjSize && g.stg.size.Load()+max(size, 0) <= g.stg.limit && pressure < memsys.PressureHigh:
+		return true, nil
+	case wp == apc.WriteDelayed:
+		return false, nil // write immediately
+	default:
+		return false, ErrStageNoMem
+	}
+}
+
+// StageReserve accounts for `n` more bytes of an object that is being staged and
+// already has `cur` bytes; returns false if the object (or the total) would exceed
+// the staging limit, in which case the caller must spill (or fail) - see StageOK
+func StageReserve(cur, n int64) bool {
+	if cur+n > stageMaxObjSize {
+		return false
+	}
+	if g.stg.size.Add(n) > g.stg.limit {
+		g.stg.size.Sub(n)
+		return false
+	}
+	return true
+}
+
+// StageFree frees `sgl` that was written subject to StageReserve but did not get staged
+func StageFree(sgl *memsys.SGL) {
+	g.stg.size.Sub(sgl.Size())
+	sgl.Free()
+}
+
+func (lom *LOM) IsStaged() bool {
+	_, ok := g.stg.m.Load(*lom.md.uname)
+	return ok
+}
+
+func (lom *LOM) staged() *sdata {
+	if v, ok := g.stg.m.Load(*lom.md.uname); ok {
+		return v.(*sdata)
+	}
+	return nil
+}
+
+// Stage takes ownership of the `sgl` that must contain the entire object
+// (and must have been written subject to StageReserve);
+// the caller must wlock and is expected to (subsequently) PersistMain
+func (lom *LOM) Stage(sgl *memsys.SGL) error {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	debug.Assert(sgl.Size() == lom.md.Size, sgl.Size(), " vs ", lom.md.Size)
+
+	// remove previous on-disk version, if any
+	if err := lom.RemoveMain(); err != nil {
+		return err
+	}
+	lom.dropChunks()
+	lom.setbid(lom.Bprops().BID) // (new object - see stager.lom)
+	sd := &sdata{sgl: sgl, md: lom.md, policy: lom.DataWritePolicy()}
+	sd.atime.Store(mono.NanoTime())
+	if v, loaded := g.stg.m.Swap(*lom.md.uname, sd); loaded {
+		prev := v.(*sdata)
+		g.stg.size.Sub(prev.sgl.Size())
+		prev.sgl.Free()
+	}
+	return nil
+}
+
+// (caller must wlock)
+func (lom *LOM) unstage() {
+	v, ok := g.stg.m.LoadAndDelete(*lom.md.uname)
+	if !ok {
+		return
+	}
+	sd := v.(*sdata)
+	g.stg.size.Sub(sd.sgl.Size())
+	sd.sgl.Free()
+}
+
+func (lom *LOM) openStaged() *memsys.Reader {
+	sd := lom.staged()
+	if sd == nil {
+		return nil
+	}
+	sd.atime.Store(mono.NanoTime())
+	return memsys.NewReader(sd.sgl)
+}
+
+// staged object: update metadata snapshot in lieu of writing xattr
+func (lom *LOM) persistStaged() bool {
+	sd := lom.staged()
+	if sd == nil {
+		return false
+	}
+	lom.setbid(lom.Bprops().BID)
+	sd.md = lom.md
+	return true
+}
+
+// write staged content into a given (work) file and compute checksum, if requested
+func (sd *sdata) toFile(lom *LOM, wfqn string, cksumType string) (cksum *cos.CksumHash, err error) {
+	var wfh cos.LomWriter
+	if wfh, err = lom.CreateWork(wfqn); err != nil {
+		return nil, err
+	}
+	_, cksum, err = cos.CopyAndChecksum(wfh, memsys.NewReader(sd.sgl), nil, cksumType)
+	if err == nil && lom.IsFeatureSet(feat.FsyncPUT) {
+		err = wfh.Sync()
+	}
+	if erc := wfh.Close(); erc != nil && err == nil {
+		err = erc
+	}
+	if err != nil {
+		if errRemove := cos.RemoveFile(wfqn); errRemove != nil && !os.IsNotExist(errRemove) {
+			nlog.Errorln("nested err:", errRemove)
+		}
+	}
+	return cksum, err
+}
+
+// FlushStaged writes staged content to stable storage and persists object metadata
+// (caller must wlock)
+func (lom *LOM) FlushStaged() error {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	sd := lom.staged()
+	if sd == nil {
+		return nil
+	}
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return err
+	}
+	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileFlush)
+	if _, err := sd.toFile(lom, wfqn, cos.ChecksumNone); err != nil {
+		T.FSHC(err, lom.Mountpath(), wfqn)
+		g.tstats.Inc(LcacheErrCount)
+		return err
+	}
+	if err := lom.EncryptWork(wfqn); err != nil {
+		g.tstats.Inc(LcacheErrCount)
+		return err
+	}
+	if err := lom.RenameFinalize(wfqn); err != nil {
+		g.tstats.Inc(LcacheErrCount)
+		return err
+	}
+	size := sd.sgl.Size()
+	lom.unstage()
+	if err := lom.PersistMain(); err != nil {
+		return err
+	}
+	g.tstats.AddMany(
+		cos.NamedVal64{Name: LcacheDataFlushCount, Value: 1},
+		cos.NamedVal64{Name: LcacheDataFlushSize, Value: size},
+	)
+	return nil
+}
+
+////////////
+// stager //
+////////////
+
+func (stg *stager) setLimit() {
+	var mem sys.MemStat
+	if err := mem.Get(); err != nil {
+		nlog.Errorln("failed to get memory stats:", err)
+		return
+	}
+	stg.limit = int64(mem.Total) * stageMaxPct / 100
+}
+
+func (stg *stager) init() {
+	hk.Reg("lcache-data"+hk.NameSuffix, stg.housekeep, stageHKTime)
+}
+
+func (stg *stager) housekeep(int64) time.Duration {
+	if stg.size.Load() == 0 {
+		return stageHKTime
+	}
+	if !stg.running.CAS(false, true) {
+		return stageHKTime
+	}
+	pressure := g.pmm.Pressure()
+	if stg.size.Load() > stg.limit*stageHighPct/100 {
+		pressure = max(pressure, memsys.PressureHigh)
+	}
+	go stg.run(pressure, false /*all*/)
+	return stageHKTime
+}
+
+// flush idle (or all) write-delayed content; drop write-never content under extreme pressure
+func (stg *stager) run(pressure int, all bool) {
+	var (
+		now              = mono.NanoTime()
+		flushed, dropped int
+	)
+	defer stg.running.Store(false)
+
+	stg.m.Range(func(k, v any) bool {
+		var (
+			uname = k.(string)
+			sd    = v.(*sdata)
+		)
+		if sd.policy == apc.WriteNever {
+			if pressure >= memsys.PressureExtreme {
+				if stg.drop(uname, sd) {
+					dropped++
+				}
+			}
+			return true
+		}
+		if !all && pressure < memsys.PressureHigh && time.Duration(now-sd.atime.Load()) < stageFlushIdle {
+			return true
+		}
+		if stg.flush(uname, sd, all) {
+			flushed++
+		}
+		return true
+	})
+	if flushed > 0 || dropped > 0 {
+		nlog.Infoln("staged data: flushed", flushed, "dropped", dropped, "[ pressure:", pressure, "]")
+	}
+}
+
+func (stg *stager) flush(uname string, sd *sdata, wait bool) bool {
+	lom, err := stg.lom(uname, sd)
+	if err != nil {
+		stg.discard(uname, sd)
+		return false
+	}
+	defer FreeLOM(lom)
+	if wait {
+		lom.Lock(true)
+	} else if !lom.TryLock(true) {
+		return false // busy, will retry next time around
+	}
+	err = lom.FlushStaged()
+	lom.Unlock(true)
+	if err != nil {
+		nlog.Errorln("failed to flush", lom.Cname(), "err:", err)
+		return false
+	}
+	return true
+}
+
+func (stg *stager) drop(uname string, sd *sdata) bool {
+	lom, err := stg.lom(uname, sd)
+	if err != nil {
+		stg.discard(uname, sd)
+		return true
+	}
+	defer FreeLOM(lom)
+	if !lom.TryLock(true) {
+		return false
+	}
+	lom.Uncache()
+	lom.unstage()
+	lom.Unlock(true)
+	nlog.Warningln("oom: dropping transient", lom.Cname())
+	g.tstats.Inc(LcacheDataDropCount)
+	return true
+}
+
+func (stg *stager) misplaced(uname string, sd *sdata, smap *meta.Smap) bool {
+	if smap == nil {
+		return false
+	}
+	lom, err := stg.lom(uname, sd)
+	if err != nil {
+		return false // (see discard)
+	}
+	_, local, err := lom.HrwTarget(smap)
+	FreeLOM(lom)
+	return err == nil && !local
+}
+
+func (*stager) lom(uname string, sd *sdata) (*LOM, error) {
+	lif := LIF{uname: uname, lid: sd.md.lid}
+	return lif.LOM()
+}
+
+// the bucket is gone (or has changed its identity)
+func (stg *stager) discard(uname string, sd *sdata) {
+	if stg.m.CompareAndDelete(uname, sd) {
+		stg.size.Sub(sd.sgl.Size())
+		sd.sgl.Free()
+	}
+}
+
+// flush all write-delayed content upon termination
+func (stg *stager) term() {
+	if stg.size.Load() == 0 {
+		return
+	}
+	stg.acquire()
+	stg.run(memsys.PressureLow, true /*all*/)
+}
+
+// wait for housekeeping (if running) to finish, and proceed regardless
+func (stg *stager) acquire() {
+	const sleep = time.Second >> 2
+	for i := 0; i < 8 && !stg.running.CAS(false, true); i++ {
+		time.Sleep(sleep)
+	}
+}
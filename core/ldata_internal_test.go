@@ -0,0 +1,269 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+const stgContent = "staged object content"
+
+// (core/mock cannot be used here)
+type (
+	stgTarget struct {
+		Target // (not implemented)
+		bmd    *meta.BMD
+	}
+	stgStats struct {
+		cos.StatsUpdater // ditto
+		counts           map[string]int64
+	}
+)
+
+func (t *stgTarget) Bowner() meta.Bowner     { return t }
+func (t *stgTarget) Get() *meta.BMD          { return t.bmd }
+func (*stgTarget) PageMM() *memsys.MMSA      { return memsys.PageMM() }
+func (*stgTarget) ByteMM() *memsys.MMSA      { return memsys.ByteMM() }
+func (*stgTarget) SID() string               { return "stg-target" }
+func (s *stgStats) Inc(name string)          { s.counts[name]++ }
+func (s *stgStats) Add(name string, v int64) { s.counts[name] += v }
+
+func (s *stgStats) AddMany(nvs ...cos.NamedVal64) {
+	for _, nv := range nvs {
+		s.counts[nv.Name] += nv.Value
+	}
+}
+
+func stgInit(t *testing.T, policy apc.WritePolicy) (*meta.Bck, *stgStats) {
+	fs.TestNew(nil)
+	_, err := fs.Add(t.TempDir(), "daeID")
+	tassert.CheckFatal(t, err)
+	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+
+	bck := meta.NewBck("stg-"+string(policy), apc.AIS, cmn.NsGlobal, &cmn.Bprops{
+		Cksum:       cmn.CksumConf{Type: cos.ChecksumNone},
+		WritePolicy: cmn.WritePolicyConf{Data: policy, MD: apc.WriteImmediate},
+	})
+	bmd := &meta.BMD{Version: 1, Providers: meta.Providers{apc.AIS: meta.Namespaces{cmn.NsGlobalUname: meta.Buckets{}}}}
+	bck.Props.BID = meta.NewBID(bmd.Version, true)
+	bmd.Add(bck)
+	errs := fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+	tassert.Fatalf(t, len(errs) == 0, "failed to create %s: %v", bck, errs)
+
+	stats := &stgStats{counts: make(map[string]int64, 4)}
+	Tinit(&stgTarget{bmd: bmd}, stats, nil /*config*/, false /*run HK*/)
+	t.Cleanup(func() { g.stg.m.Clear(); g.stg.size.Store(0) })
+	return bck, stats
+}
+
+func stgPut(t *testing.T, bck *meta.Bck, name string) *LOM {
+	lom := AllocLOM(name)
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+
+	sgl := g.pmm.NewSGL(0)
+	tassert.Fatalf(t, StageReserve(0, int64(len(stgContent))), "failed to reserve")
+	_, err := sgl.Write([]byte(stgContent))
+	tassert.CheckFatal(t, err)
+
+	lom.Lock(true)
+	lom.SetSize(sgl.Size())
+	lom.SetCksum(cos.NoneCksum)
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	err = lom.Stage(sgl)
+	if err == nil {
+		err = lom.PersistMain()
+	}
+	lom.Unlock(true)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, lom.IsStaged(), "expected %s to be staged", lom)
+	return lom
+}
+
+// make it look idle
+func stgIdle(lom *LOM) {
+	sd := lom.staged()
+	sd.atime.Store(mono.NanoTime() - int64(2*stageFlushIdle))
+}
+
+func TestStageFlush(t *testing.T) {
+	bck, stats := stgInit(t, apc.WriteDelayed)
+	lom := stgPut(t, bck, "flush")
+	defer FreeLOM(lom)
+	tassert.Errorf(t, StagedSize() == int64(len(stgContent)), "expected staged size %d, got %d", len(stgContent), StagedSize())
+
+	// not idle
+	g.stg.run(memsys.PressureLow, false /*all*/)
+	tassert.Fatalf(t, lom.IsStaged(), "expected %s to remain staged", lom)
+
+	// busy (locked)
+	stgIdle(lom)
+	lom.Lock(false)
+	g.stg.run(memsys.PressureLow, false)
+	lom.Unlock(false)
+	tassert.Fatalf(t, lom.IsStaged(), "expected %s to remain staged (busy)", lom)
+
+	g.stg.run(memsys.PressureLow, false)
+	tassert.Fatalf(t, !lom.IsStaged(), "expected %s to be flushed", lom)
+	tassert.Errorf(t, StagedSize() == 0, "expected zero staged size, got %d", StagedSize())
+	tassert.Errorf(t, stats.counts[LcacheDataFlushCount] == 1 && stats.counts[LcacheDataFlushSize] == int64(len(stgContent)),
+		"expected flush stats (1, %d), got (%d, %d)", len(stgContent), stats.counts[LcacheDataFlushCount], stats.counts[LcacheDataFlushSize])
+
+	b, err := os.ReadFile(lom.FQN)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, string(b) == stgContent, "expected %q, got %q", stgContent, b)
+	tassert.CheckFatal(t, lom.Load(false, false))
+	tassert.Errorf(t, lom.Lsize() == int64(len(stgContent)), "expected size %d, got %d", len(stgContent), lom.Lsize())
+}
+
+func TestStageFlushPressure(t *testing.T) {
+	bck, _ := stgInit(t, apc.WriteDelayed)
+	lom := stgPut(t, bck, "flush-pressure")
+	defer FreeLOM(lom)
+
+	// high pressure: flush regardless of idle time
+	g.stg.run(memsys.PressureHigh, false)
+	tassert.Fatalf(t, !lom.IsStaged(), "expected %s to be flushed", lom)
+	_, err := os.Stat(lom.FQN)
+	tassert.CheckFatal(t, err)
+}
+
+func TestStageDrop(t *testing.T) {
+	bck, stats := stgInit(t, apc.WriteNever)
+	lom := stgPut(t, bck, "drop")
+	defer FreeLOM(lom)
+
+	// transient content is never flushed
+	stgIdle(lom)
+	g.stg.run(memsys.PressureHigh, true /*all*/)
+	tassert.Fatalf(t, lom.IsStaged(), "expected %s to remain staged", lom)
+
+	g.stg.run(memsys.PressureExtreme, false)
+	tassert.Fatalf(t, !lom.IsStaged(), "expected %s to be dropped", lom)
+	tassert.Errorf(t, StagedSize() == 0, "expected zero staged size, got %d", StagedSize())
+	tassert.Errorf(t, stats.counts[LcacheDataDropCount] == 1, "expected drop count 1, got %d", stats.counts[LcacheDataDropCount])
+	_, err := os.Stat(lom.FQN)
+	tassert.Errorf(t, os.IsNotExist(err), "expected %s not to exist on disk, got %v", lom, err)
+}
+
+func TestStageTerm(t *testing.T) {
+	bck, _ := stgInit(t, apc.WriteDelayed)
+	lom := stgPut(t, bck, "term")
+	defer FreeLOM(lom)
+
+	// busy
+	g.stg.running.Store(true)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		g.stg.running.Store(false)
+	}()
+	g.stg.term()
+	tassert.Fatalf(t, !lom.IsStaged(), "expected %s to be flushed", lom)
+	tassert.Errorf(t, StagedSize() == 0, "expected zero staged size, got %d", StagedSize())
+	b, err := os.ReadFile(lom.FQN)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, string(b) == stgContent, "expected %q, got %q", stgContent, b)
+}
+
+func TestStageLimits(t *testing.T) {
+	bck, _ := stgInit(t, apc.WriteDelayed)
+	limit := g.stg.limit
+	defer func() { g.stg.limit = limit }()
+	g.stg.limit = 10 * cos.KiB
+
+	// per object
+	tassert.Errorf(t, !StageReserve(stageMaxObjSize, 1), "expected per-object limit")
+	tassert.Errorf(t, StagedSize() == 0, "expected zero staged size, got %d", StagedSize())
+
+	// total (add-then-rollback)
+	tassert.Errorf(t, StageReserve(0, 8*cos.KiB), "expected to reserve")
+	tassert.Errorf(t, !StageReserve(8*cos.KiB, 4*cos.KiB), "expected total limit")
+	tassert.Errorf(t, StagedSize() == 8*cos.KiB, "expected staged size %d, got %d", 8*cos.KiB, StagedSize())
+
+	// StageOK (and low memory pressure)
+	for _, test := range []struct {
+		size  int64
+		stage bool
+	}{
+		{-1, true}, // (unknown)
+		{cos.KiB, true},
+		{4 * cos.KiB, false},
+		{stageMaxObjSize + 1, false},
+	} {
+		ok, err := stageOK(apc.WriteDelayed, test.size, memsys.PressureLow)
+		tassert.Errorf(t, ok == test.stage && err == nil, "delayed, size %d: expected (%t, nil), got (%t, %v)", test.size, test.stage, ok, err)
+		ok, err = stageOK(apc.WriteNever, test.size, memsys.PressureLow)
+		tassert.Errorf(t, ok == test.stage && (err == nil) == test.stage, "never, size %d: expected (%t, %t), got (%t, %v)",
+			test.size, test.stage, test.stage, ok, err)
+	}
+
+	// high memory pressure
+	ok, err := stageOK(apc.WriteDelayed, cos.KiB, memsys.PressureHigh)
+	tassert.Errorf(t, !ok && err == nil, "delayed: expected (false, nil), got (%t, %v)", ok, err)
+	_, err = stageOK(apc.WriteNever, cos.KiB, memsys.PressureHigh)
+	tassert.Errorf(t, err == ErrStageNoMem, "never: expected %v, got %v", ErrStageNoMem, err)
+
+	// immediate and encrypted
+	lom := AllocLOM("limits")
+	defer FreeLOM(lom)
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+	bck.Props.SSE.Enabled = true
+	ok, err = lom.StageOK(cos.KiB)
+	bck.Props.SSE.Enabled = false
+	tassert.Errorf(t, !ok && err == nil, "encrypted: expected (false, nil), got (%t, %v)", ok, err)
+}
+
+// rebalance: write-never objects that belong elsewhere get flushed so that
+// the mountpath walk can see (and send) them; the rest remain staged
+func TestStageFlushRebalance(t *testing.T) {
+	bck, _ := stgInit(t, apc.WriteNever)
+	smap := &meta.Smap{Tmap: make(meta.NodeMap, 2), Version: 2}
+	for _, tid := range []string{"stg-target", "other-target"} {
+		tsi := &meta.Snode{}
+		tsi.Init(tid, apc.Target)
+		smap.Tmap[tid] = tsi
+	}
+	loms := make([]*LOM, 0, 16)
+	for i := range 16 {
+		lom := stgPut(t, bck, "reb-"+strconv.Itoa(i))
+		defer FreeLOM(lom)
+		loms = append(loms, lom)
+	}
+	names := StagedNames(bck.Bucket(), "reb-1")
+	tassert.Errorf(t, len(names) == 7 && names[0] == "reb-1" && names[6] == "reb-15", "unexpected staged names %v", names)
+
+	FlushAllStaged(nil) // (write-delayed only)
+	tassert.Errorf(t, len(StagedNames(bck.Bucket(), "")) == len(loms), "expected all write-never objects to remain staged")
+
+	FlushAllStaged(smap)
+	var moved int
+	for _, lom := range loms {
+		_, local, err := lom.HrwTarget(smap)
+		tassert.CheckFatal(t, err)
+		if local {
+			tassert.Errorf(t, lom.IsStaged(), "expected %s to remain staged", lom)
+			continue
+		}
+		moved++
+		tassert.Errorf(t, !lom.IsStaged(), "expected misplaced %s to be flushed", lom)
+		b, err := os.ReadFile(lom.FQN)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, string(b) == stgContent, "expected %q, got %q", stgContent, b)
+	}
+	tassert.Fatalf(t, moved > 0 && moved < len(loms), "expected some (but not all) objects to be misplaced, got %d", moved)
+	tassert.Errorf(t, StagedSize() == int64((len(loms)-moved)*len(stgContent)), "unexpected staged size %d", StagedSize())
+}
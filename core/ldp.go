@@ -49,8 +49,24 @@ func (r *deferROC) Close() (err error) {
 }
 
 // is called under rlock; unlocks on fail
-func (lom *LOM) NewDeferROC() (cos.ReadOpenCloser, error) {
-	fh, err := cos.NewFileHandle(lom.FQN)
+func (lom *LOM) NewDeferROC() (cos.ReadOpenCloser, error) { return lom.newDeferROC(false) }
+
+// same as above, without decrypting (see lsse.go)
+func (lom *LOM) NewDeferRawROC() (cos.ReadOpenCloser, error) { return lom.newDeferROC(true) }
+
+func (lom *LOM) newDeferROC(raw bool) (cos.ReadOpenCloser, error) {
+	if r := lom.openStaged(); r != nil {
+		return &deferROC{r, lom.LIF()}, nil
+	}
+	var (
+		fh  LomHandle
+		err error
+	)
+	if raw {
+		fh, err = lom.NewRawHandle()
+	} else {
+		fh, err = lom.NewHandle()
+	}
 	if err == nil {
 		return &deferROC{fh, lom.LIF()}, nil
 	}
@@ -9,10 +9,13 @@ import (
 	"os"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
 )
 
 const (
@@ -37,7 +40,19 @@ func (e *errBdir) Error() string {
 //
 
 func (lom *LOM) Open() (fh cos.LomReader, err error) {
-	fh, err = os.Open(lom.FQN)
+	if r := lom.openStaged(); r != nil {
+		return r, nil
+	}
+	if lom.md.chunks != nil {
+		if lom.md.sse == nil {
+			return lom.openChunks()
+		}
+		if fh, err = lom.openChunks(); err != nil {
+			return nil, err
+		}
+		return lom.decrypt(fh)
+	}
+	fh, err = lom.OpenFQN(lom.FQN)
 	if err == nil || !os.IsNotExist(err) {
 		return fh, err
 	}
@@ -47,6 +62,15 @@ func (lom *LOM) Open() (fh cos.LomReader, err error) {
 	return nil, err
 }
 
+// open a given (main or mirror) replica, decrypt if need be
+func (lom *LOM) OpenFQN(fqn string) (cos.LomReader, error) {
+	fh, err := os.Open(fqn)
+	if err != nil || lom.md.sse == nil {
+		return fh, err
+	}
+	return lom.decrypt(fh)
+}
+
 //
 // create
 //
@@ -121,7 +145,14 @@ func (lom *LOM) RemoveObj(force ...bool) (err error) {
 		return len(force) > 0 && force[0] && lom.isLockedRW()
 	})
 	lom.Uncache()
+	lom.unstage()
 	err = lom.RemoveMain()
+	if lc := lom.md.chunks; lc != nil {
+		lom.md.chunks = nil
+		if erc := lom.rmChunks(lc); erc != nil && err == nil {
+			err = erc
+		}
+	}
 	for copyFQN := range lom.md.copies {
 		if erc := cos.RemoveFile(copyFQN); erc != nil && !os.IsNotExist(erc) && err == nil {
 			err = erc
@@ -131,6 +162,82 @@ func (lom *LOM) RemoveObj(force ...bool) (err error) {
 	return err
 }
 
+//
+// soft delete
+//
+
+// soft-deleted object remains on its (HRW) mountpath as `fs.DeletedType` content
+func (lom *LOM) DeletedFQN() string { return fs.CSM.Gen(lom, fs.DeletedType, "") }
+
+// SoftDelete moves the main replica aside (see DeletedFQN) while removing all other copies;
+// modification time of the soft-deleted object is the time of its deletion
+// (caller must wlock and load)
+func (lom *LOM) SoftDelete() error {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	if err := lom.FlushStaged(); err != nil {
+		return err
+	}
+	delFQN := lom.DeletedFQN()
+	if err := lom.RenameMainTo(delFQN); err != nil {
+		if os.IsNotExist(err) {
+			return lom.RemoveObj()
+		}
+		return err
+	}
+	lom.Uncache()
+	for copyFQN := range lom.md.copies {
+		if copyFQN == lom.FQN {
+			continue
+		}
+		if err := cos.RemoveFile(copyFQN); err != nil {
+			nlog.Errorln("failed to remove copy", copyFQN, "of soft-deleted", lom.Cname(), "err:", err)
+		}
+	}
+	lom.md.copies = nil
+
+	// (write-delayed and write-never objects may not have their metadata persisted)
+	buf := lom.pack()
+	err := fs.SetXattr(delFQN, XattrLOM, buf)
+	g.smm.Free(buf)
+	if err == nil {
+		now := time.Now()
+		err = os.Chtimes(delFQN, now, now)
+	}
+	lom.md.lid = 0
+	return err
+}
+
+// LoadDeleted loads metadata of the soft-deleted object (compare with FromFS)
+func (lom *LOM) LoadDeleted() (deleted time.Time, err error) {
+	fqn := lom.FQN
+	lom.FQN = lom.DeletedFQN()
+	_, _, deleted, err = lom.Fstat(false /*get-atime*/)
+	if err == nil {
+		if _, err = lom.lmfs(true); err == nil {
+			lom.setbid(lom.Bprops().BID)
+		}
+	}
+	lom.FQN = fqn
+	return deleted, err
+}
+
+// Undelete restores soft-deleted object (caller must wlock and make sure
+// the object does not exist)
+func (lom *LOM) Undelete() error {
+	debug.Assert(lom.isLockedExcl(), lom.Cname())
+	if _, err := lom.LoadDeleted(); err != nil {
+		if os.IsNotExist(err) {
+			return cos.NewErrNotFound(T, lom.Cname()+" (soft-deleted)")
+		}
+		return err
+	}
+	if err := lom.RenameToMain(lom.DeletedFQN()); err != nil {
+		T.FSHC(err, lom.Mountpath(), lom.FQN)
+		return err
+	}
+	return lom.Load(true /*cache it*/, true /*locked*/)
+}
+
 //
 // rename
 //
@@ -152,5 +259,6 @@ func (lom *LOM) RenameFinalize(wfqn string) error {
 		T.FSHC(err, lom.Mountpath(), wfqn)
 		return cmn.NewErrFailedTo(T, "finalize", lom.Cname(), err)
 	}
+	lom.dropChunks()
 	return nil
 }
@@ -45,11 +45,19 @@ const (
 	LcacheEvictedCount   = "lcache.evicted.n"
 	LcacheErrCount       = "err.lcache.n" // errPrefix + "lcache.n"
 	LcacheFlushColdCount = "lcache.flush.cold.n"
+
+	// staged object data (write_policy.data = (delayed | never))
+	LcacheDataPendingSize = "lcache.data.pending.size"
+	LcacheDataFlushCount  = "lcache.data.flush.n"
+	LcacheDataFlushSize   = "lcache.data.flush.size"
+	LcacheDataDropCount   = "lcache.data.drop.n"
 )
 
 type (
-	lmeta struct { // sizeof = 72
+	lmeta struct { // sizeof = 88
 		copies fs.MPI
+		chunks *lchunks // chunk manifest (nil if not chunked - see lchunk.go)
+		sse    *lsse    // encryption metadata (nil if not encrypted - see lsse.go)
 		uname  *string
 		cmn.ObjAttrs
 		atimefs uint64 // (high bit `lomDirtyMask` | int64: atime)
@@ -73,6 +81,8 @@ type (
 		maxLmeta atomic.Int64
 		locker   nameLocker
 		lchk     lchk
+		stg      stager
+		sse      sseKeys
 	}
 )
 
@@ -105,9 +115,11 @@ func Tinit(t Target, tstats cos.StatsUpdater, config *cmn.Config, runHK bool) {
 		g.tstats = tstats
 		g.pmm = t.PageMM()
 		g.smm = t.ByteMM()
+		g.stg.setLimit()
 	}
 	if runHK {
 		g.lchk.init(config)
+		g.stg.init()
 	}
 	for i := range recordSepa {
 		recdupSepa[i] = recordSepa[i]
@@ -119,6 +131,7 @@ func Term() {
 	for i := 0; i < 8 && !g.lchk.running.CAS(false, true); i++ {
 		time.Sleep(sleep)
 	}
+	g.stg.term()
 	g.lchk.term()
 }
 
@@ -210,10 +223,10 @@ func (lom *LOM) Bucket() *cmn.Bck         { return (*cmn.Bck)(&lom.bck) }
 func (lom *LOM) Mountpath() *fs.Mountpath { return lom.mi }
 func (lom *LOM) Location() string         { return T.String() + apc.LocationPropSepa + lom.mi.String() }
 
-// chunks vs whole // TODO -- FIXME: NIY
+// chunks vs whole (see lchunk.go)
 func (lom *LOM) IsChunked(special ...bool) bool {
 	debug.Assert(len(special) > 0 || lom.loaded())
-	return false
+	return lom.md.chunks != nil
 }
 
 func ParseObjLoc(loc string) (tname, mpname string) {
@@ -422,7 +435,11 @@ func (lom *LOM) Load(cacheit, locked bool) error {
 		defer lom.Unlock(false)
 	}
 	if err := lom.FromFS(); err != nil {
-		return err
+		sd := lom.staged()
+		if sd == nil || !os.IsNotExist(err) {
+			return err
+		}
+		lom.md = sd.md // staged and (since) uncached
 	}
 	if lom.bid() == 0 {
 		// copies, etc.
@@ -470,7 +487,12 @@ func (lom *LOM) LoadUnsafe() (err error) {
 
 	// read and decode xattr; NOTE: fs.GetXattr* vs fs.SetXattr race possible and must be
 	// either a) handled or b) benign from the caller's perspective
-	if _, err = lom.lmfs(true); err == nil {
+	if _, err = lom.lmfs(true); err != nil {
+		if sd := lom.staged(); sd != nil {
+			lom.md, err = sd.md, nil
+		}
+	}
+	if err == nil {
 		if lom.bid() == 0 {
 			// copies, etc.
 			lom.setbid(lom.Bprops().BID)
@@ -575,7 +597,11 @@ func (lom *LOM) FromFS() error {
 		return err
 	}
 	// fstat & atime
-	if lom.md.Size != size { // corruption or tampering
+	if lom.md.chunks != nil {
+		if lom.md.chunks.size != size { // (the first chunk)
+			return cmn.NewErrLmetaCorrupted(lom.whingeSize(size))
+		}
+	} else if lom.md.rawSize() != size { // corruption or tampering
 		return cmn.NewErrLmetaCorrupted(lom.whingeSize(size))
 	}
 	lom.md.Atime = atimefs
@@ -585,7 +611,7 @@ func (lom *LOM) FromFS() error {
 }
 
 func (lom *LOM) whingeSize(size int64) error {
-	return fmt.Errorf("errsize (%d != %d)", lom.md.Size, size)
+	return fmt.Errorf("errsize (%d != %d)", lom.md.rawSize(), size)
 }
 
 //
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -21,6 +22,7 @@ import (
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/core/mock"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -33,16 +35,24 @@ var _ = Describe("LOM", func() {
 		bucketLocalA = "LOM_TEST_Local_A"
 		bucketLocalB = "LOM_TEST_Local_B"
 		bucketLocalC = "LOM_TEST_Local_C"
+		bucketLocalE = "LOM_TEST_Local_SSE"
+		bucketLocalW = "LOM_TEST_Local_WORM"
+		bucketLocalK = "LOM_TEST_Local_Chunks"
 
 		bucketCloudA = "LOM_TEST_Cloud_A"
 		bucketCloudB = "LOM_TEST_Cloud_B"
 
 		sameBucketName = "LOM_TEST_Local_and_Cloud"
+
+		sseKeyFile = "/tmp/lom_test_sse.key"
 	)
 
 	var (
 		localBckA = cmn.Bck{Name: bucketLocalA, Provider: apc.AIS, Ns: cmn.NsGlobal}
 		localBckB = cmn.Bck{Name: bucketLocalB, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		localBckE = cmn.Bck{Name: bucketLocalE, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		localBckW = cmn.Bck{Name: bucketLocalW, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		localBckK = cmn.Bck{Name: bucketLocalK, Provider: apc.AIS, Ns: cmn.NsGlobal}
 		cloudBckA = cmn.Bck{Name: bucketCloudA, Provider: apc.AWS, Ns: cmn.NsGlobal}
 	)
 
@@ -62,6 +72,7 @@ var _ = Describe("LOM", func() {
 
 	config := cmn.GCO.BeginUpdate()
 	config.TestFSP.Count = 1
+	config.SSE.KeySources = []string{cmn.SSEKeyFile + sseKeyFile}
 	cmn.GCO.CommitUpdate(config)
 
 	fs.TestNew(nil)
@@ -71,6 +82,8 @@ var _ = Describe("LOM", func() {
 
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	fs.CSM.Reg(fs.DeletedType, &fs.DeletedContentResolver{}, true)
+	fs.CSM.Reg(fs.ChunkType, &fs.ChunkContentResolver{}, true)
 
 	bmd := mock.NewBaseBownerMock(
 		meta.NewBck(
@@ -94,6 +107,30 @@ var _ = Describe("LOM", func() {
 		meta.NewBck(bucketCloudA, apc.AWS, cmn.NsGlobal, &cmn.Bprops{BID: 5}),
 		meta.NewBck(bucketCloudB, apc.AWS, cmn.NsGlobal, &cmn.Bprops{BID: 6}),
 		meta.NewBck(sameBucketName, apc.AWS, cmn.NsGlobal, &cmn.Bprops{BID: 7}),
+		meta.NewBck(
+			bucketLocalE, apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{
+				Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash},
+				SSE:   cmn.SSEConf{Enabled: true, KeySource: cmn.SSEKeyFile + sseKeyFile},
+				BID:   8,
+			},
+		),
+		meta.NewBck(
+			bucketLocalW, apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{
+				Cksum:   cmn.CksumConf{Type: cos.ChecksumXXHash},
+				ObjLock: cmn.ObjLockConf{Enabled: true, Mode: cmn.ObjLockGovernance, Days: 1},
+				BID:     9,
+			},
+		),
+		meta.NewBck(
+			bucketLocalK, apc.AIS, cmn.NsGlobal,
+			&cmn.Bprops{
+				Cksum:  cmn.CksumConf{Type: cos.ChecksumXXHash},
+				Chunks: cmn.ChunksConf{ObjSizeLimit: 128 * cos.KiB, ChunkSize: 64 * cos.KiB}, // (see "chunked layout")
+				BID:    10,
+			},
+		),
 	)
 
 	BeforeEach(func() {
@@ -995,6 +1032,486 @@ var _ = Describe("LOM", func() {
 		})
 	})
 
+	Describe("soft delete", func() {
+		const (
+			testObject = "foldr/test-obj-deleted.ext"
+			size       = 256
+		)
+		It("should soft-delete and undelete object", func() {
+			hlom := &core.LOM{ObjName: testObject}
+			Expect(hlom.InitBck(&localBckB)).NotTo(HaveOccurred())
+			lom := filePut(hlom.FQN, size)
+			ver := lom.Version()
+
+			lom.Lock(true)
+			Expect(lom.Load(false, true)).NotTo(HaveOccurred())
+			Expect(lom.SoftDelete()).NotTo(HaveOccurred())
+			lom.Unlock(true)
+			Expect(lom.FQN).NotTo(BeAnExistingFile())
+			Expect(lom.DeletedFQN()).To(BeAnExistingFile())
+
+			// gone but can be loaded as deleted
+			lom = NewBasicLom(hlom.FQN)
+			Expect(cos.IsNotExist(lom.Load(false, false), 0)).To(BeTrue())
+			deleted, err := lom.LoadDeleted()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(time.Since(deleted)).To(BeNumerically("<", time.Minute))
+			Expect(lom.Lsize()).To(BeEquivalentTo(size))
+			Expect(lom.Version()).To(Equal(ver))
+
+			lom = NewBasicLom(hlom.FQN)
+			lom.Lock(true)
+			Expect(lom.Undelete()).NotTo(HaveOccurred())
+			lom.Unlock(true)
+			Expect(lom.FQN).To(BeAnExistingFile())
+			Expect(lom.DeletedFQN()).NotTo(BeAnExistingFile())
+
+			lom = NewBasicLom(hlom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.Lsize()).To(BeEquivalentTo(size))
+			Expect(lom.Version()).To(Equal(ver))
+
+			// nothing to undelete
+			lom.Lock(true)
+			Expect(lom.RemoveObj()).NotTo(HaveOccurred())
+			err = lom.Undelete()
+			lom.Unlock(true)
+			Expect(cos.IsNotExist(err, 0)).To(BeTrue())
+		})
+	})
+
+	Describe("chunked layout", func() {
+		const (
+			testObject = "foldr/test-obj-chunked.ext"
+			chunkSize  = 64 * cos.KiB // (see localBckK)
+			size       = 3*chunkSize + chunkSize/2
+		)
+		// chunk files by chunk number
+		findChunks := func() map[int]string {
+			chunks := make(map[int]string, 4)
+			for _, mpath := range mpaths {
+				_ = filepath.WalkDir(mpath, func(path string, de os.DirEntry, _ error) error {
+					if de == nil || de.IsDir() || !strings.Contains(path, "/%"+fs.ChunkType+"/") {
+						return nil
+					}
+					id := path[strings.LastIndexByte(path, '.')+1:]
+					snum, _, _ := strings.Cut(id, "-")
+					num, err := strconv.Atoi(snum)
+					Expect(err).NotTo(HaveOccurred())
+					chunks[num] = path
+					return nil
+				})
+			}
+			return chunks
+		}
+		countChunks := func() int { return len(findChunks()) }
+		countWorkfiles := func() (n int) {
+			for _, mpath := range mpaths {
+				_ = filepath.WalkDir(mpath, func(path string, de os.DirEntry, _ error) error {
+					if de != nil && !de.IsDir() && strings.Contains(path, "/%"+fs.WorkfileType+"/") {
+						n++
+					}
+					return nil
+				})
+			}
+			return n
+		}
+		// PUT: write via chunk writer (size is -1 when unknown)
+		putChunked := func(lom *core.LOM, content []byte, size int64) {
+			wfqn := fs.CSM.Gen(lom, fs.WorkfileType, "test")
+			cw, err := lom.NewChunkWriter(wfqn, size)
+			Expect(err).NotTo(HaveOccurred())
+			// (odd-sized writes)
+			for b := content; len(b) > 0; {
+				l := min(len(b), 10000)
+				_, err := cw.Write(b[:l])
+				Expect(err).NotTo(HaveOccurred())
+				b = b[l:]
+			}
+			Expect(cw.Close()).NotTo(HaveOccurred())
+
+			lom.Lock(true)
+			lom.SetSize(int64(len(content)))
+			lom.SetCksum(cos.NoneCksum)
+			Expect(lom.ChunkFinalize(cw)).NotTo(HaveOccurred())
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			lom.Unlock(true)
+			Expect(wfqn).NotTo(BeAnExistingFile())
+			Expect(countWorkfiles()).To(BeZero())
+		}
+		readAll := func(lom *core.LOM) []byte {
+			lom = NewBasicLom(lom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			fh, err := lom.Open()
+			Expect(err).NotTo(HaveOccurred())
+			b, err := io.ReadAll(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fh.Close()).NotTo(HaveOccurred())
+			return b
+		}
+		BeforeEach(func() {
+			errs := fs.CreateBucket(&localBckK, false /*nilbmd*/)
+			Expect(errs).To(BeEmpty())
+		})
+		newContent := func(size int) []byte {
+			b := make([]byte, size)
+			_, _ = cryptorand.Read(b)
+			return b
+		}
+
+		It("should store, read, overwrite, and remove chunked object", func() {
+			lom := &core.LOM{ObjName: testObject}
+			Expect(lom.InitBck(&localBckK)).NotTo(HaveOccurred())
+			content := newContent(size)
+			putChunked(lom, content, size)
+
+			// directly on their respective HRW mountpaths
+			chunks := findChunks()
+			Expect(chunks).To(HaveLen(3))
+			for num, path := range chunks {
+				mi, _, err := fs.Hrw(cos.UnsafeB(lom.Uname() + "." + strconv.Itoa(num)))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(path).To(HavePrefix(mi.Path + "/"))
+			}
+
+			// load and read
+			lom = NewBasicLom(lom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.IsChunked()).To(BeTrue())
+			Expect(lom.NumChunks()).To(Equal(4))
+			Expect(lom.Lsize()).To(BeEquivalentTo(size))
+			finfo, err := os.Stat(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finfo.Size()).To(BeEquivalentTo(chunkSize))
+
+			fh, err := lom.Open()
+			Expect(err).NotTo(HaveOccurred())
+			b, err := io.ReadAll(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(content))
+
+			// read range spanning two chunks
+			rng := make([]byte, chunkSize)
+			n, err := fh.ReadAt(rng, chunkSize+chunkSize/2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(chunkSize))
+			Expect(rng).To(Equal(content[chunkSize+chunkSize/2 : 2*chunkSize+chunkSize/2]))
+			Expect(fh.Close()).NotTo(HaveOccurred())
+
+			// with checksum
+			wfqn := fs.CSM.Gen(lom, fs.WorkfileType, "test")
+			Expect(os.WriteFile(wfqn, content, 0o600)).NotTo(HaveOccurred())
+			hash := getTestFileHash(wfqn)
+			Expect(os.Remove(wfqn)).NotTo(HaveOccurred())
+			lom.Lock(true)
+			lom.SetCksum(cos.NewCksum(cos.ChecksumXXHash, hash))
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			lom.Unlock(true)
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+
+			// overwrite with a regular (non-chunked) object
+			wfqn = fs.CSM.Gen(lom, fs.WorkfileType, "test")
+			createTestFile(wfqn, chunkSize)
+			lom.Lock(true)
+			lom.SetSize(chunkSize)
+			Expect(lom.RenameFinalize(wfqn)).NotTo(HaveOccurred())
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			lom.Unlock(true)
+			Expect(countChunks()).To(Equal(0))
+
+			lom = NewBasicLom(lom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.IsChunked()).To(BeFalse())
+
+			// chunked again (fully written work file), and remove
+			wfqn = fs.CSM.Gen(lom, fs.WorkfileType, "test")
+			createTestFile(wfqn, size)
+			content, err = os.ReadFile(wfqn)
+			Expect(err).NotTo(HaveOccurred())
+			lom.Lock(true)
+			lom.SetSize(size)
+			lom.SetCksum(cos.NoneCksum)
+			Expect(lom.SplitFinalize(wfqn)).NotTo(HaveOccurred())
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			lom.Unlock(true)
+			Expect(countChunks()).To(Equal(3))
+			Expect(countWorkfiles()).To(BeZero())
+			Expect(readAll(lom)).To(Equal(content))
+
+			lom.Lock(true)
+			Expect(lom.RemoveObj()).NotTo(HaveOccurred())
+			lom.Unlock(true)
+			Expect(lom.FQN).NotTo(BeAnExistingFile())
+			Expect(countChunks()).To(Equal(0))
+		})
+
+		It("should write content of unknown size", func() {
+			lom := &core.LOM{ObjName: testObject}
+			Expect(lom.InitBck(&localBckK)).NotTo(HaveOccurred())
+
+			// not exceeding objsize_limit: not chunked
+			content := newContent(2*chunkSize - 1)
+			putChunked(lom, content, -1)
+			Expect(countChunks()).To(Equal(0))
+			Expect(readAll(lom)).To(Equal(content))
+
+			// exceeding
+			content = newContent(size)
+			putChunked(lom, content, -1)
+			Expect(countChunks()).To(Equal(3))
+			Expect(readAll(lom)).To(Equal(content))
+
+			lom.Lock(true)
+			Expect(lom.RemoveObj()).NotTo(HaveOccurred())
+			lom.Unlock(true)
+			Expect(countChunks()).To(Equal(0))
+		})
+
+		DescribeTable("should limit the number of chunks",
+			func(known bool) {
+				lom := &core.LOM{ObjName: testObject}
+				Expect(lom.InitBck(&localBckK)).NotTo(HaveOccurred())
+
+				// more than the max number of chunks that fit xattr (given xxhash)
+				var (
+					content = newContent((memsys.MaxSmallSlabSize/2/(2*8+1) + 2) * chunkSize)
+					csize   = int64(-1)
+				)
+				if known {
+					csize = int64(len(content))
+				}
+				putChunked(lom, content, csize)
+
+				lom = NewBasicLom(lom.FQN)
+				Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+				Expect(lom.IsChunked()).To(BeTrue())
+				Expect(lom.NumChunks() * chunkSize).To(BeNumerically("<", len(content)))
+				Expect(readAll(lom)).To(Equal(content))
+
+				lom.Lock(true)
+				Expect(lom.RemoveObj()).NotTo(HaveOccurred())
+				lom.Unlock(true)
+				Expect(countChunks()).To(Equal(0))
+			},
+			Entry("size known in advance", true),
+			Entry("size unknown", false),
+		)
+	})
+
+	Describe("server-side encryption", func() {
+		const (
+			testObject = "foldr/test-obj-sse.ext"
+			size       = 100*cos.KiB + 17
+		)
+		BeforeEach(func() {
+			key := make([]byte, 32)
+			_, _ = cryptorand.Read(key)
+			Expect(os.WriteFile(sseKeyFile, key, 0o600)).NotTo(HaveOccurred())
+		})
+		AfterEach(func() {
+			_ = os.Remove(sseKeyFile)
+		})
+		It("should encrypt at rest and read plaintext", func() {
+			lom := &core.LOM{ObjName: testObject}
+			Expect(lom.InitBck(&localBckE)).NotTo(HaveOccurred())
+			wfqn := fs.CSM.Gen(lom, fs.WorkfileType, "test")
+			createTestFile(wfqn, size)
+			hash := getTestFileHash(wfqn)
+			content, err := os.ReadFile(wfqn)
+			Expect(err).NotTo(HaveOccurred())
+
+			lom.Lock(true)
+			lom.SetSize(size)
+			lom.SetCksum(cos.NewCksum(cos.ChecksumXXHash, hash))
+			Expect(lom.EncryptWork(wfqn)).NotTo(HaveOccurred())
+			Expect(lom.RenameFinalize(wfqn)).NotTo(HaveOccurred())
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			lom.Unlock(true)
+
+			// at rest
+			ondisk, err := os.ReadFile(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ondisk).To(HaveLen(int(core.SSESize(size))))
+			Expect(lom.RawSize()).To(BeEquivalentTo(len(ondisk)))
+
+			// load and read
+			lom = NewBasicLom(lom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.IsEncrypted()).To(BeTrue())
+			Expect(lom.SSE()).NotTo(BeEmpty())
+
+			fh, err := lom.Open()
+			Expect(err).NotTo(HaveOccurred())
+			b, err := io.ReadAll(fh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(content))
+
+			// unaligned range
+			rng := make([]byte, 1000)
+			n, err := fh.ReadAt(rng, 4097)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(1000))
+			Expect(rng).To(Equal(content[4097 : 4097+1000]))
+			Expect(fh.Close()).NotTo(HaveOccurred())
+
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+
+			// raw (as is) handle
+			rh, err := lom.NewRawHandle()
+			Expect(err).NotTo(HaveOccurred())
+			b, err = io.ReadAll(rh)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(ondisk))
+			Expect(rh.Close()).NotTo(HaveOccurred())
+
+			// as-is content with carried-over metadata
+			packed := lom.SSE()
+			wfqn = fs.CSM.Gen(lom, fs.WorkfileType, "test")
+			Expect(os.WriteFile(wfqn, ondisk, 0o600)).NotTo(HaveOccurred())
+			lom.Lock(true)
+			Expect(lom.RenameFinalize(wfqn)).NotTo(HaveOccurred())
+			Expect(lom.SetSSE(packed)).NotTo(HaveOccurred())
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			lom.Unlock(true)
+
+			lom = NewBasicLom(lom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.SSE()).To(Equal(packed))
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+
+			Expect(lom.SetSSE("bad")).To(HaveOccurred())
+		})
+		It("should detect tampering", func() {
+			lom := &core.LOM{ObjName: testObject}
+			Expect(lom.InitBck(&localBckE)).NotTo(HaveOccurred())
+			wfqn := fs.CSM.Gen(lom, fs.WorkfileType, "test")
+			createTestFile(wfqn, size)
+
+			lom.Lock(true)
+			lom.SetSize(size)
+			lom.SetCksum(cos.NoneCksum)
+			Expect(lom.EncryptWork(wfqn)).NotTo(HaveOccurred())
+			Expect(lom.RenameFinalize(wfqn)).NotTo(HaveOccurred())
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			lom.Unlock(true)
+
+			ondisk, err := os.ReadFile(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			ondisk[len(ondisk)/2] ^= 0xff
+			Expect(os.WriteFile(lom.FQN, ondisk, 0o600)).NotTo(HaveOccurred())
+
+			lom = NewBasicLom(lom.FQN)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			fh, err := lom.Open()
+			Expect(err).NotTo(HaveOccurred())
+			_, err = io.ReadAll(fh)
+			Expect(err).To(HaveOccurred())
+			Expect(fh.Close()).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("object lock", func() {
+		const (
+			testObject   = "foldr/test-obj-worm.ext"
+			testFileSize = 123
+		)
+		future := func(d time.Duration) string { return time.Now().Add(d).UTC().Format(time.RFC3339) }
+
+		It("should apply default retention and enforce it", func() {
+			lom := filePut(mis[0].MakePathFQN(&localBckW, fs.ObjectType, testObject), testFileSize)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(lom.CheckRetained(false)).NotTo(HaveOccurred())
+
+			Expect(lom.SetDefaultRetention()).To(BeTrue())
+			Expect(lom.SetDefaultRetention()).To(BeFalse()) // (already has its own)
+			mode, _ := lom.GetCustomKey(cmn.LockModeObjMD)
+			Expect(mode).To(Equal(cmn.ObjLockGovernance))
+
+			err := lom.CheckRetained(false)
+			Expect(cmn.IsErrObjLocked(err)).To(BeTrue())
+			Expect(lom.CheckRetained(true /*bypass governance*/)).NotTo(HaveOccurred())
+
+			// legal hold cannot be bypassed
+			lom.SetCustomKey(cmn.LegalHoldObjMD, cmn.LegalHoldOn)
+			Expect(cmn.IsErrObjLocked(lom.CheckRetained(true))).To(BeTrue())
+
+			// expired
+			lom.SetCustomKey(cmn.LegalHoldObjMD, cmn.LegalHoldOff)
+			lom.SetCustomKey(cmn.RetainUntilObjMD, future(-time.Minute))
+			Expect(lom.CheckRetained(false)).NotTo(HaveOccurred())
+
+			// not enforced when object lock is disabled
+			other := filePut(mis[0].MakePathFQN(&localBckB, fs.ObjectType, testObject), testFileSize)
+			Expect(other.Load(false, false)).NotTo(HaveOccurred())
+			other.SetCustomMD(lom.GetCustomMD())
+			other.SetCustomKey(cmn.RetainUntilObjMD, future(time.Hour))
+			Expect(other.CheckRetained(false)).NotTo(HaveOccurred())
+			Expect(other.ValidateRetention(nil, other.GetCustomMD(), false)).To(HaveOccurred())
+		})
+
+		It("should validate retention updates", func() {
+			lom := filePut(mis[0].MakePathFQN(&localBckW, fs.ObjectType, testObject), testFileSize)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			gov := cos.StrKVs{cmn.LockModeObjMD: cmn.ObjLockGovernance, cmn.RetainUntilObjMD: future(time.Hour)}
+			comp := cos.StrKVs{cmn.LockModeObjMD: cmn.ObjLockCompliance, cmn.RetainUntilObjMD: future(time.Hour)}
+
+			// new
+			Expect(lom.ValidateRetention(nil, gov, false)).NotTo(HaveOccurred())
+			Expect(lom.ValidateRetention(nil, cos.StrKVs{cmn.LockModeObjMD: cmn.ObjLockGovernance,
+				cmn.RetainUntilObjMD: future(-time.Hour)}, false)).To(HaveOccurred())
+			Expect(lom.ValidateRetention(nil, cos.StrKVs{cmn.LockModeObjMD: cmn.ObjLockGovernance}, false)).To(HaveOccurred())
+			Expect(lom.ValidateRetention(nil, cos.StrKVs{cmn.LegalHoldObjMD: "yes"}, false)).To(HaveOccurred())
+
+			// governance: extend, upgrade, or shorten/remove when bypassed
+			longer := cos.StrKVs{cmn.LockModeObjMD: cmn.ObjLockGovernance, cmn.RetainUntilObjMD: future(2 * time.Hour)}
+			Expect(lom.ValidateRetention(gov, longer, false)).NotTo(HaveOccurred())
+			Expect(lom.ValidateRetention(gov, comp, false)).NotTo(HaveOccurred())
+			Expect(lom.ValidateRetention(longer, gov, false)).To(HaveOccurred())
+			Expect(lom.ValidateRetention(longer, gov, true)).NotTo(HaveOccurred())
+			Expect(lom.ValidateRetention(gov, cos.StrKVs{}, false)).To(HaveOccurred())
+			Expect(lom.ValidateRetention(gov, cos.StrKVs{}, true)).NotTo(HaveOccurred())
+
+			// compliance: extend only
+			Expect(lom.ValidateRetention(comp, gov, true)).To(HaveOccurred())
+			Expect(lom.ValidateRetention(comp, cos.StrKVs{}, true)).To(HaveOccurred())
+			Expect(lom.ValidateRetention(comp, cos.StrKVs{cmn.LockModeObjMD: cmn.ObjLockCompliance,
+				cmn.RetainUntilObjMD: future(2 * time.Hour)}, false)).NotTo(HaveOccurred())
+
+			// legal hold
+			held := cos.StrKVs{cmn.LegalHoldObjMD: cmn.LegalHoldOn}
+			for k, v := range comp {
+				held[k] = v
+			}
+			Expect(lom.ValidateRetention(comp, held, false)).NotTo(HaveOccurred())
+		})
+
+		It("should refuse to destroy bucket with retained objects", func() {
+			bck := meta.CloneBck(&localBckW)
+			Expect(bck.Init(core.T.Bowner())).NotTo(HaveOccurred())
+			Expect(core.CheckBckRetained(bck)).NotTo(HaveOccurred()) // (empty)
+
+			lom := filePut(mis[1].MakePathFQN(&localBckW, fs.ObjectType, testObject), testFileSize)
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			Expect(core.CheckBckRetained(bck)).NotTo(HaveOccurred()) // (not retained)
+
+			lom.SetCustomKey(cmn.LegalHoldObjMD, cmn.LegalHoldOn)
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			Expect(cmn.IsErrObjLocked(core.CheckBckRetained(bck))).To(BeTrue())
+
+			// governance retention (not bypassed)
+			lom.SetCustomKey(cmn.LegalHoldObjMD, cmn.LegalHoldOff)
+			lom.SetCustomKey(cmn.LockModeObjMD, cmn.ObjLockGovernance)
+			lom.SetCustomKey(cmn.RetainUntilObjMD, future(time.Hour))
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			Expect(cmn.IsErrObjLocked(core.CheckBckRetained(bck))).To(BeTrue())
+
+			// expired
+			lom.SetCustomKey(cmn.RetainUntilObjMD, future(-time.Minute))
+			Expect(persist(lom)).NotTo(HaveOccurred())
+			Expect(core.CheckBckRetained(bck)).NotTo(HaveOccurred())
+		})
+	})
+
 	Describe("local and cloud bucket with the same name", func() {
 		It("should have different fqn", func() {
 			testObject := "foldr/test-obj.ext"
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -63,6 +64,7 @@ const lomDirtyMask = uint64(1 << 63)
 const (
 	badLmeta = "bad lmeta"
 	badChunk = "bad lchunk"
+	badSSE   = "bad lsse"
 )
 
 // packing format: enum internal attrs
@@ -75,6 +77,7 @@ const (
 	packedCustom
 	packedNum
 	packedChunk
+	packedSSE
 )
 
 // packing format: separators
@@ -180,7 +183,7 @@ func (lom *LOM) unpack(b []byte, mdSize int64, populate bool) (md *lmeta, err er
 func (lom *LOM) PersistMain() (err error) {
 	atime := lom.AtimeUnix()
 	debug.Assert(cos.IsValidAtime(atime))
-	if atime < 0 /*prefetch*/ || !lom.WritePolicy().IsImmediate() /*write-never, write-delayed*/ {
+	if atime < 0 /*prefetch*/ || !lom.WritePolicy().IsImmediate() /*write-never, write-delayed*/ || lom.persistStaged() {
 		lom.md.makeDirty()
 		lom.Recache()
 		return
@@ -203,7 +206,7 @@ func (lom *LOM) Persist() (err error) {
 	atime := lom.AtimeUnix()
 	debug.Assert(cos.IsValidAtime(atime), atime)
 
-	if atime < 0 || !lom.WritePolicy().IsImmediate() {
+	if atime < 0 || !lom.WritePolicy().IsImmediate() || lom.persistStaged() {
 		lom.md.makeDirty()
 		if lom.Bprops() != nil {
 			if !lom.IsCopy() {
@@ -298,7 +301,7 @@ func (md *lmeta) unpack(buf []byte) error {
 		cksumType, cksumValue             string
 		haveSize, haveVersion, haveCopies bool
 		haveCksumType, haveCksumValue     bool
-		last                              bool
+		haveChunks, haveSSE, last         bool
 	)
 	if len(buf) < prefLen {
 		return fmt.Errorf("%s: too short (%d)", badLmeta, len(buf))
@@ -389,6 +392,26 @@ func (md *lmeta) unpack(buf []byte) error {
 				custom[entries[i]] = entries[i+1]
 			}
 			md.SetCustomMD(custom)
+		case packedChunk:
+			if haveChunks {
+				return errors.New(badChunk + " #1")
+			}
+			haveChunks = true
+			lc, err := _unpackChunks(string(record[cos.SizeofI16:]))
+			if err != nil {
+				return err
+			}
+			md.chunks = lc
+		case packedSSE:
+			if haveSSE {
+				return errors.New(badSSE + " #1")
+			}
+			haveSSE = true
+			sse, err := _unpackSSE(string(record[cos.SizeofI16:]))
+			if err != nil {
+				return err
+			}
+			md.sse = sse
 		default:
 			return errors.New(badLmeta + " #6")
 		}
@@ -400,6 +423,15 @@ func (md *lmeta) unpack(buf []byte) error {
 	if !haveSize {
 		return errors.New(badLmeta + " #8")
 	}
+	if !haveSSE {
+		md.sse = nil
+	}
+	if !haveChunks {
+		md.chunks = nil
+	} else if n, raw := int64(md.chunks.num()), md.rawSize(); raw <= (n-1)*md.chunks.size ||
+		(raw > n*md.chunks.size && n < int64(md.chunks.maxNum())) {
+		return errors.New(badChunk + " #5")
+	}
 	return nil
 }
 
@@ -436,6 +468,19 @@ func (md *lmeta) pack(mdSize int64) (buf []byte) {
 		buf = _packCustom(buf, custom)
 	}
 
+	// chunks
+	if md.chunks != nil {
+		buf = g.smm.Append(buf, recordSepa)
+		buf = _packRecord(buf, packedChunk, "", false)
+		buf = _packChunks(buf, md.chunks)
+	}
+
+	// encryption
+	if md.sse != nil {
+		buf = g.smm.Append(buf, recordSepa)
+		buf = _packRecord(buf, packedSSE, _packSSE(md.sse), false)
+	}
+
 	// checksum, prepend, and return
 	buf[0] = cmn.MetaverLOM
 	buf[1] = mdCksumTyXXHash
@@ -489,6 +534,37 @@ func _packCustom(buf []byte, md cos.StrKVs) []byte {
 	return buf
 }
 
+// chunk manifest: generation, chunk size, and per-chunk checksums (in order)
+func _packChunks(buf []byte, lc *lchunks) []byte {
+	buf = g.smm.Append(buf, lc.gen)
+	buf = g.smm.Append(buf, customSepa)
+	buf = g.smm.Append(buf, strconv.FormatInt(lc.size, 10))
+	buf = g.smm.Append(buf, customSepa)
+	for i, cksum := range lc.cksums {
+		if i > 0 {
+			buf = g.smm.Append(buf, stringSepa)
+		}
+		buf = g.smm.Append(buf, cksum)
+	}
+	return buf
+}
+
+func _unpackChunks(val string) (*lchunks, error) {
+	parts := strings.SplitN(val, customSepa, 3)
+	if len(parts) != 3 || parts[0] == "" {
+		return nil, errors.New(badChunk + " #2")
+	}
+	size, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || size <= 0 {
+		return nil, errors.New(badChunk + " #3")
+	}
+	lc := &lchunks{gen: parts[0], size: size, cksums: strings.Split(parts[2], stringSepa)}
+	if lc.num() < 2 {
+		return nil, errors.New(badChunk + " #4")
+	}
+	return lc, nil
+}
+
 // copy atime _iff_ valid and more recent
 func (md *lmeta) cpAtime(from *lmeta) {
 	if !cos.IsValidAtime(from.Atime) {
@@ -0,0 +1,183 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Object lock (WORM) - see cmn.ObjLockConf
+// - per-object retention (mode and retain-until date) and legal hold are stored as
+//   custom attributes (cmn.LockModeObjMD et al.) and travel with the object;
+// - new objects get the bucket's default retention unless they specify their own;
+// - retained objects cannot be deleted, evicted, overwritten, or renamed, and LRU skips them;
+// - governance retention can be shortened, removed, or bypassed (delete) only explicitly;
+//   compliance retention can only be extended; legal hold can be set and cleared;
+// - buckets that contain retained objects cannot be destroyed or evicted (see CheckBckRetained);
+// - all of the above is enforced only in buckets with enabled object lock.
+
+type Retention struct {
+	Until     time.Time
+	Mode      string
+	LegalHold bool
+}
+
+// ParseRetention parses object lock attributes of a given custom metadata
+func ParseRetention(md cos.StrKVs) (ret Retention, err error) {
+	if v, ok := md[cmn.LockModeObjMD]; ok {
+		if v != cmn.ObjLockGovernance && v != cmn.ObjLockCompliance {
+			return ret, fmt.Errorf("invalid %s %q (expecting %q or %q)", cmn.LockModeObjMD, v,
+				cmn.ObjLockGovernance, cmn.ObjLockCompliance)
+		}
+		ret.Mode = v
+	}
+	if v, ok := md[cmn.RetainUntilObjMD]; ok {
+		if ret.Until, err = time.Parse(time.RFC3339, v); err != nil {
+			return ret, fmt.Errorf("invalid %s %q: %v", cmn.RetainUntilObjMD, v, err)
+		}
+	}
+	if (ret.Mode == "") != ret.Until.IsZero() {
+		return ret, fmt.Errorf("%s and %s must be specified together", cmn.LockModeObjMD, cmn.RetainUntilObjMD)
+	}
+	if v, ok := md[cmn.LegalHoldObjMD]; ok {
+		switch v {
+		case cmn.LegalHoldOn:
+			ret.LegalHold = true
+		case cmn.LegalHoldOff:
+		default:
+			return ret, fmt.Errorf("invalid %s %q (expecting %q or %q)", cmn.LegalHoldObjMD, v,
+				cmn.LegalHoldOn, cmn.LegalHoldOff)
+		}
+	}
+	return ret, nil
+}
+
+func (ret *Retention) active(now time.Time) bool { return ret.Mode != "" && now.Before(ret.Until) }
+
+// CheckRetained returns cmn.ErrObjLocked if the (loaded) object is under
+// legal hold or retention; governance retention can be bypassed
+func (lom *LOM) CheckRetained(bypassGov bool) error {
+	if !lom.Bprops().ObjLock.Enabled {
+		return nil
+	}
+	ret, err := ParseRetention(lom.GetCustomMD())
+	if err != nil {
+		return fmt.Errorf("%s: %v", lom.Cname(), err)
+	}
+	switch {
+	case ret.LegalHold:
+		return cmn.NewErrObjLocked(lom.Cname(), "legal hold", time.Time{})
+	case !ret.active(time.Now()):
+		return nil
+	case ret.Mode == cmn.ObjLockGovernance && bypassGov:
+		return nil
+	}
+	return cmn.NewErrObjLocked(lom.Cname(), ret.Mode+" retention", ret.Until)
+}
+
+// CheckBckRetained walks the local objects of a bucket with enabled object lock
+// and returns cmn.ErrObjLocked upon the first one that is under legal hold or
+// active retention (governance retention included)
+func CheckBckRetained(bck *meta.Bck) error {
+	if !bck.Props.ObjLock.Enabled {
+		return nil
+	}
+	cb := func(fqn string, de fs.DirEntry) error {
+		if de.IsDir() {
+			return nil
+		}
+		lom := AllocLOM("")
+		defer FreeLOM(lom)
+		if err := lom.InitFQN(fqn, bck.Bucket()); err != nil {
+			if cmn.IsErrBucketLevel(err) {
+				return err
+			}
+			return nil
+		}
+		if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+			return nil
+		}
+		return lom.CheckRetained(false /*bypass governance*/)
+	}
+	for _, mi := range fs.GetAvail() {
+		opts := &fs.WalkOpts{Mi: mi, CTs: []string{fs.ObjectType}, Callback: cb}
+		opts.Bck.Copy(bck.Bucket())
+		if err := fs.Walk(opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateRetention validates object lock attributes of a new object (cur == nil)
+// or updated attributes of an existing one (cur => next)
+func (lom *LOM) ValidateRetention(cur, next cos.StrKVs, bypassGov bool) error {
+	nret, err := ParseRetention(next)
+	if err != nil {
+		return err
+	}
+	if !lom.Bprops().ObjLock.Enabled {
+		if (nret.Mode != "" || nret.LegalHold) && !_eqLock(cur, next) {
+			return fmt.Errorf("%s: object lock is not enabled (see bucket property 'object_lock')", lom.Bck().Cname(""))
+		}
+		return nil
+	}
+	now := time.Now()
+	if cur == nil {
+		if nret.Mode != "" && !nret.active(now) {
+			return errors.New("retain-until date must be in the future")
+		}
+		return nil
+	}
+	cret, err := ParseRetention(cur)
+	if err != nil || !cret.active(now) {
+		if nret.Mode != "" && !nret.active(now) && nret.Until != cret.Until {
+			return errors.New("retain-until date must be in the future")
+		}
+		return nil
+	}
+	// extending (or keeping) active retention is always permitted
+	if nret.Mode != "" && !nret.Until.Before(cret.Until) {
+		if nret.Mode == cret.Mode || cret.Mode == cmn.ObjLockGovernance {
+			return nil
+		}
+	}
+	if cret.Mode == cmn.ObjLockGovernance && bypassGov {
+		return nil
+	}
+	return cmn.NewErrObjLocked(lom.Cname(), cret.Mode+" retention", cret.Until)
+}
+
+func _eqLock(cur, next cos.StrKVs) bool {
+	for _, k := range []string{cmn.LockModeObjMD, cmn.RetainUntilObjMD, cmn.LegalHoldObjMD} {
+		if cur[k] != next[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// SetDefaultRetention applies the bucket's default retention (if configured)
+// to a new object that does not have its own
+func (lom *LOM) SetDefaultRetention() bool {
+	conf := &lom.Bprops().ObjLock
+	if !conf.Enabled || conf.Days == 0 {
+		return false
+	}
+	if _, ok := lom.GetCustomKey(cmn.LockModeObjMD); ok {
+		return false
+	}
+	until := time.Now().Add(time.Duration(conf.Days) * 24 * time.Hour)
+	lom.SetCustomKey(cmn.LockModeObjMD, conf.Mode)
+	lom.SetCustomKey(cmn.RetainUntilObjMD, until.UTC().Format(time.RFC3339))
+	return true
+}
@@ -0,0 +1,777 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Server-side encryption at rest (see cmn.SSEConf)
+// - each object gets its own random 256-bit data key; the latter is wrapped (AES-256-GCM)
+//   by the bucket key and stored in lmeta - along with the bucket key ID and random nonce;
+// - the content is encrypted with AES-256-GCM in fixed-size segments (sseSegSize), each
+//   sealed with its own nonce (derived from the object's nonce and segment number) and
+//   authentication tag - which makes range reads possible while detecting any tampering,
+//   including reordering and truncation (the last segment is additionally marked as such);
+// - stored (encrypted) size is, therefore, larger than the object size (see SSESize);
+//   object checksum is always computed over plaintext;
+// - mirror copies and EC slices and replicas (see ec.Metadata) carry encrypted content "as is";
+//   all other readers - GET, copy, rebalance, ETL, etc. - get plaintext (see SSEHandle);
+// - bucket keys are loaded from a local file (the same on all targets) or Vault-compatible
+//   HTTPS endpoint - either way, only those permitted by the cluster configuration
+//   (sse.key_sources): the current key followed by previous keys, if any - to keep decrypting
+//   objects encrypted with the latter (key rotation); all loaded keys are kept in the
+//   keyring by key ID (see bring) and periodically (sseKeyTTL) reloaded.
+
+const (
+	sseKeySize   = 32 // AES-256
+	sseKidSize   = 8  // bucket key ID: truncated SHA-256 of the key
+	sseNonceSize = 12 // GCM standard
+	sseTagSize   = 16 // ditto
+	sseSegSize   = 64 * cos.KiB
+	sseKeyTTL    = 10 * time.Minute
+	sseRetry     = 10 * time.Second // min interval between failed or forced (unknown key ID) reloads
+	sseTimeout   = 10 * time.Second
+	SSEEnvToken  = "AIS_SSE_TOKEN" // (optional) Vault token, to fetch bucket keys
+)
+
+type (
+	// encryption metadata (part of lmeta)
+	lsse struct {
+		kid   string // bucket key ID (hex)
+		wkey  []byte // wrapped data key: GCM nonce | encrypted key | tag
+		nonce []byte // content nonce (see _segNonce)
+	}
+
+	// decrypting reader (compare with ChunksHandle)
+	SSEHandle struct {
+		r     cos.LomReader // file or ChunksHandle
+		aead  cipher.AEAD
+		nonce []byte
+		buf   []byte // encrypted segment and, once opened, plaintext
+		slab  *memsys.Slab
+		plain []byte // decrypted segment #seg
+		size  int64  // object size
+		off   int64
+		seg   int64
+		mu    sync.Mutex
+	}
+
+	// encrypting writer (see lom.EncryptWriter)
+	sseWriter struct {
+		w      cos.LomWriter
+		aead   cipher.AEAD
+		nonce  []byte
+		buf    []byte
+		slab   *memsys.Slab
+		n      int   // buffered plaintext
+		seg    int64 // next segment to seal
+		sealed bool  // the last one
+	}
+
+	// bucket keyrings by key source
+	sseKeys struct {
+		m  map[string]*bring
+		mu sync.Mutex
+	}
+	bring struct {
+		cur    *bkey            // current (to encrypt)
+		m      map[string]*bkey // all loaded keys by ID (to decrypt)
+		loaded int64            // mono time: last successful load
+		tried  int64            // mono time: last attempt
+		mu     sync.RWMutex
+		lmu    sync.Mutex // serializes (re)loading
+	}
+	bkey struct {
+		aead cipher.AEAD
+		kid  string
+	}
+)
+
+// (unit tests only: to trust a test server's certificate)
+var sseClient *http.Client
+
+// interface guard
+var (
+	_ LomHandle     = (*SSEHandle)(nil)
+	_ cos.LomWriter = (*sseWriter)(nil)
+)
+
+func (lom *LOM) IsEncrypted() bool { return lom.md.sse != nil }
+
+// SSE returns packed encryption metadata (empty if not encrypted) - to carry
+// encrypted content along with it (see ec.Metadata)
+func (lom *LOM) SSE() string {
+	if lom.md.sse == nil {
+		return ""
+	}
+	return _packSSE(lom.md.sse)
+}
+
+// SetSSE sets encryption metadata of the content written "as is" (see SSE above)
+func (lom *LOM) SetSSE(packed string) (err error) {
+	if packed == "" {
+		lom.md.sse = nil
+		return nil
+	}
+	lom.md.sse, err = _unpackSSE(packed)
+	return err
+}
+
+// RawSize returns the size of the stored content: object size
+// unless encrypted (see SSESize)
+func (lom *LOM) RawSize() int64 { return lom.md.rawSize() }
+
+func (md *lmeta) rawSize() int64 {
+	if md.sse == nil {
+		return md.Size
+	}
+	return SSESize(md.Size)
+}
+
+// SSESize returns encrypted size of a given plaintext size:
+// one tag per segment (and a single tag for empty content)
+func SSESize(size int64) int64 {
+	return size + max(1, (size+sseSegSize-1)/sseSegSize)*sseTagSize
+}
+
+// SSEPlainSize is the inverse of SSESize
+func SSEPlainSize(raw int64) int64 {
+	const ssize = sseSegSize + sseTagSize
+	return max(0, raw-max(1, (raw+ssize-1)/ssize)*sseTagSize)
+}
+
+func _numSegs(size int64) int64 { return max(1, (size+sseSegSize-1)/sseSegSize) }
+
+// per-segment nonce: content nonce XOR big-endian segment number
+func _segNonce(nonce []byte, seg int64) []byte {
+	n := make([]byte, sseNonceSize)
+	copy(n, nonce)
+	binary.BigEndian.PutUint64(n[sseNonceSize-8:], binary.BigEndian.Uint64(nonce[sseNonceSize-8:])^uint64(seg))
+	return n
+}
+
+// additional data: whether it's the last segment (to detect truncation)
+func _segAD(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+//
+// write
+//
+
+// EncryptWriter wraps a given (work file) writer if the bucket is configured
+// for encryption; otherwise, returns the writer as is and clears encryption metadata
+// (that may be left over from the previous version)
+func (lom *LOM) EncryptWriter(w cos.LomWriter) (cos.LomWriter, bool, error) {
+	if !lom.Bprops().SSE.Enabled {
+		lom.md.sse = nil
+		return w, false, nil
+	}
+	md, aead, err := lom.newSSE()
+	if err != nil {
+		return nil, false, err
+	}
+	lom.md.sse = md
+	buf, slab := g.pmm.AllocSize(sseSegSize + sseTagSize)
+	return &sseWriter{w: w, aead: aead, nonce: md.nonce, buf: buf, slab: slab}, true, nil
+}
+
+// EncryptWork encrypts fully written work file - for content
+// that is not written via EncryptWriter (e.g., multipart upload, append, promote);
+// the same no-op-and-clear semantics as EncryptWriter when encryption is disabled
+func (lom *LOM) EncryptWork(wfqn string) error {
+	if !lom.Bprops().SSE.Enabled {
+		lom.md.sse = nil
+		return nil
+	}
+	md, aead, err := lom.newSSE()
+	if err != nil {
+		return err
+	}
+	if err := lom._encryptFile(wfqn, aead, md.nonce); err != nil {
+		return cmn.NewErrFailedTo(T, "encrypt", lom.Cname(), err)
+	}
+	lom.md.sse = md
+	return nil
+}
+
+// EncryptChunks is EncryptWork for content written via ChunkWriter: encrypts it into
+// a new set of chunks and removes the original ones (ditto when encryption is disabled)
+func (lom *LOM) EncryptChunks(cw *ChunkWriter) (*ChunkWriter, error) {
+	if !lom.Bprops().SSE.Enabled {
+		lom.md.sse = nil
+		return cw, nil
+	}
+	md, aead, err := lom.newSSE()
+	if err != nil {
+		return nil, err
+	}
+	efqn := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileEncrypt)
+	ecw, err := lom.NewChunkWriter(efqn, SSESize(cw.size))
+	if err != nil {
+		return nil, cmn.NewErrFailedTo(T, "encrypt", lom.Cname(), err)
+	}
+	var (
+		buf, slab   = g.pmm.AllocSize(sseSegSize + sseTagSize)
+		rbuf, rslab = g.pmm.Alloc()
+		w           = &sseWriter{w: ecw, aead: aead, nonce: md.nonce, buf: buf, slab: slab}
+		r           = cw.NewReader()
+	)
+	_, err = cos.CopyBuffer(w, r, rbuf)
+	if errC := w.Close(); err == nil {
+		err = errC
+	}
+	rslab.Free(rbuf)
+	cos.Close(r)
+	if err != nil {
+		ecw.Abort()
+		return nil, cmn.NewErrFailedTo(T, "encrypt", lom.Cname(), err)
+	}
+	cw.Abort()
+	lom.md.sse = md
+	return ecw, nil
+}
+
+// mirror copy (of decrypted content): re-encrypt with the source's data key
+// and nonce to produce identical bytes
+func (lom *LOM) reencryptWork(wfqn string, md *lsse) error {
+	aead, err := lom.sseCipher(md)
+	if err != nil {
+		return err
+	}
+	if err := lom._encryptFile(wfqn, aead, md.nonce); err != nil {
+		return cmn.NewErrFailedTo(T, "encrypt", lom.Cname(), err)
+	}
+	lom.md.sse = md
+	return nil
+}
+
+// encrypted content is larger - hence, writing another work file
+// and then renaming it back
+func (lom *LOM) _encryptFile(fqn string, aead cipher.AEAD, nonce []byte) error {
+	src, err := os.Open(fqn)
+	if err != nil {
+		return err
+	}
+	efqn := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileEncrypt)
+	dst, err := cos.CreateFile(efqn)
+	if err == nil {
+		var (
+			buf, slab   = g.pmm.AllocSize(sseSegSize + sseTagSize)
+			rbuf, rslab = g.pmm.Alloc()
+			w           = &sseWriter{w: dst, aead: aead, nonce: nonce, buf: buf, slab: slab}
+		)
+		_, err = cos.CopyBuffer(w, src, rbuf)
+		if errC := w.Close(); err == nil {
+			err = errC
+		}
+		rslab.Free(rbuf)
+	}
+	cos.Close(src)
+	if err == nil {
+		err = cos.Rename(efqn, fqn)
+	}
+	if err != nil {
+		if errRm := cos.RemoveFile(efqn); errRm != nil && !os.IsNotExist(errRm) {
+			nlog.Errorln("nested err:", errRm)
+		}
+	}
+	return err
+}
+
+// buffers one segment and holds it back until there's more data -
+// to seal the last segment as such upon Sync or Close
+func (w *sseWriter) Write(b []byte) (n int, err error) {
+	if w.sealed {
+		return 0, errors.New("server-side encryption: write after seal")
+	}
+	for len(b) > 0 {
+		if w.n == sseSegSize {
+			if err = w.seal(false); err != nil {
+				return n, err
+			}
+		}
+		l := copy(w.buf[w.n:sseSegSize], b)
+		w.n += l
+		n += l
+		b = b[l:]
+	}
+	return n, nil
+}
+
+func (w *sseWriter) seal(last bool) error {
+	out := w.aead.Seal(w.buf[:0], _segNonce(w.nonce, w.seg), w.buf[:w.n], _segAD(last))
+	if _, err := w.w.Write(out); err != nil {
+		return err
+	}
+	w.seg++
+	w.n = 0
+	w.sealed = last
+	return nil
+}
+
+func (w *sseWriter) Sync() error {
+	if !w.sealed {
+		if err := w.seal(true); err != nil {
+			return err
+		}
+	}
+	return w.w.Sync()
+}
+
+func (w *sseWriter) Close() (err error) {
+	if !w.sealed && w.buf != nil {
+		err = w.seal(true)
+	}
+	if w.slab != nil {
+		w.slab.Free(w.buf)
+		w.slab = nil
+	}
+	w.buf = nil
+	if errC := w.w.Close(); err == nil {
+		err = errC
+	}
+	return err
+}
+
+//
+// read
+//
+
+func (lom *LOM) decrypt(r cos.LomReader) (*SSEHandle, error) {
+	aead, err := lom.sseCipher(lom.md.sse)
+	if err != nil {
+		cos.Close(r)
+		return nil, err
+	}
+	return newSSEHandle(r, aead, lom.md.sse.nonce, lom.md.Size), nil
+}
+
+func newSSEHandle(r cos.LomReader, aead cipher.AEAD, nonce []byte, size int64) *SSEHandle {
+	buf, slab := g.pmm.AllocSize(sseSegSize + sseTagSize)
+	return &SSEHandle{r: r, aead: aead, nonce: nonce, buf: buf, slab: slab, size: size, seg: -1}
+}
+
+func (h *SSEHandle) Read(b []byte) (n int, err error) {
+	n, err = h.ReadAt(b, h.off)
+	h.off += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (h *SSEHandle) ReadAt(b []byte, off int64) (n int, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for len(b) > 0 && off < h.size {
+		seg := off / sseSegSize
+		if err = h.open(seg); err != nil {
+			return n, err
+		}
+		l := copy(b, h.plain[off-seg*sseSegSize:])
+		n += l
+		off += int64(l)
+		b = b[l:]
+	}
+	if len(b) > 0 {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// read and decrypt a given segment (unless already done)
+func (h *SSEHandle) open(seg int64) error {
+	if seg == h.seg {
+		return nil
+	}
+	var (
+		nsegs = _numSegs(h.size)
+		roff  = seg * (sseSegSize + sseTagSize)
+		rlen  = min(sseSegSize+sseTagSize, SSESize(h.size)-roff)
+		ct    = h.buf[:rlen]
+	)
+	h.seg, h.plain = -1, nil
+	n, err := h.r.ReadAt(ct, roff)
+	if n < len(ct) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	plain, err := h.aead.Open(ct[:0], _segNonce(h.nonce, seg), ct, _segAD(seg == nsegs-1))
+	if err != nil {
+		return fmt.Errorf("server-side encryption: failed to decrypt segment #%d: %w", seg, err)
+	}
+	h.seg, h.plain = seg, plain
+	return nil
+}
+
+func (h *SSEHandle) Open() (cos.ReadOpenCloser, error) {
+	roc, ok := h.r.(cos.ReadOpenCloser)
+	if !ok {
+		return nil, fmt.Errorf("%T cannot be reopened", h.r)
+	}
+	r, err := roc.Open()
+	if err != nil {
+		return nil, err
+	}
+	return newSSEHandle(r.(cos.LomReader), h.aead, h.nonce, h.size), nil
+}
+
+func (h *SSEHandle) Close() error {
+	if h.slab != nil {
+		h.slab.Free(h.buf)
+		h.slab, h.buf, h.plain = nil, nil, nil
+	}
+	return h.r.Close()
+}
+
+//
+// keys
+//
+
+// new data key wrapped by the current bucket key
+func (lom *LOM) newSSE() (*lsse, cipher.AEAD, error) {
+	bk, err := g.sse.get(&lom.Bprops().SSE)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		dkey [sseKeySize]byte
+		md   = &lsse{kid: bk.kid, nonce: make([]byte, sseNonceSize)}
+	)
+	if _, err := rand.Read(dkey[:]); err != nil {
+		return nil, nil, err
+	}
+	if _, err := rand.Read(md.nonce); err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, bk.aead.NonceSize(), bk.aead.NonceSize()+sseKeySize+bk.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+	md.wkey = bk.aead.Seal(nonce, nonce, dkey[:], nil)
+	aead, err := _newGCM(dkey[:])
+	return md, aead, err
+}
+
+// unwrap data key with the bucket key that was used to wrap it
+func (lom *LOM) sseCipher(md *lsse) (cipher.AEAD, error) {
+	bk, err := g.sse.find(&lom.Bprops().SSE, md.kid)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", lom.Cname(), err)
+	}
+	ns := bk.aead.NonceSize()
+	if len(md.wkey) <= ns {
+		return nil, errors.New(badSSE + " #5")
+	}
+	dkey, err := bk.aead.Open(nil, md.wkey[:ns], md.wkey[ns:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to unwrap data key: %v", lom.Cname(), err)
+	}
+	return _newGCM(dkey)
+}
+
+func _newGCM(key []byte) (cipher.AEAD, error) {
+	blk, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(blk)
+}
+
+func (sk *sseKeys) ring(conf *cmn.SSEConf) (*bring, error) {
+	src := conf.KeySource
+	if src == "" {
+		return nil, errors.New("server-side encryption: bucket key source (sse.key_source) is not configured")
+	}
+	sk.mu.Lock()
+	kr := sk.m[src]
+	if kr == nil {
+		if sk.m == nil {
+			sk.m = make(map[string]*bring, 2)
+		}
+		kr = &bring{m: make(map[string]*bkey, 2)}
+		sk.m[src] = kr
+	}
+	sk.mu.Unlock()
+	return kr, nil
+}
+
+// current bucket key (to encrypt)
+func (sk *sseKeys) get(conf *cmn.SSEConf) (*bkey, error) {
+	kr, err := sk.ring(conf)
+	if err != nil {
+		return nil, err
+	}
+	if bk := kr.current(); bk != nil {
+		return bk, nil
+	}
+	if err := kr.reload(conf.KeySource, ""); err != nil {
+		return nil, err
+	}
+	kr.mu.RLock()
+	bk := kr.cur
+	kr.mu.RUnlock()
+	return bk, nil
+}
+
+// bucket key by ID (to decrypt); unknown ID triggers (rate-limited) reload
+func (sk *sseKeys) find(conf *cmn.SSEConf, kid string) (*bkey, error) {
+	kr, err := sk.ring(conf)
+	if err != nil {
+		return nil, err
+	}
+	if bk := kr.find(kid); bk != nil {
+		return bk, nil
+	}
+	if err := kr.reload(conf.KeySource, kid); err != nil {
+		return nil, err
+	}
+	if bk := kr.find(kid); bk != nil {
+		return bk, nil
+	}
+	return nil, fmt.Errorf("bucket key %q not found in %s", kid, conf.KeySource)
+}
+
+// (nil when needs reloading)
+func (kr *bring) current() (bk *bkey) {
+	kr.mu.RLock()
+	if kr.cur != nil && (mono.Since(kr.loaded) < sseKeyTTL || mono.Since(kr.tried) < sseRetry) {
+		bk = kr.cur
+	}
+	kr.mu.RUnlock()
+	return bk
+}
+
+func (kr *bring) find(kid string) (bk *bkey) {
+	kr.mu.RLock()
+	bk = kr.m[kid]
+	kr.mu.RUnlock()
+	return bk
+}
+
+// (re)load is serialized: concurrent callers wait and then re-check -
+// either the current key (kid == "") or a given key ID
+func (kr *bring) reload(src, kid string) error {
+	kr.lmu.Lock()
+	defer kr.lmu.Unlock()
+
+	if kid == "" {
+		if kr.current() != nil {
+			return nil
+		}
+	} else if kr.find(kid) != nil {
+		return nil
+	} else if kr.retried() {
+		return nil // (not found)
+	}
+
+	keys, err := loadSSEKeys(src)
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.tried = mono.NanoTime()
+	if err != nil {
+		if kr.cur != nil {
+			nlog.Warningln("failed to reload bucket keys from", src, "- using cached:", err)
+			return nil
+		}
+		return fmt.Errorf("failed to load bucket keys from %s: %w", src, err)
+	}
+	for i, key := range keys {
+		bk, err := newBkey(key)
+		if err != nil {
+			return err
+		}
+		if prev, ok := kr.m[bk.kid]; ok {
+			bk = prev
+		} else {
+			kr.m[bk.kid] = bk
+		}
+		if i == 0 {
+			if kr.cur != nil && kr.cur.kid != bk.kid {
+				nlog.Infoln("bucket key", src, "rotated:", kr.cur.kid, "=>", bk.kid)
+			}
+			kr.cur = bk
+		}
+	}
+	kr.loaded = kr.tried
+	return nil
+}
+
+// (rate-limiting forced reloads)
+func (kr *bring) retried() bool {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	return kr.cur != nil && mono.Since(kr.tried) < sseRetry
+}
+
+func newBkey(key []byte) (*bkey, error) {
+	aead, err := _newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256(key)
+	return &bkey{aead: aead, kid: hex.EncodeToString(h[:sseKidSize])}, nil
+}
+
+// returns the current key followed by previous keys, if any
+func loadSSEKeys(src string) ([][]byte, error) {
+	// (in addition to validating bucket props; e.g., the permitted sources may have changed since)
+	if conf := &cmn.GCO.Get().SSE; !conf.Allowed(src) {
+		return nil, fmt.Errorf("bucket key source %q is not permitted (see sse.key_sources)", src)
+	}
+	if strings.HasPrefix(src, cmn.SSEKeyFile) {
+		b, err := os.ReadFile(strings.TrimPrefix(src, cmn.SSEKeyFile))
+		if err != nil {
+			return nil, err
+		}
+		return parseSSEKeys(b)
+	}
+	return fetchSSEKeys(src)
+}
+
+// Vault-compatible: GET secret (KV v1 or v2) that has "key" field (current key)
+// and, optionally, "prev_keys" (previous keys)
+func fetchSSEKeys(url string) ([][]byte, error) {
+	if !strings.HasPrefix(url, cmn.SSEKeyHTTPS) {
+		return nil, fmt.Errorf("bucket key source %q: expecting %q", url, cmn.SSEKeyHTTPS)
+	}
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	if token := os.Getenv(SSEEnvToken); token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	client := sseClient
+	if client == nil {
+		client = cmn.NewClient(cmn.TransportArgs{Timeout: sseTimeout, UseHTTPProxyEnv: true})
+	}
+	resp, err := client.Do(req) //nolint:bodyclose // closed below
+	if err != nil {
+		return nil, err
+	}
+	defer cos.Close(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %q", resp.Status)
+	}
+	type secret struct {
+		Key      string   `json:"key"`
+		PrevKeys []string `json:"prev_keys"`
+	}
+	var body struct {
+		secret
+		Data struct {
+			secret        // KV v1
+			Data   secret `json:"data"` // KV v2
+		} `json:"data"`
+	}
+	if err := cos.JSON.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	for _, s := range []*secret{&body.Data.Data, &body.Data.secret, &body.secret} {
+		if s.Key == "" {
+			continue
+		}
+		keys := make([][]byte, 0, 1+len(s.PrevKeys))
+		for _, k := range append([]string{s.Key}, s.PrevKeys...) {
+			key, err := parseSSEKey([]byte(k))
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		return keys, nil
+	}
+	return nil, errors.New("no \"key\" in the response")
+}
+
+// file: either a single raw key or one (hex or base64-encoded) key per line
+func parseSSEKeys(b []byte) ([][]byte, error) {
+	if len(b) == sseKeySize {
+		return [][]byte{b}, nil
+	}
+	lines := strings.Fields(string(b))
+	if len(lines) == 0 {
+		return nil, errors.New("no keys")
+	}
+	keys := make([][]byte, 0, len(lines))
+	for _, line := range lines {
+		key, err := parseSSEKey([]byte(line))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// 256-bit key: raw, hex, or base64
+func parseSSEKey(b []byte) ([]byte, error) {
+	if len(b) == sseKeySize {
+		return b, nil
+	}
+	s := strings.TrimSpace(string(b))
+	if key, err := hex.DecodeString(s); err == nil && len(key) == sseKeySize {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(s); err == nil && len(key) == sseKeySize {
+		return key, nil
+	}
+	return nil, errors.New("invalid key: expecting 256-bit key (raw, hex, or base64-encoded)")
+}
+
+//
+// pack/unpack (see lom_xattr)
+//
+
+func _packSSE(md *lsse) string {
+	debug.Assert(md.kid != "" && len(md.wkey) > 0 && len(md.nonce) == sseNonceSize)
+	return md.kid + customSepa + hex.EncodeToString(md.wkey) + customSepa + hex.EncodeToString(md.nonce)
+}
+
+func _unpackSSE(val string) (*lsse, error) {
+	parts := strings.Split(val, customSepa)
+	if len(parts) != 3 || parts[0] == "" {
+		return nil, errors.New(badSSE + " #2")
+	}
+	wkey, err := hex.DecodeString(parts[1])
+	if err != nil || len(wkey) == 0 {
+		return nil, errors.New(badSSE + " #3")
+	}
+	nonce, err := hex.DecodeString(parts[2])
+	if err != nil || len(nonce) != sseNonceSize {
+		return nil, errors.New(badSSE + " #4")
+	}
+	return &lsse{kid: parts[0], wkey: wkey, nonce: nonce}, nil
+}
@@ -0,0 +1,283 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func sseInit(t *testing.T) (*bkey, []byte) {
+	if g.pmm == nil {
+		g.pmm = memsys.PageMM()
+	}
+	bk, err := newBkey(sseRandKey(t))
+	tassert.CheckFatal(t, err)
+	nonce := make([]byte, sseNonceSize)
+	_, err = rand.Read(nonce)
+	tassert.CheckFatal(t, err)
+	return bk, nonce
+}
+
+func sseRandKey(t *testing.T) []byte {
+	key := make([]byte, sseKeySize)
+	_, err := rand.Read(key)
+	tassert.CheckFatal(t, err)
+	return key
+}
+
+// encrypt given content into a file
+func sseWrite(t *testing.T, fqn string, bk *bkey, nonce, content []byte) {
+	fh, err := os.Create(fqn)
+	tassert.CheckFatal(t, err)
+	buf, slab := g.pmm.AllocSize(sseSegSize + sseTagSize)
+	w := &sseWriter{w: fh, aead: bk.aead, nonce: nonce, buf: buf, slab: slab}
+	// (odd-sized writes)
+	for b := content; len(b) > 0; {
+		l := min(len(b), 1000)
+		_, err = w.Write(b[:l])
+		tassert.CheckFatal(t, err)
+		b = b[l:]
+	}
+	tassert.CheckFatal(t, w.Close())
+}
+
+func sseOpen(t *testing.T, fqn string, bk *bkey, nonce []byte, size int64) *SSEHandle {
+	fh, err := os.Open(fqn)
+	tassert.CheckFatal(t, err)
+	return newSSEHandle(fh, bk.aead, nonce, size)
+}
+
+func TestSSEContent(t *testing.T) {
+	bk, nonce := sseInit(t)
+	fqn := filepath.Join(t.TempDir(), "obj")
+	for _, size := range []int64{0, 1, sseSegSize - 1, sseSegSize, sseSegSize + 1, 3*sseSegSize + 17} {
+		content := make([]byte, size)
+		_, _ = rand.Read(content)
+		sseWrite(t, fqn, bk, nonce, content)
+
+		finfo, err := os.Stat(fqn)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, finfo.Size() == SSESize(size), "size %d: expected stored size %d, got %d", size, SSESize(size), finfo.Size())
+		tassert.Errorf(t, SSEPlainSize(finfo.Size()) == size, "size %d: expected plain size %d, got %d", size, size, SSEPlainSize(finfo.Size()))
+
+		h := sseOpen(t, fqn, bk, nonce, size)
+		b, err := io.ReadAll(h)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, bytes.Equal(b, content), "size %d: content mismatch", size)
+
+		// range across segment boundary
+		if size > sseSegSize+1 {
+			rng := make([]byte, 100)
+			n, err := h.ReadAt(rng, sseSegSize-50)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, n == 100 && bytes.Equal(rng, content[sseSegSize-50:sseSegSize+50]), "size %d: range mismatch", size)
+		}
+		// past the end
+		n, err := h.ReadAt(make([]byte, 10), size)
+		tassert.Errorf(t, n == 0 && err == io.EOF, "size %d: expected EOF, got (%d, %v)", size, n, err)
+		tassert.CheckFatal(t, h.Close())
+	}
+}
+
+func TestSSETamper(t *testing.T) {
+	const size = 3*sseSegSize + 17
+	var (
+		bk, nonce = sseInit(t)
+		fqn       = filepath.Join(t.TempDir(), "obj")
+		content   = make([]byte, size)
+	)
+	_, _ = rand.Read(content)
+	sseWrite(t, fqn, bk, nonce, content)
+	stored, err := os.ReadFile(fqn)
+	tassert.CheckFatal(t, err)
+
+	readAll := func(b []byte, size int64) error {
+		tassert.CheckFatal(t, os.WriteFile(fqn, b, 0o600))
+		h := sseOpen(t, fqn, bk, nonce, size)
+		_, err := io.ReadAll(h)
+		h.Close()
+		return err
+	}
+
+	// flipped bit
+	b := bytes.Clone(stored)
+	b[sseSegSize+sseTagSize+10] ^= 1
+	tassert.Errorf(t, readAll(b, size) != nil, "expected to detect modified content")
+
+	// swapped segments
+	b = bytes.Clone(stored)
+	ssize := sseSegSize + sseTagSize
+	copy(b[:ssize], stored[ssize:2*ssize])
+	copy(b[ssize:2*ssize], stored[:ssize])
+	tassert.Errorf(t, readAll(b, size) != nil, "expected to detect reordered segments")
+
+	// truncated at segment boundary (along with the size)
+	tassert.Errorf(t, readAll(stored[:3*ssize], 3*sseSegSize) != nil, "expected to detect truncation")
+
+	// wrong key
+	other, _ := sseInit(t)
+	tassert.CheckFatal(t, os.WriteFile(fqn, stored, 0o600))
+	h := sseOpen(t, fqn, other, nonce, size)
+	_, err = io.ReadAll(h)
+	h.Close()
+	tassert.Errorf(t, err != nil, "expected to fail decrypting with a wrong key")
+
+	tassert.Errorf(t, readAll(stored, size) == nil, "expected to read intact content")
+}
+
+func sseKidOf(t *testing.T, key []byte) string {
+	bk, err := newBkey(key)
+	tassert.CheckFatal(t, err)
+	return bk.kid
+}
+
+// permit bucket key source(s) for the duration of the test
+func sseAllow(t *testing.T, srcs ...string) {
+	config := cmn.GCO.BeginUpdate()
+	prev := config.SSE.KeySources
+	config.SSE.KeySources = srcs
+	cmn.GCO.CommitUpdate(config)
+	t.Cleanup(func() {
+		config := cmn.GCO.BeginUpdate()
+		config.SSE.KeySources = prev
+		cmn.GCO.CommitUpdate(config)
+	})
+}
+
+// make it look stale
+func sseExpire(kr *bring) {
+	kr.mu.Lock()
+	kr.loaded = mono.NanoTime() - int64(2*sseKeyTTL)
+	kr.tried = kr.loaded
+	kr.mu.Unlock()
+}
+
+func TestSSEKeyRotation(t *testing.T) {
+	var (
+		sk      sseKeys
+		fpath   = filepath.Join(t.TempDir(), "bucket.key")
+		conf    = &cmn.SSEConf{Enabled: true, KeySource: cmn.SSEKeyFile + fpath}
+		k1, k2  = sseRandKey(t), sseRandKey(t)
+		k3      = sseRandKey(t)
+		writeKs = func(keys ...[]byte) {
+			lines := make([]string, 0, len(keys))
+			for _, key := range keys {
+				lines = append(lines, hex.EncodeToString(key))
+			}
+			tassert.CheckFatal(t, os.WriteFile(fpath, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+		}
+	)
+	sseAllow(t, conf.KeySource)
+	tassert.CheckFatal(t, os.WriteFile(fpath, k1, 0o600)) // (raw)
+	bk, err := sk.get(conf)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, bk.kid == sseKidOf(t, k1), "expected current key %s, got %s", sseKidOf(t, k1), bk.kid)
+	kr, _ := sk.ring(conf)
+
+	// rotate: the new key first, followed by the previous one
+	writeKs(k2, k1)
+	bk, err = sk.get(conf)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bk.kid == sseKidOf(t, k1), "expected cached key %s until reloaded, got %s", sseKidOf(t, k1), bk.kid)
+	sseExpire(kr)
+	bk, err = sk.get(conf)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, bk.kid == sseKidOf(t, k2), "expected current key %s, got %s", sseKidOf(t, k2), bk.kid)
+	for _, key := range [][]byte{k1, k2} {
+		_, err := sk.find(conf, sseKidOf(t, key))
+		tassert.CheckError(t, err)
+	}
+
+	// previous key removed from the source but remains in the keyring
+	writeKs(k2)
+	sseExpire(kr)
+	_, err = sk.get(conf)
+	tassert.CheckFatal(t, err)
+	_, err = sk.find(conf, sseKidOf(t, k1))
+	tassert.CheckError(t, err)
+
+	// unknown key ID: forced reload (rate-limited)
+	writeKs(k3, k2)
+	_, err = sk.find(conf, sseKidOf(t, k3))
+	tassert.Errorf(t, err != nil, "expected reload to be rate-limited")
+	kr.mu.Lock()
+	kr.tried = mono.NanoTime() - int64(sseRetry)
+	kr.mu.Unlock()
+	_, err = sk.find(conf, sseKidOf(t, k3))
+	tassert.CheckError(t, err)
+	_, err = sk.find(conf, "0123456789abcdef")
+	tassert.Errorf(t, err != nil, "expected unknown key ID to fail")
+
+	// failed reload: keep using cached keys
+	tassert.CheckFatal(t, os.Remove(fpath))
+	sseExpire(kr)
+	bk, err = sk.get(conf)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bk.kid == sseKidOf(t, k3), "expected cached key %s, got %s", sseKidOf(t, k3), bk.kid)
+}
+
+func TestSSEKeyReload(t *testing.T) {
+	const numReaders = 16
+	var (
+		sk      sseKeys
+		fetched atomic.Int32
+		k1, k2  = sseRandKey(t), sseRandKey(t)
+		srv     = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			fetched.Inc()
+			time.Sleep(50 * time.Millisecond)
+			// KV v2
+			w.Write([]byte(`{"data": {"data": {"key": "` + hex.EncodeToString(k2) + `", "prev_keys": ["` + hex.EncodeToString(k1) + `"]}}}`))
+		}))
+		conf = &cmn.SSEConf{Enabled: true, KeySource: srv.URL}
+		wg   sync.WaitGroup
+	)
+	defer srv.Close()
+	sseClient = srv.Client()
+	defer func() { sseClient = nil }()
+	sseAllow(t, srv.URL)
+
+	// serialized: a single fetch
+	errs := make([]error, numReaders)
+	for i := range numReaders {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				_, errs[i] = sk.get(conf)
+			} else {
+				_, errs[i] = sk.find(conf, sseKidOf(t, k1))
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		tassert.CheckError(t, err)
+	}
+	tassert.Errorf(t, fetched.Load() == 1, "expected a single fetch, got %d", fetched.Load())
+
+	bk, err := sk.get(conf)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bk.kid == sseKidOf(t, k2), "expected current key %s, got %s", sseKidOf(t, k2), bk.kid)
+	_, err = sk.find(conf, sseKidOf(t, k1))
+	tassert.CheckError(t, err)
+	tassert.Errorf(t, fetched.Load() == 1, "expected a single fetch, got %d", fetched.Load())
+}
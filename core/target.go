@@ -40,8 +40,10 @@ type (
 		WorkTag string // (=> work fqn)
 		Size    int64
 		OWT     cmn.OWT
-		SkipEC  bool // don't erasure-code when finalizing
-		ColdGET bool // this PUT is in fact a cold-GET
+		SSE     string // encryption metadata of the content stored as is (see AsIs and lom.SSE)
+		SkipEC  bool   // don't erasure-code when finalizing
+		ColdGET bool   // this PUT is in fact a cold-GET
+		AsIs    bool   // store the content as is - don't encrypt (e.g., EC slices and replicas)
 	}
 	PromoteParams struct {
 		Bck             *meta.Bck   // destination bucket
@@ -173,6 +173,13 @@ cat > $AIS_CONF_FILE <<EOL
 		"data": "${WRITE_POLICY_DATA:-}",
 		"md": "${WRITE_POLICY_MD:-}"
 	},
+	"chunks": {
+		"objsize_limit": "0",
+		"chunk_size": "1GiB"
+	},
+	"sse": {
+		"key_sources": []
+	},
 	"features": "0"
 }
 EOL
@@ -359,6 +359,10 @@ func freeObject(r any) {
 			// few slices share the same handle, on error all release everything
 			_ = handle.Close()
 		}
+	case *core.ChunksHandle:
+		if handle != nil {
+			_ = handle.Close()
+		}
 	case *os.File:
 		if handle != nil {
 			cos.Close(handle)
@@ -403,8 +407,8 @@ func RequestECMeta(bck *cmn.Bck, objName string, si *meta.Snode, client *http.Cl
 	return MetaFromReader(resp.Body, resp.ContentLength)
 }
 
-// Saves the main replica to local drives
-func writeObject(lom *core.LOM, reader io.Reader, size int64, xctn core.Xact) error {
+// Saves the main replica to local drives - as is, i.e., encrypted iff md.SSE (see core/lsse.go)
+func writeObject(lom *core.LOM, reader io.Reader, size int64, xctn core.Xact, md *Metadata) error {
 	if size > 0 {
 		reader = io.LimitReader(reader, size)
 	}
@@ -418,6 +422,11 @@ func writeObject(lom *core.LOM, reader io.Reader, size int64, xctn core.Xact) er
 		params.Size = size
 		params.Xact = xctn
 		params.OWT = cmn.OwtRebalance
+		params.AsIs = true
+		params.SSE = md.SSE
+	}
+	if md.SSE != "" {
+		params.Cksum = cos.NewCksum(md.CksumType, md.ObjCksum) // (can't compute)
 	}
 	err := core.T.PutObject(lom, params)
 	core.FreePutParams(params)
@@ -489,7 +498,11 @@ func WriteReplicaAndMeta(lom *core.LOM, args *WriteArgs) (err error) {
 	}
 	lom.Unlock(false)
 
-	if err = writeObject(lom, args.Reader, lom.Lsize(true), args.Xact); err != nil {
+	md, err := MetaFromReader(bytes.NewReader(args.MD), int64(len(args.MD)))
+	if err != nil {
+		return err
+	}
+	if err = writeObject(lom, args.Reader, lom.Lsize(true), args.Xact, md); err != nil {
 		return
 	}
 	if !args.Cksum.IsEmpty() && args.Cksum.Value() != "" { // NOTE: empty value
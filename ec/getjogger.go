@@ -183,8 +183,8 @@ func (c *getJogger) copyMissingReplicas(ctx *restoreCtx, reader cos.ReadOpenClos
 	switch r := reader.(type) {
 	case *memsys.SGL:
 		srcReader = memsys.NewReader(r)
-	case *cos.FileHandle:
-		srcReader, err = cos.NewFileHandle(ctx.lom.FQN)
+	case *cos.FileHandle, *core.ChunksHandle:
+		srcReader, err = ctx.lom.NewRawHandle()
 	default:
 		debug.FailTypeCast(reader)
 		err = fmt.Errorf("unsupported reader type: %T", reader)
@@ -205,7 +205,7 @@ func (c *getJogger) copyMissingReplicas(ctx *restoreCtx, reader cos.ReadOpenClos
 	}
 	src := &dataSource{
 		reader:   srcReader,
-		size:     ctx.lom.Lsize(),
+		size:     ctx.lom.RawSize(),
 		metadata: ctx.meta,
 		reqType:  reqPut,
 	}
@@ -322,6 +322,12 @@ loop: //nolint:gocritic // keeping label for readability
 	if cmn.Rom.FastV(4, cos.SmoduleEC) {
 		nlog.Infoln("found meta -> obj get", ctx.lom.Cname())
 	}
+	if err := ctx.lom.SetSSE(ctx.meta.SSE); err != nil {
+		return err
+	}
+	if ctx.lom.IsEncrypted() {
+		ctx.lom.SetSize(core.SSEPlainSize(size)) // (see Metadata.SSE)
+	}
 	if err := ctx.lom.RenameFinalize(tmpFQN); err != nil {
 		return err
 	}
@@ -341,7 +347,7 @@ loop: //nolint:gocritic // keeping label for readability
 		return fmt.Errorf("%s metafile saved while bucket %s was being destroyed", ctMeta.ObjectName(), ctMeta.Bucket())
 	}
 
-	reader, err := cos.NewFileHandle(ctx.lom.FQN)
+	reader, err := ctx.lom.NewRawHandle()
 	if err != nil {
 		return err
 	}
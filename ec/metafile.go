@@ -16,7 +16,10 @@ import (
 	"github.com/OneOfOne/xxhash"
 )
 
-const MDVersionLast = 1 // current version of metadata
+const (
+	mdVersionNoSSE = 1 // the same as MDVersionLast sans encryption metadata (see Pack)
+	MDVersionLast  = 2 // current version of metadata
+)
 
 // Metadata - EC information stored in metafiles for every encoded object
 type Metadata struct {
@@ -33,6 +36,7 @@ type Metadata struct {
 	SliceID     int              `json:"slice_id"`      // 0 for full replica, 1 to N for slices
 	MDVersion   uint32           `json:"md_version"`    // Metadata format version
 	IsCopy      bool             `json:"is_copy"`       // object is replicated(true) or encoded(false)
+	SSE         string           `json:"sse,omitempty"` // encryption metadata: slices and replicas are encrypted (see core/lsse.go)
 }
 
 // interface guard
@@ -125,10 +129,14 @@ func (md *Metadata) Unpack(unpacker *cos.ByteUnpack) (err error) {
 	}
 	switch md.MDVersion {
 	case MDVersionLast:
+		if err = md.unpackLastVersion(unpacker); err == nil {
+			md.SSE, err = unpacker.ReadString()
+		}
+	case mdVersionNoSSE:
 		err = md.unpackLastVersion(unpacker)
 	default:
-		err = fmt.Errorf("unsupported metadata format version %d. Only %d supported",
-			md.MDVersion, MDVersionLast)
+		err = fmt.Errorf("unsupported metadata format version %d. Only %d and %d supported",
+			md.MDVersion, mdVersionNoSSE, MDVersionLast)
 	}
 	if err != nil {
 		return
@@ -187,8 +195,16 @@ func (md *Metadata) unpackLastVersion(unpacker *cos.ByteUnpack) (err error) {
 	return
 }
 
+// unencrypted objects continue to use the previous (compatible) version
+func (md *Metadata) version() uint32 {
+	if md.SSE == "" && md.MDVersion == MDVersionLast {
+		return mdVersionNoSSE
+	}
+	return md.MDVersion
+}
+
 func (md *Metadata) Pack(packer *cos.BytePack) {
-	packer.WriteUint32(md.MDVersion)
+	packer.WriteUint32(md.version())
 	packer.WriteInt64(md.Generation)
 	packer.WriteInt64(md.Size)
 	packer.WriteUint16(uint16(md.Data))
@@ -201,6 +217,9 @@ func (md *Metadata) Pack(packer *cos.BytePack) {
 	packer.WriteString(md.CksumType)
 	packer.WriteString(md.CksumValue)
 	packer.WriteMapStrUint16(md.Daemons)
+	if md.version() == MDVersionLast {
+		packer.WriteString(md.SSE)
+	}
 	h := xxhash.Checksum64S(packer.Bytes(), cos.MLCG32)
 	packer.WriteUint64(h)
 }
@@ -210,7 +229,11 @@ func (md *Metadata) PackedSize() int {
 	for k := range md.Daemons {
 		daemonListSz += cos.PackedStrLen(k) + cos.SizeofI16
 	}
-	return cos.SizeofI32 + cos.SizeofI64*2 + cos.SizeofI16*3 + 1 /*isCopy*/ +
+	var sseSz int
+	if md.version() == MDVersionLast {
+		sseSz = cos.PackedStrLen(md.SSE)
+	}
+	return cos.SizeofI32 + cos.SizeofI64*2 + cos.SizeofI16*3 + 1 /*isCopy*/ + sseSz +
 		cos.PackedStrLen(md.ObjCksum) + cos.PackedStrLen(md.ObjVersion) +
 		cos.PackedStrLen(md.CksumType) + cos.PackedStrLen(md.CksumValue) +
 		cos.PackedStrLen(md.FullReplica) + daemonListSz + cos.SizeofI64 /*md cksum*/
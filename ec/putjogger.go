@@ -31,7 +31,7 @@ type (
 	encodeCtx struct {
 		lom          *core.LOM        // replica
 		meta         *Metadata        //
-		fh           *cos.FileHandle  // file handle for the replica
+		fh           core.LomHandle   // file handle for the replica
 		sliceSize    int64            // calculated slice size
 		padSize      int64            // zero tail of the last object's data slice
 		dataSlices   int              // the number of data slices
@@ -237,11 +237,12 @@ func (c *putJogger) encode(req *request, lom *core.LOM) error {
 	meta := &Metadata{
 		MDVersion:   MDVersionLast,
 		Generation:  generation,
-		Size:        lom.Lsize(),
+		Size:        lom.RawSize(), // (encoding stored content - see SSE)
 		Data:        ecConf.DataSlices,
 		Parity:      ecConf.ParitySlices,
 		IsCopy:      req.IsCopy,
 		ObjCksum:    cksumValue,
+		SSE:         lom.SSE(),
 		CksumType:   cksumType,
 		FullReplica: core.T.SID(),
 		Daemons:     make(cos.MapStrUint16, reqTargets),
@@ -297,12 +298,12 @@ func (*putJogger) newCtx(lom *core.LOM, meta *Metadata) (ctx *encodeCtx, err err
 	ctx.meta = meta
 
 	totalCnt := ctx.paritySlices + ctx.dataSlices
-	ctx.sliceSize = SliceSize(ctx.lom.Lsize(), ctx.dataSlices)
+	ctx.sliceSize = SliceSize(meta.Size, ctx.dataSlices)
 	ctx.slices = make([]*slice, totalCnt)
-	ctx.padSize = ctx.sliceSize*int64(ctx.dataSlices) - ctx.lom.Lsize()
+	ctx.padSize = ctx.sliceSize*int64(ctx.dataSlices) - meta.Size
 	debug.Assert(ctx.padSize >= 0)
 
-	ctx.fh, err = cos.NewFileHandle(lom.FQN)
+	ctx.fh, err = lom.NewRawHandle() // (encrypted content is encoded as is)
 	return ctx, err
 }
 
@@ -357,7 +358,7 @@ func (c *putJogger) createCopies(ctx *encodeCtx) error {
 	// broadcast the replica to the targets
 	src := &dataSource{
 		reader:   ctx.fh,
-		size:     ctx.lom.Lsize(),
+		size:     ctx.meta.Size,
 		metadata: ctx.meta,
 		reqType:  reqPut,
 	}
@@ -401,7 +402,7 @@ func generateSlicesToMemory(ctx *encodeCtx) error {
 func initializeSlices(ctx *encodeCtx) (err error) {
 	// readers are slices of original object(no memory allocated)
 	cksmReaders := make([]io.Reader, ctx.dataSlices)
-	sizeLeft := ctx.lom.Lsize()
+	sizeLeft := ctx.meta.Size
 	for i := range ctx.dataSlices {
 		var (
 			reader     cos.ReadOpenCloser
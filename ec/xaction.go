@@ -136,14 +136,14 @@ func newReplicaResponse(attrs *cmn.ObjAttrs, bck *meta.Bck, objName string) (rea
 		nlog.Warningln(err)
 		return nil, err
 	}
-	reader, err = cos.NewFileHandle(lom.FQN)
+	reader, err = lom.NewRawHandle() // (see Metadata.SSE)
 	if err != nil {
 		return nil, err
 	}
 	if lom.Lsize() == 0 {
 		return nil, nil
 	}
-	attrs.Size = lom.Lsize()
+	attrs.Size = lom.RawSize()
 	attrs.CopyVersion(lom.ObjAttrs())
 	attrs.Atime = lom.AtimeUnix()
 	attrs.Cksum = lom.Checksum()
@@ -54,6 +54,10 @@ type RequestSpec struct {
 	// Desirable
 	InputExtension string `json:"input_extension" yaml:"input_extension"`
 
+	// Optional
+	// Default: "" (when input_format is a prefix, select all shards under this prefix)
+	InputRegex string `json:"input_regex" yaml:"input_regex"`
+
 	// Optional
 	// Default: InputExtension
 	OutputExtension string `json:"output_extension" yaml:"output_extension"`
@@ -73,6 +77,10 @@ type RequestSpec struct {
 	ExtractConcMaxLimit int `json:"extract_concurrency_max_limit" yaml:"extract_concurrency_max_limit"`
 	// Default: calcMaxLimit()
 	CreateConcMaxLimit int `json:"create_concurrency_max_limit" yaml:"create_concurrency_max_limit"`
+	// Default: "" (no transformation)
+	// name of a running ETL (or a comma-separated pipeline of ETLs) to transform input shards
+	// prior to extraction; the transformed shard must have the same format (input_extension)
+	ETLName string `json:"etl_name" yaml:"etl_name"`
 
 	// debug
 	DsorterType string `json:"dsorter_type"`
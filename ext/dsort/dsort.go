@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -31,6 +32,7 @@ import (
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/ext/dsort/shard"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/OneOfOne/xxhash"
@@ -189,7 +191,7 @@ func (m *Manager) extractLocalShards() (err error) {
 		err = m.iterList(ctx, group)
 	default:
 		debug.Assert(m.Pars.Pit.isPrefix())
-		debug.Assert(false, "not implemented yet") // TODO -- FIXME
+		err = m.iterPrefix(ctx, group)
 	}
 
 	m.dsorter.postExtraction()
@@ -222,7 +224,7 @@ outer:
 		}
 
 		m.extractionPhase.adjuster.acquireGoroutineSema()
-		es := &extractShard{m, metrics, name, true /*is-range*/}
+		es := &extractShard{m, metrics, name, true /*is-range*/, false /*cold*/}
 		group.Go(es.do)
 	}
 	return group.Wait()
@@ -245,12 +247,53 @@ outer:
 		}
 
 		m.extractionPhase.adjuster.acquireGoroutineSema()
-		es := &extractShard{m, metrics, name, false /*is-range*/}
+		es := &extractShard{m, metrics, name, false /*is-range*/, false /*cold*/}
 		group.Go(es.do)
 	}
 	return group.Wait()
 }
 
+// Prefix-based selection, optionally narrowed down by regex and `input_extension`.
+// In ais:// buckets, each target walks its local mountpaths; remote buckets are listed
+// page by page (selecting local shards and cold-GET-ing those not present in the cluster).
+// Unlike range and list, the total count is not known upfront - it grows as the iteration
+// progresses and, in the ais:// case, includes only the shards stored on this target.
+func (m *Manager) iterPrefix(ctx context.Context, group *errgroup.Group) error {
+	var (
+		regex *regexp.Regexp
+		bck   = meta.CloneBck(&m.Pars.InputBck)
+	)
+	if err := bck.Init(core.T.Bowner()); err != nil {
+		return err
+	}
+	if m.Pars.Pit.Regex != "" {
+		var err error
+		if regex, err = regexp.CompilePOSIX(m.Pars.Pit.Regex); err != nil {
+			return err // (unlikely: validated by the proxy)
+		}
+	}
+	pi := &prefixIter{m: m, ctx: ctx, group: group, bck: bck, regex: regex}
+
+	var err error
+	if bck.IsRemote() {
+		err = pi.list()
+	} else {
+		err = pi.walk()
+	}
+	switch {
+	case err == nil:
+		return group.Wait()
+	case cmn.IsErrAborted(err):
+		group.Wait()
+		return err
+	case ctx.Err() != nil:
+		return group.Wait() // context canceled: we have an error
+	default:
+		group.Wait()
+		return err
+	}
+}
+
 func (m *Manager) createShard(s *shard.Shard, lom *core.LOM) (err error) {
 	var (
 		metrics   = m.Metrics.Creation
@@ -370,7 +413,7 @@ func (m *Manager) createShard(s *shard.Shard, lom *core.LOM) (err error) {
 			goto exit
 		}
 
-		file, err := cos.NewFileHandle(lom.FQN)
+		file, err := lom.NewHandle()
 		if err != nil {
 			return err
 		}
@@ -925,6 +968,7 @@ type extractShard struct {
 	metrics *LocalExtraction
 	name    string
 	isRange bool
+	cold    bool // listed remotely - cold GET if not present
 }
 
 func (es *extractShard) do() (err error) {
@@ -966,7 +1010,13 @@ func (es *extractShard) _do(lom *core.LOM) error {
 	if _, local, err := lom.HrwTarget(m.smap); err != nil || !local {
 		return err
 	}
-	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+	err := lom.Load(false /*cache it*/, false /*locked*/)
+	if err != nil && es.cold && cmn.IsErrObjNought(err) {
+		if _, err = core.T.GetCold(context.Background(), lom, cmn.OwtGetLock); err == nil {
+			err = lom.Load(false /*cache it*/, false /*locked*/)
+		}
+	}
+	if err != nil {
 		if cmn.IsErrObjNought(err) {
 			msg := fmt.Sprintf("shard.do: %q does not exist", lom.Cname())
 			return m.react(m.Pars.MissingShards, msg)
@@ -999,24 +1049,45 @@ func (es *extractShard) _do(lom *core.LOM) error {
 		return err
 	}
 
-	lom.Lock(false)
-	fh, err := lom.Open()
-	if err != nil {
-		phaseInfo.adjuster.releaseSema(lom.Mountpath())
-		lom.Unlock(false)
-		return errors.Errorf("unable to open %s: %v", lom.Cname(), err)
+	var (
+		r    cos.ReadReaderAt
+		fh   cos.LomReader
+		xlom = lom // the shard to extract: original or ETL-transformed
+	)
+	if m.etlComm != nil {
+		// NOTE: not holding the lock - communicators lock (and load) the source on their own
+		sgl, err := m.transform(lom)
+		if err != nil {
+			phaseInfo.adjuster.releaseSema(lom.Mountpath())
+			return err
+		}
+		defer sgl.Free()
+		xlom = lom.CloneMD(lom.FQN)
+		xlom.SetSize(sgl.Size())
+		defer core.FreeLOM(xlom)
+		r = memsys.NewReader(sgl)
+	} else {
+		lom.Lock(false)
+		if fh, err = lom.Open(); err != nil {
+			phaseInfo.adjuster.releaseSema(lom.Mountpath())
+			lom.Unlock(false)
+			return errors.Errorf("unable to open %s: %v", lom.Cname(), err)
+		}
+		r = fh
 	}
 
-	expectedExtractedSize := uint64(float64(lom.Lsize()) / m.compressionRatio())
+	expectedExtractedSize := uint64(float64(xlom.Lsize()) / m.compressionRatio())
 	toDisk := m.dsorter.preShardExtraction(expectedExtractedSize)
 
-	extractedSize, extractedCount, err := shardRW.Extract(lom, fh, m.recm, toDisk)
-	cos.Close(fh)
+	extractedSize, extractedCount, err := shardRW.Extract(xlom, r, m.recm, toDisk)
 
-	m.addSizes(lom.Lsize(), extractedSize) // update compression rate
+	m.addSizes(xlom.Lsize(), extractedSize) // update compression rate
 
 	phaseInfo.adjuster.releaseSema(lom.Mountpath())
-	lom.Unlock(false)
+	if fh != nil {
+		cos.Close(fh)
+		lom.Unlock(false)
+	}
 
 	m.dsorter.postShardExtraction(expectedExtractedSize) // schedule freeing reserved memory on next memory update
 	if err != nil {
@@ -1063,3 +1134,26 @@ func (es *extractShard) _do(lom *core.LOM) error {
 	}
 	return nil
 }
+
+// transform the entire shard via the ETL (or ETL pipeline) specified by `etl_name`;
+// the result is then extracted from memory (see also: shard.NoOffsetRW)
+func (m *Manager) transform(lom *core.LOM) (*memsys.SGL, error) {
+	r, err := m.etlComm.OfflineTransform(lom, 0 /*timeout*/)
+	if err != nil {
+		return nil, errors.Errorf("failed to transform shard %s via %s: %v", lom.Cname(), m.etlComm, err)
+	}
+	size := r.Size()
+	if size <= 0 {
+		size = lom.Lsize() // unknown (e.g., streamed by the last stage of a pipeline)
+	}
+	sgl := core.T.PageMM().NewSGL(size)
+	_, err = io.Copy(sgl, r) // (SGL implements io.ReaderFrom)
+	if errC := r.Close(); err == nil {
+		err = errC
+	}
+	if err != nil {
+		sgl.Free()
+		return nil, errors.Errorf("failed to read %s-transformed shard %s: %v", m.etlComm, lom.Cname(), err)
+	}
+	return sgl, nil
+}
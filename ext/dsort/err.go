@@ -25,6 +25,7 @@ var (
 	errNegConcLimit      = errors.New("negative concurrency limit")
 	errMissingOutputSize = errors.New("output shard size must be set (cannot be 0 and cannot be omitted)")
 	errMissingSrcBucket  = errors.New("missing source bucket")
+	errRegexNotPrefix    = errors.New("requires prefix-based input_format (cannot be used with list or range)")
 )
 
 func (m *Manager) newErrAborted() error {
@@ -22,6 +22,7 @@ import (
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/ext/dsort/ct"
 	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/stats"
@@ -89,6 +90,7 @@ type (
 		smap               *meta.Smap
 		recm               *shard.RecordManager
 		shardRW            shard.RW
+		etlComm            etl.Communicator // when transforming input shards (see `etl_name`)
 		startShardCreation chan struct{}
 		client             *http.Client // Client for sending records metadata
 		compression        struct {
@@ -193,6 +195,14 @@ func (m *Manager) init(pars *parsedReqSpec) error {
 		return err
 	}
 
+	if pars.ETLName != "" {
+		comm, err := etl.GetCommunicator(pars.ETLName) // (single ETL or pipeline)
+		if err != nil {
+			return err
+		}
+		m.etlComm = comm
+	}
+
 	// NOTE: Total size of the records metadata can sometimes be large
 	// and so this is why we need such a long timeout.
 	m.config = cmn.GCO.Get()
@@ -473,6 +483,10 @@ func (m *Manager) setRW() (err error) {
 	if m.Pars.DryRun {
 		m.shardRW = shard.NopRW(m.shardRW)
 	}
+	if m.Pars.ETLName != "" && m.shardRW != nil {
+		// transformed shards are extracted from memory - no offsets into the original
+		m.shardRW = shard.NoOffsetRW(m.shardRW)
+	}
 
 	m.recm = shard.NewRecordManager(m.Pars.InputBck, m.shardRW, ke, m.onDupRecs)
 	return nil
@@ -217,6 +217,52 @@ var _ = Describe("RequestSpec", func() {
 			}))
 		})
 
+		It("should parse spec with prefix and regex", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("train/"),
+				InputRegex:      "shard-[0-9]+",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       Algorithm{Kind: None},
+			}
+			pars, err := rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.Pit.isPrefix()).To(BeTrue())
+			Expect(pars.Pit.Prefix).To(Equal("train/"))
+			Expect(pars.Pit.Regex).To(Equal("shard-[0-9]+"))
+			Expect(pars.InputExtension).To(Equal(archive.ExtTar))
+		})
+
+		It("should parse spec with ETL pipeline", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111}-suffix"),
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       Algorithm{Kind: None},
+				ETLName:         "decode,augment,encode",
+			}
+			pars, err := rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.ETLName).To(Equal("decode,augment,encode"))
+		})
+
+		It("should parse spec with empty input format as match-all prefix", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       Algorithm{Kind: None},
+			}
+			pars, err := rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.Pit.isPrefix()).To(BeTrue())
+			Expect(pars.Pit.Prefix).To(Equal(cos.EmptyMatchAll))
+		})
+
 		It("should parse spec and set default conc limits", func() {
 			rs := RequestSpec{
 				InputBck:            cmn.Bck{Name: "test"},
@@ -478,6 +524,47 @@ var _ = Describe("RequestSpec", func() {
 			Expect(err).Should(HaveOccurred())
 		})
 
+		It("should fail due to regex used with range input format", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111}-suffix"),
+				InputRegex:      "suffix$",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+			}
+			_, err := rs.parse()
+			Expect(err).To(MatchError(ContainSubstring("input_regex")))
+		})
+
+		It("should fail due to invalid regex", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("train/"),
+				InputRegex:      "shard-[0-9",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+			}
+			_, err := rs.parse()
+			Expect(err).To(MatchError(ContainSubstring("input_regex")))
+		})
+
+		It("should fail due to invalid ETL name", func() {
+			for _, etlName := range []string{"Decode", "decode,,encode", "decode,"} {
+				rs := RequestSpec{
+					InputBck:        cmn.Bck{Name: "test"},
+					InputExtension:  archive.ExtTar,
+					InputFormat:     newInputFormat("prefix-{0010..0111}-suffix"),
+					OutputFormat:    "prefix-{0010..0111}-suffix",
+					OutputShardSize: "10KB",
+					ETLName:         etlName,
+				}
+				_, err := rs.parse()
+				Expect(err).To(MatchError(ContainSubstring("etl_name")), etlName)
+			}
+		})
+
 		It("should fail when output shard size is empty and output format is %06d", func() {
 			rs := RequestSpec{
 				InputBck:       cmn.Bck{Name: "test"},
@@ -0,0 +1,152 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"golang.org/x/sync/errgroup"
+)
+
+// prefix-based input selection (see Manager.iterPrefix)
+type prefixIter struct {
+	m     *Manager
+	ctx   context.Context
+	group *errgroup.Group
+	bck   *meta.Bck
+	regex *regexp.Regexp
+}
+
+// ais:// bucket: each target walks its own mountpaths
+func (pi *prefixIter) walk() error {
+	opts := pi.jopts(func(objName string) error { return pi.visit(objName, false /*cold*/) })
+	jg := mpather.NewJoggerGroup(opts, cmn.GCO.Get(), nil)
+	if jg.Num() == 0 {
+		return nil
+	}
+	jg.Run()
+	select {
+	case <-jg.ListenFinished():
+	case <-pi.m.listenAborted():
+	case <-pi.ctx.Done():
+	}
+	err := jg.Stop()
+	if pi.m.aborted() {
+		return pi.m.newErrAborted()
+	}
+	return err
+}
+
+func (pi *prefixIter) jopts(visit func(objName string) error) *mpather.JgroupOpts {
+	opts := &mpather.JgroupOpts{
+		CTs: []string{fs.ObjectType},
+		VisitObj: func(lom *core.LOM, _ []byte) error {
+			// not loading, skip mirror copies (and objects misplaced across mountpaths) -
+			// the one on its HRW mountpath represents the object
+			if !lom.IsHRW() {
+				return nil
+			}
+			return visit(lom.ObjName)
+		},
+		Prefix: pi.m.Pars.Pit.Prefix,
+		// DoLoad:  noLoad (extractShard will load)
+	}
+	opts.Bck.Copy(pi.bck.Bucket())
+	return opts
+}
+
+// remote bucket: list the backend (all targets do the same), select local shards
+func (pi *prefixIter) list() error {
+	var (
+		msg     = &apc.LsoMsg{Prefix: pi.m.Pars.Pit.Prefix}
+		backend = core.T.Backend(pi.bck)
+	)
+	for {
+		lst := &cmn.LsoRes{}
+		if ecode, err := backend.ListObjects(pi.bck, msg, lst); err != nil {
+			nlog.Errorln(core.T.String(), pi.m.ManagerUUID, "[", err, "ecode", ecode, "]")
+			return err
+		}
+		for _, en := range lst.Entries {
+			if en.IsDir() {
+				continue
+			}
+			if !pi.local(en.Name) {
+				continue
+			}
+			if err := pi.visit(en.Name, true /*cold*/); err != nil {
+				return err
+			}
+		}
+		if lst.ContinuationToken == "" {
+			return nil
+		}
+		msg.ContinuationToken = lst.ContinuationToken
+	}
+}
+
+func (pi *prefixIter) local(objName string) bool {
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(pi.bck.Bucket()); err != nil {
+		return false
+	}
+	_, local, err := lom.HrwTarget(pi.m.smap)
+	return err == nil && local
+}
+
+// (may be called concurrently by mountpath joggers)
+func (pi *prefixIter) visit(objName string, cold bool) error {
+	m := pi.m
+	select {
+	case <-m.listenAborted():
+		return m.newErrAborted()
+	case <-pi.ctx.Done():
+		return pi.ctx.Err()
+	default:
+	}
+	if !pi.selected(objName) {
+		return nil
+	}
+	metrics := m.Metrics.Extraction
+	metrics.mu.Lock()
+	metrics.TotalCnt++
+	metrics.mu.Unlock()
+
+	m.extractionPhase.adjuster.acquireGoroutineSema()
+	es := &extractShard{m, metrics, objName, false /*is-range*/, cold}
+	pi.group.Go(es.do)
+	return nil
+}
+
+func (pi *prefixIter) selected(objName string) bool {
+	if pi.regex != nil && !pi.regex.MatchString(objName) {
+		return false
+	}
+	if pi.m.Pars.InputExtension == "" {
+		return true // any supported shard format (see extractShard)
+	}
+	ext, err := archive.Mime("", objName)
+	if err != nil {
+		return false
+	}
+	if !archive.EqExt(ext, pi.m.Pars.InputExtension) {
+		if cmn.Rom.FastV(5, cos.SmoduleDsort) {
+			nlog.Infof("%s: %s skipping %s: %q vs %q", core.T, pi.m.ManagerUUID, objName, ext, pi.m.Pars.InputExtension)
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("prefixIter", func() {
+	const (
+		numMpaths = 3
+		numShards = 50
+		prefix    = "shard-"
+	)
+	var (
+		dir string
+		bck = cmn.Bck{
+			Name:     "mirrored",
+			Provider: apc.AIS,
+			Ns:       cmn.NsGlobal,
+			Props: &cmn.Bprops{
+				Cksum:  cmn.CksumConf{Type: cos.ChecksumNone},
+				Mirror: cmn.MirrorConf{Enabled: true, Copies: 2},
+				BID:    0xd5e6f7a8,
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "dsort-prefix")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		fs.TestNew(mock.NewIOS())
+		for i := range numMpaths {
+			mpath := filepath.Join(dir, "mpath"+strconv.Itoa(i))
+			Expect(cos.CreateDir(mpath)).ShouldNot(HaveOccurred())
+			_, err = fs.Add(mpath, "daeID")
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+		fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+		fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+
+		core.T = mock.NewTarget(mock.NewBaseBownerMock((*meta.Bck)(&bck)))
+		Expect(fs.CreateBucket(&bck, false /*nilbmd*/)).Should(BeEmpty())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).ShouldNot(HaveOccurred())
+	})
+
+	It("should visit each object of a mirrored bucket exactly once", func() {
+		// each shard: at its HRW location and a mirror copy on another mountpath
+		for i := range numShards {
+			objName := prefix + strconv.Itoa(i) + ".tar"
+			fqn, _, err := core.HrwFQN(&bck, fs.ObjectType, objName)
+			Expect(err).ShouldNot(HaveOccurred())
+			for _, mi := range fs.GetAvail() {
+				cfqn := mi.MakePathFQN(&bck, fs.ObjectType, objName)
+				if cfqn == fqn {
+					continue
+				}
+				for _, f := range []string{fqn, cfqn} {
+					fh, err := cos.CreateFile(f)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(fh.Close()).ShouldNot(HaveOccurred())
+				}
+				break
+			}
+		}
+
+		var (
+			mu      sync.Mutex
+			visited = make(map[string]int, numShards)
+			pi      = &prefixIter{
+				m:   &Manager{Pars: &parsedReqSpec{Pit: &parsedInputTemplate{Prefix: prefix}}},
+				bck: (*meta.Bck)(&bck),
+			}
+			opts = pi.jopts(func(objName string) error {
+				mu.Lock()
+				visited[objName]++
+				mu.Unlock()
+				return nil
+			})
+			jg = mpather.NewJoggerGroup(opts, cmn.GCO.Get(), nil)
+		)
+		jg.Run()
+		<-jg.ListenFinished()
+		Expect(jg.Stop()).ShouldNot(HaveOccurred())
+
+		Expect(visited).To(HaveLen(numShards))
+		for objName, cnt := range visited {
+			Expect(cnt).To(Equal(1), objName)
+		}
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -15,13 +16,16 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/archive"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	"github.com/NVIDIA/aistore/ext/etl"
 )
 
 type parsedInputTemplate struct {
 	Template cos.ParsedTemplate `json:"template"`
 	ObjNames []string           `json:"objnames"`
 	Prefix   string             `json:"prefix"`
+	Regex    string             `json:"regex"` // optional; prefix-based selection only
 }
 
 type parsedOutputTemplate struct {
@@ -52,6 +56,7 @@ type parsedReqSpec struct {
 	ExtractConcMaxLimit int                   `json:"extract_concurrency_max_limit"`
 	CreateConcMaxLimit  int                   `json:"create_concurrency_max_limit"`
 	SbundleMult         int                   `json:"bundle_multiplier"`
+	ETLName             string                `json:"etl_name"`
 
 	// debug
 	DsorterType string `json:"dsorter_type"`
@@ -118,6 +123,15 @@ func (rs *RequestSpec) parse() (*parsedReqSpec, error) {
 	if err != nil {
 		return nil, specErr("input_format", err)
 	}
+	if rs.InputRegex != "" {
+		if !pars.Pit.isPrefix() {
+			return nil, specErr("input_regex", errRegexNotPrefix)
+		}
+		if _, err := regexp.CompilePOSIX(rs.InputRegex); err != nil {
+			return nil, specErr("input_regex", err)
+		}
+		pars.Pit.Regex = rs.InputRegex
+	}
 	if rs.InputFormat.Template != "" {
 		// template is not a filename but all we do here is
 		// checking the template's suffix for specific supported extensions
@@ -206,6 +220,16 @@ func (rs *RequestSpec) parse() (*parsedReqSpec, error) {
 		return nil, fmt.Errorf("%w ('create', %d)", errNegConcLimit, rs.CreateConcMaxLimit)
 	}
 
+	// etl (or pipeline) - existence is checked by each target (see Manager.init)
+	if rs.ETLName != "" {
+		for _, name := range strings.Split(rs.ETLName, etl.PipelineSeparator) {
+			if err := k8s.ValidateEtlName(name); err != nil {
+				return nil, specErr("etl_name", err)
+			}
+		}
+		pars.ETLName = rs.ETLName
+	}
+
 	pars.ExtractConcMaxLimit = rs.ExtractConcMaxLimit
 	pars.CreateConcMaxLimit = rs.CreateConcMaxLimit
 	pars.DsorterType = rs.DsorterType
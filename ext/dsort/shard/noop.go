@@ -13,11 +13,21 @@ import (
 )
 
 // interface guard
-var _ RW = (*nopRW)(nil)
+var (
+	_ RW = (*nopRW)(nil)
+	_ RW = (*noOffsetRW)(nil)
+)
 
-type nopRW struct {
-	internal RW
-}
+type (
+	nopRW struct {
+		internal RW
+	}
+	// records of the shards that are not extracted from their on-disk originals
+	// (e.g., ETL-transformed) cannot be referenced by offset (see OffsetStoreType)
+	noOffsetRW struct {
+		RW
+	}
+)
 
 func NopRW(internal RW) RW { return &nopRW{internal: internal} }
 
@@ -45,3 +55,7 @@ func (*nopRW) Create(s *Shard, w io.Writer, loader ContentLoader) (written int64
 	}
 	return written, nil
 }
+
+func NoOffsetRW(internal RW) RW { return &noOffsetRW{internal} }
+
+func (*noOffsetRW) SupportsOffset() bool { return false }
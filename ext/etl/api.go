@@ -5,6 +5,7 @@
 package etl
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"sort"
@@ -32,6 +33,8 @@ const CommTypeSeparator = "://"
 
 const DefaultTimeout = 45 * time.Second
 
+var wasmMagic = []byte("\x00asm")
+
 // enum communication types (`commTypes`)
 const (
 	// ETL container receives POST request from target with the data. It
@@ -46,6 +49,9 @@ const (
 	Hrev = "hrev://"
 	// Stdin/stdout communication.
 	HpushStdin = "io://"
+	// Long-lived WebSocket connection (one per target) that pipelines many objects
+	// concurrently, with per-message IDs and back-pressure (see ws.go).
+	WebSocket = "ws://"
 )
 
 // enum arg types (`argTypes`)
@@ -106,6 +112,11 @@ type (
 		ObjCount int64  `json:"obj_count"`
 		InBytes  int64  `json:"in_bytes"`
 		OutBytes int64  `json:"out_bytes"`
+		// comm-type ws:// only
+		Inflight  int64 `json:"inflight,omitempty"`  // requests currently awaiting replies
+		Throttled int64 `json:"throttled,omitempty"` // number of times back-pressure blocked a sender
+		// pipeline only (see pipeline.go)
+		Stages []StageInfo `json:"stages,omitempty"`
 	}
 
 	LogsByTarget []Logs
@@ -129,8 +140,8 @@ type (
 )
 
 var (
-	commTypes = []string{Hpush, Hpull, Hrev, HpushStdin}         // NOTE: must contain all
-	argTypes  = []string{ArgTypeDefault, ArgTypeURL, ArgTypeFQN} // ditto
+	commTypes = []string{Hpush, Hpull, Hrev, HpushStdin, WebSocket} // NOTE: must contain all
+	argTypes  = []string{ArgTypeDefault, ArgTypeURL, ArgTypeFQN}    // ditto
 )
 
 ////////////////
@@ -202,9 +213,9 @@ func (m *InitMsgBase) validate(detail string) error {
 		err := fmt.Errorf("arg-type %q requires comm-type %q (%q is not supported yet)", m.ArgTypeX, Hpull, m.CommTypeX)
 		return cmn.NewErrETLf(errCtx, ferr, err, detail)
 	}
-	if m.ArgTypeX == ArgTypeFQN && !(m.CommTypeX == Hpull || m.CommTypeX == Hpush) {
-		err := fmt.Errorf("arg-type %q requires comm-type (%q or %q) - %q is not supported yet",
-			m.ArgTypeX, Hpull, Hpush, m.CommTypeX)
+	if m.ArgTypeX == ArgTypeFQN && !(m.CommTypeX == Hpull || m.CommTypeX == Hpush || m.CommTypeX == WebSocket) {
+		err := fmt.Errorf("arg-type %q requires comm-type (%q, %q, or %q) - %q is not supported yet",
+			m.ArgTypeX, Hpull, Hpush, WebSocket, m.CommTypeX)
 		return cmn.NewErrETLf(errCtx, ferr, err, detail)
 	}
 
@@ -240,8 +251,22 @@ func (m *InitCodeMsg) Validate() error {
 	if m.Runtime == "" {
 		return fmt.Errorf("runtime is not specified (comm-type %q)", m.CommTypeX)
 	}
-	if _, ok := runtime.Get(m.Runtime); !ok {
-		return fmt.Errorf("unsupported runtime %q (supported: %v)", m.Runtime, runtime.GetNames())
+	if m.Runtime == runtime.Wasm {
+		// in-process: comm-type does not apply; the code is compiled module (see wasm.go)
+		if !bytes.HasPrefix(m.Code, wasmMagic) {
+			return fmt.Errorf("runtime %q: expecting binary WebAssembly module", m.Runtime)
+		}
+		if len(m.Deps) > 0 {
+			return fmt.Errorf("runtime %q does not support dependencies", m.Runtime)
+		}
+		if m.ArgTypeX != ArgTypeDefault {
+			return fmt.Errorf("runtime %q does not support arg-type %q", m.Runtime, m.ArgTypeX)
+		}
+	} else if _, ok := runtime.Get(m.Runtime); !ok {
+		return fmt.Errorf("unsupported runtime %q (supported: %v)", m.Runtime, append(runtime.GetNames(), runtime.Wasm))
+	} else if m.CommTypeX == WebSocket {
+		// pre-built runtimes do not serve ws:// - use init-spec with a custom transformer
+		return fmt.Errorf("runtime %q does not support comm-type %q", m.Runtime, m.CommTypeX)
 	}
 
 	if m.Funcs.Transform == "" {
@@ -34,6 +34,7 @@ type etlBootstrapper struct {
 
 	// runtime
 	xctn            core.Xact
+	proc            *etlProc // process-based runtime (no K8s) - see proc.go
 	pod             *corev1.Pod
 	svc             *corev1.Service
 	uri             string
@@ -41,6 +42,11 @@ type etlBootstrapper struct {
 	originalCommand []string
 }
 
+func newBootstrapper(msg *InitSpecMsg, opts StartOpts, config *cmn.Config) *etlBootstrapper {
+	errCtx := &cmn.ETLErrCtx{TID: core.T.SID(), ETLName: msg.IDX}
+	return &etlBootstrapper{errCtx: errCtx, config: config, env: opts.Env, msg: *msg}
+}
+
 func (b *etlBootstrapper) createPodSpec() (err error) {
 	if b.pod, err = ParsePodSpec(b.errCtx, b.msg.Spec); err != nil {
 		return
@@ -332,7 +338,7 @@ func (b *etlBootstrapper) _setPodEnv() {
 	for idx := range containers {
 		containers[idx].Env = append(containers[idx].Env, corev1.EnvVar{
 			Name:  "AIS_TARGET_URL",
-			Value: core.T.Snode().URL(cmn.NetPublic) + apc.URLPathETLObject.Join(reqSecret),
+			Value: targetURL(),
 		})
 		for k, v := range b.env {
 			containers[idx].Env = append(containers[idx].Env, corev1.EnvVar{
@@ -351,6 +357,11 @@ func (b *etlBootstrapper) _setPodEnv() {
 	}
 }
 
+// AIS_TARGET_URL: the endpoint to GET objects from (hpull, hrev)
+func targetURL() string {
+	return core.T.Snode().URL(cmn.NetPublic) + apc.URLPathETLObject.Join(reqSecret)
+}
+
 func (b *etlBootstrapper) _getHost() (string, error) {
 	client, err := k8s.GetClient()
 	if err != nil {
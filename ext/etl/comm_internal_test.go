@@ -5,17 +5,21 @@
 package etl
 
 import (
+	"bytes"
 	cryptorand "crypto/rand"
+	"encoding/binary"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -23,6 +27,8 @@ import (
 	"github.com/NVIDIA/aistore/fs"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/gorilla/websocket"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -85,7 +91,11 @@ var _ = Describe("CommunicatorTest", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		// Initialize the HTTP servers.
-		transformerServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		transformerServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if websocket.IsWebSocketUpgrade(r) {
+				serveWs(w, r, func([]byte) []byte { return transformData })
+				return
+			}
 			_, err := w.Write(transformData)
 			Expect(err).NotTo(HaveOccurred())
 		}))
@@ -109,6 +119,7 @@ var _ = Describe("CommunicatorTest", func() {
 		Hpush,
 		Hpull,
 		Hrev,
+		WebSocket,
 	}
 
 	for _, commType := range tests {
@@ -141,6 +152,161 @@ var _ = Describe("CommunicatorTest", func() {
 	}
 })
 
+var _ = Describe("WebSocketCommunicator", func() {
+	const numObjs = 3 * wsMaxInflight
+
+	var (
+		tmpDir string
+		server *httptest.Server
+		comm   *wsComm
+		loms   []*core.LOM
+
+		clusterBck = meta.NewBck("wsBck", apc.AIS, cmn.NsGlobal, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}})
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "")
+		Expect(err).NotTo(HaveOccurred())
+		mpath := filepath.Join(tmpDir, "mpath")
+		Expect(cos.CreateDir(mpath)).To(Succeed())
+		fs.TestNew(nil)
+		_, err = fs.Add(mpath, "daeID")
+		Expect(err).NotTo(HaveOccurred())
+		_ = mock.NewTarget(mock.NewBaseBownerMock(clusterBck))
+
+		loms = loms[:0]
+		for i := range numObjs {
+			lom := &core.LOM{ObjName: fmt.Sprintf("obj-%d", i)}
+			Expect(lom.InitBck(clusterBck.Bucket())).To(Succeed())
+			Expect(createRandomFile(lom.FQN, int64(i+1))).To(Succeed())
+			lom.SetAtimeUnix(time.Now().UnixNano())
+			lom.SetSize(int64(i + 1))
+			Expect(lom.Persist()).To(Succeed())
+			loms = append(loms, lom)
+		}
+	})
+
+	AfterEach(func() {
+		if comm != nil {
+			comm.stopped.Store(true)
+			if comm.conn != nil {
+				comm.conn.Close()
+			}
+		}
+		if server != nil {
+			server.Close()
+		}
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	newComm := func(transform func([]byte) []byte) {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal(wsPath))
+			serveWs(w, r, transform)
+		}))
+		boot := &etlBootstrapper{
+			msg:  InitSpecMsg{InitMsgBase: InitMsgBase{CommTypeX: WebSocket}},
+			pod:  &corev1.Pod{},
+			uri:  server.URL,
+			xctn: mock.NewXact(apc.ActETLBck),
+		}
+		comm = newCommunicator(nil, boot).(*wsComm)
+	}
+
+	It("should pipeline concurrent requests over a single connection", func() {
+		newComm(func(b []byte) []byte {
+			time.Sleep(time.Duration(len(b)%7) * time.Millisecond) // reply out of order
+			return bytes.ToUpper(b)
+		})
+
+		var (
+			wg   sync.WaitGroup
+			errs = make(chan error, numObjs)
+		)
+		for _, lom := range loms {
+			wg.Add(1)
+			go func(lom *core.LOM) {
+				defer wg.Done()
+				r, err := comm.OfflineTransform(lom, time.Minute)
+				if err != nil {
+					errs <- err
+					return
+				}
+				b, err := cos.ReadAll(r)
+				r.Close()
+				if err == nil {
+					var orig []byte
+					orig, err = os.ReadFile(lom.FQN)
+					if err == nil && !bytes.Equal(b, bytes.ToUpper(orig)) {
+						err = fmt.Errorf("%s: unexpected content", lom.Cname())
+					}
+				}
+				errs <- err
+			}(lom)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(comm.OutBytes()).To(BeEquivalentTo(numObjs * (numObjs + 1) / 2))
+		Expect(comm.Inflight()).To(BeZero())
+		Expect(comm.Throttled()).To(BeNumerically(">", 0))
+	})
+
+	It("should fail pending requests and reconnect", func() {
+		var dropped atomic.Bool
+		newComm(func(b []byte) []byte {
+			if dropped.CAS(false, true) {
+				return nil // drop the first connection
+			}
+			return b
+		})
+		_, err := comm.OfflineTransform(loms[0], time.Minute)
+		Expect(err).To(HaveOccurred())
+
+		r, err := comm.OfflineTransform(loms[1], time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		b, err := cos.ReadAll(r)
+		r.Close()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(HaveLen(2))
+	})
+})
+
+// serveWs implements the ws:// side of a transformer (see ws.go for the message format)
+func serveWs(w http.ResponseWriter, r *http.Request, transform func([]byte) []byte) {
+	upgrader := websocket.Upgrader{}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	Expect(err).NotTo(HaveOccurred())
+	defer conn.Close()
+
+	var wmu sync.Mutex
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer GinkgoRecover()
+			plen := int(binary.BigEndian.Uint16(msg[cos.SizeofI64:]))
+			body := transform(msg[wsHdrSize+plen:])
+			if body == nil {
+				conn.Close()
+				return
+			}
+			reply := make([]byte, wsHdrSize, wsHdrSize+len(body))
+			copy(reply, msg[:cos.SizeofI64])
+			binary.BigEndian.PutUint16(reply[cos.SizeofI64:], http.StatusOK)
+			reply = append(reply, body...)
+			wmu.Lock()
+			_ = conn.WriteMessage(websocket.BinaryMessage, reply)
+			wmu.Unlock()
+		}()
+	}
+}
+
 // Creates a file with random content.
 func createRandomFile(fileName string, size int64) error {
 	b := make([]byte, size)
@@ -41,6 +41,9 @@ type (
 		PodName() string
 		SvcName() string
 
+		// local (non-K8s) runtime, if any: process (proc.go) or WASM (wasm.go)
+		local() localRuntime
+
 		String() string
 
 		// InlineTransform uses one of the two ETL container endpoints:
@@ -53,6 +56,7 @@ type (
 		// - pushComm
 		// - redirectComm
 		// - revProxyComm
+		// - wsComm (ws.go)
 		// See also, and separately: on-the-fly transformation as part of a user (e.g. training model) GET request handling
 		OfflineTransform(lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error)
 
@@ -77,6 +81,14 @@ type (
 		rp *httputil.ReverseProxy
 	}
 
+	// transformer runtime other than K8s pod (see proc.go, wasm.go)
+	localRuntime interface {
+		logBytes() []byte
+		health() string
+		metrics() (cpu float64, mem int64, err error)
+		stop()
+	}
+
 	// TODO: Generalize and move to `cos` package
 	cbWriter struct {
 		w       io.Writer
@@ -130,6 +142,8 @@ func newCommunicator(listener meta.Slistener, boot *etlBootstrapper) Communicato
 		}
 		rp.rp = revProxy
 		return rp
+	case WebSocket:
+		return newWsComm(listener, boot)
 	}
 
 	debug.Assert(false, "unknown comm-type '"+boot.msg.CommTypeX+"'")
@@ -140,6 +154,15 @@ func (c *baseComm) Name() string    { return c.boot.originalPodName }
 func (c *baseComm) PodName() string { return c.boot.pod.Name }
 func (c *baseComm) SvcName() string { return c.boot.pod.Name /*same as pod name*/ }
 
+func (c *baseComm) local() localRuntime {
+	if c.boot.proc == nil {
+		return nil
+	}
+	return c.boot.proc
+}
+
+func (c *baseComm) argType() string { return c.boot.msg.ArgTypeX }
+
 func (c *baseComm) ListenSmapChanged() { c.listener.ListenSmapChanged() }
 
 func (c *baseComm) String() string {
@@ -222,11 +245,8 @@ func (pc *pushComm) doRequest(lom *core.LOM, timeout time.Duration) (r cos.ReadC
 
 func (pc *pushComm) do(lom *core.LOM, timeout time.Duration) (_ cos.ReadCloseSizer, ecode int, err error) {
 	var (
-		body   io.ReadCloser
-		cancel func()
-		req    *http.Request
-		resp   *http.Response
-		u      string
+		body io.ReadCloser
+		u    string
 	)
 	if err := pc.boot.xctn.AbortErr(); err != nil {
 		return nil, 0, err
@@ -236,6 +256,10 @@ func (pc *pushComm) do(lom *core.LOM, timeout time.Duration) (_ cos.ReadCloseSiz
 	}
 	size := lom.Lsize()
 
+	if p := pc.boot.proc; p != nil && pc.boot.msg.CommTypeX == HpushStdin {
+		return pc.doProc(p, lom, size, timeout)
+	}
+
 	switch pc.boot.msg.ArgTypeX {
 	case ArgTypeDefault, ArgTypeURL:
 		// to remove the following assert (and the corresponding limitation):
@@ -244,18 +268,29 @@ func (pc *pushComm) do(lom *core.LOM, timeout time.Duration) (_ cos.ReadCloseSiz
 		debug.Assert(lom.Bck().Ns.IsGlobal(), lom.Bck().Cname(""), " - bucket with namespace")
 		u = pc.boot.uri + "/" + lom.Bck().Name + "/" + lom.ObjName
 
-		fh, err := cos.NewFileHandle(lom.FQN)
+		fh, err := lom.NewHandle()
 		if err != nil {
 			return nil, 0, err
 		}
 		body = fh
 	case ArgTypeFQN:
+		if lom.IsChunked() {
+			return nil, 0, errChunkedFQN(lom)
+		}
 		body = http.NoBody
 		u = cos.JoinPath(pc.boot.uri, url.PathEscape(lom.FQN)) // compare w/ rc.redirectURL()
 	default:
 		debug.Assert(false, "unexpected msg type:", pc.boot.msg.ArgTypeX) // is validated at construction time
 	}
+	return pc.put(u, body, size, timeout)
+}
 
+func (pc *pushComm) put(u string, body io.ReadCloser, size int64, timeout time.Duration) (_ cos.ReadCloseSizer, ecode int, err error) {
+	var (
+		cancel func()
+		req    *http.Request
+		resp   *http.Response
+	)
 	if timeout != 0 {
 		var ctx context.Context
 		ctx, cancel = context.WithTimeout(context.Background(), timeout)
@@ -307,6 +342,47 @@ finish:
 	return cos.NewReaderWithArgs(args), 0, nil
 }
 
+// pipeline stage (see pipeline.go): transform the output of the previous stage
+func (pc *pushComm) stream(r cos.ReadCloseSizer, lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := pc.boot.xctn.AbortErr(); err != nil {
+		r.Close()
+		return nil, err
+	}
+	if p := pc.boot.proc; p != nil && pc.boot.msg.CommTypeX == HpushStdin {
+		return pc.stdio(p, r, r.Size(), timeout)
+	}
+	u := pc.boot.uri + "/" + lom.Bck().Name + "/" + lom.ObjName
+	out, _, err := pc.put(u, r, r.Size(), timeout)
+	return out, err
+}
+
+// io:// via process-based runtime (no K8s): run the transformer locally
+func (pc *pushComm) doProc(p *etlProc, lom *core.LOM, size int64, timeout time.Duration) (cos.ReadCloseSizer, int, error) {
+	fh, err := lom.NewHandle()
+	if err != nil {
+		return nil, 0, err
+	}
+	r, err := pc.stdio(p, fh, size, timeout)
+	return r, 0, err
+}
+
+func (pc *pushComm) stdio(p *etlProc, fh io.ReadCloser, size int64, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	r, err := p.stdio(fh, timeout)
+	if err != nil {
+		return nil, err
+	}
+	args := cos.ReaderArgs{
+		R:      r,
+		Size:   -1,
+		ReadCb: func(n int, _ error) { pc.boot.xctn.InObjsAdd(0, int64(n)) },
+		DeferCb: func() {
+			pc.boot.xctn.InObjsAdd(1, 0)
+			pc.boot.xctn.OutObjsAdd(1, size)
+		},
+	}
+	return cos.NewReaderWithArgs(args), nil
+}
+
 func (pc *pushComm) InlineTransform(w http.ResponseWriter, _ *http.Request, lom *core.LOM) error {
 	r, err := pc.doRequest(lom, 0 /*timeout*/)
 	if err != nil {
@@ -337,6 +413,11 @@ func (pc *pushComm) OfflineTransform(lom *core.LOM, timeout time.Duration) (r co
 	return
 }
 
+// chunked object cannot be read by the container directly from its FQN (see core/lchunk.go)
+func errChunkedFQN(lom *core.LOM) error {
+	return fmt.Errorf("%s is chunked and cannot be passed to ETL by FQN (arg type %q)", lom.Cname(), ArgTypeFQN)
+}
+
 //////////////////
 // redirectComm: implements Hpull
 //////////////////
@@ -349,6 +430,9 @@ func (rc *redirectComm) InlineTransform(w http.ResponseWriter, r *http.Request,
 	if err != nil {
 		return err
 	}
+	if rc.boot.msg.ArgTypeX == ArgTypeFQN && lom.IsChunked() {
+		return errChunkedFQN(lom)
+	}
 	if size > 0 {
 		rc.boot.xctn.OutObjsAdd(1, size)
 	}
@@ -377,6 +461,9 @@ func (rc *redirectComm) OfflineTransform(lom *core.LOM, timeout time.Duration) (
 	if errV != nil {
 		return nil, errV
 	}
+	if rc.boot.msg.ArgTypeX == ArgTypeFQN && clone.IsChunked() {
+		return nil, errChunkedFQN(&clone)
+	}
 
 	etlURL := rc.redirectURL(&clone)
 	r, err := rc.getWithTimeout(etlURL, size, timeout)
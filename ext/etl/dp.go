@@ -5,6 +5,7 @@
 package etl
 
 import (
+	"context"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -40,14 +41,18 @@ func NewOfflineDP(msg *apc.TCBMsg, config *cmn.Config) (*OfflineDP, error) {
 }
 
 // Returns reader resulting from lom ETL transformation.
-// TODO -- FIXME: comm.OfflineTransform to support latestVer and sync
+// With `latestVer` or `sync`, the (remote) source is first revalidated - see `checkLatest`.
 func (dp *OfflineDP) Reader(lom *core.LOM, latestVer, sync bool) (cos.ReadOpenCloser, cos.OAH, error) {
 	var (
 		r      cos.ReadCloseSizer // note: +sizer
 		err    error
 		action = "read [" + dp.tcbmsg.Transform.Name + "]-transformed " + lom.Cname()
 	)
-	debug.Assert(!latestVer && !sync, "NIY") // TODO -- FIXME
+	if latestVer || sync {
+		if err = checkLatest(lom, sync); err != nil {
+			return nil, nil, err
+		}
+	}
 	call := func() (int, error) {
 		r, err = dp.comm.OfflineTransform(lom, dp.requestTimeout)
 		return 0, err
@@ -77,3 +82,39 @@ func (dp *OfflineDP) Reader(lom *core.LOM, latestVer, sync bool) (cos.ReadOpenCl
 	}
 	return cos.NopOpener(r), oah, nil
 }
+
+// Revalidate in-cluster copy of the remote source object prior to transforming it:
+// - version changed: GET the latest (and store it in-cluster, as in: cold GET);
+// - remotely deleted: return NotFound (having also removed the local copy when `sync`);
+// - not present in-cluster: nothing to do (the communicator will cold GET the latest).
+// (compare w/ core.LDP.Reader)
+func checkLatest(lom *core.LOM, sync bool) error {
+	debug.Assert(lom.Bck().IsRemote(), lom.Bck().String()) // caller's responsibility
+	lom.Lock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		if cos.IsNotExist(err, 0) {
+			return nil
+		}
+		return cmn.NewErrFailedTo(core.T, "etl-load", lom.Cname(), err)
+	}
+	res := lom.CheckRemoteMD(true /*rlocked*/, sync, nil /*origReq*/)
+	lom.Unlock(false)
+	if res.Err != nil {
+		if !cos.IsNotExist(res.Err, res.ErrCode) {
+			res.Err = cmn.NewErrFailedTo(core.T, "head-latest", lom.Cname(), res.Err)
+		}
+		return res.Err
+	}
+	if res.Eq {
+		return nil
+	}
+	// version changed
+	if _, err := core.T.GetCold(context.Background(), lom, cmn.OwtGetLock); err != nil {
+		return err
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln("etl: updated", lom.Cname(), "to the latest remote version")
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// remote backend as seen by `checkLatest`
+type latestTarget struct {
+	*mock.TargetMock
+	version string // remote version; empty when remotely deleted
+	heads   int
+	colds   int
+}
+
+func (t *latestTarget) HeadCold(*core.LOM, *http.Request) (*cmn.ObjAttrs, int, error) {
+	t.heads++
+	if t.version == "" {
+		return nil, http.StatusNotFound, errors.New("remotely deleted")
+	}
+	oa := &cmn.ObjAttrs{}
+	oa.SetVersion(t.version)
+	oa.SetCustomKey(cmn.ETag, "etag-"+t.version) // (see cmn.ObjAttrs.CheckEq)
+	return oa, 0, nil
+}
+
+func (t *latestTarget) GetCold(context.Context, *core.LOM, cmn.OWT) (int, error) {
+	t.colds++
+	return 0, nil
+}
+
+var _ = Describe("CheckLatest", func() {
+	const content = "remote content"
+
+	var (
+		tmpDir string
+		tgt    *latestTarget
+
+		remoteBck    = meta.NewBck("latestBck", apc.AWS, cmn.NsGlobal, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}})
+		oldProviders = cmn.GCO.Get().Backend.Providers
+	)
+
+	newLOM := func(name string) *core.LOM {
+		lom := &core.LOM{ObjName: name}
+		Expect(lom.InitBck(remoteBck.Bucket())).To(Succeed())
+		return lom
+	}
+
+	BeforeEach(func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Backend.Providers = map[string]cmn.Ns{apc.AWS: cmn.NsGlobal}
+		cmn.GCO.CommitUpdate(config)
+
+		var err error
+		tmpDir, err = os.MkdirTemp("", "")
+		Expect(err).NotTo(HaveOccurred())
+		mpath := filepath.Join(tmpDir, "mpath")
+		Expect(cos.CreateDir(mpath)).To(Succeed())
+		fs.TestNew(nil)
+		_, err = fs.Add(mpath, "daeID")
+		Expect(err).NotTo(HaveOccurred())
+
+		tgt = &latestTarget{TargetMock: &mock.TargetMock{BO: mock.NewBaseBownerMock(remoteBck)}, version: "1"}
+		core.Tinit(tgt, mock.NewStatsTracker(), nil /*config*/, false /*run HK*/)
+
+		// in-cluster copy (version 1)
+		lom := newLOM("obj")
+		f, err := cos.CreateFile(lom.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = f.WriteString(content)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+		lom.SetAtimeUnix(time.Now().UnixNano())
+		lom.SetSize(int64(len(content)))
+		lom.SetVersion("1")
+		lom.SetCustomKey(cmn.ETag, "etag-1")
+		Expect(lom.Persist()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tmpDir)
+		config := cmn.GCO.BeginUpdate()
+		config.Backend.Providers = oldProviders
+		cmn.GCO.CommitUpdate(config)
+	})
+
+	It("should skip objects that are not present in-cluster", func() {
+		Expect(checkLatest(newLOM("none"), false)).To(Succeed())
+		Expect(tgt.heads).To(BeZero())
+		Expect(tgt.colds).To(BeZero())
+	})
+
+	It("should keep the latest version", func() {
+		Expect(checkLatest(newLOM("obj"), false)).To(Succeed())
+		Expect(tgt.heads).To(Equal(1))
+		Expect(tgt.colds).To(BeZero())
+	})
+
+	It("should get the latest when version changes", func() {
+		tgt.version = "2"
+		Expect(checkLatest(newLOM("obj"), false)).To(Succeed())
+		Expect(tgt.colds).To(Equal(1))
+	})
+
+	It("should handle remotely deleted object", func() {
+		tgt.version = ""
+		lom := newLOM("obj")
+		err := checkLatest(lom, false /*sync*/)
+		Expect(cos.IsNotExist(err, 0)).To(BeTrue())
+		Expect(lom.FQN).To(BeARegularFile())
+
+		err = checkLatest(lom, true /*sync*/)
+		Expect(cos.IsNotExist(err, 0)).To(BeTrue())
+		Expect(lom.FQN).NotTo(BeAnExistingFile())
+		Expect(tgt.colds).To(BeZero())
+	})
+})
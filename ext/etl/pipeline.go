@@ -0,0 +1,227 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Pipeline is an ordered list of running ETLs referenced by their comma-separated names,
+// e.g. "decode,augment,encode" - anywhere a single ETL name is accepted:
+// inline GET (`apc.QparamETLName`), `apc.ActETLBck`, `apc.ActETLObjects`, and dsort (`etl_name`).
+//
+// On each target, the object is read and transformed by the first stage; the resulting
+// stream is then piped into the second stage, and so on - without intermediate buckets.
+// Since subsequent stages receive the data from their predecessors (rather than fetch
+// it on their own), they must support streaming: hpull:// and hrev:// (as well as
+// non-default arg types) are only permitted in the first stage.
+
+const (
+	PipelineSeparator = ","
+	maxPipelineStages = 8
+)
+
+type (
+	// per-stage metrics (see `Info.Stages`)
+	StageInfo struct {
+		Name     string `json:"id"`
+		ObjCount int64  `json:"obj_count"` // objects that passed through this stage
+		OutBytes int64  `json:"out_bytes"` // bytes produced by this stage
+		ErrCount int64  `json:"err_count"`
+	}
+
+	pipeline struct {
+		name   string
+		stages []*stage
+	}
+	stage struct {
+		comm     Communicator
+		name     string // (registered) ETL name
+		objs     atomic.Int64
+		outBytes atomic.Int64
+		errs     atomic.Int64
+	}
+
+	// implemented by communicators that can transform a stream of bytes
+	// produced by the previous stage of a pipeline (compare w/ `OfflineTransform`);
+	// `stream` takes ownership of (and eventually closes) the reader - even on error
+	streamer interface {
+		stream(r cos.ReadCloseSizer, lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error)
+		argType() string
+	}
+)
+
+// interface guard
+var (
+	_ Communicator = (*pipeline)(nil)
+
+	_ streamer = (*pushComm)(nil)
+	_ streamer = (*wsComm)(nil)
+	_ streamer = (*wasmComm)(nil)
+)
+
+func IsPipeline(name string) bool { return strings.Contains(name, PipelineSeparator) }
+
+// resolve pipeline stages; subsequent lookups reuse the same pipeline (and its metrics)
+// until one of its stages gets stopped
+func getPipeline(name string) (*pipeline, error) {
+	if p, ok := reg.getPipeline(name); ok {
+		return p, nil
+	}
+	names := strings.Split(name, PipelineSeparator)
+	if len(names) > maxPipelineStages {
+		return nil, fmt.Errorf("pipeline %q: too many stages (%d, max %d)", name, len(names), maxPipelineStages)
+	}
+	p := &pipeline{name: name, stages: make([]*stage, 0, len(names))}
+	for i, etlName := range names {
+		if etlName == "" {
+			return nil, fmt.Errorf("pipeline %q: empty ETL name (stage %d)", name, i+1)
+		}
+		comm, err := GetCommunicator(etlName)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			if err := canStream(comm); err != nil {
+				return nil, fmt.Errorf("pipeline %q, stage %d: %v", name, i+1, err)
+			}
+		}
+		p.stages = append(p.stages, &stage{comm: comm, name: etlName})
+	}
+	return reg.addPipeline(p), nil
+}
+
+func canStream(comm Communicator) error {
+	s, ok := comm.(streamer)
+	if !ok {
+		return fmt.Errorf("%s does not support streaming - can only be used as the first stage", comm)
+	}
+	if s.argType() != ArgTypeDefault {
+		return fmt.Errorf("%s with arg-type %q can only be used as the first stage", comm, s.argType())
+	}
+	return nil
+}
+
+func (p *pipeline) has(etlName string) bool {
+	for _, s := range p.stages {
+		if s.name == etlName {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *pipeline) last() *stage { return p.stages[len(p.stages)-1] }
+
+func (p *pipeline) info() Info {
+	last := p.last()
+	info := Info{
+		Name:     p.name,
+		XactID:   last.comm.Xact().ID(),
+		ObjCount: last.objs.Load(),
+		InBytes:  last.outBytes.Load(),
+		Stages:   make([]StageInfo, len(p.stages)),
+	}
+	for i, s := range p.stages {
+		info.Stages[i] = StageInfo{
+			Name:     s.name,
+			ObjCount: s.objs.Load(),
+			OutBytes: s.outBytes.Load(),
+			ErrCount: s.errs.Load(),
+		}
+	}
+	return info
+}
+
+func (p *pipeline) transform(lom *core.LOM, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
+	first := p.stages[0]
+	if r, err = first.comm.OfflineTransform(lom, timeout); err != nil {
+		first.errs.Inc()
+		return nil, err
+	}
+	r = first.wrap(r)
+	for _, s := range p.stages[1:] {
+		var out cos.ReadCloseSizer
+		if out, err = s.comm.(streamer).stream(r, lom, timeout); err != nil {
+			s.errs.Inc()
+			return nil, err
+		}
+		r = s.wrap(out)
+	}
+	return r, nil
+}
+
+//
+// Communicator
+//
+
+func (p *pipeline) Name() string      { return p.name }
+func (p *pipeline) Xact() core.Xact   { return p.last().comm.Xact() }
+func (*pipeline) PodName() string     { return "" }
+func (*pipeline) SvcName() string     { return "" }
+func (*pipeline) local() localRuntime { return nil }
+func (*pipeline) ListenSmapChanged()  {}
+func (p *pipeline) String() string    { return "pipeline[" + p.name + "]" }
+func (p *pipeline) ObjCount() int64   { return p.last().objs.Load() }
+func (p *pipeline) InBytes() int64    { return p.last().outBytes.Load() }
+func (*pipeline) OutBytes() int64     { return 0 }
+func (*pipeline) Stop()               {} // (stages are stopped individually)
+
+func (p *pipeline) InlineTransform(w http.ResponseWriter, _ *http.Request, lom *core.LOM) error {
+	r, err := p.transform(lom, 0 /*timeout*/)
+	if err != nil {
+		return err
+	}
+	// stream via fixed-size buffer - not sizing it by the (transformer-reported) output size
+	buf, slab := core.T.PageMM().AllocSize(memsys.DefaultBufSize)
+	_, err = io.CopyBuffer(w, r, buf)
+	slab.Free(buf)
+	if errC := r.Close(); err == nil {
+		err = errC
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(p.String(), lom.Cname(), err)
+	}
+	return err
+}
+
+func (p *pipeline) OfflineTransform(lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	r, err := p.transform(lom, timeout)
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(p.String(), lom.Cname(), err)
+	}
+	return r, err
+}
+
+///////////
+// stage //
+///////////
+
+// count objects and bytes produced by the stage (and consumed by the next one)
+func (s *stage) wrap(r cos.ReadCloseSizer) cos.ReadCloseSizer {
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:    r,
+		Size: r.Size(),
+		ReadCb: func(n int, err error) {
+			s.outBytes.Add(int64(n))
+			if err != nil && !errors.Is(err, io.EOF) {
+				s.errs.Inc()
+			}
+		},
+		DeferCb: func() { s.objs.Inc() },
+	})
+}
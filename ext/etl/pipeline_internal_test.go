@@ -0,0 +1,195 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/ext/etl/runtime"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Pipeline", func() {
+	const content = "hello, pipeline"
+
+	var (
+		tmpDir  string
+		lom     *core.LOM
+		servers []*httptest.Server
+		names   []string
+
+		clusterBck = meta.NewBck("pipelineBck", apc.AIS, cmn.NsGlobal, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}})
+	)
+
+	newBoot := func(name, commType, uri string) *etlBootstrapper {
+		pod := &corev1.Pod{}
+		pod.SetName(name)
+		return &etlBootstrapper{
+			msg:             InitSpecMsg{InitMsgBase: InitMsgBase{IDX: name, CommTypeX: commType}},
+			pod:             pod,
+			originalPodName: name,
+			uri:             uri,
+			xctn:            mock.NewXact(apc.ActETLInline),
+		}
+	}
+	register := func(name string, comm Communicator) {
+		Expect(reg.add(name, comm)).To(Succeed())
+		names = append(names, name)
+	}
+	// HTTP transformer (hpush:// and hpull://)
+	newServer := func(transform func([]byte) []byte) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == wsPath {
+				serveWs(w, r, transform)
+				return
+			}
+			b, err := cos.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = w.Write(transform(b))
+			Expect(err).NotTo(HaveOccurred())
+		}))
+		servers = append(servers, server)
+		return server.URL
+	}
+	find := func(name string) *Info {
+		for _, info := range List() {
+			if info.Name == name {
+				return &info
+			}
+		}
+		return nil
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "")
+		Expect(err).NotTo(HaveOccurred())
+		mpath := filepath.Join(tmpDir, "mpath")
+		Expect(cos.CreateDir(mpath)).To(Succeed())
+		fs.TestNew(nil)
+		_, err = fs.Add(mpath, "daeID")
+		Expect(err).NotTo(HaveOccurred())
+		_ = mock.NewTarget(mock.NewBaseBownerMock(clusterBck))
+
+		lom = &core.LOM{ObjName: "obj"}
+		Expect(lom.InitBck(clusterBck.Bucket())).To(Succeed())
+		f, err := cos.CreateFile(lom.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = f.WriteString(content)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+		lom.SetAtimeUnix(time.Now().UnixNano())
+		lom.SetSize(int64(len(content)))
+		Expect(lom.Persist()).To(Succeed())
+
+		// upper (WASM) => reverse (hpush://) => exclaim (ws://)
+		msg := &InitCodeMsg{Code: wasmUpper, Runtime: runtime.Wasm}
+		msg.Funcs.Transform = "transform"
+		wr, err := newWasmRuntime(msg)
+		Expect(err).NotTo(HaveOccurred())
+		wc := &wasmComm{wr: wr}
+		wc.boot = newBoot("upper", Hpush, "")
+		register("upper", wc)
+
+		reverse := func(b []byte) []byte {
+			b = slices.Clone(b)
+			slices.Reverse(b)
+			return b
+		}
+		register("reverse", newCommunicator(nil, newBoot("reverse", Hpush, newServer(reverse))))
+		exclaim := func(b []byte) []byte { return append(slices.Clone(b), '!') }
+		register("exclaim", newCommunicator(nil, newBoot("exclaim", WebSocket, newServer(exclaim))))
+		register("pull", newCommunicator(nil, newBoot("pull", Hpull, newServer(reverse))))
+	})
+
+	AfterEach(func() {
+		for _, name := range names {
+			c := reg.del(name)
+			if c == nil {
+				continue
+			}
+			if l := c.local(); l != nil {
+				l.stop()
+			}
+			if wc, ok := c.(*wsComm); ok && wc.conn != nil {
+				wc.stopped.Store(true)
+				wc.conn.Close()
+			}
+		}
+		names = names[:0]
+		for _, server := range servers {
+			server.Close()
+		}
+		servers = servers[:0]
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	It("should stream through all stages", func() {
+		const name = "upper,reverse,exclaim"
+		comm, err := GetCommunicator(name)
+		Expect(err).NotTo(HaveOccurred())
+
+		for range 2 {
+			r, err := comm.OfflineTransform(lom, time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			b, err := cos.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Close()).To(Succeed())
+			Expect(string(b)).To(Equal("ENILEPIP ,OLLEH!"))
+		}
+
+		// same pipeline (and metrics)
+		again, err := GetCommunicator(name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(BeIdenticalTo(comm))
+
+		info := find(name)
+		Expect(info).NotTo(BeNil())
+		Expect(info.ObjCount).To(BeEquivalentTo(2))
+		Expect(info.Stages).To(HaveLen(3))
+		for i, stage := range info.Stages {
+			Expect(stage.Name).To(Equal([]string{"upper", "reverse", "exclaim"}[i]))
+			Expect(stage.ObjCount).To(BeEquivalentTo(2))
+			Expect(stage.ErrCount).To(BeZero())
+		}
+		Expect(info.Stages[2].OutBytes).To(BeEquivalentTo(2 * (len(content) + 1)))
+
+		// stopping any stage invalidates the pipeline
+		reg.del("reverse")
+		Expect(find(name)).To(BeNil())
+		_, err = GetCommunicator(name)
+		Expect(cos.IsErrNotFound(err)).To(BeTrue())
+	})
+
+	It("should reject invalid pipelines", func() {
+		_, err := GetCommunicator("upper,nonexistent")
+		Expect(cos.IsErrNotFound(err)).To(BeTrue())
+
+		_, err = GetCommunicator("upper,,reverse")
+		Expect(err).To(MatchError(ContainSubstring("empty")))
+
+		// hpull:// is only permitted in the first stage
+		_, err = GetCommunicator("upper,pull")
+		Expect(err).To(MatchError(ContainSubstring("first stage")))
+
+		comm, err := GetCommunicator("pull,upper")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comm.Name()).To(Equal("pull,upper"))
+	})
+})
@@ -0,0 +1,488 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/env"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/k8s"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/ext/etl/runtime"
+	"github.com/NVIDIA/aistore/sys"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Process-based ETL runtime (no Kubernetes)
+//
+// When AIS is not deployed on K8s, each target spawns, supervises, and eventually
+// terminates its own local transformer process (instead of ETL pod):
+// - init code: code and dependencies are written into the ETL's work directory
+//   (under the target's config dir), dependencies are pip-installed into the same
+//   directory, and the transform function gets then served by the embedded python
+//   runtime (see runtime/server.py);
+// - init spec: the (single) container's `command` and `args` are executed as is -
+//   the corresponding binary must be installed on the target's host (container
+//   image, init containers, and volumes are ignored).
+//
+// Since local processes execute user-provided code and commands on the target's host
+// (with the target's privileges), the runtime is disabled by default and must be
+// explicitly enabled via `Enable-Local-ETL-Process` feature flag (feat.LocalETLProcess).
+// The process environment is limited to the allow-listed variables (see procEnvAllowed).
+//
+// Either way, the transformer must listen on the port given by AIS_ETL_PORT environment
+// and respond to the readiness probe (e.g., GET /health) - same as ETL container.
+// With io:// communication, there's no long-lived transformer - the command
+// gets executed once per object (stdin => stdout).
+
+const (
+	procPortEnv     = "AIS_ETL_PORT"
+	procPython      = "python3"
+	procWorkDir     = "etl"
+	procLogsSize    = 256 * cos.KiB // (stdout and stderr tail)
+	procStopTimeout = 10 * time.Second
+)
+
+// target's environment variables that local transformers inherit (all the rest - including
+// credentials and secrets - is not passed)
+var procEnvAllowed = [...]string{"PATH", "HOME", "USER", "LANG", "LC_ALL", "TZ", "TMPDIR"}
+
+var errProcDisabled = errors.New("the operation requires Kubernetes " +
+	"(or else, local process runtime enabled via \"Enable-Local-ETL-Process\" feature flag)")
+
+// process health (cf. K8s pod phases)
+const (
+	procRunning = "Running"
+	procFailed  = "Failed"
+)
+
+type (
+	etlProc struct {
+		cmd     *exec.Cmd
+		logs    *procLogs
+		done    chan struct{}
+		err     error    // exit status (valid once done)
+		dir     string   // work directory
+		probe   string   // readiness probe path
+		command []string // transformer command line
+		env     []string
+		started int64 // mono-time
+		stopped sync.Once
+	}
+	procLogs struct {
+		buf []byte
+		mu  sync.Mutex
+	}
+	// io:// transformer output
+	procStdout struct {
+		io.ReadCloser
+		cmd    *exec.Cmd
+		fh     io.Closer
+		cancel context.CancelFunc
+		logs   *procLogs
+		waited bool
+	}
+)
+
+// interface guard
+var (
+	_ localRuntime  = (*etlProc)(nil)
+	_ io.Writer     = (*procLogs)(nil)
+	_ io.ReadCloser = (*procStdout)(nil)
+)
+
+func procDir(name string, config *cmn.Config) string {
+	return filepath.Join(config.ConfigDir, procWorkDir, name)
+}
+
+// init spec => etlProc
+func (b *etlBootstrapper) procSpec() (*etlProc, error) {
+	pod, err := ParsePodSpec(b.errCtx, b.msg.Spec)
+	if err != nil {
+		return nil, err
+	}
+	b.pod, b.originalPodName = pod, pod.GetName()
+	b.errCtx.ETLName = b.originalPodName
+	b._procName()
+
+	c := &pod.Spec.Containers[0]
+	command := make([]string, 0, len(c.Command)+len(c.Args))
+	command = append(command, c.Command...)
+	command = append(command, c.Args...)
+	if len(command) == 0 {
+		return nil, cmn.NewErrETL(b.errCtx, "process-based runtime (no K8s) requires container command")
+	}
+	p := &etlProc{
+		command: command,
+		dir:     procDir(b.msg.IDX, b.config),
+		env:     make([]string, 0, len(c.Env)+len(b.env)),
+	}
+	if c.ReadinessProbe != nil && c.ReadinessProbe.HTTPGet != nil {
+		p.probe = c.ReadinessProbe.HTTPGet.Path
+	}
+	for i := range c.Env {
+		ev := &c.Env[i]
+		if ev.ValueFrom != nil {
+			return nil, cmn.NewErrETLf(b.errCtx, "process-based runtime (no K8s): env %q: valueFrom is not supported", ev.Name)
+		}
+		p.env = append(p.env, ev.Name+"="+ev.Value)
+	}
+	for k, v := range b.env {
+		p.env = append(p.env, k+"="+v)
+	}
+	if err := cos.CreateDir(p.dir); err != nil {
+		return nil, cmn.NewErrETL(b.errCtx, err.Error())
+	}
+	return p, nil
+}
+
+// init code => etlProc
+func (b *etlBootstrapper) procCode(msg *InitCodeMsg) (*etlProc, error) {
+	b.pod, b.originalPodName = &corev1.Pod{}, msg.IDX
+	b._procName()
+
+	var (
+		python = cos.GetEnvOrDefault(env.AIS.ETLPython, procPython)
+		p      = &etlProc{dir: procDir(msg.IDX, b.config), probe: "/health"}
+	)
+	// (re)create work directory
+	if err := os.RemoveAll(p.dir); err != nil {
+		return nil, cmn.NewErrETL(b.errCtx, err.Error())
+	}
+	if err := cos.CreateDir(p.dir); err != nil {
+		return nil, cmn.NewErrETL(b.errCtx, err.Error())
+	}
+	for fname, content := range map[string][]byte{
+		"code.py":          msg.Code,
+		"requirements.txt": msg.Deps,
+		"server.py":        cos.UnsafeB(runtime.PyServer()),
+	} {
+		if err := os.WriteFile(filepath.Join(p.dir, fname), content, cos.PermRWR); err != nil {
+			return nil, cmn.NewErrETL(b.errCtx, err.Error())
+		}
+	}
+
+	// install dependencies, if any
+	if len(strings.TrimSpace(string(msg.Deps))) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), msg.Timeout.D())
+		cmd := exec.CommandContext(ctx, python, "-m", "pip", "install", "--quiet", //nolint:gosec // user-provided dependencies is the whole point
+			"--target", filepath.Join(p.dir, "runtime"), "-r", filepath.Join(p.dir, "requirements.txt"))
+		out, err := cmd.CombinedOutput()
+		cancel()
+		if err != nil {
+			return nil, cmn.NewErrETLf(b.errCtx, "failed to install dependencies: %v\n%s", err, _tail(out))
+		}
+	}
+
+	var chunk, flags string
+	if msg.ChunkSize > 0 {
+		chunk = strconv.FormatInt(msg.ChunkSize, 10)
+	}
+	if msg.Flags > 0 {
+		flags = strconv.FormatInt(msg.Flags, 10)
+	}
+	p.env = []string{
+		"MOD_NAME=code",
+		"FUNC_TRANSFORM=" + msg.Funcs.Transform,
+		"COMM_TYPE=" + msg.CommTypeX,
+		"ARG_TYPE=" + msg.ArgTypeX,
+		"CHUNK_SIZE=" + chunk,
+		"FLAGS=" + flags,
+		"PYTHONPATH=" + filepath.Join(p.dir, "runtime") + string(os.PathListSeparator) + p.dir,
+	}
+	if msg.CommTypeX == HpushStdin {
+		p.command = []string{python, filepath.Join(p.dir, "code.py")}
+	} else {
+		p.command = []string{python, filepath.Join(p.dir, "server.py")}
+	}
+	return p, nil
+}
+
+// same naming convention as ETL pods
+func (b *etlBootstrapper) _procName() {
+	b.pod.SetName(k8s.CleanName(b.msg.IDX + "-" + core.T.SID()))
+	b.errCtx.PodName = b.pod.GetName()
+}
+
+// spawn the transformer and wait for it to become ready
+func (b *etlBootstrapper) startProc(p *etlProc) error {
+	b.proc = p
+	p.logs = &procLogs{}
+	if b.msg.CommTypeX == HpushStdin {
+		b.originalCommand = p.command // (see pushComm.do)
+		return nil
+	}
+
+	port, err := _freePort()
+	if err != nil {
+		return cmn.NewErrETL(b.errCtx, err.Error())
+	}
+	cmd := exec.Command(p.command[0], p.command[1:]...) //nolint:gosec // ditto
+	cmd.Dir = p.dir
+	cmd.Env = p._env(procPortEnv + "=" + strconv.Itoa(port))
+	cmd.Stdout, cmd.Stderr = p.logs, p.logs
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true} // to terminate the entire process group
+	if err := cmd.Start(); err != nil {
+		return cmn.NewErrETLf(b.errCtx, "failed to start %q: %v", p.command, err)
+	}
+	p.cmd, p.done, p.started = cmd, make(chan struct{}), mono.NanoTime()
+	go p.wait()
+
+	host := core.T.Snode().PubNet.Hostname
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	b.uri = "http://" + net.JoinHostPort(host, strconv.Itoa(port))
+	if err := p.waitReady(b.uri, b.msg.Timeout.D()); err != nil {
+		p.stop()
+		return cmn.NewErrETLf(b.errCtx, "%v\n%s", err, _tail(p.logs.bytes()))
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleETL) {
+		nlog.Infof("process %d %q is ready at %s, %s", cmd.Process.Pid, p.command, b.uri, b.errCtx)
+	}
+	return nil
+}
+
+func _freePort() (int, error) {
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return 0, err
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	cos.Close(l)
+	return port, nil
+}
+
+/////////////
+// etlProc //
+/////////////
+
+func procEnabled() error {
+	if cmn.Rom.Features().IsSet(feat.LocalETLProcess) {
+		return nil
+	}
+	return errProcDisabled
+}
+
+func (p *etlProc) _env(extra ...string) []string {
+	environ := make([]string, 0, len(procEnvAllowed)+len(p.env)+len(extra)+1)
+	for _, name := range procEnvAllowed {
+		if v, ok := os.LookupEnv(name); ok {
+			environ = append(environ, name+"="+v)
+		}
+	}
+	environ = append(environ, "AIS_TARGET_URL="+targetURL())
+	environ = append(environ, p.env...)
+	return append(environ, extra...)
+}
+
+func (p *etlProc) wait() {
+	p.err = p.cmd.Wait()
+	close(p.done)
+}
+
+func (p *etlProc) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *etlProc) waitReady(uri string, timeout time.Duration) error {
+	if p.probe == "" {
+		return nil
+	}
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	var (
+		u        = cos.JoinPath(uri, p.probe)
+		interval = cos.ProbingFrequency(timeout)
+		deadline = time.Now().Add(timeout)
+		err      error
+	)
+	for {
+		if p.exited() {
+			return fmt.Errorf("process %q exited: %v", p.command, p.err)
+		}
+		if err = _probe(u, interval); err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("process %q failed to become ready in %v: %v", p.command, timeout, err)
+		}
+		time.Sleep(interval)
+	}
+}
+
+func _probe(u string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := core.T.DataClient().Do(req)
+	if err != nil {
+		return err
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("readiness probe: %s", resp.Status)
+	}
+	return nil
+}
+
+// terminate the process group: SIGTERM, and then SIGKILL upon timeout
+func (p *etlProc) stop() {
+	p.stopped.Do(func() {
+		if p.cmd != nil && !p.exited() {
+			pgid := -p.cmd.Process.Pid
+			_ = syscall.Kill(pgid, syscall.SIGTERM)
+			select {
+			case <-p.done:
+			case <-time.After(procStopTimeout):
+				_ = syscall.Kill(pgid, syscall.SIGKILL)
+				<-p.done
+			}
+		}
+		if err := os.RemoveAll(p.dir); err != nil {
+			nlog.Errorln(err)
+		}
+	})
+}
+
+func (p *etlProc) logBytes() []byte { return p.logs.bytes() }
+
+func (p *etlProc) health() string {
+	switch {
+	case p.cmd == nil: // io://
+		return procRunning
+	case p.exited():
+		return fmt.Sprintf("%s (%v)", procFailed, p.err)
+	default:
+		return procRunning
+	}
+}
+
+// CPU: average number of cores since start; memory: resident set size
+func (p *etlProc) metrics() (cpu float64, mem int64, err error) {
+	if p.cmd == nil || p.exited() {
+		return 0, 0, nil
+	}
+	stats, err := sys.ProcessStats(p.cmd.Process.Pid)
+	if err != nil {
+		return 0, 0, err
+	}
+	if elapsed := mono.Since(p.started).Milliseconds(); elapsed > 0 {
+		cpu = float64(stats.CPU.Total) / float64(elapsed)
+	}
+	return cpu, int64(stats.Mem.Resident), nil
+}
+
+// io:// - run the command with object's content => stdin; stdout => caller
+func (p *etlProc) stdio(fh io.ReadCloser, timeout time.Duration) (*procStdout, error) {
+	var (
+		ctx    = context.Background()
+		cancel context.CancelFunc
+	)
+	if timeout != 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	cmd := exec.CommandContext(ctx, p.command[0], p.command[1:]...) //nolint:gosec // ditto
+	cmd.Dir = p.dir
+	cmd.Env = p._env()
+	cmd.Stdin = fh
+	cmd.Stderr = p.logs
+	stdout, err := cmd.StdoutPipe()
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		cancel()
+		cos.Close(fh)
+		return nil, err
+	}
+	return &procStdout{ReadCloser: stdout, cmd: cmd, fh: fh, cancel: cancel, logs: p.logs}, nil
+}
+
+//////////////
+// procLogs //
+//////////////
+
+func (l *procLogs) Write(b []byte) (int, error) {
+	l.mu.Lock()
+	l.buf = append(l.buf, b...)
+	if n := len(l.buf); n > procLogsSize {
+		l.buf = append(l.buf[:0], l.buf[n-procLogsSize:]...)
+	}
+	l.mu.Unlock()
+	return len(b), nil
+}
+
+func (l *procLogs) bytes() []byte {
+	l.mu.Lock()
+	b := make([]byte, len(l.buf))
+	copy(b, l.buf)
+	l.mu.Unlock()
+	return b
+}
+
+// (the last few lines to include in error messages)
+func _tail(b []byte) []byte {
+	const maxTail = 2 * cos.KiB
+	if len(b) > maxTail {
+		b = b[len(b)-maxTail:]
+	}
+	return b
+}
+
+////////////////
+// procStdout //
+////////////////
+
+func (r *procStdout) Read(b []byte) (n int, err error) {
+	n, err = r.ReadCloser.Read(b)
+	if err == io.EOF && !r.waited {
+		r.waited = true
+		if errW := r.cmd.Wait(); errW != nil {
+			err = fmt.Errorf("%q: %v\n%s", r.cmd.Args, errW, _tail(r.logs.bytes()))
+		}
+	}
+	return n, err
+}
+
+func (r *procStdout) Close() error {
+	if !r.waited {
+		r.waited = true
+		r.cancel() // kills the process if still running
+		_ = r.cmd.Wait()
+	}
+	r.cancel()
+	return r.fh.Close()
+}
@@ -0,0 +1,147 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/core/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProcessRuntime", func() {
+	var (
+		tmpDir string
+		config *cmn.Config
+	)
+
+	BeforeEach(func() {
+		if _, err := exec.LookPath(procPython); err != nil {
+			Skip("python3 not found")
+		}
+		var err error
+		tmpDir, err = os.MkdirTemp("", "")
+		Expect(err).NotTo(HaveOccurred())
+		config = &cmn.Config{}
+		config.ConfigDir = tmpDir
+		_ = mock.NewTarget(mock.NewBaseBownerMock())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	newMsg := func(commType, code string) *InitCodeMsg {
+		msg := &InitCodeMsg{
+			InitMsgBase: InitMsgBase{IDX: "etl-proc", CommTypeX: commType, Timeout: cos.Duration(time.Minute)},
+			Code:        []byte(code),
+			Runtime:     "python3.11v2",
+		}
+		msg.Funcs.Transform = "transform"
+		return msg
+	}
+
+	It("should run hpush transformer", func() {
+		msg := newMsg(Hpush, "def transform(b):\n    print('transforming', len(b))\n    return b.upper()\n")
+		boot := newBootstrapper(&InitSpecMsg{InitMsgBase: msg.InitMsgBase}, StartOpts{}, config)
+		p, err := boot.procCode(msg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(boot.startProc(p)).To(Succeed())
+		defer p.stop()
+
+		req, err := http.NewRequest(http.MethodPut, boot.uri+"/bck/obj", strings.NewReader("hello"))
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		b, err := cos.ReadAll(resp.Body)
+		resp.Body.Close()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("HELLO"))
+
+		Expect(p.health()).To(Equal(procRunning))
+		_, mem, err := p.metrics()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mem).To(BeNumerically(">", 0))
+		Eventually(func() string { return string(p.logs.bytes()) }, 5*time.Second).Should(ContainSubstring("transforming 5"))
+
+		p.stop()
+		Expect(p.health()).To(HavePrefix(procFailed))
+		Expect(p.dir).NotTo(BeADirectory())
+	})
+
+	It("should run io:// transformer once per object", func() {
+		msg := newMsg(HpushStdin, "import sys\n\ndef transform():\n    sys.stdout.buffer.write(sys.stdin.buffer.read()[::-1])\n\ntransform()\n")
+		boot := newBootstrapper(&InitSpecMsg{InitMsgBase: msg.InitMsgBase}, StartOpts{}, config)
+		p, err := boot.procCode(msg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(boot.startProc(p)).To(Succeed())
+		defer p.stop()
+
+		for range 2 {
+			r, err := p.stdio(io.NopCloser(strings.NewReader("abc")), time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+			b, err := cos.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Close()).To(Succeed())
+			Expect(string(b)).To(Equal("cba"))
+		}
+	})
+
+	It("should fail to start when transformer exits", func() {
+		msg := newMsg(Hpush, "raise SystemExit('bad transformer')\n")
+		boot := newBootstrapper(&InitSpecMsg{InitMsgBase: msg.InitMsgBase}, StartOpts{}, config)
+		p, err := boot.procCode(msg)
+		Expect(err).NotTo(HaveOccurred())
+		err = boot.startProc(p)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("bad transformer"))
+	})
+})
+
+var _ = Describe("ProcessRuntimeOptIn", func() {
+	BeforeEach(func() {
+		_ = mock.NewTarget(mock.NewBaseBownerMock())
+	})
+
+	It("should be disabled by default", func() {
+		Expect(procEnabled()).To(MatchError(errProcDisabled))
+
+		var (
+			orig = cmn.GCO.Clone()
+			cfg  = cmn.GCO.Clone()
+		)
+		if orig.Log.Level == "" {
+			orig.Log.Level, cfg.Log.Level = "0", "0" // (not initialized in this test - see cos.LogLevel.Parse)
+		}
+		cfg.Features = feat.LocalETLProcess
+		cmn.Rom.Set(&cfg.ClusterConfig)
+		defer cmn.Rom.Set(&orig.ClusterConfig)
+		Expect(procEnabled()).To(Succeed())
+	})
+
+	It("should pass only allow-listed environment", func() {
+		os.Setenv("AIS_TEST_SECRET", "secret")
+		defer os.Unsetenv("AIS_TEST_SECRET")
+
+		p := &etlProc{env: []string{"FOO=bar"}}
+		environ := p._env(procPortEnv + "=1234")
+		Expect(environ).To(ContainElements("FOO=bar", procPortEnv+"=1234", "AIS_TARGET_URL="+targetURL()))
+		for _, kv := range environ {
+			Expect(kv).NotTo(HavePrefix("AIS_TEST_SECRET="))
+		}
+		if path, ok := os.LookupEnv("PATH"); ok {
+			Expect(environ).To(ContainElement("PATH=" + path))
+		}
+	})
+})
@@ -15,8 +15,9 @@ import (
 
 type (
 	registry struct {
-		m   map[string]Communicator
-		mtx sync.RWMutex
+		m     map[string]Communicator
+		pipes map[string]*pipeline // (see pipeline.go)
+		mtx   sync.RWMutex
 	}
 )
 
@@ -26,7 +27,7 @@ var (
 )
 
 func init() {
-	reg = &registry{m: make(map[string]Communicator)}
+	reg = &registry{m: make(map[string]Communicator), pipes: make(map[string]*pipeline)}
 	reqSecret = cos.CryptoRandS(10)
 }
 
@@ -55,21 +56,56 @@ func (r *registry) del(name string) (c Communicator) {
 	if c, ok = r.m[name]; ok {
 		delete(r.m, name)
 	}
+	for pname, p := range r.pipes {
+		if p.has(name) {
+			delete(r.pipes, pname)
+		}
+	}
 	r.mtx.Unlock()
 	return c
 }
 
+func (r *registry) getPipeline(name string) (p *pipeline, exists bool) {
+	r.mtx.RLock()
+	p, exists = r.pipes[name]
+	r.mtx.RUnlock()
+	return
+}
+
+// returns the one that's already there, if any
+func (r *registry) addPipeline(p *pipeline) *pipeline {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if existing, ok := r.pipes[p.name]; ok {
+		return existing
+	}
+	for _, s := range p.stages {
+		if r.m[s.name] != s.comm {
+			return p // stopped in the meantime - don't cache
+		}
+	}
+	r.pipes[p.name] = p
+	return p
+}
+
 func (r *registry) list() []Info {
 	r.mtx.RLock()
-	etls := make([]Info, 0, len(r.m))
+	etls := make([]Info, 0, len(r.m)+len(r.pipes))
 	for name, comm := range r.m {
-		etls = append(etls, Info{
+		info := Info{
 			Name:     name,
 			XactID:   comm.Xact().ID(),
 			ObjCount: comm.ObjCount(),
 			InBytes:  comm.InBytes(),
 			OutBytes: comm.OutBytes(),
-		})
+		}
+		if ss, ok := comm.(streamStats); ok {
+			info.Inflight, info.Throttled = ss.Inflight(), ss.Throttled()
+		}
+		etls = append(etls, info)
+	}
+	for _, p := range r.pipes {
+		etls = append(etls, p.info())
 	}
 	r.mtx.RUnlock()
 	return etls
@@ -15,6 +15,10 @@ const (
 	Py38  = "python3.8v2"
 	Py310 = "python3.10v2"
 	Py311 = "python3.11v2"
+
+	// WebAssembly module executed in-process by each target (not a pod runtime)
+	// see also: ext/etl/wasm.go
+	Wasm = "wasm"
 )
 
 type (
@@ -34,6 +38,9 @@ var (
 	//go:embed podspec.yaml
 	pyPodSpec string
 
+	//go:embed server.py
+	pyServer string
+
 	all map[string]runtime
 )
 
@@ -61,6 +68,10 @@ func init() {
 	}
 }
 
+// python server to run `init code` transformers as local processes (no K8s)
+// see also: ext/etl/proc.go
+func PyServer() string { return pyServer }
+
 func (runbase) CodeEnvName() string { return "AISTORE_CODE" }
 func (runbase) DepsEnvName() string { return "AISTORE_DEPS" }
 
@@ -0,0 +1,92 @@
+#
+# Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+#
+# Local (process-based, no Kubernetes) python runtime - see ext/etl/proc.go
+#
+# Serves the user-defined `transform` function from the module MOD_NAME:
+# - hpush://       PUT /<path>  - the request body is the object's content
+# - hpull, hrev:// GET /<path>  - the runtime itself reads the object (via AIS_TARGET_URL)
+# - ARG_TYPE=fqn   the <path> is the object's (URL-escaped) fully-qualified local filename
+# - CHUNK_SIZE > 0 transform(reader, writer) is called with reader yielding CHUNK_SIZE chunks
+#
+import importlib
+import io
+import os
+import sys
+import urllib.parse
+import urllib.request
+from http.server import BaseHTTPRequestHandler, ThreadingHTTPServer
+
+PORT = int(os.environ["AIS_ETL_PORT"])
+TARGET_URL = os.environ.get("AIS_TARGET_URL", "")
+ARG_TYPE = os.environ.get("ARG_TYPE", "")
+CHUNK_SIZE = int(os.environ.get("CHUNK_SIZE") or 0)
+
+mod = importlib.import_module(os.environ.get("MOD_NAME", "code"))
+transform = getattr(mod, os.environ["FUNC_TRANSFORM"])
+
+
+def _chunks(reader):
+    while True:
+        b = reader.read(CHUNK_SIZE)
+        if not b:
+            return
+        yield b
+
+
+def _transform(reader):
+    if CHUNK_SIZE > 0:
+        writer = io.BytesIO()
+        transform(_chunks(reader), writer)
+        return writer.getvalue()
+    return transform(reader.read())
+
+
+class Handler(BaseHTTPRequestHandler):
+    protocol_version = "HTTP/1.1"
+
+    def _reply(self, code, body):
+        self.send_response(code)
+        self.send_header("Content-Length", str(len(body)))
+        self.end_headers()
+        self.wfile.write(body)
+
+    def _fqn(self):
+        return urllib.parse.unquote(self.path.lstrip("/"))
+
+    def _handle(self, reader):
+        try:
+            body = _transform(reader)
+        except Exception as e:  # pylint: disable=broad-except
+            self._reply(500, repr(e).encode())
+            return
+        self._reply(200, body)
+
+    def do_GET(self):
+        if self.path == "/health":
+            self._reply(200, b"Running")
+            return
+        if ARG_TYPE == "fqn":
+            with open(self._fqn(), "rb") as f:
+                self._handle(f)
+            return
+        with urllib.request.urlopen(TARGET_URL + self.path) as resp:
+            self._handle(resp)
+
+    def do_PUT(self):
+        if ARG_TYPE == "fqn":
+            with open(self._fqn(), "rb") as f:
+                self._handle(f)
+            return
+        size = int(self.headers.get("Content-Length") or 0)
+        self._handle(io.BytesIO(self.rfile.read(size)))
+
+    do_POST = do_PUT
+
+    def log_message(self, *args):  # pylint: disable=arguments-differ
+        pass
+
+
+if __name__ == "__main__":
+    sys.stdout.reconfigure(line_buffering=True)
+    ThreadingHTTPServer(("", PORT), Handler).serve_forever()
@@ -147,6 +147,17 @@ func (e *Aborter) ListenSmapChanged() {
 // (common for both `InitCode` and `InitSpec` flows)
 func InitSpec(msg *InitSpecMsg, etlName string, opts StartOpts) error {
 	config := cmn.GCO.Get()
+	if !k8s.IsK8s() {
+		if err := procEnabled(); err != nil {
+			return err
+		}
+		boot := newBootstrapper(msg, opts, config)
+		p, err := boot.procSpec()
+		if err == nil {
+			err = boot.start(p, etlName)
+		}
+		return err
+	}
 	errCtx, podName, svcName, err := start(msg, etlName, opts, config)
 	if err == nil {
 		if cmn.Rom.FastV(4, cos.SmoduleETL) {
@@ -168,6 +179,20 @@ func InitSpec(msg *InitSpecMsg, etlName string, opts StartOpts) error {
 // - execute `InitSpec` with the modified podspec
 // See also: etl/runtime/podspec.yaml
 func InitCode(msg *InitCodeMsg, xid string) error {
+	if msg.Runtime == runtime.Wasm {
+		return initWasm(msg, xid)
+	}
+	if !k8s.IsK8s() {
+		if err := procEnabled(); err != nil {
+			return err
+		}
+		boot := newBootstrapper(&InitSpecMsg{InitMsgBase: msg.InitMsgBase}, StartOpts{}, cmn.GCO.Get())
+		p, err := boot.procCode(msg)
+		if err == nil {
+			err = boot.start(p, xid)
+		}
+		return err
+	}
 	var (
 		ftp      = fromToPairs(msg)
 		replacer = strings.NewReplacer(ftp...)
@@ -249,9 +274,8 @@ func start(msg *InitSpecMsg, xid string, opts StartOpts, config *cmn.Config) (er
 	podName, svcName string, err error) {
 	debug.Assert(k8s.NodeName != "") // checked above
 
-	errCtx = &cmn.ETLErrCtx{TID: core.T.SID(), ETLName: msg.IDX}
-	boot := &etlBootstrapper{errCtx: errCtx, config: config, env: opts.Env}
-	boot.msg = *msg
+	boot := newBootstrapper(msg, opts, config)
+	errCtx = boot.errCtx
 
 	// Parse spec template and fill Pod object with necessary fields.
 	if err = boot.createPodSpec(); err != nil {
@@ -295,6 +319,34 @@ func start(msg *InitSpecMsg, xid string, opts StartOpts, config *cmn.Config) (er
 	return
 }
 
+// process-based runtime (no K8s): same as above minus pods and services
+func (b *etlBootstrapper) start(p *etlProc, xid string) error {
+	if err := b.startProc(p); err != nil {
+		p.stop()
+		return err
+	}
+	if err := b.register(xid, newCommunicator); err != nil {
+		p.stop()
+		return err
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleETL) {
+		nlog.Infof("started etl[%s], msg %s, process %q", xid, b.msg.String(), p.command)
+	}
+	return nil
+}
+
+// (common for process-based and WASM runtimes)
+func (b *etlBootstrapper) register(xid string, newComm func(meta.Slistener, *etlBootstrapper) Communicator) error {
+	b.setupXaction(xid)
+	comm := newComm(newAborter(b.msg.IDX), b)
+	if err := reg.add(b.msg.IDX, comm); err != nil {
+		b.xctn.Finish()
+		return err
+	}
+	core.T.Sowner().Listeners().Reg(comm)
+	return nil
+}
+
 // Stop deletes all occupied by the ETL resources, including Pods and Services.
 // It unregisters ETL smap listener.
 func Stop(id string, errCause error) error {
@@ -306,14 +358,16 @@ func Stop(id string, errCause error) error {
 	// Abort all running offline ETLs.
 	xreg.AbortKind(errCause, apc.ActETLBck)
 
-	c, err := GetCommunicator(id)
-	if err != nil {
-		return cmn.NewErrETL(errCtx, err.Error())
+	c, exists := reg.get(id) // (pipelines are not stopped - their stages are)
+	if !exists {
+		return cmn.NewErrETL(errCtx, cos.NewErrNotFound(core.T, "etl job "+id).Error())
 	}
 	errCtx.PodName = c.PodName()
 	errCtx.SvcName = c.SvcName()
 
-	if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
+	if l := c.local(); l != nil {
+		l.stop()
+	} else if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
 		return err
 	}
 
@@ -328,9 +382,6 @@ func Stop(id string, errCause error) error {
 
 // StopAll terminates all running ETLs.
 func StopAll() {
-	if !k8s.IsK8s() {
-		return
-	}
 	for _, e := range List() {
 		if err := Stop(e.Name, nil); err != nil {
 			nlog.Errorln(err)
@@ -338,7 +389,11 @@ func StopAll() {
 	}
 }
 
+// (the name may also reference a pipeline of ETLs - see pipeline.go)
 func GetCommunicator(etlName string) (Communicator, error) {
+	if IsPipeline(etlName) {
+		return getPipeline(etlName)
+	}
 	c, exists := reg.get(etlName)
 	if !exists {
 		return nil, cos.NewErrNotFound(core.T, "etl job "+etlName)
@@ -353,6 +408,9 @@ func PodLogs(transformID string) (logs Logs, err error) {
 	if err != nil {
 		return logs, err
 	}
+	if l := c.local(); l != nil {
+		return Logs{TargetID: core.T.SID(), Logs: l.logBytes()}, nil
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return logs, err
@@ -372,6 +430,9 @@ func PodHealth(etlName string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if l := c.local(); l != nil {
+		return l.health(), nil
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return "", err
@@ -384,6 +445,13 @@ func PodMetrics(etlName string) (*CPUMemUsed, error) {
 	if err != nil {
 		return nil, err
 	}
+	if l := c.local(); l != nil {
+		cpuUsed, memUsed, err := l.metrics()
+		if err != nil {
+			return nil, err
+		}
+		return &CPUMemUsed{TargetID: core.T.SID(), CPU: cpuUsed, Mem: memUsed}, nil
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return nil, err
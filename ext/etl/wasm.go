@@ -0,0 +1,393 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/sys"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// WASM runtime: transformers compiled to WebAssembly and executed in-process
+// by each target (pure-Go runtime: no containers, no per-object HTTP hop).
+//
+// Module ABI (i32 unless noted):
+// - exported linear memory;
+// - alloc(size) => ptr - buffer to write `size` bytes of input into;
+// - ChunkSize == 0: <transform>(ptr, len) => i64 - transforms the entire object in one shot;
+// - ChunkSize > 0:  <transform>(ptr, len, last) => i64 - called once per (up to) ChunkSize bytes,
+//   with last = 1 for the final chunk (possibly of zero length);
+// - the returned i64 is (out-ptr << 32 | out-len): the output that host copies out prior to the next call.
+//
+// Memory management is module's own (e.g., `alloc` may simply reuse the same buffer).
+// WASI (preview1) is provided sans filesystem and network; module's stdout and stderr go to ETL logs.
+
+const (
+	wasmAllocFn  = "alloc"
+	wasmMaxPages = 16 * 1024 // max memory per module instance: 1GiB (64KiB pages)
+)
+
+type (
+	wasmRuntime struct {
+		rt      wazero.Runtime
+		cmod    wazero.CompiledModule
+		logs    *procLogs
+		pool    chan api.Module // idle instances
+		fname   string
+		chunk   int64
+		started int64        // mono-time
+		busy    atomic.Int64 // time spent transforming (ns)
+		mem     atomic.Int64 // memory size of all instances
+		stopped sync.Once
+	}
+	wasmComm struct {
+		baseComm
+		wr *wasmRuntime
+	}
+)
+
+// interface guard
+var (
+	_ Communicator = (*wasmComm)(nil)
+	_ localRuntime = (*wasmRuntime)(nil)
+)
+
+// compile, validate, and instantiate (first instance of) the module
+func newWasmRuntime(msg *InitCodeMsg) (_ *wasmRuntime, err error) {
+	var (
+		ctx  = context.Background()
+		rcfg = wazero.NewRuntimeConfig().WithMemoryLimitPages(wasmMaxPages).WithCloseOnContextDone(true)
+		wr   = &wasmRuntime{
+			rt:      wazero.NewRuntimeWithConfig(ctx, rcfg),
+			logs:    &procLogs{},
+			pool:    make(chan api.Module, sys.NumCPU()),
+			fname:   msg.Funcs.Transform,
+			chunk:   msg.ChunkSize,
+			started: mono.NanoTime(),
+		}
+	)
+	defer func() {
+		if err != nil {
+			wr.rt.Close(ctx)
+		}
+	}()
+	if _, err = wasi_snapshot_preview1.Instantiate(ctx, wr.rt); err != nil {
+		return nil, err
+	}
+	if wr.cmod, err = wr.rt.CompileModule(ctx, msg.Code); err != nil {
+		return nil, fmt.Errorf("invalid WASM module: %v", err)
+	}
+	if err = wr.validate(); err != nil {
+		return nil, err
+	}
+	mod, err := wr.instantiate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wr.pool <- mod
+	return wr, nil
+}
+
+func (wr *wasmRuntime) validate() error {
+	if len(wr.cmod.ExportedMemories()) == 0 {
+		return errors.New("WASM module must export its memory")
+	}
+	fns := wr.cmod.ExportedFunctions()
+	check := func(name string, params, results []api.ValueType) error {
+		fn, ok := fns[name]
+		if !ok {
+			return fmt.Errorf("WASM module must export %q function", name)
+		}
+		if !bytes.Equal(fn.ParamTypes(), params) || !bytes.Equal(fn.ResultTypes(), results) {
+			return fmt.Errorf("WASM function %q: invalid signature (%v) => %v, expecting (%v) => %v",
+				name, fn.ParamTypes(), fn.ResultTypes(), params, results)
+		}
+		return nil
+	}
+	i32, i64 := api.ValueTypeI32, api.ValueTypeI64
+	if err := check(wasmAllocFn, []api.ValueType{i32}, []api.ValueType{i32}); err != nil {
+		return err
+	}
+	if wr.chunk > 0 {
+		return check(wr.fname, []api.ValueType{i32, i32, i32}, []api.ValueType{i64})
+	}
+	return check(wr.fname, []api.ValueType{i32, i32}, []api.ValueType{i64})
+}
+
+func (wr *wasmRuntime) instantiate(ctx context.Context) (api.Module, error) {
+	mcfg := wazero.NewModuleConfig().
+		WithName(""). // (multiple instances)
+		WithStdout(wr.logs).
+		WithStderr(wr.logs).
+		WithStartFunctions("_initialize") // (WASI reactor, if present)
+	mod, err := wr.rt.InstantiateModule(ctx, wr.cmod, mcfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate WASM module: %v", err)
+	}
+	wr.mem.Add(int64(mod.Memory().Size()))
+	return mod, nil
+}
+
+func (wr *wasmRuntime) get(ctx context.Context) (api.Module, error) {
+	select {
+	case mod := <-wr.pool:
+		return mod, nil
+	default:
+		return wr.instantiate(ctx)
+	}
+}
+
+// return instance to the pool unless (it's in a questionable state after) failure
+func (wr *wasmRuntime) put(mod api.Module, size uint32, err error) {
+	wr.mem.Add(int64(mod.Memory().Size()) - int64(size))
+	if err == nil {
+		select {
+		case wr.pool <- mod:
+			return
+		default:
+		}
+	}
+	wr.mem.Add(-int64(mod.Memory().Size()))
+	mod.Close(context.Background())
+}
+
+// transform reader => writer; returns the number of transformed (output) bytes
+func (wr *wasmRuntime) transform(ctx context.Context, r io.Reader, w io.Writer) (n int64, err error) {
+	mod, err := wr.get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	started := mono.NanoTime()
+	size := mod.Memory().Size()
+	if wr.chunk == 0 {
+		var in []byte
+		if in, err = cos.ReadAll(r); err == nil {
+			n, err = wr.call(ctx, mod, w, in)
+		}
+	} else {
+		n, err = wr.chunks(ctx, mod, r, w)
+	}
+	wr.busy.Add(mono.SinceNano(started))
+	wr.put(mod, size, err)
+	return n, err
+}
+
+func (wr *wasmRuntime) chunks(ctx context.Context, mod api.Module, r io.Reader, w io.Writer) (n int64, err error) {
+	buf, slab := core.T.PageMM().AllocSize(wr.chunk)
+	defer slab.Free(buf)
+	buf = buf[:wr.chunk]
+	for {
+		k, errR := io.ReadFull(r, buf)
+		last := errR == io.EOF || errR == io.ErrUnexpectedEOF
+		if errR != nil && !last {
+			return n, errR
+		}
+		m, errC := wr.call(ctx, mod, w, buf[:k], last)
+		n += m
+		if errC != nil || last {
+			return n, errC
+		}
+	}
+}
+
+// one call: copy input in, transform, and write output out
+func (wr *wasmRuntime) call(ctx context.Context, mod api.Module, w io.Writer, in []byte, last ...bool) (int64, error) {
+	res, err := mod.ExportedFunction(wasmAllocFn).Call(ctx, uint64(len(in)))
+	if err != nil {
+		return 0, err
+	}
+	mem, ptr := mod.Memory(), uint32(res[0])
+	if !mem.Write(ptr, in) {
+		return 0, fmt.Errorf("WASM %s(%d) returned out-of-range buffer %d", wasmAllocFn, len(in), ptr)
+	}
+	args := []uint64{uint64(ptr), uint64(len(in))}
+	if len(last) > 0 {
+		var flag uint64
+		if last[0] {
+			flag = 1
+		}
+		args = append(args, flag)
+	}
+	if res, err = mod.ExportedFunction(wr.fname).Call(ctx, args...); err != nil {
+		return 0, err
+	}
+	optr, olen := uint32(res[0]>>32), uint32(res[0])
+	out, ok := mem.Read(optr, olen)
+	if !ok {
+		return 0, fmt.Errorf("WASM %s returned out-of-range output [%d, %d)", wr.fname, optr, optr+olen)
+	}
+	k, err := w.Write(out)
+	return int64(k), err
+}
+
+func (wr *wasmRuntime) logBytes() []byte { return wr.logs.bytes() }
+func (*wasmRuntime) health() string      { return procRunning }
+
+// CPU: average number of cores (busy transforming) since start; memory: all instances
+func (wr *wasmRuntime) metrics() (cpu float64, mem int64, _ error) {
+	if elapsed := mono.SinceNano(wr.started); elapsed > 0 {
+		cpu = float64(wr.busy.Load()) / float64(elapsed)
+	}
+	return cpu, wr.mem.Load(), nil
+}
+
+func (wr *wasmRuntime) stop() {
+	wr.stopped.Do(func() {
+		if err := wr.rt.Close(context.Background()); err != nil {
+			nlog.Errorln(err)
+		}
+	})
+}
+
+//////////////
+// wasmComm //
+//////////////
+
+func initWasm(msg *InitCodeMsg, xid string) error {
+	boot := newBootstrapper(&InitSpecMsg{InitMsgBase: msg.InitMsgBase}, StartOpts{}, cmn.GCO.Get())
+	boot.originalPodName = msg.IDX
+	wr, err := newWasmRuntime(msg)
+	if err != nil {
+		return cmn.NewErrETL(boot.errCtx, err.Error())
+	}
+	newComm := func(listener meta.Slistener, boot *etlBootstrapper) Communicator {
+		c := &wasmComm{wr: wr}
+		c.listener, c.boot = listener, boot
+		return c
+	}
+	if err := boot.register(xid, newComm); err != nil {
+		wr.stop()
+		return err
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleETL) {
+		nlog.Infof("started etl[%s], msg %s, WASM", xid, msg)
+	}
+	return nil
+}
+
+// no pods (and no services)
+func (*wasmComm) PodName() string       { return "" }
+func (*wasmComm) SvcName() string       { return "" }
+func (c *wasmComm) local() localRuntime { return c.wr }
+
+func (c *wasmComm) InlineTransform(w http.ResponseWriter, _ *http.Request, lom *core.LOM) error {
+	fh, size, err := c.open(lom)
+	if err != nil {
+		return err
+	}
+	n, err := c.wr.transform(context.Background(), fh, w)
+	cos.Close(fh)
+	lom.Unlock(false)
+	c.boot.xctn.OutObjsAdd(1, size)
+	c.boot.xctn.InObjsAdd(1, n)
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln("wasm", lom.Cname(), err)
+	}
+	return err
+}
+
+// (offline transformation is streamed via pipe)
+func (c *wasmComm) OfflineTransform(lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	clone := *lom
+	fh, _, err := c.open(&clone)
+	if err != nil {
+		return nil, err
+	}
+	return c.pipe(fh, timeout, func() { clone.Unlock(false) }), nil
+}
+
+// pipeline stage (see pipeline.go)
+func (c *wasmComm) stream(r cos.ReadCloseSizer, _ *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := c.boot.xctn.AbortErr(); err != nil {
+		r.Close()
+		return nil, err
+	}
+	return c.pipe(r, timeout, nil), nil
+}
+
+// (the size of the input is not necessarily known - e.g., when it's the output of the previous
+// pipeline stage - counting bytes actually read)
+func (c *wasmComm) pipe(fh io.ReadCloser, timeout time.Duration, unlock func()) cos.ReadCloseSizer {
+	var (
+		ctx    = context.Background()
+		cancel context.CancelFunc
+	)
+	if timeout != 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		cr := &cntReader{r: fh}
+		n, err := c.wr.transform(ctx, cr, pw)
+		cos.Close(fh)
+		if unlock != nil {
+			unlock()
+		}
+		c.boot.xctn.OutObjsAdd(1, cr.n)
+		c.boot.xctn.InObjsAdd(1, n)
+		pw.CloseWithError(err)
+	}()
+	return cos.NewReaderWithArgs(cos.ReaderArgs{R: pr, Size: -1, DeferCb: cancel})
+}
+
+// open read-locked object (the caller unlocks), with cold GET if need be
+func (c *wasmComm) open(lom *core.LOM) (fh io.ReadCloser, size int64, err error) {
+	if err = c.boot.xctn.AbortErr(); err != nil {
+		return nil, 0, err
+	}
+	if err = lom.InitBck(lom.Bucket()); err != nil {
+		return nil, 0, err
+	}
+	lom.Lock(false)
+	if err = lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		lom.Unlock(false)
+		if !cos.IsNotExist(err, 0) || !lom.Bucket().IsRemote() {
+			return nil, 0, err
+		}
+		if _, err = core.T.GetCold(context.Background(), lom, cmn.OwtGetLock); err != nil {
+			return nil, 0, err
+		}
+		lom.Lock(false)
+		if err = lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+			lom.Unlock(false)
+			return nil, 0, err
+		}
+	}
+	if fh, err = lom.NewHandle(); err != nil {
+		lom.Unlock(false)
+		return nil, 0, err
+	}
+	return fh, lom.Lsize(), nil
+}
+
+type cntReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *cntReader) Read(b []byte) (n int, err error) {
+	n, err = cr.r.Read(b)
+	cr.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,128 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/ext/etl/runtime"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// hand-assembled module that converts ASCII to upper case, in place:
+// - exports memory (initially, one 64KiB page);
+// - alloc(len) grows the memory as needed and always returns 1024;
+// - transform(ptr, len) and transform_chunk(ptr, len, last) return (ptr << 32 | len)
+var wasmUpper = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x01, 0x13, 0x03, 0x60, 0x01, 0x7f, 0x01, 0x7f,
+	0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e, 0x60, 0x03, 0x7f, 0x7f, 0x7f, 0x01, 0x7e, 0x03, 0x04, 0x03,
+	0x00, 0x01, 0x02, 0x05, 0x03, 0x01, 0x00, 0x01, 0x07, 0x30, 0x04, 0x06, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x02, 0x00, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x00, 0x00, 0x09, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x00, 0x01, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
+	0x72, 0x6d, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x00, 0x02, 0x0a, 0x72, 0x03, 0x21, 0x01, 0x01,
+	0x7f, 0x20, 0x00, 0x41, 0xff, 0x87, 0x04, 0x6a, 0x41, 0x10, 0x76, 0x3f, 0x00, 0x6b, 0x22, 0x01,
+	0x41, 0x00, 0x4a, 0x04, 0x40, 0x20, 0x01, 0x40, 0x00, 0x1a, 0x0b, 0x41, 0x80, 0x08, 0x0b, 0x45,
+	0x01, 0x02, 0x7f, 0x02, 0x40, 0x03, 0x40, 0x20, 0x02, 0x20, 0x01, 0x4f, 0x0d, 0x01, 0x20, 0x00,
+	0x20, 0x02, 0x6a, 0x2d, 0x00, 0x00, 0x22, 0x03, 0x41, 0xe1, 0x00, 0x6b, 0x41, 0x1a, 0x49, 0x04,
+	0x40, 0x20, 0x00, 0x20, 0x02, 0x6a, 0x20, 0x03, 0x41, 0x20, 0x6b, 0x3a, 0x00, 0x00, 0x0b, 0x20,
+	0x02, 0x41, 0x01, 0x6a, 0x21, 0x02, 0x0c, 0x00, 0x0b, 0x0b, 0x20, 0x00, 0xad, 0x42, 0x20, 0x86,
+	0x20, 0x01, 0xad, 0x84, 0x0b, 0x08, 0x00, 0x20, 0x00, 0x20, 0x01, 0x10, 0x01, 0x0b,
+}
+
+var _ = Describe("WasmRuntime", func() {
+	BeforeEach(func() {
+		_ = mock.NewTarget(mock.NewBaseBownerMock())
+	})
+
+	newMsg := func(fname string, chunk int64) *InitCodeMsg {
+		msg := &InitCodeMsg{
+			InitMsgBase: InitMsgBase{IDX: "etl-wasm", CommTypeX: Hpush},
+			Code:        wasmUpper,
+			Runtime:     runtime.Wasm,
+			ChunkSize:   chunk,
+		}
+		msg.Funcs.Transform = fname
+		return msg
+	}
+
+	transform := func(wr *wasmRuntime, in string) string {
+		var out bytes.Buffer
+		n, err := wr.transform(context.Background(), strings.NewReader(in), &out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(BeEquivalentTo(out.Len()))
+		return out.String()
+	}
+
+	It("should validate", func() {
+		Expect(newMsg("transform", 0).Validate()).To(Succeed())
+
+		msg := newMsg("transform", 0)
+		msg.Code = []byte("def transform(b): return b")
+		Expect(msg.Validate()).NotTo(Succeed())
+
+		msg = newMsg("transform", 0)
+		msg.Deps = []byte("numpy")
+		Expect(msg.Validate()).NotTo(Succeed())
+	})
+
+	It("should transform entire object", func() {
+		wr, err := newWasmRuntime(newMsg("transform", 0))
+		Expect(err).NotTo(HaveOccurred())
+		defer wr.stop()
+
+		Expect(transform(wr, "hello, World")).To(Equal("HELLO, WORLD"))
+		Expect(transform(wr, "")).To(Equal(""))
+
+		// larger than initial memory (the module grows it)
+		large := strings.Repeat("abc", 100_000)
+		Expect(transform(wr, large)).To(Equal(strings.ToUpper(large)))
+
+		_, mem, err := wr.metrics()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mem).To(BeNumerically(">", 64*1024))
+	})
+
+	It("should transform in chunks", func() {
+		wr, err := newWasmRuntime(newMsg("transform_chunk", 7))
+		Expect(err).NotTo(HaveOccurred())
+		defer wr.stop()
+
+		for _, in := range []string{"", "short", "exactly 14 b..", strings.Repeat("chunked ", 1000)} {
+			Expect(transform(wr, in)).To(Equal(strings.ToUpper(in)))
+		}
+	})
+
+	It("should transform concurrently", func() {
+		wr, err := newWasmRuntime(newMsg("transform", 0))
+		Expect(err).NotTo(HaveOccurred())
+		defer wr.stop()
+
+		done := make(chan string, 16)
+		for range cap(done) {
+			go func() {
+				defer GinkgoRecover()
+				done <- transform(wr, "concurrent")
+			}()
+		}
+		for range cap(done) {
+			Expect(<-done).To(Equal("CONCURRENT"))
+		}
+	})
+
+	It("should reject invalid ABI", func() {
+		_, err := newWasmRuntime(newMsg("nonexistent", 0))
+		Expect(err).To(MatchError(ContainSubstring("must export")))
+
+		// (wrong signature for the given chunk size)
+		_, err = newWasmRuntime(newMsg("transform", 1024))
+		Expect(err).To(MatchError(ContainSubstring("invalid signature")))
+		_, err = newWasmRuntime(newMsg("transform_chunk", 0))
+		Expect(err).To(MatchError(ContainSubstring("invalid signature")))
+	})
+})
@@ -0,0 +1,419 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket communicator (comm-type `ws://`): a single long-lived connection between
+// a given target and its transformer; many objects are pipelined over this connection
+// concurrently, each request and its reply matched by a per-message ID.
+//
+// Both requests and replies are binary messages:
+//
+//	request: | ID (uint64) | path length (uint16) | path | object's content |
+//	reply:   | ID (uint64) | status (uint16)      | transformed content (or error text) |
+//
+// where:
+// - path is "bucket/object" or, when arg-type is "fqn", the object's fully-qualified name
+// (in the latter case the object's content is not sent);
+// - status is HTTP status: anything other than 200 indicates failure to transform.
+//
+// The transformer must serve the connection at `wsPath` and may reply in any order.
+// Each object is a single message - the comm-type is intended for (many) small objects;
+// replies larger than `wsMaxReply` terminate the connection (and fail all pending requests).
+//
+// Back-pressure: at most `wsMaxInflight` requests may await their replies at any given time;
+// senders block otherwise (see `Info.Throttled`).
+
+const (
+	wsPath        = "/ws"
+	wsMaxInflight = 64
+	wsMaxReply    = 64 * cos.MiB // (transformed object + header)
+	wsHdrSize     = cos.SizeofI64 + cos.SizeofI16
+)
+
+type (
+	wsComm struct {
+		baseComm
+		conn      *websocket.Conn
+		pending   map[uint64]chan *wsReply // awaiting replies
+		sema      chan struct{}            // in-flight limit
+		mu        sync.Mutex               // protects conn and pending
+		wmu       sync.Mutex               // serializes writes
+		id        atomic.Uint64
+		inflight  atomic.Int64
+		throttled atomic.Int64
+		stopped   atomic.Bool
+	}
+	wsReply struct {
+		err  error
+		body []byte
+	}
+
+	// optional stats (currently, only wsComm)
+	streamStats interface {
+		Inflight() int64
+		Throttled() int64
+	}
+)
+
+// interface guard
+var (
+	_ Communicator = (*wsComm)(nil)
+	_ streamStats  = (*wsComm)(nil)
+)
+
+func newWsComm(listener meta.Slistener, boot *etlBootstrapper) *wsComm {
+	wc := &wsComm{
+		pending: make(map[uint64]chan *wsReply, wsMaxInflight),
+		sema:    make(chan struct{}, wsMaxInflight),
+	}
+	wc.listener, wc.boot = listener, boot
+	return wc
+}
+
+func (wc *wsComm) Inflight() int64  { return wc.inflight.Load() }
+func (wc *wsComm) Throttled() int64 { return wc.throttled.Load() }
+
+func (wc *wsComm) Stop() {
+	wc.stopped.Store(true)
+	wc.mu.Lock()
+	conn := wc.conn
+	wc.mu.Unlock()
+	if conn != nil {
+		conn.Close() // (recv fails pending requests)
+	}
+	wc.baseComm.Stop()
+}
+
+func (wc *wsComm) InlineTransform(w http.ResponseWriter, _ *http.Request, lom *core.LOM) error {
+	r, err := wc.transform(lom, 0 /*timeout*/)
+	if err != nil {
+		return err
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(WebSocket, lom.Cname())
+	}
+	_, err = io.Copy(w, r)
+	r.Close()
+	return err
+}
+
+func (wc *wsComm) OfflineTransform(lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	clone := *lom
+	r, err := wc.transform(&clone, timeout)
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(WebSocket, clone.Cname(), err)
+	}
+	return r, err
+}
+
+func (wc *wsComm) transform(lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := lom.InitBck(lom.Bucket()); err != nil {
+		return nil, err
+	}
+	if err := wc.acquire(timeout); err != nil {
+		return nil, err
+	}
+	defer wc.release()
+
+	conn, err := wc.connect()
+	if err != nil {
+		return nil, err
+	}
+	id, ch := wc.expect()
+
+	lom.Lock(false)
+	size, err := wc.send(conn, id, lom)
+	lom.Unlock(false)
+
+	if err != nil && cos.IsNotExist(err, 0) && lom.Bucket().IsRemote() {
+		if _, err = core.T.GetCold(context.Background(), lom, cmn.OwtGetLock); err == nil {
+			lom.Lock(false)
+			size, err = wc.send(conn, id, lom)
+			lom.Unlock(false)
+		}
+	}
+	if err != nil {
+		wc.forget(id)
+		return nil, err
+	}
+
+	body, err := wc.wait(id, ch, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return wc.reply(body, size), nil
+}
+
+// pipeline stage (see pipeline.go)
+func (wc *wsComm) stream(r cos.ReadCloseSizer, lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+	if err := wc.acquire(timeout); err != nil {
+		r.Close()
+		return nil, err
+	}
+	defer wc.release()
+
+	conn, err := wc.connect()
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	id, ch := wc.expect()
+	err = wc.request(conn, id, lom.Bck().Name+"/"+lom.ObjName, r)
+	r.Close()
+	if err != nil {
+		wc.forget(id)
+		return nil, err
+	}
+	body, err := wc.wait(id, ch, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return wc.reply(body, r.Size()), nil
+}
+
+func (wc *wsComm) reply(body []byte, size int64) cos.ReadCloseSizer {
+	args := cos.ReaderArgs{
+		R:      bytes.NewReader(body),
+		Size:   int64(len(body)),
+		ReadCb: func(n int, _ error) { wc.boot.xctn.InObjsAdd(0, int64(n)) },
+		DeferCb: func() {
+			wc.boot.xctn.InObjsAdd(1, 0)
+			wc.boot.xctn.OutObjsAdd(1, size) // see also: `coi.objsAdd`
+		},
+	}
+	return cos.NewReaderWithArgs(args)
+}
+
+// back-pressure: block when there are `wsMaxInflight` requests awaiting replies
+func (wc *wsComm) acquire(timeout time.Duration) error {
+	select {
+	case wc.sema <- struct{}{}:
+		wc.inflight.Inc()
+		return nil
+	default:
+	}
+	wc.throttled.Inc()
+
+	var tch <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		tch = timer.C
+	}
+	select {
+	case wc.sema <- struct{}{}:
+		wc.inflight.Inc()
+		return nil
+	case err := <-wc.boot.xctn.ChanAbort():
+		return err
+	case <-tch:
+		return fmt.Errorf("%s: timed out waiting for in-flight requests (%d) to complete", wc, wsMaxInflight)
+	}
+}
+
+func (wc *wsComm) release() {
+	wc.inflight.Dec()
+	<-wc.sema
+}
+
+// connect (or reconnect) on demand
+func (wc *wsComm) connect() (*websocket.Conn, error) {
+	if err := wc.boot.xctn.AbortErr(); err != nil {
+		return nil, err
+	}
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	if wc.conn != nil {
+		return wc.conn, nil
+	}
+	if wc.stopped.Load() {
+		return nil, fmt.Errorf("%s: stopped", wc)
+	}
+	u := "ws" + strings.TrimPrefix(wc.boot.uri, "http") + wsPath // (http => ws, https => wss)
+	conn, resp, err := websocket.DefaultDialer.Dial(u, nil)
+	if resp != nil {
+		resp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to connect to %q: %w", wc, u, err)
+	}
+	conn.SetReadLimit(wsMaxReply)
+	wc.conn = conn
+	go wc.recv(conn)
+	return conn, nil
+}
+
+func (wc *wsComm) expect() (uint64, chan *wsReply) {
+	id, ch := wc.id.Inc(), make(chan *wsReply, 1)
+	wc.mu.Lock()
+	wc.pending[id] = ch
+	wc.mu.Unlock()
+	return id, ch
+}
+
+func (wc *wsComm) forget(id uint64) {
+	wc.mu.Lock()
+	delete(wc.pending, id)
+	wc.mu.Unlock()
+}
+
+// NOTE: lom is r-locked by the caller
+func (wc *wsComm) send(conn *websocket.Conn, id uint64, lom *core.LOM) (size int64, err error) {
+	var (
+		path string
+		fh   io.ReadCloser
+	)
+	if err = wc.boot.xctn.AbortErr(); err != nil {
+		return 0, err
+	}
+	if err = lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return 0, err
+	}
+	size = lom.Lsize()
+
+	switch wc.boot.msg.ArgTypeX {
+	case ArgTypeDefault:
+		debug.Assert(lom.Bck().Ns.IsGlobal(), lom.Bck().Cname(""), " - bucket with namespace")
+		path = lom.Bck().Name + "/" + lom.ObjName
+		if fh, err = lom.NewHandle(); err != nil {
+			return 0, err
+		}
+		defer cos.Close(fh)
+	case ArgTypeFQN:
+		if lom.IsChunked() {
+			return 0, errChunkedFQN(lom)
+		}
+		path = lom.FQN
+	default:
+		debug.Assert(false, "unexpected arg type:", wc.boot.msg.ArgTypeX) // is validated at construction time
+	}
+	return size, wc.request(conn, id, path, fh)
+}
+
+func (wc *wsComm) request(conn *websocket.Conn, id uint64, path string, body io.Reader) error {
+	if len(path) > math.MaxUint16 {
+		return fmt.Errorf("%s: name too long (%d)", wc, len(path))
+	}
+	hdr := make([]byte, wsHdrSize, wsHdrSize+len(path))
+	binary.BigEndian.PutUint64(hdr, id)
+	binary.BigEndian.PutUint16(hdr[cos.SizeofI64:], uint16(len(path)))
+	hdr = append(hdr, path...)
+
+	wc.wmu.Lock()
+	err := wc.write(conn, hdr, body)
+	wc.wmu.Unlock()
+	if err != nil {
+		conn.Close() // (recv fails pending requests and resets the connection)
+	}
+	return err
+}
+
+func (*wsComm) write(conn *websocket.Conn, hdr []byte, body io.Reader) error {
+	w, err := conn.NextWriter(websocket.BinaryMessage)
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(hdr); err == nil && body != nil {
+		buf, slab := core.T.PageMM().Alloc()
+		_, err = io.CopyBuffer(w, body, buf)
+		slab.Free(buf)
+	}
+	if errC := w.Close(); err == nil {
+		err = errC
+	}
+	return err
+}
+
+func (wc *wsComm) wait(id uint64, ch chan *wsReply, timeout time.Duration) ([]byte, error) {
+	var tch <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		tch = timer.C
+	}
+	select {
+	case reply := <-ch:
+		return reply.body, reply.err
+	case err := <-wc.boot.xctn.ChanAbort():
+		wc.forget(id)
+		return nil, err
+	case <-tch:
+		wc.forget(id)
+		return nil, fmt.Errorf("%s: timed out waiting for reply #%d", wc, id)
+	}
+}
+
+// single reader: dispatches replies to their respective (waiting) senders
+func (wc *wsComm) recv(conn *websocket.Conn) {
+	for {
+		typ, b, err := conn.ReadMessage()
+		if err == nil && (typ != websocket.BinaryMessage || len(b) < wsHdrSize) {
+			err = fmt.Errorf("%s: invalid message (type %d, size %d)", wc, typ, len(b))
+		}
+		if err != nil {
+			wc.disconnect(conn, err)
+			return
+		}
+		var (
+			id     = binary.BigEndian.Uint64(b)
+			status = int(binary.BigEndian.Uint16(b[cos.SizeofI64:]))
+			reply  = &wsReply{body: b[wsHdrSize:]}
+		)
+		if status != http.StatusOK {
+			reply.err = cmn.NewErrETLf(&cmn.ETLErrCtx{ETLName: wc.Name()}, "transform failed (status %d): %s", status, reply.body)
+			reply.body = nil
+		}
+		wc.mu.Lock()
+		ch, ok := wc.pending[id]
+		delete(wc.pending, id)
+		wc.mu.Unlock()
+		if ok {
+			ch <- reply
+		}
+	}
+}
+
+func (wc *wsComm) disconnect(conn *websocket.Conn, err error) {
+	if wc.stopped.Load() {
+		err = errors.New(wc.String() + ": stopped")
+	} else {
+		nlog.Warningln(wc.String(), "connection closed:", err)
+		err = fmt.Errorf("%s: connection closed: %w", wc, err)
+	}
+	wc.mu.Lock()
+	if wc.conn == conn {
+		wc.conn = nil
+		for id, ch := range wc.pending {
+			ch <- &wsReply{err: err}
+			delete(wc.pending, id)
+		}
+	}
+	wc.mu.Unlock()
+	conn.Close()
+}
@@ -38,6 +38,8 @@ const (
 	WorkfileType = "wk"
 	ECSliceType  = "ec"
 	ECMetaType   = "mt"
+	DeletedType  = "dl" // soft-deleted objects (see core/lfile.go)
+	ChunkType    = "ch" // chunks of a chunked object except the first one (see core/lchunk.go)
 )
 
 type (
@@ -177,6 +179,8 @@ type (
 	WorkfileContentResolver struct{}
 	ECSliceContentResolver  struct{}
 	ECMetaContentResolver   struct{}
+	DeletedContentResolver  struct{}
+	ChunkContentResolver    struct{}
 )
 
 func (*ObjectContentResolver) PermToMove() bool                   { return true }
@@ -251,3 +255,28 @@ func (*ECMetaContentResolver) GenUniqueFQN(base, _ string) string { return base
 func (*ECMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
 	return base, false, true
 }
+
+func (*DeletedContentResolver) PermToMove() bool    { return false }
+func (*DeletedContentResolver) PermToEvict() bool   { return true }
+func (*DeletedContentResolver) PermToProcess() bool { return false }
+
+func (*DeletedContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*DeletedContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
+
+// chunk ID is the suffix: <object-name>.<num>-<generation>
+func (*ChunkContentResolver) PermToMove() bool    { return false }
+func (*ChunkContentResolver) PermToEvict() bool   { return true }
+func (*ChunkContentResolver) PermToProcess() bool { return false }
+
+func (*ChunkContentResolver) GenUniqueFQN(base, id string) string { return base + "." + id }
+
+func (*ChunkContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	i := strings.LastIndexByte(base, '.')
+	if i <= 0 || i == len(base)-1 {
+		return "", false, false
+	}
+	return base[:i], false, true
+}
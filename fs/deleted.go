@@ -20,7 +20,9 @@ import (
 	"github.com/NVIDIA/aistore/cmn/nlog"
 )
 
-// TODO: undelete (feature)
+// NOTE: removed buckets and directories are moved to mountpath's 'deleted' root
+// and get purged by space cleanup; individual (soft-)deleted objects, on the other hand,
+// remain in their respective buckets as `DeletedType` content and can be restored.
 
 const (
 	deletedRoot = ".$deleted"
@@ -35,6 +35,9 @@ const (
 	WorkfileAppend       = "append"         // APPEND to object (as file)
 	WorkfileAppendToArch = "append-to-arch" // APPEND to existing archive
 	WorkfileCreateArch   = "create-arch"    // CREATE multi-object archive
+	WorkfileFlush        = "flush"          // flush staged (in-memory) object data
+	WorkfileChunk        = "chunk"          // write chunk of a chunked object
+	WorkfileEncrypt      = "encrypt"        // encrypt work file (server-side encryption)
 )
 
 type ParsedFQN struct {
@@ -188,6 +191,10 @@ func CleanPathErr(err error) {
 			what = "'ec slice'"
 		case ECMetaType:
 			what = "'ec metadata'"
+		case DeletedType:
+			what = "'deleted object'"
+		case ChunkType:
+			what = "'object chunk'"
 		default:
 			what = fmt.Sprintf("'%s'(?)", parsed.ContentType)
 		}
@@ -49,24 +49,28 @@ func WalkBck(opts *WalkBckOpts) error {
 	debug.Assert(opts.Mi == nil && opts.Sorted) // TODO: support `opts.Sorted == false`
 	var (
 		avail      = GetAvail()
-		l          = len(avail)
+		l          = len(avail) * len(opts.CTs)
 		joggers    = make([]*joggerBck, l)
 		group, ctx = errgroup.WithContext(context.Background())
 		idx        int
 	)
+	// one jogger per mountpath per content type (to merge-sort all of them, see below)
 	for _, mi := range avail {
-		workCh := make(chan *wbe, mpathQueueSize)
-		jg := &joggerBck{
-			workCh:   workCh,
-			mi:       mi,
-			validate: opts.ValidateCb,
-			ctx:      ctx,
-			opts:     opts.WalkOpts,
+		for _, ct := range opts.CTs {
+			workCh := make(chan *wbe, mpathQueueSize)
+			jg := &joggerBck{
+				workCh:   workCh,
+				mi:       mi,
+				validate: opts.ValidateCb,
+				ctx:      ctx,
+				opts:     opts.WalkOpts,
+			}
+			jg.opts.Callback = jg.cb // --> jg.validate --> opts.ValidateCb
+			jg.opts.Mi = mi
+			jg.opts.CTs = []string{ct}
+			joggers[idx] = jg
+			idx++
 		}
-		jg.opts.Callback = jg.cb // --> jg.validate --> opts.ValidateCb
-		jg.opts.Mi = mi
-		joggers[idx] = jg
-		idx++
 	}
 
 	for i := range l {
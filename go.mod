@@ -13,9 +13,11 @@ require (
 	github.com/aws/aws-sdk-go-v2/feature/s3/manager v1.17.32
 	github.com/aws/aws-sdk-go-v2/service/s3 v1.65.3
 	github.com/aws/smithy-go v1.22.0
-	github.com/golang-jwt/jwt/v4 v4.5.0
+	github.com/golang-jwt/jwt/v5 v5.2.1
+	github.com/gorilla/websocket v1.5.3
 	github.com/json-iterator/go v1.1.12
 	github.com/karrick/godirwalk v1.17.0
+	github.com/klauspost/compress v1.17.11
 	github.com/klauspost/reedsolomon v1.12.4
 	github.com/lufia/iostat v1.2.1
 	github.com/onsi/ginkgo/v2 v2.20.2
@@ -25,6 +27,7 @@ require (
 	github.com/prometheus/client_golang v1.20.4
 	github.com/seiflotfy/cuckoofilter v0.0.0-20240715131351-a2f2c23f1771
 	github.com/teris-io/shortid v0.0.0-20220617161101-71ec9f2aa569
+	github.com/tetratelabs/wazero v1.10.1
 	github.com/tidwall/buntdb v1.3.2
 	github.com/tinylib/msgp v1.2.2
 	github.com/valyala/fasthttp v1.56.0
@@ -101,7 +104,6 @@ require (
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.22.0 // indirect
 	github.com/imdario/mergo v0.3.16 // indirect
 	github.com/josharian/intern v1.0.0 // indirect
-	github.com/klauspost/compress v1.17.11 // indirect
 	github.com/klauspost/cpuid/v2 v2.2.8 // indirect
 	github.com/mailru/easyjson v0.7.7 // indirect
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
@@ -413,6 +413,12 @@ func (r *Reader) Read(b []byte) (n int, err error) {
 	return n, err
 }
 
+// (as io.ReaderAt - does not change the current read offset)
+func (r *Reader) ReadAt(b []byte, off int64) (n int, err error) {
+	n, _, err = r.z._readAt(b, off)
+	return n, err
+}
+
 func (r *Reader) Seek(from int64, whence int) (offset int64, err error) {
 	switch whence {
 	case io.SeekStart:
@@ -78,7 +78,7 @@ class Etl:
             template (str): Kubernetes pod spec template
                 Existing templates can be found at `sdk.etl_templates`
                 For more information visit: https://github.com/NVIDIA/ais-etl/tree/master/transformers
-            communication_type (str): Communication type of the ETL (options: hpull, hrev, hpush)
+            communication_type (str): Communication type of the ETL (options: hpull, hrev, hpush, ws)
             timeout (str): Timeout of the ETL job (e.g. 5m for 5 minutes)
         Returns:
             Job ID string associated with this ETL
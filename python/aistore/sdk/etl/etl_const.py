@@ -12,9 +12,11 @@ ETL_COMM_HPULL = "hpull"
 ETL_COMM_HREV = "hrev"
 # ext/etl/api.go HpushStdin
 ETL_COMM_IO = "io"
+# ext/etl/api.go WebSocket
+ETL_COMM_WS = "ws"
 
 ETL_COMM_CODE = [ETL_COMM_IO, ETL_COMM_HPUSH, ETL_COMM_HREV, ETL_COMM_HPULL]
-ETL_COMM_SPEC = [ETL_COMM_HPUSH, ETL_COMM_HREV, ETL_COMM_HPULL]
+ETL_COMM_SPEC = [ETL_COMM_HPUSH, ETL_COMM_HREV, ETL_COMM_HPULL, ETL_COMM_WS]
 
 ETL_SUPPORTED_PYTHON_VERSIONS = ["3.10", "3.11"]
 
@@ -24,15 +24,16 @@ type (
 	syncCallback func(tsi *meta.Snode, rargs *rebArgs) (ok bool)
 
 	Status struct {
-		Targets     meta.Nodes `json:"targets"`             // targets I'm waiting for ACKs from
-		SmapVersion int64      `json:"smap_version,string"` // current Smap version (via smapOwner)
-		RebVersion  int64      `json:"reb_version,string"`  // Smap version of *this* rebalancing op
-		RebID       int64      `json:"reb_id,string"`       // rebalance ID
-		Stats       core.Stats `json:"stats"`               // transmitted/received totals
-		Stage       uint32     `json:"stage"`               // the current stage - see enum above
-		Aborted     bool       `json:"aborted"`             // aborted?
-		Running     bool       `json:"running"`             // running?
-		Quiescent   bool       `json:"quiescent"`           // true when queue is empty
+		Targets     meta.Nodes `json:"targets"`               // targets I'm waiting for ACKs from
+		SmapVersion int64      `json:"smap_version,string"`   // current Smap version (via smapOwner)
+		RebVersion  int64      `json:"reb_version,string"`    // Smap version of *this* rebalancing op
+		RebID       int64      `json:"reb_id,string"`         // rebalance ID
+		ResumedPct  int64      `json:"resumed_pct,omitempty"` // when resumed: percentage of work done by previous run(s)
+		Stats       core.Stats `json:"stats"`                 // transmitted/received totals
+		Stage       uint32     `json:"stage"`                 // the current stage - see enum above
+		Aborted     bool       `json:"aborted"`               // aborted?
+		Running     bool       `json:"running"`               // running?
+		Quiescent   bool       `json:"quiescent"`             // true when queue is empty
 	}
 )
 
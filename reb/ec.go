@@ -127,7 +127,7 @@ func (reb *Reb) sendFromDisk(ct *core.CT, meta *ec.Metadata, target *meta.Snode,
 	// open
 	if lom != nil {
 		defer core.FreeLOM(lom)
-		roc, err = lom.NewDeferROC()
+		roc, err = lom.NewDeferRawROC() // (see ec.Metadata.SSE)
 	} else {
 		roc, err = cos.NewFileHandle(fqn)
 	}
@@ -142,6 +142,7 @@ func (reb *Reb) sendFromDisk(ct *core.CT, meta *ec.Metadata, target *meta.Snode,
 	o.Hdr.Bck.Copy(ct.Bck().Bucket())
 	if lom != nil {
 		o.Hdr.ObjAttrs.CopyFrom(lom.ObjAttrs(), false /*skip cksum*/)
+		o.Hdr.ObjAttrs.Size = lom.RawSize() // (sending stored content)
 	}
 	if meta.SliceID != 0 {
 		o.Hdr.ObjAttrs.Size = ec.SliceSize(meta.Size, meta.Data)
@@ -0,0 +1,322 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Rebalance dry-run: run the same HRW placement over local objects against a hypothetical
+// cluster map (see apc.RebEstimateMsg) and count what would move and where.
+// Runs asynchronously, as apc.ActRebEstimate xaction (compare with bucket summary).
+// Limitations:
+// - only objects (and not EC slices) are counted;
+// - disk throughput is extrapolated from the current disk stats (when busy enough - see estMinUtil);
+// - network throughput is the one observed during this target's most recent rebalance, if any
+//   (and as such, includes rebalance's own overhead), limited by the rebalance bandwidth cap, if any.
+
+const (
+	// disk throughput is extrapolated from the current one only when the disk is busy enough
+	estMinUtil = 10
+
+	// observed network throughput requires at least as much data sent (or received)
+	estMinBytes = cos.MiB
+)
+
+type (
+	estFactory struct {
+		xreg.RenewBase
+		xctn *XactEstimate
+	}
+	XactEstimate struct {
+		msg   *apc.RebEstimateMsg
+		hsmap *meta.Smap
+		res   *apc.RebEstimate // upon completion
+		xact.Base
+	}
+	estJogger struct {
+		r    *XactEstimate
+		est  apc.RebEstimate
+		opts fs.WalkOpts
+	}
+)
+
+// interface guard
+var (
+	_ xreg.Renewable = (*estFactory)(nil)
+	_ core.Xact      = (*XactEstimate)(nil)
+)
+
+func Xreg() { xreg.RegNonBckXact(&estFactory{}) }
+
+////////////////
+// estFactory //
+////////////////
+
+func (*estFactory) New(args xreg.Args, _ *meta.Bck) xreg.Renewable {
+	return &estFactory{RenewBase: xreg.RenewBase{Args: args}}
+}
+
+func (p *estFactory) Start() error {
+	msg := p.Args.Custom.(*apc.RebEstimateMsg)
+	if msg.IsEmpty() {
+		return errors.New("rebalance estimate: empty cluster map change")
+	}
+	hsmap, err := hypoSmap(core.T.Sowner().Get(), msg)
+	if err != nil {
+		return err
+	}
+	p.xctn = &XactEstimate{msg: msg, hsmap: hsmap}
+	p.xctn.InitBase(p.UUID(), p.Kind(), nil)
+	xact.GoRunW(p.xctn)
+	return nil
+}
+
+func (*estFactory) Kind() string     { return apc.ActRebEstimate }
+func (p *estFactory) Get() core.Xact { return p.xctn }
+
+func (*estFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) {
+	return xreg.WprKeepAndStartNew, nil
+}
+
+//////////////////
+// XactEstimate //
+//////////////////
+
+func (r *XactEstimate) Run(started *sync.WaitGroup) {
+	started.Done()
+	nlog.Infoln(r.Name(), "msg:", r.msg)
+	var (
+		avail   = fs.GetAvail()
+		bmd     = core.T.Bowner().Get()
+		joggers = make([]*estJogger, 0, len(avail))
+		wg      = &sync.WaitGroup{}
+	)
+	for _, mi := range avail {
+		j := &estJogger{r: r}
+		j.est.Bcks = make(map[string]*apc.RebEstimateCnt, 4)
+		j.est.Dsts = make(map[string]*apc.RebEstimateCnt, 4)
+		j.opts.Mi = mi
+		j.opts.CTs = []string{fs.ObjectType}
+		j.opts.Callback = j.visitObj
+		joggers = append(joggers, j)
+		wg.Add(1)
+		go j.jog(bmd, wg)
+	}
+	wg.Wait()
+	if r.IsAborted() {
+		r.Finish()
+		return
+	}
+
+	est := &apc.RebEstimate{
+		Bcks: make(map[string]*apc.RebEstimateCnt, 4),
+		Dsts: make(map[string]*apc.RebEstimateCnt, 4),
+	}
+	for _, j := range joggers {
+		est.Scanned.Merge(&j.est.Scanned)
+		est.Out.Merge(&j.est.Out)
+		_merge(est.Bcks, j.est.Bcks)
+		_merge(est.Dsts, j.est.Dsts)
+	}
+	config := cmn.GCO.Get()
+	est.DiskBps = diskBps(config)
+	est.NetBps = netBps(config, time.Now())
+	r.res = est
+	r.Finish()
+}
+
+// returns nil result while still running
+func (r *XactEstimate) Result() (*apc.RebEstimate, error) {
+	if err := r.AbortErr(); err != nil {
+		return nil, err
+	}
+	if !r.Finished() {
+		return nil, nil
+	}
+	return r.res, nil
+}
+
+func (r *XactEstimate) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+func _merge(dst, src map[string]*apc.RebEstimateCnt) {
+	for k, cnt := range src {
+		if dst[k] == nil {
+			dst[k] = &apc.RebEstimateCnt{}
+		}
+		dst[k].Merge(cnt)
+	}
+}
+
+// hypothetical Smap: only targets matter
+func hypoSmap(smap *meta.Smap, msg *apc.RebEstimateMsg) (*meta.Smap, error) {
+	tmap := make(meta.NodeMap, len(smap.Tmap)+len(msg.Join))
+	for tid, tsi := range smap.Tmap {
+		tmap[tid] = tsi
+	}
+	for _, tid := range msg.Join {
+		if _, ok := tmap[tid]; ok {
+			return nil, fmt.Errorf("cannot join target %s: already present in %s", tid, smap)
+		}
+		tsi := &meta.Snode{}
+		tsi.Init(tid, apc.Target)
+		tmap[tid] = tsi
+	}
+	for _, tid := range msg.Remove {
+		if _, ok := tmap[tid]; !ok {
+			return nil, fmt.Errorf("cannot remove target %s: not present in %s", tid, smap)
+		}
+		delete(tmap, tid)
+	}
+	for _, tid := range msg.Maint {
+		tsi, ok := tmap[tid]
+		if !ok {
+			return nil, fmt.Errorf("cannot put target %s in maintenance: not present in %s", tid, smap)
+		}
+		tsi = tsi.Clone()
+		tsi.Flags = tsi.Flags.Set(meta.SnodeMaint)
+		tmap[tid] = tsi
+	}
+	hsmap := &meta.Smap{Tmap: tmap, Version: smap.Version, UUID: smap.UUID}
+	if hsmap.CountActiveTs() == 0 {
+		return nil, errors.New("rebalance estimate: no active targets left")
+	}
+	return hsmap, nil
+}
+
+func (j *estJogger) jog(bmd *meta.BMD, wg *sync.WaitGroup) {
+	defer wg.Done()
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		j.opts.Bck.Copy(bck.Bucket())
+		if err := fs.Walk(&j.opts); err != nil {
+			if j.r.IsAborted() {
+				return true
+			}
+			nlog.Errorln(j.r.Name(), "failed to traverse", j.opts.Mi.String(), bck.Cname(""), err)
+		}
+		return false
+	})
+}
+
+func (j *estJogger) visitObj(fqn string, de fs.DirEntry) error {
+	if de.IsDir() {
+		return nil
+	}
+	if err := j.r.AbortErr(); err != nil {
+		return err
+	}
+	lom := core.AllocLOM("")
+	defer core.FreeLOM(lom)
+	if err := lom.InitFQN(fqn, nil); err != nil {
+		if cmn.IsErrBucketLevel(err) {
+			return err
+		}
+		return nil
+	}
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil || lom.IsCopy() {
+		return nil
+	}
+	size := lom.Lsize()
+	j.est.Scanned.Add(size)
+	j.r.ObjsAdd(1, size)
+	tsi, err := j.r.hsmap.HrwHash2T(lom.Digest())
+	if err != nil {
+		return err
+	}
+	if tsi.ID() == core.T.SID() {
+		return nil
+	}
+	j.est.Out.Add(size)
+	_add(j.est.Bcks, lom.Bck().Cname(""), size)
+	_add(j.est.Dsts, tsi.ID(), size)
+	return nil
+}
+
+func _add(m map[string]*apc.RebEstimateCnt, key string, size int64) {
+	cnt, ok := m[key]
+	if !ok {
+		cnt = &apc.RebEstimateCnt{}
+		m[key] = cnt
+	}
+	cnt.Add(size)
+}
+
+// extrapolate (read + write) throughput of the busy-enough disks to 100% utilization;
+// return zero when none
+func diskBps(config *cmn.Config) int64 {
+	var (
+		total, n int64
+		dstats   = make(ios.AllDiskStats, fs.NumAvail())
+	)
+	fs.DiskStats(dstats, nil, config, false /*refresh cap*/)
+	for _, ds := range dstats {
+		if ds.Util >= estMinUtil {
+			total += (ds.RBps + ds.WBps) * 100 / ds.Util
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / n * int64(len(dstats))
+}
+
+// observed throughput of this target's most recent rebalance (see snapBps),
+// limited by the currently configured cap (if any); zero when unknown
+func netBps(config *cmn.Config, now time.Time) (bps int64) {
+	entry := xreg.GetLatest(xreg.Flt{Kind: apc.ActRebalance})
+	if entry == nil {
+		return 0
+	}
+	xctn := entry.Get()
+	if xctn == nil {
+		return 0
+	}
+	if bps = snapBps(xctn.Snap(), now); bps == 0 {
+		return 0
+	}
+	if limit, _ := config.Rebalance.Bps(now); limit > 0 {
+		bps = min(bps, limit)
+	}
+	return bps
+}
+
+// bytes sent or received (whichever is greater) over the rebalance duration (so far)
+func snapBps(snap *core.Snap, now time.Time) int64 {
+	if !snap.Started() {
+		return 0
+	}
+	end := snap.EndTime
+	if end.IsZero() {
+		end = now
+	}
+	var (
+		elapsed = end.Sub(snap.StartTime)
+		size    = max(snap.Stats.OutBytes, snap.Stats.InBytes)
+	)
+	if size < estMinBytes || elapsed < time.Second {
+		return 0
+	}
+	return int64(float64(size) / elapsed.Seconds())
+}
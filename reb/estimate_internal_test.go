@@ -0,0 +1,117 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Estimate", func() {
+	newSmap := func(tids ...string) *meta.Smap {
+		smap := &meta.Smap{Tmap: make(meta.NodeMap, len(tids)), Version: 10, UUID: "uuid"}
+		for _, tid := range tids {
+			tsi := &meta.Snode{}
+			tsi.Init(tid, apc.Target)
+			smap.Tmap[tid] = tsi
+		}
+		return smap
+	}
+
+	Describe("hypoSmap", func() {
+		It("should add joining targets", func() {
+			smap := newSmap("t1", "t2")
+			hsmap, err := hypoSmap(smap, &apc.RebEstimateMsg{Join: []string{"t3", "t4"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hsmap.CountActiveTs()).To(Equal(4))
+			Expect(hsmap.GetTarget("t3")).NotTo(BeNil())
+			Expect(hsmap.Version).To(Equal(smap.Version))
+			Expect(smap.Tmap).To(HaveLen(2)) // (unchanged)
+		})
+
+		It("should remove leaving targets", func() {
+			smap := newSmap("t1", "t2", "t3")
+			hsmap, err := hypoSmap(smap, &apc.RebEstimateMsg{Remove: []string{"t2"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hsmap.CountActiveTs()).To(Equal(2))
+			Expect(hsmap.GetTarget("t2")).To(BeNil())
+			Expect(smap.GetTarget("t2")).NotTo(BeNil())
+		})
+
+		It("should put targets in maintenance without changing the original", func() {
+			smap := newSmap("t1", "t2")
+			hsmap, err := hypoSmap(smap, &apc.RebEstimateMsg{Maint: []string{"t1"}, Join: []string{"t3"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hsmap.CountTargets()).To(Equal(3))
+			Expect(hsmap.CountActiveTs()).To(Equal(2))
+			Expect(hsmap.GetTarget("t1").InMaint()).To(BeTrue())
+			Expect(smap.GetTarget("t1").InMaint()).To(BeFalse())
+		})
+
+		It("should move objects only off the targets that leave or enter maintenance", func() {
+			smap := newSmap("t1", "t2", "t3")
+			hsmap, err := hypoSmap(smap, &apc.RebEstimateMsg{Maint: []string{"t1"}})
+			Expect(err).NotTo(HaveOccurred())
+			for i := range 1000 {
+				uname := []byte("bck/obj-" + strconv.Itoa(i))
+				was, err := smap.HrwName2T(uname)
+				Expect(err).NotTo(HaveOccurred())
+				will, err := hsmap.HrwName2T(uname)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(will.ID()).NotTo(Equal("t1"))
+				if was.ID() != "t1" {
+					Expect(will.ID()).To(Equal(was.ID()))
+				}
+			}
+		})
+
+		DescribeTable("should fail",
+			func(msg *apc.RebEstimateMsg) {
+				_, err := hypoSmap(newSmap("t1", "t2"), msg)
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("joining target already present", &apc.RebEstimateMsg{Join: []string{"t2"}}),
+			Entry("removing target not present", &apc.RebEstimateMsg{Remove: []string{"t3"}}),
+			Entry("maintenance target not present", &apc.RebEstimateMsg{Maint: []string{"t3"}}),
+			Entry("removing target twice", &apc.RebEstimateMsg{Remove: []string{"t1", "t1"}}),
+			Entry("no active targets left", &apc.RebEstimateMsg{Remove: []string{"t1"}, Maint: []string{"t2"}}),
+		)
+	})
+
+	Describe("snapBps", func() {
+		var (
+			now   = time.Now()
+			start = now.Add(-10 * time.Second)
+		)
+		It("should return zero when unknown", func() {
+			Expect(snapBps(&core.Snap{}, now)).To(BeZero())
+
+			snap := &core.Snap{StartTime: start, EndTime: now}
+			snap.Stats.OutBytes = estMinBytes - 1
+			Expect(snapBps(snap, now)).To(BeZero())
+
+			snap = &core.Snap{StartTime: now.Add(-time.Millisecond)}
+			snap.Stats.OutBytes = 100 * cos.MiB
+			Expect(snapBps(snap, now)).To(BeZero())
+		})
+
+		It("should compute observed throughput", func() {
+			snap := &core.Snap{StartTime: start, EndTime: start.Add(5 * time.Second)}
+			snap.Stats.OutBytes = 10 * cos.MiB
+			snap.Stats.InBytes = 50 * cos.MiB
+			Expect(snapBps(snap, now)).To(BeEquivalentTo(10 * cos.MiB)) // (max of sent and received) / 5s
+
+			snap.EndTime = time.Time{} // still running
+			Expect(snapBps(snap, now)).To(BeEquivalentTo(5 * cos.MiB))
+		})
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/cmn/prob"
 	"github.com/NVIDIA/aistore/core"
@@ -89,6 +90,7 @@ type (
 		nxtID   atomic.Int64
 		inQueue atomic.Int64
 		onAir   atomic.Int64
+		resumed atomic.Int64 // percentage of the work skipped by resumed rebalance (see progress.go)
 		mu      sync.RWMutex
 		laterx  atomic.Bool
 	}
@@ -103,14 +105,17 @@ type (
 	}
 	rebJogger struct {
 		joggerBase
-		smap *meta.Smap
-		opts fs.WalkOpts
-		ver  int64
+		smap  *meta.Smap
+		rargs *rebArgs
+		prog  *mpathProg
+		opts  fs.WalkOpts
+		ver   int64
 	}
 	rebArgs struct {
 		smap   *meta.Smap
 		config *cmn.Config
 		apaths fs.MPI
+		progs  []*mpathProg
 		id     int64
 		ecUsed bool
 	}
@@ -132,6 +137,8 @@ func New(config *cmn.Config) *Reb {
 	dmExtra := bundle.Extra{
 		RecvAck:     reb.recvAck,
 		Config:      config,
+		SendBps:     sendBps,
+		RecvBps:     recvBps,
 		Compression: config.Rebalance.Compression,
 		Multiplier:  config.Rebalance.SbundleMult,
 	}
@@ -147,6 +154,10 @@ func New(config *cmn.Config) *Reb {
 	return reb
 }
 
+// bandwidth caps (rebalance.send_bps and rebalance.recv_bps) can change at runtime
+func sendBps() int64 { send, _ := cmn.GCO.Get().Rebalance.Bps(time.Now()); return send }
+func recvBps() int64 { _, recv := cmn.GCO.Get().Rebalance.Bps(time.Now()); return recv }
+
 func (reb *Reb) regRecv() {
 	if err := reb.dm.RegRecv(); err != nil {
 		cos.ExitLog(err)
@@ -209,10 +220,12 @@ func (reb *Reb) RunRebalance(smap *meta.Smap, id int64, notif *xact.NotifXact, t
 		// cleanup and leave
 		nlog.Infof("%s: nothing to do: %s, %s", logHdr, smap.StringEx(), bmd.StringEx())
 		reb.stages.stage.Store(rebStageDone)
+		reb.resumed.Store(0)
 		reb.unregRecv()
 		reb.semaCh.Release()
 		fs.RemoveMarker(fname.RebalanceMarker)
 		fs.RemoveMarker(fname.NodeRestartedPrev)
+		removeProgress()
 		reb.xctn().Finish()
 		return
 	}
@@ -229,6 +242,9 @@ func (reb *Reb) RunRebalance(smap *meta.Smap, id int64, notif *xact.NotifXact, t
 
 	tstats.SetFlag(cos.NodeAlerts, cos.Rebalancing)
 
+	// staged (in-memory) objects are invisible to the mountpath walk
+	core.FlushAllStaged(smap)
+
 	errCnt := 0
 	err := reb.run(rargs)
 	if err == nil {
@@ -535,15 +551,23 @@ func (reb *Reb) runNoEC(rargs *rebArgs) error {
 		nlog.Errorln(logHdr, "rx-ready num-fail", errCnt) // unlikely
 	}
 
+	// resume (skipping what's been done and verified by the previous interrupted run(s))
+	var pct int
+	rargs.progs, pct = loadProgress(rargs, core.T.Bowner().Get())
+	reb.resumed.Store(int64(pct))
+	if pct > 0 {
+		nlog.Infof("%s: resumed from %d%%", reb.logHdr(rargs.id, rargs.smap), pct)
+	}
+
 	wg := &sync.WaitGroup{}
 	ver := rargs.smap.Version
-	for _, mi := range rargs.apaths {
+	for _, mprog := range rargs.progs {
 		rl := &rebJogger{
 			joggerBase: joggerBase{m: reb, xreb: reb.xctn(), wg: wg},
-			smap:       rargs.smap, ver: ver,
+			smap:       rargs.smap, rargs: rargs, prog: mprog, ver: ver,
 		}
 		wg.Add(1)
-		go rl.jog(mi)
+		go rl.jog(mprog.mi)
 	}
 	wg.Wait()
 
@@ -724,6 +748,14 @@ func (reb *Reb) fini(rargs *rebArgs, logHdr string, err error) {
 		}
 		_ = fs.RemoveMarker(fname.NodeRestartedPrev)
 	}
+	// persist progress to resume from (or, when successfully done, cleanup)
+	if err == nil && !reb.xctn().IsAborted() {
+		removeProgress()
+	} else {
+		for _, mprog := range rargs.progs {
+			mprog.checkpoint(reb, rargs)
+		}
+	}
 	reb.endStreams(err)
 	reb.filterGFN.Reset()
 
@@ -760,17 +792,26 @@ func (rj *rebJogger) jog(mi *fs.Mountpath) {
 		rj.opts.Mi = mi
 		rj.opts.CTs = []string{fs.ObjectType}
 		rj.opts.Callback = rj.visitObj
-		rj.opts.Sorted = false
+		rj.opts.Sorted = true // (see progMark)
 	}
 	bmd := core.T.Bowner().Get()
 	bmd.Range(nil, nil, rj.walkBck)
 }
 
 func (rj *rebJogger) walkBck(bck *meta.Bck) bool {
+	if rj.prog.skip(bck) {
+		return false
+	}
 	rj.opts.Bck.Copy(bck.Bucket())
+	rj.prog.begin(bck)
 	err := fs.Walk(&rj.opts)
 	if err == nil {
-		return rj.xreb.IsAborted()
+		if rj.xreb.IsAborted() {
+			return true
+		}
+		rj.prog.end()
+		rj.prog.checkpoint(rj.m, rj.rargs)
+		return false
 	}
 	if rj.xreb.IsAborted() {
 		nlog.Infoln(rj.xreb.Name(), "aborting traversal")
@@ -804,18 +845,29 @@ func (rj *rebJogger) visitObj(fqn string, de fs.DirEntry) error {
 		nlog.Infoln(rj.xreb.Name(), "rj-walk-visit aborted", err)
 		return err
 	}
+	name, done := rj.prog.done(fqn, de.IsDir())
 	if de.IsDir() {
+		if done {
+			return filepath.SkipDir
+		}
+		return nil
+	}
+	if done {
 		return nil
 	}
 	lom := core.AllocLOM(fqn)
 	err := rj._lwalk(lom, fqn)
 	if err != nil {
 		core.FreeLOM(lom)
-		if err == cmn.ErrSkip {
-			err = nil
+		if err != cmn.ErrSkip {
+			return err
 		}
 	}
-	return err
+	rj.prog.cur.last = name
+	if mono.Since(rj.prog.saved) > progIval {
+		rj.prog.checkpoint(rj.m, rj.rargs)
+	}
+	return nil
 }
 
 func (rj *rebJogger) _lwalk(lom *core.LOM, fqn string) error {
@@ -0,0 +1,284 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/cmn/xoshiro256"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Resumable (non-EC) rebalance
+// - mountpath jogger traverses each bucket in sorted order (see walkCmp) and periodically
+//   records its position: the object before which all objects sent from this mountpath
+//   are ACK-ed (see progMark);
+// - fully traversed bucket becomes "verified" once all its objects are ACK-ed;
+// - verified buckets and positions (marks) are persisted in the mountpath's progress file
+//   (fname.RebProgress) together with the RMD and Smap versions of the rebalance;
+// - next rebalance skips verified buckets, and objects up to the mark in the partially
+//   traversed ones, iff the set of active targets (and therefore HRW placement) did not
+//   change - otherwise, it starts from scratch;
+// - progress files are removed upon successful completion.
+
+const (
+	progressVer = 2
+
+	progIval = time.Minute // checkpoint (persist) interval while traversing
+)
+
+type (
+	progress struct {
+		Marks   map[string]*progMark `json:"marks,omitempty"`     // partially traversed buckets (ditto)
+		Bcks    []string             `json:"bcks"`                // verified buckets (see progKey)
+		UUID    string               `json:"uuid"`                // cluster UUID
+		Tdigest uint64               `json:"tdigest,string"`      // active targets (see tdigest)
+		RebID   int64                `json:"reb_id,string"`       // RMD version
+		SmapVer int64                `json:"smap_version,string"` // Smap version
+	}
+	// all objects that precede ObjName (in the traversal order) are done;
+	// so is ObjName itself unless Excl
+	progMark struct {
+		ObjName string `json:"obj"`
+		Excl    bool   `json:"excl,omitempty"`
+	}
+	// runtime, one per mountpath jogger
+	mpathProg struct {
+		mi       *fs.Mountpath
+		verified cos.StrSet           // including resumed
+		marks    map[string]*progMark // ditto
+		walked   []*meta.Bck          // traversed by this run and pending ACKs
+		// bucket that's being traversed
+		cur struct {
+			bck  *meta.Bck
+			mark *progMark // resumed from
+			bdir string    // objects' directory
+			last string    // last object sent or skipped
+		}
+		saved int64 // mono-time
+	}
+)
+
+// interface guard
+var _ jsp.Opts = (*progress)(nil)
+
+func (*progress) JspOpts() jsp.Options { return jsp.CksumSign(progressVer) }
+
+func progKey(bck *meta.Bck) string {
+	return bck.Cname("") + "@" + strconv.FormatUint(bck.Props.BID, 16)
+}
+
+// order-independent digest of the targets that participate in HRW
+func tdigest(smap *meta.Smap) (digest uint64) {
+	for _, tsi := range smap.Tmap {
+		if !tsi.InMaintOrDecomm() {
+			digest ^= xoshiro256.Hash(tsi.Digest())
+		}
+	}
+	return digest
+}
+
+// load (and validate) previously persisted progress; returns percentage of the
+// (mountpath, bucket) pairs that this run will skip
+func loadProgress(rargs *rebArgs, bmd *meta.BMD) (progs []*mpathProg, pct int) {
+	var (
+		total, skipped int
+		digest         = tdigest(rargs.smap)
+	)
+	progs = make([]*mpathProg, 0, len(rargs.apaths))
+	for _, mi := range rargs.apaths {
+		var (
+			prog  = &progress{}
+			mprog = &mpathProg{mi: mi, verified: cos.StrSet{}, marks: make(map[string]*progMark, 4), saved: mono.NanoTime()}
+			fpath = filepath.Join(mi.Path, fname.RebProgress)
+		)
+		progs = append(progs, mprog)
+		if _, err := jsp.LoadMeta(fpath, prog); err != nil {
+			if !os.IsNotExist(err) {
+				nlog.Warningln("failed to load", fpath, "- starting from scratch:", err)
+			}
+			continue
+		}
+		if prog.UUID != rargs.smap.UUID || prog.Tdigest != digest || prog.RebID >= rargs.id {
+			nlog.Infof("%s: incompatible rebalance progress (g%d, v%d) - starting from scratch",
+				mi, prog.RebID, prog.SmapVer)
+			continue
+		}
+		for _, key := range prog.Bcks {
+			mprog.verified.Add(key)
+		}
+		for key, mark := range prog.Marks {
+			mprog.marks[key] = mark
+		}
+	}
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		key := progKey(bck)
+		for _, mprog := range progs {
+			total++
+			if mprog.verified.Contains(key) {
+				skipped++
+			}
+		}
+		return false
+	})
+	if skipped > 0 {
+		pct = max(skipped*100/total, 1)
+	}
+	return progs, pct
+}
+
+func (mprog *mpathProg) skip(bck *meta.Bck) bool { return mprog.verified.Contains(progKey(bck)) }
+
+func (mprog *mpathProg) begin(bck *meta.Bck) {
+	mprog.cur.bck = bck
+	mprog.cur.mark = mprog.marks[progKey(bck)]
+	mprog.cur.bdir = mprog.mi.MakePathCT(bck.Bucket(), fs.ObjectType) + cos.PathSeparator
+	mprog.cur.last = ""
+}
+
+func (mprog *mpathProg) end() {
+	mprog.walked = append(mprog.walked, mprog.cur.bck)
+	mprog.cur.bck, mprog.cur.mark = nil, nil
+}
+
+// returns object name (or directory's path relative to the bucket) and whether
+// the latter was already done by the previous run(s), which is when:
+// - object is at or before the resumed mark;
+// - directory is before the mark and does not contain it
+func (mprog *mpathProg) done(fqn string, isDir bool) (string, bool) {
+	name, ok := strings.CutPrefix(fqn, mprog.cur.bdir)
+	if !ok || mprog.cur.mark == nil {
+		return name, false
+	}
+	mark := mprog.cur.mark
+	if isDir {
+		return name, !strings.HasPrefix(mark.ObjName, name+cos.PathSeparator) && walkCmp(name, mark.ObjName) < 0
+	}
+	c := walkCmp(name, mark.ObjName)
+	return name, c < 0 || (c == 0 && !mark.Excl)
+}
+
+// checkpoint:
+// - traversed buckets that have no pending ACKs become verified;
+// - marks the position in the buckets that do (including the current one)
+func (mprog *mpathProg) checkpoint(reb *Reb, rargs *rebArgs) {
+	if len(mprog.walked) == 0 && mprog.cur.bck == nil {
+		return
+	}
+	// first pending (in traversal order) by bucket
+	pending := make(map[*meta.Bck]string, len(mprog.walked)+1)
+	for _, lomAck := range reb.lomAcks() {
+		lomAck.mu.Lock()
+		for _, lom := range lomAck.q {
+			if lom.Mountpath().Path != mprog.mi.Path {
+				continue
+			}
+			if bck := mprog.cur.bck; bck != nil && lom.Bck().Equal(bck, true /*same BID*/, false) {
+				_first(pending, bck, lom.ObjName)
+				continue
+			}
+			for _, bck := range mprog.walked {
+				if lom.Bck().Equal(bck, true, false) {
+					_first(pending, bck, lom.ObjName)
+					break
+				}
+			}
+		}
+		lomAck.mu.Unlock()
+	}
+	mprog.update(pending)
+	mprog.persist(rargs)
+}
+
+func _first(pending map[*meta.Bck]string, bck *meta.Bck, objName string) {
+	if first, ok := pending[bck]; !ok || walkCmp(objName, first) < 0 {
+		pending[bck] = objName
+	}
+}
+
+func (mprog *mpathProg) update(pending map[*meta.Bck]string) {
+	walked := mprog.walked[:0]
+	for _, bck := range mprog.walked {
+		key := progKey(bck)
+		if first, ok := pending[bck]; ok {
+			walked = append(walked, bck)
+			mprog.marks[key] = &progMark{ObjName: first, Excl: true}
+		} else {
+			mprog.verified.Add(key)
+			delete(mprog.marks, key)
+		}
+	}
+	clear(mprog.walked[len(walked):])
+	mprog.walked = walked
+
+	bck := mprog.cur.bck
+	if bck == nil {
+		return
+	}
+	if first, ok := pending[bck]; ok {
+		mprog.marks[progKey(bck)] = &progMark{ObjName: first, Excl: true}
+	} else if mprog.cur.last != "" {
+		mprog.marks[progKey(bck)] = &progMark{ObjName: mprog.cur.last}
+	}
+}
+
+func (mprog *mpathProg) persist(rargs *rebArgs) {
+	var (
+		prog = &progress{
+			Marks:   mprog.marks,
+			Bcks:    mprog.verified.ToSlice(),
+			UUID:    rargs.smap.UUID,
+			Tdigest: tdigest(rargs.smap),
+			RebID:   rargs.id,
+			SmapVer: rargs.smap.Version,
+		}
+		fpath = filepath.Join(mprog.mi.Path, fname.RebProgress)
+	)
+	if err := jsp.SaveMeta(fpath, prog, nil); err != nil {
+		nlog.Errorln(core.T.String(), "failed to persist rebalance progress:", err)
+	}
+	mprog.saved = mono.NanoTime()
+}
+
+func removeProgress() {
+	avail := fs.GetAvail()
+	for _, mi := range avail {
+		if err := cos.RemoveFile(filepath.Join(mi.Path, fname.RebProgress)); err != nil {
+			nlog.Errorln(err)
+		}
+	}
+}
+
+// compares object names in the order of sorted fs.Walk, that is, component by component
+// (e.g., "a/b" precedes "a.b" even though '/' > '.')
+func walkCmp(a, b string) int {
+	for {
+		ca, ra, oka := strings.Cut(a, cos.PathSeparator)
+		cb, rb, okb := strings.Cut(b, cos.PathSeparator)
+		if c := strings.Compare(ca, cb); c != 0 {
+			return c
+		}
+		switch {
+		case !oka && !okb:
+			return 0
+		case !oka:
+			return -1
+		case !okb:
+			return 1
+		}
+		a, b = ra, rb
+	}
+}
@@ -0,0 +1,222 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Progress", func() {
+	var (
+		mpath string
+		rargs *rebArgs
+		bck   = meta.NewBck("reb-progress", apc.AIS, cmn.NsGlobal, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}, BID: 0xb1})
+		other = meta.NewBck("reb-other", apc.AIS, cmn.NsGlobal, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}, BID: 0xb2})
+	)
+
+	newSmap := func(uuid string, tids ...string) *meta.Smap {
+		smap := &meta.Smap{Tmap: make(meta.NodeMap, len(tids)), Version: 10, UUID: uuid}
+		for _, tid := range tids {
+			tsi := &meta.Snode{}
+			tsi.Init(tid, apc.Target)
+			smap.Tmap[tid] = tsi
+		}
+		return smap
+	}
+	newReb := func() *Reb {
+		reb := &Reb{}
+		for i := range reb.lomacks {
+			reb.lomacks[i] = &lomAcks{mu: &sync.Mutex{}, q: make(map[string]*core.LOM, 4)}
+		}
+		return reb
+	}
+	newLOM := func(b *meta.Bck, name string) *core.LOM {
+		lom := core.AllocLOM(name)
+		Expect(lom.InitBck(b.Bucket())).To(Succeed())
+		return lom
+	}
+	load := func(rargs *rebArgs) (*mpathProg, int) {
+		progs, pct := loadProgress(rargs, core.T.Bowner().Get())
+		Expect(progs).To(HaveLen(1))
+		return progs[0], pct
+	}
+
+	BeforeEach(func() {
+		mpath = GinkgoT().TempDir()
+		fs.TestNew(nil)
+		_, err := fs.Add(mpath, "daeID")
+		Expect(err).NotTo(HaveOccurred())
+		fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+		_ = mock.NewTarget(mock.NewBaseBownerMock(bck, other))
+
+		rargs = &rebArgs{smap: newSmap("uuid", "t1", "t2"), apaths: fs.GetAvail(), id: 5}
+	})
+
+	It("should start from scratch when there's no progress", func() {
+		mprog, pct := load(rargs)
+		Expect(pct).To(BeZero())
+		Expect(mprog.skip(bck)).To(BeFalse())
+		Expect(mprog.marks).To(BeEmpty())
+	})
+
+	It("should checkpoint and resume", func() {
+		var (
+			reb      = newReb()
+			mprog, _ = load(rargs)
+		)
+		// "other" is fully traversed and ACK-ed; "bck" is in progress
+		mprog.begin(other)
+		mprog.cur.last = "z"
+		mprog.end()
+
+		mprog.begin(bck)
+		for _, name := range []string{"a/b", "a.b", "c"} {
+			reb.addLomAck(newLOM(bck, name))
+		}
+		mprog.cur.last = "d"
+		mprog.checkpoint(reb, rargs)
+		Expect(mprog.skip(other)).To(BeTrue())
+		Expect(mprog.marks).NotTo(HaveKey(progKey(other)))
+		Expect(mprog.marks[progKey(bck)]).To(Equal(&progMark{ObjName: "a/b", Excl: true})) // (traversal order)
+
+		// resume
+		rargs.id++
+		resumed, pct := load(rargs)
+		Expect(pct).To(Equal(50))
+		Expect(resumed.skip(other)).To(BeTrue())
+		Expect(resumed.skip(bck)).To(BeFalse())
+		resumed.begin(bck)
+		for name, done := range map[string]bool{"a": true, "a/a": true, "a/b": false, "a.b": false, "0": true, "a/b/c": false} {
+			_, isDone := resumed.done(resumed.cur.bdir+name, false)
+			Expect(isDone).To(Equal(done), name)
+		}
+
+		// ACK-ed
+		for _, lomAck := range reb.lomAcks() {
+			clear(lomAck.q)
+		}
+		mprog.checkpoint(reb, rargs)
+		Expect(mprog.marks[progKey(bck)]).To(Equal(&progMark{ObjName: "d"}))
+
+		mprog.end()
+		mprog.checkpoint(reb, rargs)
+		Expect(mprog.skip(bck)).To(BeTrue())
+		Expect(mprog.marks).To(BeEmpty())
+		Expect(mprog.walked).To(BeEmpty())
+
+		rargs.id++
+		_, pct = load(rargs)
+		Expect(pct).To(Equal(100))
+	})
+
+	It("should skip directories that precede the mark", func() {
+		mprog, _ := load(rargs)
+		mprog.marks[progKey(bck)] = &progMark{ObjName: "b/c/d"}
+		mprog.begin(bck)
+		for name, done := range map[string]bool{"a": true, "b": false, "b/a": true, "b/c": false, "b/c/d": false, "b/d": false, "b.c": false, "c": false} {
+			_, isDone := mprog.done(mprog.cur.bdir+name, true /*dir*/)
+			Expect(isDone).To(Equal(done), name)
+		}
+		name, isDone := mprog.done(mprog.cur.bdir+"b/c/d", false)
+		Expect(name).To(Equal("b/c/d"))
+		Expect(isDone).To(BeTrue()) // (inclusive)
+	})
+
+	DescribeTable("should start from scratch upon",
+		func(modify func(rargs *rebArgs, fpath string)) {
+			mprog, _ := load(rargs)
+			mprog.begin(bck)
+			mprog.cur.last = "obj"
+			mprog.end()
+			mprog.checkpoint(newReb(), rargs)
+			Expect(mprog.skip(bck)).To(BeTrue())
+
+			rargs.id++
+			modify(rargs, filepath.Join(mpath, fname.RebProgress))
+			resumed, pct := load(rargs)
+			Expect(pct).To(BeZero())
+			Expect(resumed.skip(bck)).To(BeFalse())
+			Expect(resumed.marks).To(BeEmpty())
+		},
+		Entry("target joining", func(rargs *rebArgs, _ string) {
+			rargs.smap = newSmap("uuid", "t1", "t2", "t3")
+		}),
+		Entry("target in maintenance", func(rargs *rebArgs, _ string) {
+			rargs.smap = newSmap("uuid", "t1", "t2")
+			rargs.smap.Tmap["t2"].Flags = rargs.smap.Tmap["t2"].Flags.Set(meta.SnodeMaint)
+		}),
+		Entry("different cluster", func(rargs *rebArgs, _ string) {
+			rargs.smap = newSmap("uuid-other", "t1", "t2")
+		}),
+		Entry("same or older rebalance", func(rargs *rebArgs, _ string) {
+			rargs.id -= 2
+		}),
+		Entry("corrupted progress file", func(_ *rebArgs, fpath string) {
+			b, err := os.ReadFile(fpath)
+			Expect(err).NotTo(HaveOccurred())
+			b[len(b)-1] ^= 0xff
+			Expect(os.WriteFile(fpath, b, 0o644)).To(Succeed())
+		}),
+	)
+
+	It("should resume with the same targets in a different order", func() {
+		mprog, _ := load(rargs)
+		mprog.begin(bck)
+		mprog.end()
+		mprog.checkpoint(newReb(), rargs)
+
+		rargs.id++
+		rargs.smap = newSmap("uuid", "t2", "t1")
+		rargs.smap.Version++
+		resumed, _ := load(rargs)
+		Expect(resumed.skip(bck)).To(BeTrue())
+	})
+
+	It("should compare names in the traversal order", func() {
+		names := []string{"a.b", "a/b", "a/b/c", "a", "b", "a-b", "a/b.c", "ab", "a/c"}
+		for _, name := range names {
+			if name == "a" || name == "a/b" {
+				continue // (directories)
+			}
+			lom := newLOM(bck, name)
+			Expect(cos.CreateDir(filepath.Dir(lom.FQN))).To(Succeed())
+			Expect(os.WriteFile(lom.FQN, []byte(name), 0o644)).To(Succeed())
+			core.FreeLOM(lom)
+		}
+		var (
+			walked []string
+			bdir   = fs.GetAvail()[mpath].MakePathCT(bck.Bucket(), fs.ObjectType) + cos.PathSeparator
+			opts   = &fs.WalkOpts{Mi: fs.GetAvail()[mpath], CTs: []string{fs.ObjectType}, Sorted: true}
+		)
+		opts.Bck.Copy(bck.Bucket())
+		opts.Callback = func(fqn string, _ fs.DirEntry) error {
+			if name, ok := strings.CutPrefix(fqn, bdir); ok {
+				walked = append(walked, name)
+			}
+			return nil
+		}
+		Expect(fs.Walk(opts)).To(Succeed())
+		Expect(walked).To(HaveLen(len(names)))
+
+		sorted := append([]string(nil), names...)
+		sort.Slice(sorted, func(i, j int) bool { return walkCmp(sorted[i], sorted[j]) < 0 })
+		Expect(walked).To(Equal(sorted))
+	})
+})
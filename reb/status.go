@@ -30,6 +30,7 @@ func (reb *Reb) RebStatus(status *Status) {
 	reb.mu.RLock()
 	status.Stage = reb.stages.stage.Load()
 	status.RebID = reb.rebID.Load()
+	status.ResumedPct = reb.resumed.Load()
 	status.Quiescent = reb.isQuiescent()
 	status.SmapVersion = tsmap.Version
 	smap := reb.smap.Load()
@@ -48,6 +48,7 @@ type (
 	joggerCtx struct {
 		xres   *xs.Resilver
 		config *cmn.Config
+		bwlim  *cos.Throttle // resilver.max_bps
 	}
 )
 
@@ -100,7 +101,7 @@ func (res *Res) RunResilver(args Args) {
 		jg        *mpather.Jgroup
 		slab, err = core.T.PageMM().GetSlab(memsys.MaxPageSlabSize)
 		config    = cmn.GCO.Get()
-		jctx      = &joggerCtx{xres: xres, config: config}
+		jctx      = &joggerCtx{xres: xres, config: config, bwlim: cos.NewThrottle(maxBps)}
 
 		opts = &mpather.JgroupOpts{
 			CTs:                   []string{fs.ObjectType, fs.ECSliceType},
@@ -125,6 +126,9 @@ func (res *Res) RunResilver(args Args) {
 		}
 	}
 
+	// staged (in-memory) objects are invisible to joggers
+	core.FlushAllStaged(nil)
+
 	// run and block waiting
 	res.end.Store(0)
 	jg.Run()
@@ -139,6 +143,15 @@ func (res *Res) RunResilver(args Args) {
 	xres.Finish()
 }
 
+// (can change at runtime)
+func maxBps() int64 { return int64(cmn.GCO.Get().Resilver.MaxBps) }
+
+func (jg *joggerCtx) throttle(size int64) {
+	if d := jg.bwlim.Charge(size); d > 0 {
+		jg.xres.AbortedAfter(d)
+	}
+}
+
 // Wait for an abort or for resilvering joggers to finish.
 func wait(jg *mpather.Jgroup, xres *xs.Resilver) (err error) {
 	for {
@@ -187,7 +200,8 @@ func (jg *joggerCtx) _mvSlice(ct *core.CT, buf []byte) {
 	if cmn.Rom.FastV(4, cos.SmoduleReb) {
 		nlog.Infof("%s: moving %q -> %q", core.T, ct.FQN(), destFQN)
 	}
-	if _, _, err = cos.CopyFile(ct.FQN(), destFQN, buf, cos.ChecksumNone); err != nil {
+	n, _, err := cos.CopyFile(ct.FQN(), destFQN, buf, cos.ChecksumNone)
+	if err != nil {
 		errV := fmt.Errorf("failed to copy %q -> %q: %v. Rolling back", ct.FQN(), destFQN, err)
 		jg.xres.AddErr(errV, 0)
 		if err = cos.RemoveFile(destMetaFQN); err != nil {
@@ -202,6 +216,7 @@ func (jg *joggerCtx) _mvSlice(ct *core.CT, buf []byte) {
 	if errSlice := cos.RemoveFile(ct.FQN()); errSlice != nil {
 		nlog.Warningln("failed to cleanup slice", ct.FQN(), "[", errSlice, "]")
 	}
+	jg.throttle(n)
 }
 
 // Copies EC metafile to correct mpath. It returns FQNs of the source and
@@ -249,6 +264,7 @@ func (jg *joggerCtx) visitObj(lom *core.LOM, buf []byte) (errHrw error) {
 		lom.Unlock(true)
 		if copied && errHrw == nil {
 			jg.xres.ObjsAdd(1, size)
+			jg.throttle(size)
 		}
 	}()
 
@@ -349,6 +365,14 @@ redo:
 		break
 	}
 ret:
+	// chunks (if any) are placed independently of the object itself
+	if lom.IsChunked() {
+		n, err := lom.RelocateChunks(buf)
+		if err != nil {
+			jg.xres.AddErr(err)
+		}
+		copied = copied || n > 0
+	}
 	// EC: remove old metafile
 	if metaOldPath != "" {
 		if err := os.Remove(metaOldPath); err != nil {
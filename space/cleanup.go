@@ -34,6 +34,8 @@ type (
 		StatsT  stats.Tracker
 		Buckets []cmn.Bck // optional list of specific buckets to cleanup
 		WG      *sync.WaitGroup
+		// user-initiated (vs. OOS): purge all soft-deleted objects regardless of retention
+		Explicit bool
 	}
 	XactCln struct {
 		xact.Base
@@ -60,11 +62,14 @@ type (
 		// runtime
 		oldWork   []string
 		misplaced struct {
-			loms []*core.LOM
-			ec   []*core.CT // EC slices and replicas without corresponding metafiles (CT FQN -> Meta FQN)
+			loms   []*core.LOM
+			ec     []*core.CT // EC slices and replicas without corresponding metafiles (CT FQN -> Meta FQN)
+			chunks []string   // chunks that are not referenced by their objects (see core/lchunk.go)
 		}
 		bck cmn.Bck
 		now int64
+		// soft-deleted objects: purge all (when explicit or above cleanup watermark) or only expired
+		rmAllDeleted bool
 		// init-time
 		p       *clnP
 		ini     *IniCln
@@ -242,6 +247,8 @@ func (j *clnJ) run(providers []string) {
 	}
 
 	// traverse
+	cs := fs.Cap()
+	j.rmAllDeleted = j.ini.Explicit || int64(cs.PctMax) > j.config.Space.CleanupWM
 	if len(j.ini.Buckets) != 0 {
 		size, err = j.jogBcks(j.ini.Buckets)
 	} else {
@@ -347,7 +354,7 @@ func (j *clnJ) jogBck() (size int64, err error) {
 	opts := &fs.WalkOpts{
 		Mi:       j.mi,
 		Bck:      j.bck,
-		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType},
+		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType, fs.DeletedType, fs.ChunkType},
 		Callback: j.walk,
 		Sorted:   false,
 	}
@@ -410,11 +417,49 @@ func (j *clnJ) visitCT(parsedFQN *fs.ParsedFQN, fqn string) {
 			return
 		}
 		j.oldWork = append(j.oldWork, fqn)
+	case fs.DeletedType:
+		// soft-deleted objects: remove those that are past retention (mtime is the time of deletion)
+		retention := j.config.Space.DeletedRetention.D()
+		if !j.rmAllDeleted && retention > 0 {
+			finfo, err := os.Stat(fqn)
+			if err != nil || finfo.ModTime().UnixNano()+int64(retention) > j.now {
+				return
+			}
+		}
+		j.oldWork = append(j.oldWork, fqn)
+	case fs.ChunkType:
+		j.visitChunk(parsedFQN, fqn)
 	default:
 		debug.Assertf(false, "Unsupported content type: %s", parsedFQN.ContentType)
 	}
 }
 
+// orphan chunk is referenced neither by its object nor by the latter's soft-deleted version
+// (e.g., leftovers of the overwritten or purged object)
+func (j *clnJ) visitChunk(parsedFQN *fs.ParsedFQN, fqn string) {
+	finfo, err := os.Stat(fqn)
+	if err != nil || finfo.ModTime().UnixNano()+int64(j.config.LRU.DontEvictTime) > j.now {
+		return
+	}
+	objName, _, ok := fs.CSM.Resolver(fs.ChunkType).ParseUniqueFQN(parsedFQN.ObjName)
+	if !ok {
+		j.oldWork = append(j.oldWork, fqn)
+		return
+	}
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if lom.InitBck(&j.bck) != nil {
+		return
+	}
+	if lom.Load(false /*cache it*/, false /*locked*/) == nil && lom.IsChunkRef(fqn) {
+		return
+	}
+	if _, err := lom.LoadDeleted(); err == nil && lom.IsChunkRef(fqn) {
+		return
+	}
+	j.misplaced.chunks = append(j.misplaced.chunks, fqn)
+}
+
 // TODO: add stats error counters (stats.ErrLmetaCorruptedCount, ...)
 // TODO: revisit rm-ed byte counting
 func (j *clnJ) visitObj(fqn string, lom *core.LOM) {
@@ -582,7 +627,26 @@ func (j *clnJ) rmLeftovers() (size int64, err error) {
 	}
 	j.misplaced.loms = j.misplaced.loms[:0]
 
-	// 3. rm EC slices and replicas that are still without correcponding metafile
+	// 3. rm orphan chunks (same rules as above: misplaced objects may still be referencing them)
+	if len(j.misplaced.chunks) > 0 && j.p.rmMisplaced() {
+		for _, fqn := range j.misplaced.chunks {
+			finfo, erc := os.Stat(fqn)
+			if erc != nil || cos.RemoveFile(fqn) != nil {
+				continue
+			}
+			fevicted++
+			bevicted += finfo.Size()
+			if cmn.Rom.FastV(4, cos.SmoduleSpace) {
+				nlog.Infof("%s: rm orphan chunk %q, size=%d", j, fqn, finfo.Size())
+			}
+			if err = j.yieldTerm(); err != nil {
+				return
+			}
+		}
+	}
+	j.misplaced.chunks = j.misplaced.chunks[:0]
+
+	// 4. rm EC slices and replicas that are still without correcponding metafile
 	for _, ct := range j.misplaced.ec {
 		metaFQN := fs.CSM.Gen(ct, fs.ECMetaType, "")
 		if cos.Stat(metaFQN) == nil {
@@ -327,6 +327,9 @@ func (j *lruJ) _visit(lom *core.LOM) (pushed bool) {
 	if lom.HasCopies() && lom.IsCopy() {
 		return
 	}
+	if lom.CheckRetained(false) != nil { // object lock
+		return
+	}
 	// do nothing if the heap's curSize >= totalSize and
 	// the file is more recent then the the heap's newest.
 	if j.curSize >= j.totalSize && lom.AtimeUnix() > j.newest {
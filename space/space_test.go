@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -282,6 +283,34 @@ var _ = Describe("space evict/cleanup tests", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(len(files)).To(Equal(0))
 			})
+			It("should remove unexpired soft-deleted objects only when explicitly requested", func() {
+				var (
+					avail = fs.GetAvail()
+					mi    = avail[basePath]
+					bck   = cmn.Bck{Name: bucketName, Provider: apc.AIS, Ns: cmn.NsGlobal}
+					fqn   = mi.MakePathFQN(&bck, fs.DeletedType, "soft-deleted")
+				)
+				config := cmn.GCO.BeginUpdate()
+				retention, cleanupWM := config.Space.DeletedRetention, config.Space.CleanupWM
+				config.Space.DeletedRetention = cos.Duration(time.Hour)
+				config.Space.CleanupWM = 100 // (never above)
+				cmn.GCO.CommitUpdate(config)
+				defer func() {
+					config := cmn.GCO.BeginUpdate()
+					config.Space.DeletedRetention, config.Space.CleanupWM = retention, cleanupWM
+					cmn.GCO.CommitUpdate(config)
+				}()
+				Expect(cos.CreateDir(filepath.Dir(fqn))).NotTo(HaveOccurred())
+				Expect(os.WriteFile(fqn, []byte("content"), cos.PermRWR)).NotTo(HaveOccurred())
+
+				// OOS-triggered: retention applies
+				space.RunCleanup(newInitStoreCln())
+				Expect(fqn).To(BeAnExistingFile())
+
+				ini.Explicit = true
+				space.RunCleanup(ini)
+				Expect(fqn).NotTo(BeAnExistingFile())
+			})
 		})
 	})
 })
@@ -380,6 +409,7 @@ func createAndAddMountpath(path string) {
 
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	fs.CSM.Reg(fs.DeletedType, &fs.DeletedContentResolver{}, true)
 }
 
 func getRandomFileName(fileCounter int) string {
@@ -94,6 +94,9 @@ const (
 	ErrDownloadCount  = errPrefix + "dl.n"
 	ErrPutMirrorCount = errPrefix + "put.mirror.n"
 
+	// requests throttled by bucket (and per-user) rate limits
+	RatelimCount = "ratelim.n"
+
 	// KindLatency
 	// latency stats have numSamples used to compute average latency
 	GetLatency         = "get.ns"
@@ -254,6 +257,11 @@ func (r *runner) regCommon(snode *meta.Snode) {
 			Help: "number of n-way mirroring errors",
 		},
 	)
+	r.reg(snode, RatelimCount, KindCounter,
+		&Extra{
+			Help: "number of requests throttled by bucket rate limits (gateway: requests per second; target: bytes per second)",
+		},
+	)
 
 	// basic latencies
 	r.reg(snode, GetLatency, KindLatency,
@@ -95,6 +95,11 @@ const (
 	LcacheErrCount       = core.LcacheErrCount
 	LcacheFlushColdCount = core.LcacheFlushColdCount
 
+	LcacheDataPendingSize = core.LcacheDataPendingSize
+	LcacheDataFlushCount  = core.LcacheDataFlushCount
+	LcacheDataFlushSize   = core.LcacheDataFlushSize
+	LcacheDataDropCount   = core.LcacheDataDropCount
+
 	// variable label used for prometheus disk metrics
 	diskMetricLabel = "disk"
 )
@@ -463,6 +468,27 @@ func (r *Trunner) RegMetrics(snode *meta.Snode) {
 			Help: "number of times a LOM from cache was written to stable storage (core, internal)",
 		},
 	)
+	r.reg(snode, LcacheDataPendingSize, KindGauge,
+		&Extra{
+			StrName: "lcache_data_pending_bytes",
+			Help:    "total size of object data staged in memory and pending flush (write_policy.data = delayed | never)",
+		},
+	)
+	r.reg(snode, LcacheDataFlushCount, KindCounter,
+		&Extra{
+			Help: "number of staged (write-delayed) objects written to stable storage",
+		},
+	)
+	r.reg(snode, LcacheDataFlushSize, KindSize,
+		&Extra{
+			Help: "total size of staged (write-delayed) object data written to stable storage",
+		},
+	)
+	r.reg(snode, LcacheDataDropCount, KindCounter,
+		&Extra{
+			Help: "number of transient (write-never) objects dropped under extreme memory pressure",
+		},
+	)
 }
 
 func (r *Trunner) RegDiskMetrics(snode *meta.Snode, disk string) {
@@ -581,6 +607,7 @@ func (r *Trunner) log(now int64, uptime time.Duration, config *cmn.Config) {
 		v = s.Tracker[r.nameUtil(disk)]
 		v.Value = stats.Util
 	}
+	s.Tracker[LcacheDataPendingSize].Value = core.StagedSize()
 
 	// 2 copy stats, reset latencies, send via StatsD if configured
 	s.updateUptime(uptime)
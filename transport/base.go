@@ -52,7 +52,7 @@ const (
 
 type (
 	streamer interface {
-		compressed() bool
+		compression() string
 		dryrun()
 		terminate(error, string) (string, error)
 		doRequest() error
@@ -330,11 +330,16 @@ func (extra *Extra) Compressed() bool {
 }
 
 func (extra *Extra) Lid(sb *strings.Builder) {
-	if extra.Compressed() {
-		sb.WriteByte('[')
+	if !extra.Compressed() {
+		return
+	}
+	sb.WriteByte('[')
+	if extra.Compression == apc.CompressAlways {
 		sb.WriteString(cos.ToSizeIEC(int64(extra.Config.Transport.LZ4BlockMaxSize), 0))
-		sb.WriteByte(']')
+	} else {
+		sb.WriteString(extra.Compression)
 	}
+	sb.WriteByte(']')
 }
 
 //
@@ -47,6 +47,10 @@ type (
 			opened atomic.Bool
 			laterx atomic.Bool
 		}
+		bwlim struct {
+			send *cos.Throttle
+			recv *cos.Throttle
+		}
 		sizePDU    int32
 		maxHdrSize int32
 	}
@@ -54,6 +58,8 @@ type (
 	Extra struct {
 		RecvAck     transport.RecvObj
 		Config      *cmn.Config
+		SendBps     func() int64 // send bandwidth cap that may change at runtime (zero - unlimited)
+		RecvBps     func() int64 // ditto receive
 		Compression string
 		Multiplier  int
 		SizePDU     int32
@@ -74,10 +80,16 @@ func NewDataMover(trname string, recvCB transport.RecvObj, owt cmn.OWT, extra Ex
 	dm.owt = owt
 	dm.multiplier = extra.Multiplier
 	dm.sizePDU, dm.maxHdrSize = extra.SizePDU, extra.MaxHdrSize
+	if extra.SendBps != nil {
+		dm.bwlim.send = cos.NewThrottle(extra.SendBps)
+	}
+	if extra.RecvBps != nil {
+		dm.bwlim.recv = cos.NewThrottle(extra.RecvBps)
+	}
 	switch extra.Compression {
 	case "":
 		dm.compression = apc.CompressNever
-	case apc.CompressAlways, apc.CompressNever:
+	case apc.CompressAlways, apc.CompressNever, apc.CompressZstd, apc.CompressAuto:
 		dm.compression = extra.Compression
 	default:
 		return nil, fmt.Errorf("invalid compression %q", extra.Compression)
@@ -237,6 +249,9 @@ func (dm *DataMover) UnregRecv() {
 }
 
 func (dm *DataMover) Send(obj *transport.Obj, roc cos.ReadOpenCloser, tsi *meta.Snode) (err error) {
+	if dm.bwlim.send != nil && !transport.ReservedOpcode(obj.Hdr.Opcode) {
+		dm.throttle(dm.bwlim.send, obj.Size())
+	}
 	err = dm.data.streams.Send(obj, roc, tsi)
 	if err == nil && !transport.ReservedOpcode(obj.Hdr.Opcode) {
 		dm.xctn.OutObjsAdd(1, obj.Size())
@@ -263,9 +278,20 @@ func (dm *DataMover) quicb(_ time.Duration /*accum. sleep time*/) core.QuiRes {
 	return core.QuiInactiveCB
 }
 
+// throttle (see Extra.SendBps and Extra.RecvBps); when receiving, delays reading
+// from the stream and, therefore, slows down the sender
+func (dm *DataMover) throttle(th *cos.Throttle, size int64) {
+	if d := th.Charge(size); d > 0 {
+		dm.xctn.AbortedAfter(d)
+	}
+}
+
 func (dm *DataMover) wrapRecvData(hdr *transport.ObjHdr, reader io.Reader, err error) error {
 	if hdr.Bck.Name != "" && hdr.ObjName != "" && hdr.ObjAttrs.Size >= 0 {
 		dm.xctn.InObjsAdd(1, hdr.ObjAttrs.Size)
+		if dm.bwlim.recv != nil && err == nil {
+			dm.throttle(dm.bwlim.recv, hdr.ObjAttrs.Size)
+		}
 	}
 	// NOTE: in re (hdr.ObjAttrs.Size < 0) see transport.UsePDU()
 
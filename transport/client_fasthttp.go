@@ -61,8 +61,9 @@ func (s *streamBase) do(body io.Reader) (err error) {
 	req.Header.SetMethod(http.MethodPut)
 	req.SetRequestURI(s.dstURL)
 	req.SetBodyStream(body, -1)
-	if s.streamer.compressed() {
-		req.Header.Set(apc.HdrCompress, apc.LZ4Compression)
+	compression := s.streamer.compression()
+	if compression != "" {
+		req.Header.Set(apc.HdrCompress, compression)
 	}
 	req.Header.Set(apc.HdrSessID, strconv.FormatInt(s.sessID, 10))
 	req.Header.Set(cos.HdrUserAgent, ua)
@@ -78,7 +79,7 @@ func (s *streamBase) do(body io.Reader) (err error) {
 	resp.BodyWriteTo(io.Discard)
 	fasthttp.ReleaseRequest(req)
 	fasthttp.ReleaseResponse(resp)
-	if s.streamer.compressed() {
+	if compression != "" {
 		s.streamer.resetCompression()
 	}
 	return nil
@@ -53,8 +53,9 @@ func (s *streamBase) do(body io.Reader) (err error) {
 	if request, err = http.NewRequest(http.MethodPut, s.dstURL, body); err != nil {
 		return
 	}
-	if s.streamer.compressed() {
-		request.Header.Set(apc.HdrCompress, apc.LZ4Compression)
+	compression := s.streamer.compression()
+	if compression != "" {
+		request.Header.Set(apc.HdrCompress, compression)
 	}
 	request.Header.Set(apc.HdrSessID, strconv.FormatInt(s.sessID, 10))
 	request.Header.Set(cos.HdrUserAgent, ua)
@@ -68,7 +69,7 @@ func (s *streamBase) do(body io.Reader) (err error) {
 	}
 	cos.DrainReader(response.Body)
 	response.Body.Close()
-	if s.streamer.compressed() {
+	if compression != "" {
 		s.streamer.resetCompression()
 	}
 	return
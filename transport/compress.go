@@ -0,0 +1,332 @@
+// Package transport provides long-lived http/tcp connections for
+// intra-cluster communications (see README for details and usage example).
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package transport
+
+import (
+	"io"
+	"path"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v3"
+)
+
+// Tx compression - one of the (non-"never") enumerated apc.SupportedCompression:
+// - "always": LZ4;
+// - "zstd":   Zstandard at the configured transport.zstd_level;
+// - "auto":   zstd that gets turned on and off at HTTP session boundaries, whereby:
+//   * each stream periodically samples compressibility of the objects it sends,
+//     separately for each object type (as in: object name's extension);
+//   * each object votes on whether the (current) session should be compressed;
+//   * enough consecutive contrary votes end the session - the next one starts
+//     with compression toggled;
+//   * already compressed formats (JPEG, tar.gz, etc.) start out as incompressible.
+
+const (
+	autoMinRatio    = 1.1         // compressible iff the (sampled) compression ratio is at least
+	autoMinSample   = 4 * cos.KiB // sample size: min and max
+	autoMaxSample   = 64 * cos.KiB
+	autoInitSamples = 4       // sample the first N objects of a given type, and
+	autoSampleEvery = 64      // every N-th object thereafter
+	autoSwitchCnt   = 4       // num consecutive objects voting to toggle compression
+	autoSwitchSize  = cos.MiB // ditto, total size
+	autoMaxTypes    = 32      // beyond which all the rest fall into a single "other" type
+	zstdWindowSize  = cos.MiB // (to reduce per-stream memory)
+)
+
+// extensions of the formats that are compressed as is
+var cmprExts = [...]string{
+	".jpg", ".jpeg", ".png", ".gif", ".webp", ".mp3", ".mp4", ".m4a", ".mkv", ".avi", ".mov", ".webm",
+	".gz", ".tgz", ".bz2", ".xz", ".zst", ".lz4", ".zip", ".7z", ".rar",
+}
+
+type (
+	compressor interface {
+		io.Writer
+		Flush() error
+		Close() error
+		Reset(io.Writer)
+	}
+	cmprStream struct {
+		s     *Stream
+		zw    compressor  // orig reader => zw
+		sgl   *memsys.SGL // zw => sgl => network
+		auto  *autoCmpr   // non-nil iff "auto"
+		codec string      // apc.LZ4Compression | apc.ZstdCompression
+		on    bool        // true: the current session is compressed
+		eof   bool        // zstd: end of session pending (see Read)
+		// lz4
+		blockMaxSize  int  // *uncompressed* block max size
+		frameChecksum bool // true: checksum lz4 frames
+		// zstd
+		level zstd.EncoderLevel
+	}
+	autoCmpr struct {
+		types  map[string]*ctype
+		other  ctype
+		ct     *ctype // type of the object in flight
+		buf    []byte // sampling output
+		contra struct {
+			cnt  int
+			size int64
+		}
+		on     bool // true: compress the next session
+		sample bool // true: sample the object in flight
+	}
+	ctype struct {
+		ratio    float64 // moving average
+		cnt      int64   // num objects sent
+		nsamples int
+	}
+)
+
+// all streams share the one (stateless) encoder to sample compressibility
+var sampler struct {
+	enc  *zstd.Encoder
+	once sync.Once
+}
+
+func (s *Stream) initCompression(extra *Extra) {
+	var (
+		config = &extra.Config.Transport
+		cmpr   = &cmprStream{s: s, codec: apc.ZstdCompression, on: true}
+	)
+	switch extra.Compression {
+	case apc.CompressAlways:
+		cmpr.codec = apc.LZ4Compression
+		cmpr.blockMaxSize = int(config.LZ4BlockMaxSize)
+		cmpr.frameChecksum = config.LZ4FrameChecksum
+	case apc.CompressAuto:
+		cmpr.auto = &autoCmpr{types: make(map[string]*ctype, 8), on: true}
+	default:
+		debug.Assert(extra.Compression == apc.CompressZstd, extra.Compression)
+	}
+	cmpr.level = zstd.EncoderLevel(cos.NonZero(config.ZstdLevel, cmn.DfltZstdLevel))
+	if cmpr.codec == apc.ZstdCompression || cmpr.blockMaxSize >= memsys.MaxPageSlabSize {
+		cmpr.sgl = g.mm.NewSGL(memsys.MaxPageSlabSize, memsys.MaxPageSlabSize)
+	} else {
+		cmpr.sgl = g.mm.NewSGL(cos.KiB*64, cos.KiB*64)
+	}
+	s.cmpr = cmpr
+}
+
+// current session: "" (not compressed), apc.LZ4Compression, or apc.ZstdCompression
+func (s *Stream) compression() string {
+	if s.cmpr == nil || !s.cmpr.on {
+		return ""
+	}
+	return s.cmpr.codec
+}
+
+func (s *Stream) resetCompression() {
+	s.cmpr.sgl.Reset()
+	s.cmpr.zw.Reset(nil)
+}
+
+////////////////
+// cmprStream //
+////////////////
+
+// new session
+func (cmpr *cmprStream) begin() {
+	cmpr.on, cmpr.eof = cmpr.auto == nil || cmpr.auto.on, false
+	if !cmpr.on {
+		return
+	}
+	cmpr.sgl.Reset()
+	if cmpr.codec == apc.ZstdCompression {
+		cmpr._zstd()
+		return
+	}
+	var zw *lz4.Writer
+	if cmpr.zw == nil {
+		zw = lz4.NewWriter(cmpr.sgl)
+		cmpr.zw = zw
+	} else {
+		zw = cmpr.zw.(*lz4.Writer)
+		zw.Reset(cmpr.sgl)
+	}
+	// lz4 framing spec at http://fastcompression.blogspot.com/2013/04/lz4-streaming-format-final.html
+	zw.Header.BlockChecksum = false
+	zw.Header.NoChecksum = !cmpr.frameChecksum
+	zw.Header.BlockMaxSize = cmpr.blockMaxSize
+}
+
+func (cmpr *cmprStream) _zstd() {
+	if cmpr.zw != nil {
+		cmpr.zw.Reset(cmpr.sgl)
+		return
+	}
+	zw, err := zstd.NewWriter(cmpr.sgl,
+		zstd.WithEncoderLevel(cmpr.level),
+		zstd.WithEncoderConcurrency(1), // synchronous
+		zstd.WithWindowSize(zstdWindowSize),
+		zstd.WithLowerEncoderMem(true),
+	)
+	debug.AssertNoErr(err) // (all options are valid)
+	cmpr.zw = zw
+}
+
+func (cmpr *cmprStream) free() {
+	cmpr.sgl.Free()
+	if cmpr.zw != nil {
+		cmpr.zw.Reset(nil)
+	}
+}
+
+// (the object in flight gets to vote)
+func (cmpr *cmprStream) toggle(hdr *ObjHdr) bool {
+	if cmpr.auto == nil {
+		return false
+	}
+	return cmpr.auto.toggle(hdr, cmpr.on)
+}
+
+// as io.Reader
+func (cmpr *cmprStream) Read(b []byte) (n int, err error) {
+	var (
+		sendoff = &cmpr.s.sendoff
+		last    = sendoff.obj.Hdr.isFin()
+		retry   = maxInReadRetries // insist on returning n > 0 (note that lz4 and zstd compress /blocks/)
+	)
+	if !cmpr.on { // "auto" with compression off
+		n, err = cmpr.s.Read(b)
+		cmpr.s.stats.CompressedSize.Add(int64(n))
+		return
+	}
+	if cmpr.sgl.Len() > 0 {
+		cmpr.zw.Flush()
+		n, err = cmpr.sgl.Read(b)
+		if err == io.EOF { // reusing/rewinding this buf multiple times
+			err = nil
+		}
+		goto ex
+	}
+	if cmpr.eof {
+		cmpr.eof = false
+		return 0, io.EOF
+	}
+re:
+	n, err = cmpr.s.Read(b)
+	_, _ = cmpr.zw.Write(b[:n])
+	switch {
+	case err == io.EOF && cmpr.codec == apc.ZstdCompression:
+		cmpr.zw.Close() // end of session: complete the zstd frame
+		retry = 0
+	case last || cmpr.s.sendoff.ins == inEOB || err != nil:
+		cmpr.zw.Flush()
+		retry = 0
+	}
+	n, _ = cmpr.sgl.Read(b)
+	if n == 0 {
+		if retry > 0 {
+			retry--
+			runtime.Gosched()
+			goto re
+		}
+		cmpr.zw.Flush()
+		n, _ = cmpr.sgl.Read(b)
+	}
+ex:
+	cmpr.s.stats.CompressedSize.Add(int64(n))
+	if last && err == nil {
+		err = io.EOF
+	}
+	if cmpr.sgl.Len() == 0 {
+		cmpr.sgl.Reset()
+	}
+	// NOTE: zstd only - not to lose the rest of the (closed) frame, report end of session
+	// separately, when there's no data (LZ4 keeps its original (n > 0, io.EOF) semantics)
+	if err == io.EOF && n > 0 && cmpr.codec == apc.ZstdCompression {
+		cmpr.eof, err = true, nil
+	}
+	return
+}
+
+//////////////
+// autoCmpr //
+//////////////
+
+func (a *autoCmpr) toggle(hdr *ObjHdr, on bool) bool {
+	a.sample = false
+	if hdr.IsHeaderOnly() || hdr.isFin() {
+		return false
+	}
+	ct := a.ctype(hdr.ObjName)
+	ct.cnt++
+	a.ct = ct
+	a.sample = ct.nsamples < autoInitSamples || ct.cnt%autoSampleEvery == 0
+
+	if ct.compressible() == on {
+		a.contra.cnt, a.contra.size = 0, 0
+		return false
+	}
+	a.contra.cnt++
+	if size := hdr.ObjSize(); size > 0 {
+		a.contra.size += size
+	}
+	if a.contra.cnt < autoSwitchCnt && a.contra.size < autoSwitchSize {
+		return false
+	}
+	a.contra.cnt, a.contra.size = 0, 0
+	a.on = !on
+	return true
+}
+
+func (a *autoCmpr) ctype(objName string) *ctype {
+	ext := strings.ToLower(path.Ext(objName))
+	if ct, ok := a.types[ext]; ok {
+		return ct
+	}
+	if len(a.types) >= autoMaxTypes {
+		return &a.other
+	}
+	ct := &ctype{}
+	for _, e := range cmprExts {
+		if ext == e {
+			ct.ratio, ct.nsamples = 1, autoInitSamples
+			break
+		}
+	}
+	a.types[ext] = ct
+	return ct
+}
+
+// compress (a prefix of) the object's data and update its type's ratio
+func (a *autoCmpr) sampleData(b []byte) {
+	if !a.sample || len(b) < autoMinSample {
+		return
+	}
+	a.sample = false
+	b = b[:min(len(b), autoMaxSample)]
+
+	sampler.once.Do(_initSampler)
+	a.buf = sampler.enc.EncodeAll(b, a.buf[:0])
+
+	ratio := float64(len(b)) / float64(max(len(a.buf), 1))
+	ct := a.ct
+	if ct.nsamples == 0 {
+		ct.ratio = ratio
+	} else {
+		ct.ratio = ct.ratio*0.75 + ratio*0.25
+	}
+	ct.nsamples++
+}
+
+func _initSampler() {
+	var err error
+	sampler.enc, err = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithLowerEncoderMem(true))
+	debug.AssertNoErr(err)
+}
+
+func (ct *ctype) compressible() bool { return ct.nsamples == 0 || ct.ratio >= autoMinRatio }
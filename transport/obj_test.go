@@ -14,6 +14,7 @@ package transport_test
 // go test -v -run=Multi -tags=debug
 
 import (
+	"bytes"
 	cryptorand "crypto/rand"
 	"encoding/binary"
 	"flag"
@@ -42,6 +43,7 @@ import (
 	"github.com/NVIDIA/aistore/tools/tassert"
 	"github.com/NVIDIA/aistore/tools/tlog"
 	"github.com/NVIDIA/aistore/transport"
+	"github.com/OneOfOne/xxhash"
 )
 
 const (
@@ -523,6 +525,77 @@ func TestCompressedOne(t *testing.T) {
 	printNetworkStats()
 }
 
+// zstd and "auto" compression: alternate batches of incompressible (random) and
+// highly compressible objects - the latter to make "auto" toggle compression back and forth
+func TestCompressedMixed(t *testing.T) {
+	for _, compression := range []string{apc.CompressZstd, apc.CompressAuto} {
+		t.Run(compression, func(t *testing.T) { testCompressedMixed(t, compression) })
+	}
+}
+
+func testCompressedMixed(t *testing.T, compression string) {
+	const (
+		numObjs = 256
+		batch   = 8
+	)
+	ts := httptest.NewServer(objmux)
+	defer ts.Close()
+
+	var receivedCount atomic.Int64
+	recvFunc := func(hdr *transport.ObjHdr, objReader io.Reader, err error) error {
+		cos.Assert(err == nil || cos.IsEOF(err))
+		data, err := io.ReadAll(objReader)
+		cos.AssertNoErr(err)
+		cos.Assertf(int64(len(data)) == hdr.ObjAttrs.Size, "%s: received %d, expected %d", hdr.ObjName, len(data), hdr.ObjAttrs.Size)
+		cos.Assertf(binary.BigEndian.Uint64(hdr.Opaque) == xxhash.Checksum64S(data, cos.MLCG32), "%s: checksum mismatch", hdr.ObjName)
+		receivedCount.Inc()
+		return nil
+	}
+	trname := "cmpr-mixed-" + compression
+	err := transport.Handle(trname, recvFunc)
+	tassert.CheckFatal(t, err)
+	defer transport.Unhandle(trname)
+
+	httpclient := transport.NewIntraDataClient()
+	url := ts.URL + transport.ObjURLPath(trname)
+	stream := transport.NewObjStream(httpclient, url, cos.GenTie(), &transport.Extra{Compression: compression})
+
+	random := newRand(mono.NanoTime())
+	text := []byte("the quick brown fox jumps over the lazy dog; ")
+	for i := range numObjs {
+		var (
+			size = random.IntN(512*cos.KiB) + 8*cos.KiB
+			data = make([]byte, size)
+			name = "obj-" + strconv.Itoa(i)
+		)
+		if (i/batch)%2 == 0 {
+			_, _ = cryptorand.Read(data)
+			name += ".jpg"
+		} else {
+			for off := 0; off < size; off += len(text) {
+				copy(data[off:], text)
+			}
+			name += ".txt"
+		}
+		hdr := transport.ObjHdr{
+			Bck:      cmn.Bck{Name: trname, Provider: apc.AIS},
+			ObjName:  name,
+			ObjAttrs: cmn.ObjAttrs{Size: int64(size)},
+			Opaque:   binary.BigEndian.AppendUint64(nil, xxhash.Checksum64S(data, cos.MLCG32)),
+		}
+		if err := stream.Send(&transport.Obj{Hdr: hdr, Reader: io.NopCloser(bytes.NewReader(data))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stream.Fin()
+	if receivedCount.Load() != numObjs {
+		t.Fatalf("invalid received count: %d, expected: %d", receivedCount.Load(), numObjs)
+	}
+	stats := stream.GetStats()
+	tlog.Logf("%s: offset=%d, compression-ratio=%.2f\n", stream, stats.Offset.Load(), stats.CompressionRatio())
+	tassert.Errorf(t, stats.CompressionRatio() > 1, "expecting compression ratio > 1, got %.2f", stats.CompressionRatio())
+}
+
 func TestDryRun(t *testing.T) {
 	tools.CheckSkip(t, &tools.SkipTestArgs{Long: true})
 
@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/klauspost/compress/zstd"
 )
 
 //////////////
@@ -62,3 +63,30 @@ func FreeRecv(object io.Reader) {
 	*obj = robj0
 	recvPool.Put(obj)
 }
+
+/////////////////
+// zstdDecPool //
+/////////////////
+
+// zstd decoders are reused across Rx sessions (compression "auto" rotates sessions often)
+var zstdDecPool sync.Pool
+
+func allocZstdDec(r io.Reader) (dec *zstd.Decoder, err error) {
+	if v := zstdDecPool.Get(); v != nil {
+		dec = v.(*zstd.Decoder)
+		if err = dec.Reset(r); err != nil {
+			dec.Close()
+			dec = nil
+		}
+		return dec, err
+	}
+	return zstd.NewReader(r, zstd.WithDecoderConcurrency(1), zstd.WithDecoderLowmem(true))
+}
+
+func freeZstdDec(dec *zstd.Decoder) {
+	if err := dec.Reset(nil); err != nil {
+		dec.Close()
+		return
+	}
+	zstdDecPool.Put(dec)
+}
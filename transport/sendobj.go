@@ -8,33 +8,23 @@ package transport
 import (
 	"fmt"
 	"io"
-	"runtime"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
-	"github.com/NVIDIA/aistore/memsys"
-	"github.com/pierrec/lz4/v3"
 )
 
 // object stream & private types
 type (
 	Stream struct {
-		workCh   chan *Obj // aka SQ: next object to stream
-		cmplCh   chan cmpl // aka SCQ; note that SQ and SCQ together form a FIFO
-		callback ObjSentCB // to free SGLs, close files, etc.
-		lz4s     *lz4Stream
+		workCh   chan *Obj   // aka SQ: next object to stream
+		cmplCh   chan cmpl   // aka SCQ; note that SQ and SCQ together form a FIFO
+		callback ObjSentCB   // to free SGLs, close files, etc.
+		cmpr     *cmprStream // nil when not compressed (see compress.go)
 		sendoff  sendoff
 		streamBase
 	}
-	lz4Stream struct {
-		s             *Stream
-		zw            *lz4.Writer // orig reader => zw
-		sgl           *memsys.SGL // zw => bb => network
-		blockMaxSize  int         // *uncompressed* block max size
-		frameChecksum bool        // true: checksum lz4 frames
-	}
 	sendoff struct {
 		obj Obj
 		off int64
@@ -75,34 +65,13 @@ func (s *Stream) terminate(err error, reason string) (actReason string, actErr e
 	// would be under lock.
 	gc.remove(&s.streamBase)
 
-	if s.compressed() {
-		s.lz4s.sgl.Free()
-		if s.lz4s.zw != nil {
-			s.lz4s.zw.Reset(nil)
-		}
+	if s.cmpr != nil {
+		s.cmpr.free()
 	}
 	return
 }
 
-func (s *Stream) initCompression(extra *Extra) {
-	s.lz4s = &lz4Stream{}
-	s.lz4s.s = s
-	s.lz4s.blockMaxSize = int(extra.Config.Transport.LZ4BlockMaxSize)
-	s.lz4s.frameChecksum = extra.Config.Transport.LZ4FrameChecksum
-	if s.lz4s.blockMaxSize >= memsys.MaxPageSlabSize {
-		s.lz4s.sgl = g.mm.NewSGL(memsys.MaxPageSlabSize, memsys.MaxPageSlabSize)
-	} else {
-		s.lz4s.sgl = g.mm.NewSGL(cos.KiB*64, cos.KiB*64)
-	}
-}
-
-func (s *Stream) compressed() bool { return s.lz4s != nil }
-func (s *Stream) usePDU() bool     { return s.pdu != nil }
-
-func (s *Stream) resetCompression() {
-	s.lz4s.sgl.Reset()
-	s.lz4s.zw.Reset(nil)
-}
+func (s *Stream) usePDU() bool { return s.pdu != nil }
 
 func (s *Stream) cmplLoop() {
 	for {
@@ -158,20 +127,11 @@ func (s *Stream) doCmpl(obj *Obj, err error) {
 
 func (s *Stream) doRequest() error {
 	s.numCur, s.sizeCur = 0, 0
-	if !s.compressed() {
+	if s.cmpr == nil {
 		return s.do(s)
 	}
-	s.lz4s.sgl.Reset()
-	if s.lz4s.zw == nil {
-		s.lz4s.zw = lz4.NewWriter(s.lz4s.sgl)
-	} else {
-		s.lz4s.zw.Reset(s.lz4s.sgl)
-	}
-	// lz4 framing spec at http://fastcompression.blogspot.com/2013/04/lz4-streaming-format-final.html
-	s.lz4s.zw.Header.BlockChecksum = false
-	s.lz4s.zw.Header.NoChecksum = !s.lz4s.frameChecksum
-	s.lz4s.zw.Header.BlockMaxSize = s.lz4s.blockMaxSize
-	return s.do(s.lz4s)
+	s.cmpr.begin()
+	return s.do(s.cmpr)
 }
 
 // as io.Reader
@@ -232,6 +192,9 @@ repeat:
 		l := insObjHeader(s.maxhdr, &obj.Hdr, s.usePDU())
 		s.header = s.maxhdr[:l]
 		s.sendoff.ins = inHdr
+		if s.cmpr != nil && s.cmpr.toggle(&obj.Hdr) {
+			return s.rotate()
+		}
 		return s.sendHdr(b)
 	case <-s.stopCh.Listen():
 		if cmn.Rom.FastV(5, cos.SmoduleTransport) {
@@ -274,6 +237,9 @@ func (s *Stream) sendData(b []byte) (n int, err error) {
 		objSize = obj.Size()
 	)
 	n, err = obj.Reader.Read(b)
+	if s.cmpr != nil && s.cmpr.auto != nil {
+		s.cmpr.auto.sampleData(b[:n])
+	}
 	s.sendoff.off += int64(n)
 	if err != nil {
 		if err == io.EOF {
@@ -334,6 +300,19 @@ exit:
 	s.sendoff = sendoff{ins: inEOB}
 }
 
+// end the current session prior to sending the (already dequeued) object
+// that'll then go first in the next one
+func (s *Stream) rotate() (int, error) {
+	select {
+	case s.postCh <- struct{}{}:
+	default:
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleTransport) {
+		nlog.Infoln(s.String(), "toggle compression: [", s.numCur, s.stats.Num.Load(), "]")
+	}
+	return 0, io.EOF
+}
+
 func (s *Stream) inSend() bool { return s.sendoff.ins >= inHdr || s.sendoff.ins < inEOB }
 
 func (s *Stream) dryrun() {
@@ -408,52 +387,3 @@ func (stats *Stats) CompressionRatio() float64 {
 	bytesSent := stats.CompressedSize.Load()
 	return float64(bytesRead) / float64(bytesSent)
 }
-
-///////////////
-// lz4Stream //
-///////////////
-
-func (lz4s *lz4Stream) Read(b []byte) (n int, err error) {
-	var (
-		sendoff = &lz4s.s.sendoff
-		last    = sendoff.obj.Hdr.isFin()
-		retry   = maxInReadRetries // insist on returning n > 0 (note that lz4 compresses /blocks/)
-	)
-	if lz4s.sgl.Len() > 0 {
-		lz4s.zw.Flush()
-		n, err = lz4s.sgl.Read(b)
-		if err == io.EOF { // reusing/rewinding this buf multiple times
-			err = nil
-		}
-		goto ex
-	}
-re:
-	n, err = lz4s.s.Read(b)
-	_, _ = lz4s.zw.Write(b[:n])
-	if last {
-		lz4s.zw.Flush()
-		retry = 0
-	} else if lz4s.s.sendoff.ins == inEOB || err != nil {
-		lz4s.zw.Flush()
-		retry = 0
-	}
-	n, _ = lz4s.sgl.Read(b)
-	if n == 0 {
-		if retry > 0 {
-			retry--
-			runtime.Gosched()
-			goto re
-		}
-		lz4s.zw.Flush()
-		n, _ = lz4s.sgl.Read(b)
-	}
-ex:
-	lz4s.s.stats.CompressedSize.Add(int64(n))
-	if lz4s.sgl.Len() == 0 {
-		lz4s.sgl.Reset()
-	}
-	if last && err == nil {
-		err = io.EOF
-	}
-	return
-}
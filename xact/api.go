@@ -118,6 +118,9 @@ var Table = map[string]Descriptor{
 	apc.ActElection:  {DisplayName: "elect-primary", Scope: ScopeG, Startable: false},
 	apc.ActRebalance: {Scope: ScopeG, Startable: true, Metasync: true, Rebalance: true},
 
+	// (rebalance dry-run: local traversal and nothing else)
+	apc.ActRebEstimate: {Scope: ScopeG, Startable: false, AbortRebRes: true},
+
 	apc.ActETLInline: {Scope: ScopeG, Startable: false, AbortRebRes: true},
 
 	// (one bucket) | (all buckets)
@@ -260,6 +263,9 @@ var Table = map[string]Descriptor{
 	// cache management, internal usage
 	apc.ActLoadLomCache:   {DisplayName: "warm-up-metadata", Scope: ScopeB, Startable: true},
 	apc.ActInvalListCache: {Scope: ScopeB, Access: apc.AceObjLIST, Startable: false},
+
+	// periodic (see also ais/s3/lifecycle.go)
+	apc.ActS3Lifecycle: {DisplayName: "lifecycle", Scope: ScopeB, Access: apc.AceObjDELETE, Startable: true},
 }
 
 func IsValidKind(kind string) bool {
@@ -6,6 +6,7 @@ package xreg
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -36,6 +37,10 @@ type (
 		Phase   string
 		Recover bool
 	}
+	// object expiration rules, e.g. S3 bucket lifecycle configuration
+	Expirer interface {
+		Expired(objName string, mtime, now time.Time) bool
+	}
 	BckRenameArgs struct {
 		BckFrom *meta.Bck
 		BckTo   *meta.Bck
@@ -118,6 +123,10 @@ func RenewBckLoadLomCache(uuid string, bck *meta.Bck) RenewRes {
 	return RenewBucketXact(apc.ActLoadLomCache, bck, Args{UUID: uuid})
 }
 
+func RenewLifecycle(uuid string, bck *meta.Bck, exp Expirer) RenewRes {
+	return RenewBucketXact(apc.ActS3Lifecycle, bck, Args{UUID: uuid, Custom: exp})
+}
+
 func RenewPutMirror(lom *core.LOM) RenewRes {
 	return RenewBucketXact(apc.ActPutCopies, lom.Bck(), Args{Custom: lom})
 }
@@ -58,3 +58,8 @@ func RenewBckSummary(bck *meta.Bck, msg *apc.BsummCtrlMsg) RenewRes {
 	e := dreg.nonbckXacts[apc.ActSummaryBck].New(Args{UUID: msg.UUID, Custom: msg}, bck)
 	return dreg.renew(e, bck)
 }
+
+func RenewRebEstimate(msg *apc.RebEstimateMsg) RenewRes {
+	e := dreg.nonbckXacts[apc.ActRebEstimate].New(Args{UUID: msg.UUID, Custom: msg}, nil)
+	return dreg.renew(e, nil)
+}
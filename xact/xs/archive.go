@@ -11,7 +11,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"path/filepath"
 	"sync"
 	"time"
@@ -579,7 +578,7 @@ func (wi *archwi) do(lom *core.LOM, lrit *lrit) {
 		}
 	}
 
-	fh, err := cos.NewFileHandle(lom.FQN)
+	fh, err := lom.NewHandle()
 	if err != nil {
 		wi.r.AddErr(err, 5, cos.SmoduleXs)
 		return
@@ -648,15 +647,14 @@ func (wi *archwi) finalize() (int64, error) {
 		debug.AssertNoErr(err)
 		return 0, err
 	}
-	// tar append
+	// tar append: the (appended-to) file must be re-checksummed in its entirety
 	if wi.appendPos > 0 {
-		finfo, err := os.Stat(wi.fqn)
+		size, cksum, err := cos.ChecksumFile(wi.fqn, wi.archlom.CksumType())
 		if err != nil {
-			debug.AssertNoErr(err)
 			return 0, err
 		}
-		wi.archlom.SetCksum(cos.NewCksum(cos.ChecksumNone, "")) // TODO: checksum NIY
-		return finfo.Size(), nil
+		wi.archlom.SetCksum(cksum)
+		return size, nil
 	}
 	// default
 	wi.cksum.Finalize()
@@ -30,6 +30,7 @@ func Xreg(xeleOnly bool) {
 
 	xreg.RegBckXact(&proFactory{})
 	xreg.RegBckXact(&llcFactory{})
+	xreg.RegBckXact(&lifecycleFactory{})
 
 	xreg.RegBckXact(&tcbFactory{kind: apc.ActCopyBck})
 	xreg.RegBckXact(&tcbFactory{kind: apc.ActETLBck})
@@ -0,0 +1,131 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// x-lifecycle walks the bucket's (local) content and deletes expired objects,
+// as per the bucket's expiration rules (e.g., S3 lifecycle configuration)
+// - object's modification time is the mtime of its (main replica) file
+// - ais:// buckets only (remote and remote-backed buckets are not supported)
+
+type (
+	lifecycleFactory struct {
+		xreg.RenewBase
+		xctn *xactLifecycle
+	}
+	xactLifecycle struct {
+		exp xreg.Expirer
+		now time.Time
+		xact.BckJog
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*xactLifecycle)(nil)
+	_ xreg.Renewable = (*lifecycleFactory)(nil)
+)
+
+//////////////////////
+// lifecycleFactory //
+//////////////////////
+
+func (*lifecycleFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &lifecycleFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *lifecycleFactory) Start() error {
+	exp, ok := p.Args.Custom.(xreg.Expirer)
+	debug.Assert(ok)
+	p.xctn = newXactLifecycle(p.UUID(), p.Bck, exp)
+	go p.xctn.Run(nil)
+	return nil
+}
+
+func (*lifecycleFactory) Kind() string     { return apc.ActS3Lifecycle }
+func (p *lifecycleFactory) Get() core.Xact { return p.xctn }
+
+func (*lifecycleFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+///////////////////
+// xactLifecycle //
+///////////////////
+
+func newXactLifecycle(uuid string, bck *meta.Bck, exp xreg.Expirer) (r *xactLifecycle) {
+	r = &xactLifecycle{exp: exp, now: time.Now()}
+	mpopts := &mpather.JgroupOpts{
+		CTs:                   []string{fs.ObjectType},
+		VisitObj:              r.visit,
+		DoLoad:                mpather.Load,
+		SkipGloballyMisplaced: true,
+		Throttle:              true,
+	}
+	mpopts.Bck.Copy(bck.Bucket())
+	r.BckJog.Init(uuid, apc.ActS3Lifecycle, bck, mpopts, cmn.GCO.Get())
+	return
+}
+
+func (r *xactLifecycle) Run(*sync.WaitGroup) {
+	nlog.Infoln(r.Name())
+	r.BckJog.Run()
+	err := r.BckJog.Wait()
+	if err != nil {
+		r.AddErr(err)
+	}
+	r.Finish()
+}
+
+func (r *xactLifecycle) visit(lom *core.LOM, _ []byte) error {
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			r.AddErr(err, 5, cos.SmoduleXs)
+		}
+		return nil
+	}
+	if !r.exp.Expired(lom.ObjName, finfo.ModTime(), r.now) {
+		return nil
+	}
+	size := lom.Lsize(true)
+	ecode, err := core.T.DeleteObject(lom, false /*evict*/)
+	switch {
+	case err == nil:
+		r.ObjsAdd(1, size)
+	case cos.IsNotExist(err, ecode) || cmn.IsErrObjNought(err):
+		// race vs. (user) delete
+	case cmn.IsErrObjLocked(err):
+		// retained (see object lock) - expires later
+	default:
+		r.AddErr(err, 5, cos.SmoduleXs)
+	}
+	return nil
+}
+
+func (r *xactLifecycle) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
@@ -43,6 +43,7 @@ type (
 	}
 	LsoXact struct {
 		msg       *apc.LsoMsg
+		where     *cmn.LsoWhere    // filtering predicate (optional)
 		msgCh     chan *apc.LsoMsg // incoming requests
 		respCh    chan *LsoRsp     // responses - next pages
 		remtCh    chan *LsoRsp     // remote paging by the responsible target
@@ -54,6 +55,7 @@ type (
 			pageCh       chan *cmn.LsoEnt // channel to accumulate listed object entries
 			stopCh       *cos.StopCh      // to abort bucket walk
 			wi           *walkInfo        // walking context and state
+			staged       []string         // staged (in-memory) objects not yet listed - see core.StagedNames
 			wg           sync.WaitGroup   // wait until this walk finishes
 			done         bool             // done walking (indication)
 			wor          bool             // wantOnlyRemote
@@ -108,6 +110,14 @@ func (p *lsoFactory) Start() error {
 		respCh:     make(chan *LsoRsp),     // ditto: one caller-requested page at a time
 	}
 
+	if p.msg.Where != "" {
+		where, err := cmn.ParseLsoWhere(p.msg.Where)
+		if err != nil {
+			return err
+		}
+		r.where = where
+	}
+
 	r.lastPage = allocLsoEntries()
 	r.stopCh.Init()
 
@@ -186,7 +196,8 @@ loop:
 		select {
 		case msg := <-r.msgCh:
 			// Copy only the values that can change between calls
-			debug.Assert(r.msg.UUID == msg.UUID && r.msg.Prefix == msg.Prefix && r.msg.Flags == msg.Flags)
+			debug.Assert(r.msg.UUID == msg.UUID && r.msg.Prefix == msg.Prefix && r.msg.Flags == msg.Flags &&
+				r.msg.Where == msg.Where)
 			r.msg.ContinuationToken = msg.ContinuationToken
 			r.msg.PageSize = msg.PageSize
 
@@ -391,6 +402,7 @@ func (r *LsoXact) nextPageR() (err error) {
 		smap = core.T.Sowner().Get()
 		tsi  = smap.GetActiveNode(r.msg.SID)
 	)
+	npg.wi.where = r.where
 	if tsi == nil {
 		err = fmt.Errorf("%s: designated (\"paging\") %s is down or inactive, %s", r, meta.Tname(r.msg.SID), smap)
 		goto ex
@@ -425,6 +437,10 @@ func (r *LsoXact) nextPageR() (err error) {
 			err = ErrGone
 		}
 	}
+	// (having broadcast the entire page)
+	if err == nil && r.where != nil {
+		err = npg.filter(page, r.walk.wor)
+	}
 
 	r.wiCnt.Dec()
 ex:
@@ -559,12 +575,22 @@ func (r *LsoXact) shiftLastPage(token string) {
 
 func (r *LsoXact) doWalk(msg *apc.LsoMsg) {
 	r.walk.wi = newWalkInfo(msg, r.LomAdd)
+	r.walk.wi.where = r.where
+	cts := []string{fs.ObjectType}
+	if msg.IsFlagSet(apc.LsDeleted) {
+		cts = append(cts, fs.DeletedType)
+	}
 	opts := &fs.WalkBckOpts{
-		WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Callback: r.cb, Prefix: msg.Prefix, Sorted: true},
+		WalkOpts: fs.WalkOpts{CTs: cts, Callback: r.cb, Prefix: msg.Prefix, Sorted: true},
 	}
 	opts.WalkOpts.Bck.Copy(r.Bck().Bucket())
 	opts.ValidateCb = r.validateCb
-	if err := fs.WalkBck(opts); err != nil {
+	r.walk.staged = core.StagedNames(r.Bck().Bucket(), msg.Prefix)
+	err := fs.WalkBck(opts)
+	if err == nil {
+		err = r.mergeStaged("" /*all remaining*/)
+	}
+	if err != nil {
 		if err != filepath.SkipDir && err != errStopped {
 			r.AddErr(err, 0)
 		}
@@ -611,6 +637,9 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 	if entry.Name <= msg.StartAfter {
 		return nil
 	}
+	if err := r.mergeStaged(entry.Name); err != nil {
+		return err
+	}
 
 	select {
 	case r.walk.pageCh <- entry:
@@ -619,7 +648,7 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 		return errStopped
 	}
 
-	if !msg.IsFlagSet(apc.LsArchDir) {
+	if !msg.IsFlagSet(apc.LsArchDir) || entry.Status() == apc.LocIsDeleted {
 		return nil
 	}
 
@@ -704,3 +733,31 @@ func (r *LsoXact) _recv(hdr *transport.ObjHdr, objReader io.Reader, buf []byte)
 	}
 	return
 }
+
+// staged objects are not on disk - merge those that precede `name` (or all remaining, if empty)
+func (r *LsoXact) mergeStaged(name string) error {
+	msg := r.walk.wi.lsmsg()
+	for len(r.walk.staged) > 0 {
+		objName := r.walk.staged[0]
+		if name != "" && objName > name {
+			break
+		}
+		r.walk.staged = r.walk.staged[1:]
+		if objName == name || objName <= msg.StartAfter { // (flushed in the meantime; already listed)
+			continue
+		}
+		entry, err := r.walk.wi.lsStaged(r.Bck().Bucket(), objName)
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			continue
+		}
+		select {
+		case r.walk.pageCh <- entry:
+		case <-r.walk.stopCh.Listen():
+			return errStopped
+		}
+	}
+	return nil
+}
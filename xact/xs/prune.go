@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -32,6 +33,7 @@ type prune struct {
 	bckFrom, bckTo *meta.Bck
 	smap           *meta.Smap
 	prefix         string
+	ext            cos.StrKVs // destination objname extensions (see `apc.TCBMsg.Ext`)
 	// run
 	joggers *mpather.Jgroup
 	filter  *prob.Filter
@@ -92,15 +94,45 @@ func (rp *prune) do(dst *core.LOM, _ []byte) error {
 		_, local, err := dst.HrwTarget(rp.smap)
 		debug.Assertf(local, "local %t, err: %v", local, err)
 	})
+	// destination name => source name(s)
+	msg := apc.TCBMsg{Ext: rp.ext}
+	for _, name := range msg.FromNames(dst.ObjName) {
+		if exists, err := rp.exists(dst, name); exists || err != nil {
+			return err
+		}
+	}
+
+	// source does not exist: try to remove the destination (NOTE best effort)
+	if !dst.TryLock(true) {
+		return nil
+	}
+	err := dst.Load(false, true)
+	if err == nil {
+		err = dst.RemoveObj()
+	}
+	dst.Unlock(true)
+
+	if err == nil {
+		if cmn.Rom.FastV(5, cos.SmoduleXs) {
+			nlog.Infoln(rp.parent.Name(), dst.Cname())
+		}
+	} else if !cmn.IsErrObjNought(err) && !cmn.IsErrBucketNought(err) {
+		rp.parent.AddErr(err, 4, cos.SmoduleXs)
+	}
+	return nil
+}
+
+// returns false only if the source object does not exist
+func (rp *prune) exists(dst *core.LOM, name string) (bool, error) {
 	// construct src lom
 	var src *core.LOM
-	if rp.same {
+	if rp.same && name == dst.ObjName {
 		src = dst
 	} else {
-		src = core.AllocLOM(dst.ObjName)
+		src = core.AllocLOM(name)
 		defer core.FreeLOM(src)
 		if src.InitBck(rp.bckFrom.Bucket()) != nil {
-			return nil
+			return true, nil
 		}
 	}
 
@@ -109,7 +141,7 @@ func (rp *prune) do(dst *core.LOM, _ []byte) error {
 	bname := cos.UnsafeBptr(uname)
 	if rp.filter != nil && rp.filter.Lookup(*bname) { // TODO -- FIXME: rm filter nil check once x-tco supports prob. filtering
 		rp.filter.Delete(*bname)
-		return nil
+		return true, nil
 	}
 
 	// check whether src lom exists
@@ -120,7 +152,7 @@ func (rp *prune) do(dst *core.LOM, _ []byte) error {
 	if src.Bck().IsAIS() {
 		tsi, errV := rp.smap.HrwHash2T(src.Digest())
 		if errV != nil {
-			return fmt.Errorf("prune %s: fatal err: %w", rp.parent.Name(), errV)
+			return false, fmt.Errorf("prune %s: fatal err: %w", rp.parent.Name(), errV)
 		}
 		if tsi.ID() == core.T.SID() {
 			err = src.Load(false, false)
@@ -132,27 +164,5 @@ func (rp *prune) do(dst *core.LOM, _ []byte) error {
 	} else {
 		_, ecode, err = core.T.HeadCold(src, nil /*origReq*/)
 	}
-
-	if (err == nil && ecode == 0) || !cos.IsNotExist(err, ecode) /*not complaining*/ {
-		return nil
-	}
-
-	// source does not exist: try to remove the destination (NOTE best effort)
-	if !dst.TryLock(true) {
-		return nil
-	}
-	err = dst.Load(false, true)
-	if err == nil {
-		err = dst.RemoveObj()
-	}
-	dst.Unlock(true)
-
-	if err == nil {
-		if cmn.Rom.FastV(5, cos.SmoduleXs) {
-			nlog.Infoln(rp.parent.Name(), dst.Cname())
-		}
-	} else if !cmn.IsErrObjNought(err) && !cmn.IsErrBucketNought(err) {
-		rp.parent.AddErr(err, 4, cos.SmoduleXs)
-	}
-	return nil
+	return (err == nil && ecode == 0) || !cos.IsNotExist(err, ecode) /*not complaining*/, nil
 }
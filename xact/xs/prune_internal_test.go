@@ -0,0 +1,85 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// prune destination objects (named by the source's with replaced extensions - see apc.TCBMsg.Ext)
+// that are no longer present at the source
+func TestPruneExt(t *testing.T) {
+	var (
+		mpath   = t.TempDir()
+		bckFrom = meta.NewBck("prune-src", apc.AIS, cmn.NsGlobal, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}, BID: 0xa1})
+		bckTo   = meta.NewBck("prune-dst", apc.AIS, cmn.NsGlobal, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}, BID: 0xa2})
+	)
+	fs.TestNew(nil)
+	_, err := fs.Add(mpath, "daeID")
+	tassert.CheckFatal(t, err)
+	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	_ = mock.NewTarget(mock.NewBaseBownerMock(bckFrom, bckTo))
+
+	tsi := &meta.Snode{}
+	tsi.Init(core.T.SID(), apc.Target)
+	rp := &prune{
+		bckFrom: bckFrom,
+		bckTo:   bckTo,
+		smap:    &meta.Smap{Tmap: meta.NodeMap{tsi.ID(): tsi}},
+		ext:     cos.StrKVs{"jpg": "png", "jpeg": ".png", "txt": "md"},
+	}
+
+	for _, name := range []string{"a.jpeg", "b.txt", "c.bin", "d.png"} {
+		putObj(t, bckFrom, name)
+	}
+	tests := []struct {
+		name string
+		keep bool
+	}{
+		{"a.png", true},  // a.jpeg
+		{"b.md", true},   // b.txt
+		{"c.bin", true},  // same name
+		{"d.png", true},  // same name (png is not a source extension)
+		{"e.png", false}, // neither e.jpg nor e.jpeg (nor e.png)
+		{"b.png", false}, // b.txt => b.md
+		{"a.md", false},  // a.jpeg => a.png
+		{"c.txt", false}, // would've been renamed (.md)
+		{"f", false},     // no extension
+	}
+	for _, test := range tests {
+		dst := putObj(t, bckTo, test.name)
+		tassert.CheckFatal(t, rp.do(dst, nil))
+		_, err := os.Stat(dst.FQN)
+		tassert.Errorf(t, (err == nil) == test.keep, "%s: expected keep=%t, got err=%v", test.name, test.keep, err)
+		core.FreeLOM(dst)
+	}
+}
+
+func putObj(t *testing.T, bck *meta.Bck, name string) *core.LOM {
+	lom := core.AllocLOM(name)
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+	fh, err := cos.CreateFile(lom.FQN)
+	tassert.CheckFatal(t, err)
+	_, err = fh.Write([]byte(name))
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, fh.Close())
+	lom.SetSize(int64(len(name)))
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	tassert.CheckFatal(t, lom.Persist())
+	return lom
+}
@@ -188,6 +188,7 @@ func newTCB(p *tcbFactory, slab *memsys.Slab, config *cmn.Config, smap *meta.Sma
 			r.prune.bckFrom = p.args.BckFrom
 			r.prune.bckTo = p.args.BckTo
 			r.prune.prefix = p.args.Msg.Prefix
+			r.prune.ext = p.args.Msg.Ext
 		}
 		r.prune.init(config)
 	}
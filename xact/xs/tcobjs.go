@@ -179,10 +179,10 @@ func (r *XactTCObjs) Run(wg *sync.WaitGroup) {
 				if msg.Sync && lrit.lrp != lrpList {
 					wg = &sync.WaitGroup{}
 					wg.Add(1)
-					go func(pt *cos.ParsedTemplate) {
-						r.prune(lrit, smap, pt)
+					go func(pt *cos.ParsedTemplate, ext cos.StrKVs) {
+						r.prune(lrit, smap, pt, ext)
 						wg.Done()
-					}(lrit.pt.Clone())
+					}(lrit.pt.Clone(), msg.Ext)
 				}
 				err = lrit.run(wi, smap)
 			}
@@ -355,8 +355,8 @@ type syncwi struct {
 // interface guard
 var _ lrwi = (*syncwi)(nil)
 
-func (r *XactTCObjs) prune(pruneit *lrit, smap *meta.Smap, pt *cos.ParsedTemplate) {
-	rp := prune{parent: r, smap: smap}
+func (r *XactTCObjs) prune(pruneit *lrit, smap *meta.Smap, pt *cos.ParsedTemplate, ext cos.StrKVs) {
+	rp := prune{parent: r, smap: smap, ext: ext}
 	rp.bckFrom, rp.bckTo = r.FromTo()
 
 	// tcb use case
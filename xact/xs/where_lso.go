@@ -0,0 +1,170 @@
+// Package xs contains most of the supported eXtended actions (xactions) with some
+// exceptions that include certain storage services (mirror, EC) and extensions (downloader, lru).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core"
+)
+
+// list-objects filtering predicate (apc.LsoMsg.Where) - see cmn/lsowhere.go
+
+type (
+	// local object (loaded, unless name-only)
+	lomWhere core.LOM
+	// remote object (as listed by the backend)
+	entWhere struct {
+		e *cmn.LsoEnt
+	}
+)
+
+// interface guard
+var (
+	_ cmn.LsoWhereObj = (*lomWhere)(nil)
+	_ cmn.LsoWhereObj = entWhere{}
+)
+
+//
+// lomWhere
+//
+
+func (w *lomWhere) lom() *core.LOM { return (*core.LOM)(w) }
+
+func (w *lomWhere) Name() string                        { return w.ObjName }
+func (w *lomWhere) Size() (int64, bool)                 { return w.lom().Lsize(), true }
+func (w *lomWhere) CksumType() (string, bool)           { return w.lom().Checksum().Type(), true }
+func (w *lomWhere) Copies() (int64, bool)               { return int64(w.lom().NumCopies()), true }
+func (w *lomWhere) CustomKey(key string) (string, bool) { return w.lom().GetCustomKey(key) }
+
+func (w *lomWhere) Atime() (int64, bool) {
+	atime := w.lom().AtimeUnix()
+	return atime, atime != 0
+}
+
+// remote LastModified, if available; otherwise, local (slow path)
+func (w *lomWhere) Mtime() (int64, bool) {
+	lom := w.lom()
+	if v, ok := lom.GetCustomKey(cmn.LastModified); ok {
+		return parseMtime(v)
+	}
+	_, _, mtime, err := lom.Fstat(false)
+	if err != nil {
+		return 0, false
+	}
+	return mtime.UnixNano(), true
+}
+
+func (w *lomWhere) ECStatus() (string, bool) {
+	ecconf := &w.lom().Bprops().EC
+	switch {
+	case !ecconf.Enabled:
+		return cmn.LsoECNone, true
+	case ecconf.ObjSizeLimit == cmn.ObjSizeToAlwaysReplicate || w.lom().Lsize() < ecconf.ObjSizeLimit:
+		return cmn.LsoECReplicated, true
+	default:
+		return cmn.LsoECEncoded, true
+	}
+}
+
+//
+// entWhere
+//
+
+func (w entWhere) Name() string            { return w.e.Name }
+func (w entWhere) Size() (int64, bool)     { return w.e.Size, true }
+func (entWhere) Atime() (int64, bool)      { return 0, false }
+func (entWhere) CksumType() (string, bool) { return "", false }
+func (entWhere) Copies() (int64, bool)     { return 0, false }
+func (entWhere) ECStatus() (string, bool)  { return "", false }
+
+func (w entWhere) Mtime() (int64, bool) {
+	if v, ok := w.CustomKey(cmn.LastModified); ok {
+		return parseMtime(v)
+	}
+	return 0, false
+}
+
+// e.g. "[ETag:67c24314d6587da16bfa50dd4d2f6a0a LastModified:2023-09-20T21:04:51Z]"
+// (see cmn.CustomMD2S)
+func (w entWhere) CustomKey(key string) (string, bool) {
+	custom := w.e.Custom
+	if len(custom) < 2 {
+		return "", false
+	}
+	for _, kv := range strings.Split(custom[1:len(custom)-1], " ") {
+		if k, v, ok := strings.Cut(kv, ":"); ok && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func parseMtime(v string) (int64, bool) {
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return 0, false
+	}
+	return t.UnixNano(), true
+}
+
+//
+// remote buckets
+//
+
+// evaluate the predicate for the objects in the (remote) page:
+//   - present objects - using local metadata; otherwise, properties listed by the backend
+//   - unless `all` (single target executing list-objects), each target keeps only
+//     the objects it owns (HRW), and the proxy then combines the results
+//   - the resulting short page is refilled by the proxy (see ais/proxy.go lsRefill)
+func (npg *npgCtx) filter(lst *cmn.LsoRes, all bool) error {
+	var (
+		entries = lst.Entries
+		j       int
+	)
+	for _, e := range entries {
+		keep, err := npg.keep(e, all)
+		if err != nil {
+			return err
+		}
+		if keep {
+			entries[j] = e
+			j++
+		}
+	}
+	clear(entries[j:])
+	lst.Entries = entries[:j]
+	return nil
+}
+
+func (npg *npgCtx) keep(e *cmn.LsoEnt, all bool) (bool, error) {
+	if e.IsDir() {
+		return true, nil
+	}
+	if !all {
+		si, err := npg.wi.smap.HrwName2T(npg.bck.MakeUname(e.Name))
+		if err != nil {
+			return false, err
+		}
+		if si.ID() != core.T.SID() {
+			return false, nil
+		}
+	}
+	if !e.IsPresent() {
+		return npg.wi.where.Match(entWhere{e}), nil
+	}
+	lom := core.AllocLOM(e.Name)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(npg.bck.Bucket()); err != nil {
+		return false, err
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		return false, nil // (removed in the meantime)
+	}
+	return npg.wi.where.Match((*lomWhere)(lom)), nil
+}
@@ -28,6 +28,7 @@ type (
 		smap         *meta.Smap
 		msg          *apc.LsoMsg
 		lomVisitedCb lomVisitedCb
+		where        *cmn.LsoWhere // filtering predicate, if any
 		markerDir    string
 		wanted       cos.BitFlags
 		custom       cos.StrKVs
@@ -125,11 +126,77 @@ func (wi *walkInfo) callback(fqn string, de fs.DirEntry) (entry *cmn.LsoEnt, err
 	}
 
 	lom := core.AllocLOM("")
+	if wi.msg.IsFlagSet(apc.LsDeleted) {
+		var parsed fs.ParsedFQN
+		if err = parsed.Init(fqn); err == nil && parsed.ContentType == fs.DeletedType {
+			entry, err = wi._cbDeleted(lom, &parsed)
+			core.FreeLOM(lom)
+			return
+		}
+	}
 	entry, err = wi._cb(lom, fqn)
 	core.FreeLOM(lom)
 	return
 }
 
+// soft-deleted object: list only those that can be undeleted,
+// i.e., the ones located at their respective HRW target and mountpath
+func (wi *walkInfo) _cbDeleted(lom *core.LOM, parsed *fs.ParsedFQN) (*cmn.LsoEnt, error) {
+	if !wi.match(parsed.ObjName) {
+		return nil, nil
+	}
+	lom.ObjName = parsed.ObjName
+	if err := lom.InitBck(&parsed.Bck); err != nil {
+		return nil, err
+	}
+	if lom.Mountpath() != parsed.Mountpath {
+		return nil, nil
+	}
+	if _, local, err := lom.HrwTarget(wi.smap); err != nil || !local {
+		return nil, err
+	}
+	e := &cmn.LsoEnt{Name: lom.ObjName, Flags: apc.LocIsDeleted}
+	if wi.msg.IsFlagSet(apc.LsNameOnly) && (wi.where == nil || wi.where.NameOnly()) {
+		if wi.where != nil && !wi.where.Match((*lomWhere)(lom)) {
+			return nil, nil
+		}
+		return e, nil
+	}
+	if _, err := lom.LoadDeleted(); err != nil {
+		return nil, nil // (removed or being undeleted)
+	}
+	if wi.where != nil && !wi.where.Match((*lomWhere)(lom)) {
+		return nil, nil
+	}
+	wi.setWanted(e, lom)
+	return e, nil
+}
+
+// staged (in-memory) object - see core.StagedNames
+func (wi *walkInfo) lsStaged(bck *cmn.Bck, objName string) (*cmn.LsoEnt, error) {
+	if !wi.match(objName) {
+		return nil, nil
+	}
+	if wi.msg.IsFlagSet(apc.LsNoRecursion) && strings.Contains(strings.TrimPrefix(objName, wi.msg.Prefix), "/") {
+		return nil, nil
+	}
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck); err != nil {
+		return nil, err
+	}
+	if _, local, err := lom.HrwTarget(wi.smap); err != nil || !local {
+		return nil, err
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		return nil, nil // (dropped or deleted in the meantime)
+	}
+	if wi.where != nil && !wi.where.Match((*lomWhere)(lom)) {
+		return nil, nil
+	}
+	return wi.ls(lom, apc.LocOK), nil
+}
+
 func (wi *walkInfo) _cb(lom *core.LOM, fqn string) (*cmn.LsoEnt, error) {
 	if err := lom.PreInit(fqn); err != nil {
 		return nil, err
@@ -155,10 +222,13 @@ func (wi *walkInfo) _cb(lom *core.LOM, fqn string) (*cmn.LsoEnt, error) {
 	}
 
 	// shortcut #1: name-only optimizes-out loading md (NOTE: won't show misplaced and copies)
-	if wi.msg.IsFlagSet(apc.LsNameOnly) {
+	if wi.msg.IsFlagSet(apc.LsNameOnly) && (wi.where == nil || wi.where.NameOnly()) {
 		if !isOK(status) {
 			return nil, nil
 		}
+		if wi.where != nil && !wi.where.Match((*lomWhere)(lom)) {
+			return nil, nil
+		}
 		return wi.ls(lom, status), nil
 	}
 	// load
@@ -172,6 +242,9 @@ func (wi *walkInfo) _cb(lom *core.LOM, fqn string) (*cmn.LsoEnt, error) {
 		// still may change below
 		status = apc.LocIsCopy
 	}
+	if wi.where != nil && !wi.where.Match((*lomWhere)(lom)) {
+		return nil, nil
+	}
 	if isOK(status) {
 		return wi.ls(lom, status), nil
 	}